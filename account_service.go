@@ -52,6 +52,55 @@ type Balance struct {
 	Locked string `json:"locked"`
 }
 
+// GetAccountCommissionService get the commission rates of a symbol for the account
+type GetAccountCommissionService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *GetAccountCommissionService) Symbol(symbol string) *GetAccountCommissionService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *GetAccountCommissionService) Do(ctx context.Context, opts ...RequestOption) (res *AccountCommission, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/account/commission",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AccountCommission)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AccountCommission define the commission rates of a symbol, the standard, special and tax rates add up
+type AccountCommission struct {
+	Symbol             string             `json:"symbol"`
+	StandardCommission CommissionRates    `json:"standardCommission"`
+	SpecialCommission  CommissionRates    `json:"specialCommission"`
+	TaxCommission      CommissionRates    `json:"taxCommission"`
+	Discount           CommissionDiscount `json:"discount"`
+}
+
+// CommissionDiscount define the discount on the standard commission when it is paid with DiscountAsset
+type CommissionDiscount struct {
+	EnabledForAccount bool   `json:"enabledForAccount"`
+	EnabledForSymbol  bool   `json:"enabledForSymbol"`
+	DiscountAsset     string `json:"discountAsset"`
+	Discount          string `json:"discount"`
+}
+
 // GetAccountSnapshotService all account orders; active, canceled, or filled
 type GetAccountSnapshotService struct {
 	c           *Client
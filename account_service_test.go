@@ -110,6 +110,72 @@ func (s *accountServiceTestSuite) assertAccountEqual(e, a *Account) {
 	}
 }
 
+func (s *accountServiceTestSuite) TestGetAccountCommission() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"standardCommission": {
+			"maker": "0.00000010",
+			"taker": "0.00000020",
+			"buyer": "0.00000030",
+			"seller": "0.00000040"
+		},
+		"specialCommission": {
+			"maker": "0.01000000",
+			"taker": "0.02000000",
+			"buyer": "0.03000000",
+			"seller": "0.04000000"
+		},
+		"taxCommission": {
+			"maker": "0.00000112",
+			"taker": "0.00000114",
+			"buyer": "0.00000118",
+			"seller": "0.00000116"
+		},
+		"discount": {
+			"enabledForAccount": true,
+			"enabledForSymbol": true,
+			"discountAsset": "BNB",
+			"discount": "0.75000000"
+		}
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetAccountCommissionService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&AccountCommission{
+		Symbol: "BTCUSDT",
+		StandardCommission: CommissionRates{
+			Maker:  "0.00000010",
+			Taker:  "0.00000020",
+			Buyer:  "0.00000030",
+			Seller: "0.00000040",
+		},
+		SpecialCommission: CommissionRates{
+			Maker:  "0.01000000",
+			Taker:  "0.02000000",
+			Buyer:  "0.03000000",
+			Seller: "0.04000000",
+		},
+		TaxCommission: CommissionRates{
+			Maker:  "0.00000112",
+			Taker:  "0.00000114",
+			Buyer:  "0.00000118",
+			Seller: "0.00000116",
+		},
+		Discount: CommissionDiscount{
+			EnabledForAccount: true,
+			EnabledForSymbol:  true,
+			DiscountAsset:     "BNB",
+			Discount:          "0.75000000",
+		},
+	}, res)
+}
+
 func (s *accountServiceTestSuite) TestGetAccountSnapshot() {
 	data := []byte(`{
 		"code":200,
@@ -0,0 +1,569 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateFuturesAlgoVpOrderService place a volume participation (VP) algo order on futures
+type CreateFuturesAlgoVpOrderService struct {
+	c            *Client
+	symbol       string
+	side         SideType
+	positionSide *PositionSideType
+	quantity     string
+	urgency      AlgoUrgencyType
+	clientAlgoID *string
+	reduceOnly   *bool
+	limitPrice   *string
+}
+
+// Symbol set symbol
+func (s *CreateFuturesAlgoVpOrderService) Symbol(symbol string) *CreateFuturesAlgoVpOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateFuturesAlgoVpOrderService) Side(side SideType) *CreateFuturesAlgoVpOrderService {
+	s.side = side
+	return s
+}
+
+// PositionSide set positionSide, required in hedge mode
+func (s *CreateFuturesAlgoVpOrderService) PositionSide(positionSide PositionSideType) *CreateFuturesAlgoVpOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateFuturesAlgoVpOrderService) Quantity(quantity string) *CreateFuturesAlgoVpOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Urgency set urgency, the share of market volume the order is allowed to take
+func (s *CreateFuturesAlgoVpOrderService) Urgency(urgency AlgoUrgencyType) *CreateFuturesAlgoVpOrderService {
+	s.urgency = urgency
+	return s
+}
+
+// ClientAlgoID set clientAlgoId
+func (s *CreateFuturesAlgoVpOrderService) ClientAlgoID(clientAlgoID string) *CreateFuturesAlgoVpOrderService {
+	s.clientAlgoID = &clientAlgoID
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *CreateFuturesAlgoVpOrderService) ReduceOnly(reduceOnly bool) *CreateFuturesAlgoVpOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// LimitPrice set limitPrice, sub orders are placed as limit orders at this price
+func (s *CreateFuturesAlgoVpOrderService) LimitPrice(limitPrice string) *CreateFuturesAlgoVpOrderService {
+	s.limitPrice = &limitPrice
+	return s
+}
+
+// Do send request
+func (s *CreateFuturesAlgoVpOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateAlgoOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/algo/futures/newOrderVp",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"urgency":  s.urgency,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.clientAlgoID != nil {
+		m["clientAlgoId"] = *s.clientAlgoID
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.limitPrice != nil {
+		m["limitPrice"] = *s.limitPrice
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateAlgoOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateFuturesAlgoTwapOrderService place a time weighted average price (TWAP) algo order on futures
+type CreateFuturesAlgoTwapOrderService struct {
+	c            *Client
+	symbol       string
+	side         SideType
+	positionSide *PositionSideType
+	quantity     string
+	duration     int64
+	clientAlgoID *string
+	reduceOnly   *bool
+	limitPrice   *string
+}
+
+// Symbol set symbol
+func (s *CreateFuturesAlgoTwapOrderService) Symbol(symbol string) *CreateFuturesAlgoTwapOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateFuturesAlgoTwapOrderService) Side(side SideType) *CreateFuturesAlgoTwapOrderService {
+	s.side = side
+	return s
+}
+
+// PositionSide set positionSide, required in hedge mode
+func (s *CreateFuturesAlgoTwapOrderService) PositionSide(positionSide PositionSideType) *CreateFuturesAlgoTwapOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateFuturesAlgoTwapOrderService) Quantity(quantity string) *CreateFuturesAlgoTwapOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Duration set duration in seconds, between 300 and 86400
+func (s *CreateFuturesAlgoTwapOrderService) Duration(duration int64) *CreateFuturesAlgoTwapOrderService {
+	s.duration = duration
+	return s
+}
+
+// ClientAlgoID set clientAlgoId
+func (s *CreateFuturesAlgoTwapOrderService) ClientAlgoID(clientAlgoID string) *CreateFuturesAlgoTwapOrderService {
+	s.clientAlgoID = &clientAlgoID
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *CreateFuturesAlgoTwapOrderService) ReduceOnly(reduceOnly bool) *CreateFuturesAlgoTwapOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// LimitPrice set limitPrice, sub orders are placed as limit orders at this price
+func (s *CreateFuturesAlgoTwapOrderService) LimitPrice(limitPrice string) *CreateFuturesAlgoTwapOrderService {
+	s.limitPrice = &limitPrice
+	return s
+}
+
+// Do send request
+func (s *CreateFuturesAlgoTwapOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateAlgoOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/algo/futures/newOrderTwap",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"duration": s.duration,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.clientAlgoID != nil {
+		m["clientAlgoId"] = *s.clientAlgoID
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.limitPrice != nil {
+		m["limitPrice"] = *s.limitPrice
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateAlgoOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateSpotAlgoTwapOrderService place a time weighted average price (TWAP) algo order on spot
+type CreateSpotAlgoTwapOrderService struct {
+	c            *Client
+	symbol       string
+	side         SideType
+	quantity     string
+	duration     int64
+	clientAlgoID *string
+	limitPrice   *string
+}
+
+// Symbol set symbol
+func (s *CreateSpotAlgoTwapOrderService) Symbol(symbol string) *CreateSpotAlgoTwapOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateSpotAlgoTwapOrderService) Side(side SideType) *CreateSpotAlgoTwapOrderService {
+	s.side = side
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateSpotAlgoTwapOrderService) Quantity(quantity string) *CreateSpotAlgoTwapOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Duration set duration in seconds, between 300 and 86400
+func (s *CreateSpotAlgoTwapOrderService) Duration(duration int64) *CreateSpotAlgoTwapOrderService {
+	s.duration = duration
+	return s
+}
+
+// ClientAlgoID set clientAlgoId
+func (s *CreateSpotAlgoTwapOrderService) ClientAlgoID(clientAlgoID string) *CreateSpotAlgoTwapOrderService {
+	s.clientAlgoID = &clientAlgoID
+	return s
+}
+
+// LimitPrice set limitPrice, sub orders are placed as limit orders at this price
+func (s *CreateSpotAlgoTwapOrderService) LimitPrice(limitPrice string) *CreateSpotAlgoTwapOrderService {
+	s.limitPrice = &limitPrice
+	return s
+}
+
+// Do send request
+func (s *CreateSpotAlgoTwapOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateAlgoOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/algo/spot/newOrderTwap",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"duration": s.duration,
+	}
+	if s.clientAlgoID != nil {
+		m["clientAlgoId"] = *s.clientAlgoID
+	}
+	if s.limitPrice != nil {
+		m["limitPrice"] = *s.limitPrice
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateAlgoOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateAlgoOrderResponse define create algo order response
+type CreateAlgoOrderResponse struct {
+	ClientAlgoID string `json:"clientAlgoId"`
+	Success      bool   `json:"success"`
+	Code         int64  `json:"code"`
+	Msg          string `json:"msg"`
+}
+
+// CancelAlgoOrderService cancel an active algo order
+type CancelAlgoOrderService struct {
+	c        *Client
+	algoType AlgoType
+	algoID   int64
+}
+
+// AlgoID set algoId
+func (s *CancelAlgoOrderService) AlgoID(algoID int64) *CancelAlgoOrderService {
+	s.algoID = algoID
+	return s
+}
+
+// Do send request
+func (s *CancelAlgoOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelAlgoOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: algoEndpoint(s.algoType, "order"),
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("algoId", s.algoID)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CancelAlgoOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelAlgoOrderResponse define cancel algo order response
+type CancelAlgoOrderResponse struct {
+	AlgoID  int64  `json:"algoId"`
+	Success bool   `json:"success"`
+	Code    int64  `json:"code"`
+	Msg     string `json:"msg"`
+}
+
+// ListAlgoOpenOrdersService list active algo orders
+type ListAlgoOpenOrdersService struct {
+	c        *Client
+	algoType AlgoType
+}
+
+// Do send request
+func (s *ListAlgoOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: algoEndpoint(s.algoType, "openOrders"),
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AlgoOrderList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListAlgoHistoricalOrdersService list historical algo orders
+type ListAlgoHistoricalOrdersService struct {
+	c         *Client
+	algoType  AlgoType
+	symbol    *string
+	side      *SideType
+	startTime *int64
+	endTime   *int64
+	page      *int
+	pageSize  *int
+}
+
+// Symbol set symbol
+func (s *ListAlgoHistoricalOrdersService) Symbol(symbol string) *ListAlgoHistoricalOrdersService {
+	s.symbol = &symbol
+	return s
+}
+
+// Side set side
+func (s *ListAlgoHistoricalOrdersService) Side(side SideType) *ListAlgoHistoricalOrdersService {
+	s.side = &side
+	return s
+}
+
+// StartTime set startTime
+func (s *ListAlgoHistoricalOrdersService) StartTime(startTime int64) *ListAlgoHistoricalOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListAlgoHistoricalOrdersService) EndTime(endTime int64) *ListAlgoHistoricalOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Page set page, starting from 1
+func (s *ListAlgoHistoricalOrdersService) Page(page int) *ListAlgoHistoricalOrdersService {
+	s.page = &page
+	return s
+}
+
+// PageSize set pageSize, max 100
+func (s *ListAlgoHistoricalOrdersService) PageSize(pageSize int) *ListAlgoHistoricalOrdersService {
+	s.pageSize = &pageSize
+	return s
+}
+
+// Do send request
+func (s *ListAlgoHistoricalOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: algoEndpoint(s.algoType, "historicalOrders"),
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.side != nil {
+		r.setParam("side", *s.side)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AlgoOrderList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AlgoOrderList define a page of algo orders
+type AlgoOrderList struct {
+	Total  int64        `json:"total"`
+	Orders []*AlgoOrder `json:"orders"`
+}
+
+// AlgoOrder define algo order info
+type AlgoOrder struct {
+	AlgoID           int64            `json:"algoId"`
+	Symbol           string           `json:"symbol"`
+	Side             SideType         `json:"side"`
+	PositionSide     PositionSideType `json:"positionSide"`
+	TotalQuantity    string           `json:"totalQty"`
+	ExecutedQuantity string           `json:"executedQty"`
+	ExecutedAmount   string           `json:"executedAmt"`
+	AvgPrice         string           `json:"avgPrice"`
+	ClientAlgoID     string           `json:"clientAlgoId"`
+	BookTime         int64            `json:"bookTime"`
+	EndTime          int64            `json:"endTime"`
+	AlgoStatus       AlgoStatusType   `json:"algoStatus"`
+	AlgoType         string           `json:"algoType"`
+	Urgency          AlgoUrgencyType  `json:"urgency"`
+}
+
+// ListAlgoSubOrdersService list the sub orders of an algo order page by page
+type ListAlgoSubOrdersService struct {
+	c        *Client
+	algoType AlgoType
+	algoID   int64
+	page     *int
+	pageSize *int
+}
+
+// AlgoID set algoId
+func (s *ListAlgoSubOrdersService) AlgoID(algoID int64) *ListAlgoSubOrdersService {
+	s.algoID = algoID
+	return s
+}
+
+// Page set page, starting from 1
+func (s *ListAlgoSubOrdersService) Page(page int) *ListAlgoSubOrdersService {
+	s.page = &page
+	return s
+}
+
+// PageSize set pageSize, max 100
+func (s *ListAlgoSubOrdersService) PageSize(pageSize int) *ListAlgoSubOrdersService {
+	s.pageSize = &pageSize
+	return s
+}
+
+// Do send request
+func (s *ListAlgoSubOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoSubOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: algoEndpoint(s.algoType, "subOrders"),
+		secType:  secTypeSigned,
+	}
+	r.setParam("algoId", s.algoID)
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AlgoSubOrderList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DoAll fetch every page of sub orders, starting from the page set on the service
+func (s *ListAlgoSubOrdersService) DoAll(ctx context.Context, opts ...RequestOption) (res []*AlgoSubOrder, err error) {
+	page := 1
+	if s.page != nil {
+		page = *s.page
+	}
+	for {
+		s.Page(page)
+		list, err := s.Do(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, list.SubOrders...)
+		if len(list.SubOrders) == 0 || int64(len(res)) >= list.Total {
+			return res, nil
+		}
+		page++
+	}
+}
+
+// AlgoSubOrderList define a page of algo sub orders
+type AlgoSubOrderList struct {
+	Total            int64           `json:"total"`
+	ExecutedQuantity string          `json:"executedQty"`
+	ExecutedAmount   string          `json:"executedAmt"`
+	SubOrders        []*AlgoSubOrder `json:"subOrders"`
+}
+
+// AlgoSubOrder define algo sub order info
+type AlgoSubOrder struct {
+	AlgoID           int64           `json:"algoId"`
+	OrderID          int64           `json:"orderId"`
+	OrderStatus      OrderStatusType `json:"orderStatus"`
+	ExecutedQuantity string          `json:"executedQty"`
+	ExecutedAmount   string          `json:"executedAmt"`
+	FeeAmount        string          `json:"feeAmt"`
+	FeeAsset         string          `json:"feeAsset"`
+	BookTime         int64           `json:"bookTime"`
+	AvgPrice         string          `json:"avgPrice"`
+	Side             SideType        `json:"side"`
+	Symbol           string          `json:"symbol"`
+	SubID            int64           `json:"subId"`
+	TimeInForce      TimeInForceType `json:"timeInForce"`
+	OrigQuantity     string          `json:"origQty"`
+}
+
+func algoEndpoint(algoType AlgoType, path string) string {
+	return "/sapi/v1/algo/" + string(algoType) + "/" + path
+}
@@ -0,0 +1,236 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type algoServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestAlgoService(t *testing.T) {
+	suite.Run(t, new(algoServiceTestSuite))
+}
+
+func (s *algoServiceTestSuite) TestCreateFuturesAlgoVpOrder() {
+	data := []byte(`{
+		"clientAlgoId": "00358ce6a268403398bd34eaa36dffe7",
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	side := SideTypeSell
+	positionSide := PositionSideTypeBoth
+	quantity := "0.012"
+	urgency := AlgoUrgencyTypeHigh
+	reduceOnly := false
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":       symbol,
+			"side":         side,
+			"positionSide": positionSide,
+			"quantity":     quantity,
+			"urgency":      urgency,
+			"reduceOnly":   reduceOnly,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateFuturesAlgoVpOrderService().Symbol(symbol).
+		Side(side).PositionSide(positionSide).Quantity(quantity).
+		Urgency(urgency).ReduceOnly(reduceOnly).Do(newContext())
+	s.r().NoError(err)
+	e := &CreateAlgoOrderResponse{
+		ClientAlgoID: "00358ce6a268403398bd34eaa36dffe7",
+		Success:      true,
+		Code:         0,
+		Msg:          "OK",
+	}
+	s.r().Equal(e, res)
+}
+
+func (s *algoServiceTestSuite) TestCreateSpotAlgoTwapOrder() {
+	data := []byte(`{
+		"clientAlgoId": "65ce1630101a480b85915d7e11fd5078",
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	side := SideTypeBuy
+	quantity := "0.012"
+	duration := int64(86400)
+	limitPrice := "30000"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":     symbol,
+			"side":       side,
+			"quantity":   quantity,
+			"duration":   duration,
+			"limitPrice": limitPrice,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateSpotAlgoTwapOrderService().Symbol(symbol).
+		Side(side).Quantity(quantity).Duration(duration).
+		LimitPrice(limitPrice).Do(newContext())
+	s.r().NoError(err)
+	s.r().True(res.Success)
+	s.r().Equal("65ce1630101a480b85915d7e11fd5078", res.ClientAlgoID)
+}
+
+func (s *algoServiceTestSuite) TestCancelFuturesAlgoOrder() {
+	data := []byte(`{
+		"algoId": 14511,
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	algoID := int64(14511)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParam("algoId", algoID)
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCancelFuturesAlgoOrderService().AlgoID(algoID).Do(newContext())
+	s.r().NoError(err)
+	e := &CancelAlgoOrderResponse{
+		AlgoID:  algoID,
+		Success: true,
+		Code:    0,
+		Msg:     "OK",
+	}
+	s.r().Equal(e, res)
+}
+
+func (s *algoServiceTestSuite) TestListFuturesAlgoHistoricalOrders() {
+	data := []byte(`{
+		"total": 1,
+		"orders": [
+			{
+				"algoId": 14518,
+				"symbol": "BNBUSDT",
+				"side": "BUY",
+				"positionSide": "BOTH",
+				"totalQty": "100.00",
+				"executedQty": "0.00",
+				"executedAmt": "0.00000000",
+				"avgPrice": "0.000",
+				"clientAlgoId": "acacab56b3c44bef9f6a8f8ebd2a8408",
+				"bookTime": 1649756817004,
+				"endTime": 1649756817004,
+				"algoStatus": "CANCELLED",
+				"algoType": "VP",
+				"urgency": "LOW"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BNBUSDT"
+	page := 1
+	pageSize := 100
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":   symbol,
+			"page":     page,
+			"pageSize": pageSize,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListFuturesAlgoHistoricalOrdersService().Symbol(symbol).
+		Page(page).PageSize(pageSize).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(int64(1), res.Total)
+	s.r().Len(res.Orders, 1)
+	e := &AlgoOrder{
+		AlgoID:           14518,
+		Symbol:           symbol,
+		Side:             SideTypeBuy,
+		PositionSide:     PositionSideTypeBoth,
+		TotalQuantity:    "100.00",
+		ExecutedQuantity: "0.00",
+		ExecutedAmount:   "0.00000000",
+		AvgPrice:         "0.000",
+		ClientAlgoID:     "acacab56b3c44bef9f6a8f8ebd2a8408",
+		BookTime:         1649756817004,
+		EndTime:          1649756817004,
+		AlgoStatus:       AlgoStatusTypeCancelled,
+		AlgoType:         "VP",
+		Urgency:          AlgoUrgencyTypeLow,
+	}
+	s.r().Equal(e, res.Orders[0])
+}
+
+func (s *algoServiceTestSuite) TestListSpotAlgoSubOrders() {
+	data := []byte(`{
+		"total": 1,
+		"executedQty": "1.000",
+		"executedAmt": "3229.44000000",
+		"subOrders": [
+			{
+				"algoId": 13723,
+				"orderId": 8389765519993908929,
+				"orderStatus": "FILLED",
+				"executedQty": "1.000",
+				"executedAmt": "3229.44000000",
+				"feeAmt": "-1.61471999",
+				"feeAsset": "USDT",
+				"bookTime": 1649319001964,
+				"avgPrice": "3229.44",
+				"side": "SELL",
+				"symbol": "ETHUSDT",
+				"subId": 1,
+				"timeInForce": "IMMEDIATE_OR_CANCEL",
+				"origQty": "1.000"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	algoID := int64(13723)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"algoId": algoID,
+			"page":   1,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSpotAlgoSubOrdersService().AlgoID(algoID).DoAll(newContext())
+	s.r().NoError(err)
+	s.r().Len(res, 1)
+	e := &AlgoSubOrder{
+		AlgoID:           algoID,
+		OrderID:          8389765519993908929,
+		OrderStatus:      OrderStatusTypeFilled,
+		ExecutedQuantity: "1.000",
+		ExecutedAmount:   "3229.44000000",
+		FeeAmount:        "-1.61471999",
+		FeeAsset:         "USDT",
+		BookTime:         1649319001964,
+		AvgPrice:         "3229.44",
+		Side:             SideTypeSell,
+		Symbol:           "ETHUSDT",
+		SubID:            1,
+		TimeInForce:      "IMMEDIATE_OR_CANCEL",
+		OrigQuantity:     "1.000",
+	}
+	s.r().Equal(e, res[0])
+}
@@ -8,7 +8,6 @@ import (
 	"crypto/tls"
 	"fmt"
 	"github.com/dictxwang/go-binance/portfolio"
-	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
@@ -109,6 +108,33 @@ type BNBTransferSide string
 // MarginOpType define the operation type of margin: 'BORROW' or 'REPAY'
 type MarginOpType string
 
+// CancelReplaceModeType define the behavior of cancel replace when the cancel fails
+type CancelReplaceModeType string
+
+// CancelReplaceResultType define the result of each part of cancel replace
+type CancelReplaceResultType string
+
+// CancelRestrictionsType define the status an order must have to be canceled
+type CancelRestrictionsType string
+
+// PositionSideType define position side type of futures order
+type PositionSideType string
+
+// AlgoType define the market an algo order is placed on
+type AlgoType string
+
+// AlgoUrgencyType define urgency of volume participation algo order
+type AlgoUrgencyType string
+
+// AlgoStatusType define status of algo order
+type AlgoStatusType string
+
+// TickerType define the verbosity of ticker statistics
+type TickerType string
+
+// SelfTradePreventionModeType define how an order matching an order of the same account is expired
+type SelfTradePreventionModeType string
+
 // Endpoints
 var (
 	BaseAPIMainURL    = "http://api.binance.com"
@@ -252,6 +278,40 @@ const (
 
 	MarginOpTypeBorrow MarginOpType = "BORROW"
 	MarginOpTypeRepay  MarginOpType = "REPAY"
+
+	CancelReplaceModeTypeStopOnFailure CancelReplaceModeType = "STOP_ON_FAILURE"
+	CancelReplaceModeTypeAllowFailure  CancelReplaceModeType = "ALLOW_FAILURE"
+
+	CancelReplaceResultTypeSuccess      CancelReplaceResultType = "SUCCESS"
+	CancelReplaceResultTypeFailure      CancelReplaceResultType = "FAILURE"
+	CancelReplaceResultTypeNotAttempted CancelReplaceResultType = "NOT_ATTEMPTED"
+
+	CancelRestrictionsTypeOnlyNew             CancelRestrictionsType = "ONLY_NEW"
+	CancelRestrictionsTypeOnlyPartiallyFilled CancelRestrictionsType = "ONLY_PARTIALLY_FILLED"
+
+	PositionSideTypeBoth  PositionSideType = "BOTH"
+	PositionSideTypeLong  PositionSideType = "LONG"
+	PositionSideTypeShort PositionSideType = "SHORT"
+
+	AlgoTypeFutures AlgoType = "futures"
+	AlgoTypeSpot    AlgoType = "spot"
+
+	AlgoUrgencyTypeLow    AlgoUrgencyType = "LOW"
+	AlgoUrgencyTypeMedium AlgoUrgencyType = "MEDIUM"
+	AlgoUrgencyTypeHigh   AlgoUrgencyType = "HIGH"
+
+	AlgoStatusTypeWorking   AlgoStatusType = "WORKING"
+	AlgoStatusTypeFinished  AlgoStatusType = "FINISHED"
+	AlgoStatusTypeCancelled AlgoStatusType = "CANCELLED"
+
+	TickerTypeFull TickerType = "FULL"
+	TickerTypeMini TickerType = "MINI"
+
+	SelfTradePreventionModeTypeNone        SelfTradePreventionModeType = "NONE"
+	SelfTradePreventionModeTypeExpireTaker SelfTradePreventionModeType = "EXPIRE_TAKER"
+	SelfTradePreventionModeTypeExpireMaker SelfTradePreventionModeType = "EXPIRE_MAKER"
+	SelfTradePreventionModeTypeExpireBoth  SelfTradePreventionModeType = "EXPIRE_BOTH"
+	SelfTradePreventionModeTypeDecrement   SelfTradePreventionModeType = "DECREMENT"
 )
 
 func currentTimestamp() int64 {
@@ -271,14 +331,6 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getAPIEndpoint return the base endpoint of the Rest API according the UseTestnet flag
-func getAPIEndpoint() string {
-	if UseTestnet {
-		return BaseAPITestnetURL
-	}
-	return BaseAPIMainURL
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
@@ -293,6 +345,20 @@ func NewClient(apiKey, secretKey string) *Client {
 	}
 }
 
+// NewClientWithEnvironment initialize an API client instance sending requests to the REST API of env.
+func NewClientWithEnvironment(apiKey, secretKey string, env *Environment) *Client {
+	c := NewClient(apiKey, secretKey)
+	c.BaseURL = env.BaseURL
+	return c
+}
+
+// NewClientWithIPPool initialize an API client instance sending requests from the egress IPs of pool.
+func NewClientWithIPPool(apiKey, secretKey string, pool *IPPool) *Client {
+	c := NewClientWithEnvironment(apiKey, secretKey, pool.env)
+	c.HTTPClient = &http.Client{Transport: pool}
+	return c
+}
+
 // NewClientWithIP initialize an API client instance with API key, secret key and local IP.
 func NewClientWithIP(apiKey, secretKey, ip string) *Client {
 	parsedIP := net.ParseIP(ip)
@@ -382,6 +448,14 @@ type Client struct {
 	Logger     *log.Logger
 	TimeOffset int64
 	do         doFunc
+
+	middlewares []common.APIMiddleware
+}
+
+// Use add middlewares around the REST calls of the client, the first middleware sees the request first
+func (c *Client) Use(middlewares ...common.APIMiddleware) *Client {
+	c.middlewares = append(c.middlewares, middlewares...)
+	return c
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -437,10 +511,13 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
+	// the signature and the listen key never reach the logs
+	debugURL := fullURL
 	if queryString != "" {
+		debugURL = fmt.Sprintf("%s?%s", fullURL, common.RedactQuery(queryString))
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.debug("full url: %s, body: %s", debugURL, common.RedactQuery(bodyString))
 
 	r.fullURL = fullURL
 	r.header = header
@@ -459,28 +536,17 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.debug("request: %s %s, header: %v", req.Method, common.RedactURL(req.URL), common.RedactHeader(req.Header))
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
+	res, err := common.ChainAPIMiddlewares(common.NewAPIHandler(f), c.middlewares...)(req)
 	if err != nil {
 		return []byte{}, err
 	}
-	data, err = ioutil.ReadAll(res.Body)
-	if err != nil {
-		return []byte{}, err
-	}
-	defer func() {
-		cerr := res.Body.Close()
-		// Only overwrite the retured error if the original error was nil and an
-		// error occurred while closing the body.
-		if err == nil && cerr != nil {
-			err = cerr
-		}
-	}()
-	c.debug("response: %#v", res)
+	data = res.Body
+	c.debug("response header: %v", res.Header)
 	c.debug("response body: %s", string(data))
 	c.debug("response status code: %d", res.StatusCode)
 
@@ -490,7 +556,8 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 		if e != nil {
 			c.debug("failed to unmarshal json: %s", e)
 		}
-		return nil, apiErr
+		// keep the body, some endpoints return details next to the error code
+		return data, apiErr
 	}
 	return data, nil
 }
@@ -536,6 +603,11 @@ func (c *Client) NewKlinesService() *KlinesService {
 	return &KlinesService{c: c}
 }
 
+// NewUiKlinesService init UI klines service
+func (c *Client) NewUiKlinesService() *UiKlinesService {
+	return &UiKlinesService{c: c}
+}
+
 // NewListPriceChangeStatsService init list prices change stats service
 func (c *Client) NewListPriceChangeStatsService() *ListPriceChangeStatsService {
 	return &ListPriceChangeStatsService{c: c}
@@ -556,6 +628,11 @@ func (c *Client) NewListSymbolTickerService() *ListSymbolTickerService {
 	return &ListSymbolTickerService{c: c}
 }
 
+// NewTradingDayTickerService init trading day tickers service
+func (c *Client) NewTradingDayTickerService() *TradingDayTickerService {
+	return &TradingDayTickerService{c: c}
+}
+
 // NewCreateOrderService init creating order service
 func (c *Client) NewCreateOrderService() *CreateOrderService {
 	return &CreateOrderService{c: c}
@@ -571,6 +648,51 @@ func (c *Client) NewCancelOCOService() *CancelOCOService {
 	return &CancelOCOService{c: c}
 }
 
+// NewCreateSOROrderService init creating SOR order service
+func (c *Client) NewCreateSOROrderService() *CreateSOROrderService {
+	return &CreateSOROrderService{c: c}
+}
+
+// NewListAllocationsService init listing SOR allocations service
+func (c *Client) NewListAllocationsService() *ListAllocationsService {
+	return &ListAllocationsService{c: c}
+}
+
+// NewCancelReplaceService init cancel replace service
+func (c *Client) NewCancelReplaceService() *CancelReplaceService {
+	return &CancelReplaceService{c: c}
+}
+
+// NewAmendOrderKeepPriorityService init amend order keep priority service
+func (c *Client) NewAmendOrderKeepPriorityService() *AmendOrderKeepPriorityService {
+	return &AmendOrderKeepPriorityService{c: c}
+}
+
+// NewCreateOrderListOCOService init creating OCO order list service
+func (c *Client) NewCreateOrderListOCOService() *CreateOrderListOCOService {
+	return &CreateOrderListOCOService{c: c}
+}
+
+// NewCreateOrderListOTOService init creating OTO order list service
+func (c *Client) NewCreateOrderListOTOService() *CreateOrderListOTOService {
+	return &CreateOrderListOTOService{c: c}
+}
+
+// NewCreateOrderListOTOCOService init creating OTOCO order list service
+func (c *Client) NewCreateOrderListOTOCOService() *CreateOrderListOTOCOService {
+	return &CreateOrderListOTOCOService{c: c}
+}
+
+// NewGetOrderListService init getting order list service
+func (c *Client) NewGetOrderListService() *GetOrderListService {
+	return &GetOrderListService{c: c}
+}
+
+// NewListOrderListsService init listing all order lists service
+func (c *Client) NewListOrderListsService() *ListOrderListsService {
+	return &ListOrderListsService{c: c}
+}
+
 // NewGetOrderService init get order service
 func (c *Client) NewGetOrderService() *GetOrderService {
 	return &GetOrderService{c: c}
@@ -606,41 +728,153 @@ func (c *Client) NewGetAccountService() *GetAccountService {
 	return &GetAccountService{c: c}
 }
 
+// NewGetAccountCommissionService init getting account commission service
+func (c *Client) NewGetAccountCommissionService() *GetAccountCommissionService {
+	return &GetAccountCommissionService{c: c}
+}
+
 // NewGetAPIKeyPermission init getting API key permission
 func (c *Client) NewGetAPIKeyPermission() *GetAPIKeyPermission {
 	return &GetAPIKeyPermission{c: c}
 }
 
 // NewSavingFlexibleProductPositionsService get flexible products positions (Savings)
+//
+// Deprecated: use the Simple Earn services, NewListSimpleEarnFlexiblePositionsService replaces it.
 func (c *Client) NewSavingFlexibleProductPositionsService() *SavingFlexibleProductPositionsService {
 	return &SavingFlexibleProductPositionsService{c: c}
 }
 
 // NewSavingFixedProjectPositionsService get fixed project positions (Savings)
+//
+// Deprecated: use the Simple Earn services, NewListSimpleEarnLockedPositionsService replaces it.
 func (c *Client) NewSavingFixedProjectPositionsService() *SavingFixedProjectPositionsService {
 	return &SavingFixedProjectPositionsService{c: c}
 }
 
 // NewListSavingsFlexibleProductsService get flexible products list (Savings)
+//
+// Deprecated: use the Simple Earn services, NewListSimpleEarnFlexibleProductsService replaces it.
 func (c *Client) NewListSavingsFlexibleProductsService() *ListSavingsFlexibleProductsService {
 	return &ListSavingsFlexibleProductsService{c: c}
 }
 
 // NewPurchaseSavingsFlexibleProductService purchase a flexible product (Savings)
+//
+// Deprecated: use the Simple Earn services, NewSubscribeSimpleEarnFlexibleProductService replaces it.
 func (c *Client) NewPurchaseSavingsFlexibleProductService() *PurchaseSavingsFlexibleProductService {
 	return &PurchaseSavingsFlexibleProductService{c: c}
 }
 
 // NewRedeemSavingsFlexibleProductService redeem a flexible product (Savings)
+//
+// Deprecated: use the Simple Earn services, NewRedeemSimpleEarnFlexibleProductService replaces it.
 func (c *Client) NewRedeemSavingsFlexibleProductService() *RedeemSavingsFlexibleProductService {
 	return &RedeemSavingsFlexibleProductService{c: c}
 }
 
 // NewListSavingsFixedAndActivityProductsService get fixed and activity product list (Savings)
+//
+// Deprecated: use the Simple Earn services, NewListSimpleEarnLockedProductsService replaces it.
 func (c *Client) NewListSavingsFixedAndActivityProductsService() *ListSavingsFixedAndActivityProductsService {
 	return &ListSavingsFixedAndActivityProductsService{c: c}
 }
 
+// NewListSimpleEarnFlexibleProductsService list flexible products (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleProductsService() *ListSimpleEarnFlexibleProductsService {
+	return &ListSimpleEarnFlexibleProductsService{c: c}
+}
+
+// NewListSimpleEarnLockedProductsService list locked products (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedProductsService() *ListSimpleEarnLockedProductsService {
+	return &ListSimpleEarnLockedProductsService{c: c}
+}
+
+// NewSubscribeSimpleEarnFlexibleProductService subscribe a flexible product (Simple Earn)
+func (c *Client) NewSubscribeSimpleEarnFlexibleProductService() *SubscribeSimpleEarnFlexibleProductService {
+	return &SubscribeSimpleEarnFlexibleProductService{c: c}
+}
+
+// NewSubscribeSimpleEarnLockedProductService subscribe a locked product (Simple Earn)
+func (c *Client) NewSubscribeSimpleEarnLockedProductService() *SubscribeSimpleEarnLockedProductService {
+	return &SubscribeSimpleEarnLockedProductService{c: c}
+}
+
+// NewRedeemSimpleEarnFlexibleProductService redeem a flexible product (Simple Earn)
+func (c *Client) NewRedeemSimpleEarnFlexibleProductService() *RedeemSimpleEarnFlexibleProductService {
+	return &RedeemSimpleEarnFlexibleProductService{c: c}
+}
+
+// NewRedeemSimpleEarnLockedProductService redeem a locked position (Simple Earn)
+func (c *Client) NewRedeemSimpleEarnLockedProductService() *RedeemSimpleEarnLockedProductService {
+	return &RedeemSimpleEarnLockedProductService{c: c}
+}
+
+// NewListSimpleEarnFlexiblePositionsService list flexible positions (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexiblePositionsService() *ListSimpleEarnFlexiblePositionsService {
+	return &ListSimpleEarnFlexiblePositionsService{c: c}
+}
+
+// NewListSimpleEarnLockedPositionsService list locked positions (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedPositionsService() *ListSimpleEarnLockedPositionsService {
+	return &ListSimpleEarnLockedPositionsService{c: c}
+}
+
+// NewGetSimpleEarnAccountService get the Simple Earn account
+func (c *Client) NewGetSimpleEarnAccountService() *GetSimpleEarnAccountService {
+	return &GetSimpleEarnAccountService{c: c}
+}
+
+// NewListSimpleEarnFlexibleSubscriptionRecordsService list flexible subscriptions (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleSubscriptionRecordsService() *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	return &ListSimpleEarnFlexibleSubscriptionRecordsService{c: c}
+}
+
+// NewListSimpleEarnLockedSubscriptionRecordsService list locked subscriptions (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedSubscriptionRecordsService() *ListSimpleEarnLockedSubscriptionRecordsService {
+	return &ListSimpleEarnLockedSubscriptionRecordsService{c: c}
+}
+
+// NewListSimpleEarnFlexibleRedemptionRecordsService list flexible redemptions (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleRedemptionRecordsService() *ListSimpleEarnFlexibleRedemptionRecordsService {
+	return &ListSimpleEarnFlexibleRedemptionRecordsService{c: c}
+}
+
+// NewListSimpleEarnLockedRedemptionRecordsService list locked redemptions (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedRedemptionRecordsService() *ListSimpleEarnLockedRedemptionRecordsService {
+	return &ListSimpleEarnLockedRedemptionRecordsService{c: c}
+}
+
+// NewListSimpleEarnFlexibleRewardsRecordsService list flexible rewards (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleRewardsRecordsService() *ListSimpleEarnFlexibleRewardsRecordsService {
+	return &ListSimpleEarnFlexibleRewardsRecordsService{c: c}
+}
+
+// NewListSimpleEarnLockedRewardsRecordsService list locked rewards (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedRewardsRecordsService() *ListSimpleEarnLockedRewardsRecordsService {
+	return &ListSimpleEarnLockedRewardsRecordsService{c: c}
+}
+
+// NewGetSimpleEarnFlexiblePersonalLeftQuotaService get the left personal quota of a flexible product (Simple Earn)
+func (c *Client) NewGetSimpleEarnFlexiblePersonalLeftQuotaService() *GetSimpleEarnFlexiblePersonalLeftQuotaService {
+	return &GetSimpleEarnFlexiblePersonalLeftQuotaService{c: c}
+}
+
+// NewGetSimpleEarnLockedPersonalLeftQuotaService get the left personal quota of a locked product (Simple Earn)
+func (c *Client) NewGetSimpleEarnLockedPersonalLeftQuotaService() *GetSimpleEarnLockedPersonalLeftQuotaService {
+	return &GetSimpleEarnLockedPersonalLeftQuotaService{c: c}
+}
+
+// NewGetSimpleEarnFlexibleSubscriptionPreviewService preview a flexible subscription (Simple Earn)
+func (c *Client) NewGetSimpleEarnFlexibleSubscriptionPreviewService() *GetSimpleEarnFlexibleSubscriptionPreviewService {
+	return &GetSimpleEarnFlexibleSubscriptionPreviewService{c: c}
+}
+
+// NewGetSimpleEarnLockedSubscriptionPreviewService preview a locked subscription (Simple Earn)
+func (c *Client) NewGetSimpleEarnLockedSubscriptionPreviewService() *GetSimpleEarnLockedSubscriptionPreviewService {
+	return &GetSimpleEarnLockedSubscriptionPreviewService{c: c}
+}
+
 // NewGetAccountSnapshotService init getting account snapshot service
 func (c *Client) NewGetAccountSnapshotService() *GetAccountSnapshotService {
 	return &GetAccountSnapshotService{c: c}
@@ -651,6 +885,11 @@ func (c *Client) NewListTradesService() *ListTradesService {
 	return &ListTradesService{c: c}
 }
 
+// NewListPreventedMatchesService init listing prevented matches service
+func (c *Client) NewListPreventedMatchesService() *ListPreventedMatchesService {
+	return &ListPreventedMatchesService{c: c}
+}
+
 // NewHistoricalTradesService init listing trades service
 func (c *Client) NewHistoricalTradesService() *HistoricalTradesService {
 	return &HistoricalTradesService{c: c}
@@ -959,6 +1198,46 @@ func (c *Client) NewConvertTradeHistoryService() *ConvertTradeHistoryService {
 	return &ConvertTradeHistoryService{c: c}
 }
 
+// NewConvertExchangeInfoService init the convert exchange info service
+func (c *Client) NewConvertExchangeInfoService() *ConvertExchangeInfoService {
+	return &ConvertExchangeInfoService{c: c}
+}
+
+// NewConvertAssetInfoService init the convert asset info service
+func (c *Client) NewConvertAssetInfoService() *ConvertAssetInfoService {
+	return &ConvertAssetInfoService{c: c}
+}
+
+// NewConvertGetQuoteService init the convert get quote service
+func (c *Client) NewConvertGetQuoteService() *ConvertGetQuoteService {
+	return &ConvertGetQuoteService{c: c}
+}
+
+// NewConvertAcceptQuoteService init the convert accept quote service
+func (c *Client) NewConvertAcceptQuoteService() *ConvertAcceptQuoteService {
+	return &ConvertAcceptQuoteService{c: c}
+}
+
+// NewConvertOrderStatusService init the convert order status service
+func (c *Client) NewConvertOrderStatusService() *ConvertOrderStatusService {
+	return &ConvertOrderStatusService{c: c}
+}
+
+// NewConvertLimitPlaceOrderService init the convert limit place order service
+func (c *Client) NewConvertLimitPlaceOrderService() *ConvertLimitPlaceOrderService {
+	return &ConvertLimitPlaceOrderService{c: c}
+}
+
+// NewConvertLimitCancelOrderService init the convert limit cancel order service
+func (c *Client) NewConvertLimitCancelOrderService() *ConvertLimitCancelOrderService {
+	return &ConvertLimitCancelOrderService{c: c}
+}
+
+// NewConvertLimitOpenOrdersService init the convert limit open orders service
+func (c *Client) NewConvertLimitOpenOrdersService() *ConvertLimitOpenOrdersService {
+	return &ConvertLimitOpenOrdersService{c: c}
+}
+
 // NewGetIsolatedMarginAllPairsService init get isolated margin all pairs service
 func (c *Client) NewGetIsolatedMarginAllPairsService() *GetIsolatedMarginAllPairsService {
 	return &GetIsolatedMarginAllPairsService{c: c}
@@ -1126,3 +1405,58 @@ func (c *Client) NewFlexibleLoanRepayService() *FlexibleLoanRepayService {
 func (c *Client) NewFlexibleLoanCollateralRepayService() *FlexibleLoanCollateralRepayService {
 	return &FlexibleLoanCollateralRepayService{c: c}
 }
+
+// NewCreateFuturesAlgoVpOrderService init creating futures VP algo order service
+func (c *Client) NewCreateFuturesAlgoVpOrderService() *CreateFuturesAlgoVpOrderService {
+	return &CreateFuturesAlgoVpOrderService{c: c}
+}
+
+// NewCreateFuturesAlgoTwapOrderService init creating futures TWAP algo order service
+func (c *Client) NewCreateFuturesAlgoTwapOrderService() *CreateFuturesAlgoTwapOrderService {
+	return &CreateFuturesAlgoTwapOrderService{c: c}
+}
+
+// NewCancelFuturesAlgoOrderService init canceling futures algo order service
+func (c *Client) NewCancelFuturesAlgoOrderService() *CancelAlgoOrderService {
+	return &CancelAlgoOrderService{c: c, algoType: AlgoTypeFutures}
+}
+
+// NewListFuturesAlgoOpenOrdersService init listing futures open algo orders service
+func (c *Client) NewListFuturesAlgoOpenOrdersService() *ListAlgoOpenOrdersService {
+	return &ListAlgoOpenOrdersService{c: c, algoType: AlgoTypeFutures}
+}
+
+// NewListFuturesAlgoHistoricalOrdersService init listing futures historical algo orders service
+func (c *Client) NewListFuturesAlgoHistoricalOrdersService() *ListAlgoHistoricalOrdersService {
+	return &ListAlgoHistoricalOrdersService{c: c, algoType: AlgoTypeFutures}
+}
+
+// NewListFuturesAlgoSubOrdersService init listing futures algo sub orders service
+func (c *Client) NewListFuturesAlgoSubOrdersService() *ListAlgoSubOrdersService {
+	return &ListAlgoSubOrdersService{c: c, algoType: AlgoTypeFutures}
+}
+
+// NewCreateSpotAlgoTwapOrderService init creating spot TWAP algo order service
+func (c *Client) NewCreateSpotAlgoTwapOrderService() *CreateSpotAlgoTwapOrderService {
+	return &CreateSpotAlgoTwapOrderService{c: c}
+}
+
+// NewCancelSpotAlgoOrderService init canceling spot algo order service
+func (c *Client) NewCancelSpotAlgoOrderService() *CancelAlgoOrderService {
+	return &CancelAlgoOrderService{c: c, algoType: AlgoTypeSpot}
+}
+
+// NewListSpotAlgoOpenOrdersService init listing spot open algo orders service
+func (c *Client) NewListSpotAlgoOpenOrdersService() *ListAlgoOpenOrdersService {
+	return &ListAlgoOpenOrdersService{c: c, algoType: AlgoTypeSpot}
+}
+
+// NewListSpotAlgoHistoricalOrdersService init listing spot historical algo orders service
+func (c *Client) NewListSpotAlgoHistoricalOrdersService() *ListAlgoHistoricalOrdersService {
+	return &ListAlgoHistoricalOrdersService{c: c, algoType: AlgoTypeSpot}
+}
+
+// NewListSpotAlgoSubOrdersService init listing spot algo sub orders service
+func (c *Client) NewListSpotAlgoSubOrdersService() *ListAlgoSubOrdersService {
+	return &ListAlgoSubOrdersService{c: c, algoType: AlgoTypeSpot}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
 	"testing"
@@ -167,3 +168,24 @@ func TestFormatTimestamp(t *testing.T) {
 	tm, _ := time.Parse("2006-01-02 15:04:05", "2018-06-01 01:01:01")
 	assert.Equal(t, int64(1527814861000), FormatTimestamp(tm))
 }
+
+func TestParseRequestDebugRedacted(t *testing.T) {
+	var logs bytes.Buffer
+	c := NewClient("apiKey", "secretKey")
+	c.Debug = true
+	c.Logger = log.New(&logs, "", 0)
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/api/v3/userDataStream",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", "BTCUSDT")
+	r.setFormParam("listenKey", "secretListenKey")
+	require.NoError(t, c.parseRequest(r))
+
+	assert.Contains(t, r.fullURL, "signature=")
+	assert.Contains(t, logs.String(), "symbol=BTCUSDT")
+	assert.Contains(t, logs.String(), "signature=[REDACTED]")
+	assert.Contains(t, logs.String(), "listenKey=[REDACTED]")
+	assert.NotContains(t, logs.String(), "secretListenKey")
+}
@@ -0,0 +1,455 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultIPWeightLimit = 2400
+	defaultWsStaleAfter  = time.Second
+	wsPathSwitchMargin   = time.Millisecond
+)
+
+// EgressIP define the routing state of a local egress IP of an IPPool
+type EgressIP struct {
+	IP string
+	// UsedWeight is the X-Mbx-Used-Weight-1m of the last response in the current minute
+	UsedWeight int64
+	// Latency is the smoothed round trip time of the REST requests
+	Latency     time.Duration
+	Requests    int64
+	Failures    int64
+	BannedUntil time.Time
+	minute      int64
+	transport   http.RoundTripper
+}
+
+// WsPath define a market data connection from a local egress IP to a resolved server address
+type WsPath struct {
+	LocalIP    string
+	ServerAddr string
+	// Name is the discriminator of the path in the metrics, the pool name and the path index
+	// followed by its local IP, e.g. pool0@10.0.0.1
+	Name string
+	// ConnectTime is the duration of the TCP connect, used as latency until events are received
+	ConnectTime time.Duration
+	// Latency is the smoothed lag between the event time and the local receive time
+	Latency time.Duration
+	Events  int64
+	Alive   bool
+	// LastEventTime is the event time of the newest event received on the path
+	LastEventTime int64
+	// BehindSince is set while other paths deliver events newer than the last one of this path
+	BehindSince time.Time
+}
+
+func (path *WsPath) String() string {
+	return path.LocalIP + "->" + path.ServerAddr
+}
+
+// IPPool spread the REST requests and websocket connections of a client across several local egress IPs,
+// the exchange limits are accounted per IP and the lowest latency path is preferred
+type IPPool struct {
+	mu          sync.Mutex
+	name        string
+	ips         []*EgressIP
+	weightLimit int64
+	maxPaths    int
+	staleAfter  time.Duration
+	groups      map[*WsPathGroup]struct{}
+}
+
+// NewIPPool init an IPPool sending from the given local IPs
+func NewIPPool(ips ...string) (*IPPool, error) {
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("ip pool needs at least one egress ip")
+	}
+	p := &IPPool{
+		name:        "pool",
+		weightLimit: defaultIPWeightLimit,
+		staleAfter:  defaultWsStaleAfter,
+		groups:      make(map[*WsPathGroup]struct{}),
+	}
+	for _, ip := range ips {
+		parsedIP := net.ParseIP(ip)
+		if parsedIP == nil {
+			return nil, fmt.Errorf("invalid egress ip %s", ip)
+		}
+		dialer := &net.Dialer{
+			LocalAddr: &net.TCPAddr{IP: parsedIP},
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}
+		// keep the proxy, HTTP/2, idle connection and TLS handshake settings of the default transport
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = dialer.DialContext
+		p.ips = append(p.ips, &EgressIP{IP: ip, transport: transport})
+	}
+	return p, nil
+}
+
+// SetName set the prefix of the path names reported to the metrics, defaults to pool
+func (p *IPPool) SetName(name string) {
+	p.mu.Lock()
+	p.name = name
+	p.mu.Unlock()
+}
+
+// SetWeightLimit set the request weight per minute an IP may use before it is skipped
+func (p *IPPool) SetWeightLimit(weightLimit int64) {
+	p.mu.Lock()
+	p.weightLimit = weightLimit
+	p.mu.Unlock()
+}
+
+// SetMaxPaths set the max number of connections opened for a stream, defaults to every local IP and server address pair
+func (p *IPPool) SetMaxPaths(maxPaths int) {
+	p.mu.Lock()
+	p.maxPaths = maxPaths
+	p.mu.Unlock()
+}
+
+// SetStaleAfter set how long a path may miss the events delivered by other paths before it is
+// no longer delivered from, defaults to 1s
+func (p *IPPool) SetStaleAfter(staleAfter time.Duration) {
+	p.mu.Lock()
+	p.staleAfter = staleAfter
+	p.mu.Unlock()
+}
+
+// RoundTrip send the request from the egress IP with the best latency and weight headroom
+func (p *IPPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	ip := p.pick(time.Now())
+	start := time.Now()
+	res, err := ip.transport.RoundTrip(req)
+	p.update(ip, time.Since(start), res, err)
+	return res, err
+}
+
+func (p *IPPool) pick(now time.Time) *EgressIP {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	minute := now.Unix() / 60
+	var best *EgressIP
+	var bestScore float64
+	for _, ip := range p.ips {
+		if now.Before(ip.BannedUntil) {
+			continue
+		}
+		used := ip.UsedWeight
+		if ip.minute != minute {
+			used = 0
+		}
+		if used >= p.weightLimit {
+			continue
+		}
+		// unmeasured IPs score 0 so that every IP gets a latency sample
+		score := float64(ip.Latency) * (1 + float64(used)/float64(p.weightLimit))
+		if best == nil || score < bestScore || (score == bestScore && ip.Requests < best.Requests) {
+			best, bestScore = ip, score
+		}
+	}
+	if best != nil {
+		return best
+	}
+	// every IP is exhausted, use the one recovering first
+	best = p.ips[0]
+	for _, ip := range p.ips[1:] {
+		if ip.BannedUntil.Before(best.BannedUntil) {
+			best = ip
+		}
+	}
+	return best
+}
+
+func (p *IPPool) update(ip *EgressIP, rtt time.Duration, res *http.Response, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ip.Requests++
+	if err != nil {
+		ip.Failures++
+		return
+	}
+	if ip.Latency == 0 {
+		ip.Latency = rtt
+	} else {
+		ip.Latency = (ip.Latency*7 + rtt) / 8
+	}
+	if weight, e := strconv.ParseInt(res.Header.Get("X-Mbx-Used-Weight-1m"), 10, 64); e == nil {
+		ip.UsedWeight = weight
+		ip.minute = time.Now().Unix() / 60
+	}
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+		retryAfter, e := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
+		if e != nil || retryAfter <= 0 {
+			retryAfter = 60
+		}
+		ip.BannedUntil = time.Now().Add(time.Duration(retryAfter) * time.Second)
+	}
+}
+
+// Stats return a snapshot of the egress IPs
+func (p *IPPool) Stats() []EgressIP {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make([]EgressIP, 0, len(p.ips))
+	for _, ip := range p.ips {
+		stats = append(stats, *ip)
+	}
+	return stats
+}
+
+// WsPaths return a snapshot of the paths of the running streams
+func (p *IPPool) WsPaths() []WsPath {
+	p.mu.Lock()
+	groups := make([]*WsPathGroup, 0, len(p.groups))
+	for g := range p.groups {
+		groups = append(groups, g)
+	}
+	p.mu.Unlock()
+	var paths []WsPath
+	for _, g := range groups {
+		g.mu.Lock()
+		for _, path := range g.paths {
+			paths = append(paths, *path)
+		}
+		g.mu.Unlock()
+	}
+	return paths
+}
+
+// NewWsPathGroup resolve the host of endpoint and prepare a path for every local IP and server address pair,
+// up to the max paths of the pool
+func (p *IPPool) NewWsPathGroup(endpoint string) (*WsPathGroup, error) {
+	p.mu.Lock()
+	ips := append([]*EgressIP(nil), p.ips...)
+	maxPaths := p.maxPaths
+	p.mu.Unlock()
+	return p.newWsPathGroup(endpoint, ips, maxPaths)
+}
+
+// NewWsPath prepare a group with a single path from the egress IP preferred for the REST requests,
+// for the streams such as the user data stream whose events can't be told apart across connections
+func (p *IPPool) NewWsPath(endpoint string) (*WsPathGroup, error) {
+	return p.newWsPathGroup(endpoint, []*EgressIP{p.pick(time.Now())}, 1)
+}
+
+func (p *IPPool) newWsPathGroup(endpoint string, ips []*EgressIP, maxPaths int) (*WsPathGroup, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := net.LookupHost(u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no server address resolved for %s", u.Hostname())
+	}
+	p.mu.Lock()
+	name := p.name
+	g := &WsPathGroup{pool: p, staleAfter: p.staleAfter, lastID: make(map[string]int64)}
+	p.mu.Unlock()
+	// spread the first paths over distinct local IPs and server addresses
+	for j := range addrs {
+		for i, ip := range ips {
+			g.paths = append(g.paths, &WsPath{
+				LocalIP:    ip.IP,
+				ServerAddr: addrs[(i+j)%len(addrs)],
+			})
+		}
+	}
+	if maxPaths > 0 && len(g.paths) > maxPaths {
+		g.paths = g.paths[:maxPaths]
+	}
+	for i, path := range g.paths {
+		path.Name = name + strconv.Itoa(i) + "@" + path.LocalIP
+	}
+	return g, nil
+}
+
+func (p *IPPool) unregister(g *WsPathGroup) {
+	p.mu.Lock()
+	delete(p.groups, g)
+	p.mu.Unlock()
+}
+
+// WsPathServeFunc start the stream of a group on path through dialer
+type WsPathServeFunc func(path *WsPath, dialer *websocket.Dialer, errHandler func(err error)) (doneC, stopC chan struct{}, err error)
+
+// WsPathGroup run the same stream on several paths of an IPPool and deliver the events from the path
+// with the lowest latency, the other paths are kept as hot standby. A WsPathGroup serves a single stream
+type WsPathGroup struct {
+	mu         sync.Mutex
+	deliverMu  sync.Mutex
+	pool       *IPPool
+	paths      []*WsPath
+	best       *WsPath
+	staleAfter time.Duration
+	// lastEventTime is the newest event time received on any path
+	lastEventTime int64
+	lastID        map[string]int64
+}
+
+// Serve start serve on every path of the group, doneC is closed once all of them have exited
+func (g *WsPathGroup) Serve(serve WsPathServeFunc, errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	g.pool.mu.Lock()
+	g.pool.groups[g] = struct{}{}
+	g.pool.mu.Unlock()
+	doneC, stopC, err = ServeGroup(len(g.paths), func(i int) (chan struct{}, chan struct{}, error) {
+		path := g.paths[i]
+		dialer := NewEgressDialer(path.LocalIP, path.ServerAddr, func(connectTime time.Duration) {
+			g.mu.Lock()
+			path.ConnectTime = connectTime
+			g.mu.Unlock()
+		})
+		g.mu.Lock()
+		path.Alive = true
+		g.mu.Unlock()
+		pathDone, pathStop, err := serve(path, dialer, func(err error) {
+			errHandler(fmt.Errorf("%s: %w", path, err))
+		})
+		if err != nil {
+			g.mu.Lock()
+			path.Alive = false
+			g.mu.Unlock()
+			err = fmt.Errorf("%s: %w", path, err)
+			errHandler(err)
+			return nil, nil, err
+		}
+		return pathDone, pathStop, nil
+	}, func(i int) {
+		g.mu.Lock()
+		g.paths[i].Alive = false
+		g.elect(time.Now())
+		alive := g.best != nil
+		g.mu.Unlock()
+		if !alive {
+			g.pool.unregister(g)
+		}
+	})
+	if err != nil {
+		g.pool.unregister(g)
+		return nil, nil, err
+	}
+	g.mu.Lock()
+	g.elect(time.Now())
+	g.mu.Unlock()
+	return doneC, stopC, nil
+}
+
+// Deliver record an event of key and id received on path and call deliver if path is the one to deliver from,
+// ids at or below the last delivered one of key are skipped so that a switch of path does not replay events
+func (g *WsPathGroup) Deliver(path *WsPath, eventTime int64, key string, id int64, deliver func()) {
+	if !g.Observe(path, eventTime) {
+		return
+	}
+	g.mu.Lock()
+	if id <= g.lastID[key] {
+		g.mu.Unlock()
+		return
+	}
+	g.lastID[key] = id
+	g.mu.Unlock()
+	deliver()
+}
+
+// DeliverFirst record an event of key and id received on path and call deliver on its first arrival from any path,
+// for the streams without an event time, such as the spot book ticker, whose path latency can't be measured.
+// Deliveries are serialized so the handler is never called concurrently
+func (g *WsPathGroup) DeliverFirst(path *WsPath, key string, id int64, deliver func()) {
+	g.deliverMu.Lock()
+	defer g.deliverMu.Unlock()
+	g.mu.Lock()
+	path.Events++
+	first := id > g.lastID[key]
+	if first {
+		g.lastID[key] = id
+	}
+	g.mu.Unlock()
+	if first {
+		deliver()
+	}
+}
+
+// Observe record the lag of an event received on path and report whether path is the one to deliver from
+func (g *WsPathGroup) Observe(path *WsPath, eventTime int64) bool {
+	now := time.Now()
+	lag := now.Sub(time.Unix(0, eventTime*int64(time.Millisecond)))
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// the clock offset is the same on every path so it does not change their order
+	if path.Events == 0 {
+		path.Latency = lag
+	} else {
+		path.Latency = (path.Latency*15 + lag) / 16
+	}
+	path.Events++
+	if eventTime > path.LastEventTime {
+		path.LastEventTime = eventTime
+	}
+	if eventTime > g.lastEventTime {
+		g.lastEventTime = eventTime
+	}
+	// a silent path keeps its latency, track since when it misses the newer events of the others
+	for _, other := range g.paths {
+		switch {
+		case other.LastEventTime >= g.lastEventTime:
+			other.BehindSince = time.Time{}
+		case other.BehindSince.IsZero():
+			other.BehindSince = now
+		}
+	}
+	g.elect(now)
+	return path == g.best
+}
+
+// elect choose the alive path with the lowest latency, the current one is only replaced
+// by a path faster by more than wsPathSwitchMargin to avoid flapping,
+// paths missing the events of the others for longer than staleAfter are only used when no other is left
+func (g *WsPathGroup) elect(now time.Time) {
+	var best, stale *WsPath
+	for _, path := range g.paths {
+		switch {
+		case !path.Alive:
+		case g.stale(path, now):
+			if stale == nil || pathFaster(path, stale, 0) {
+				stale = path
+			}
+		case best == nil || pathFaster(path, best, 0):
+			best = path
+		}
+	}
+	if best == nil {
+		g.best = stale
+		return
+	}
+	if g.best != nil && g.best.Alive && !g.stale(g.best, now) && !pathFaster(best, g.best, wsPathSwitchMargin) {
+		return
+	}
+	g.best = best
+}
+
+func (g *WsPathGroup) stale(path *WsPath, now time.Time) bool {
+	return !path.BehindSince.IsZero() && now.Sub(path.BehindSince) >= g.staleAfter
+}
+
+// pathFaster report whether a is faster than b by more than margin, paths with events
+// are compared by event lag and always win over paths only measured by connect time
+func pathFaster(a, b *WsPath, margin time.Duration) bool {
+	if (a.Events > 0) != (b.Events > 0) {
+		return a.Events > 0
+	}
+	if a.Events == 0 {
+		return a.ConnectTime+margin < b.ConnectTime
+	}
+	return a.Latency+margin < b.Latency
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPPoolPick(t *testing.T) {
+	p, err := NewIPPool("127.0.0.1", "127.0.0.2", "127.0.0.3")
+	require.NoError(t, err)
+	now := time.Now()
+	p.ips[0].Latency = time.Millisecond
+	p.ips[1].Latency = 2 * time.Millisecond
+	p.ips[2].Latency = 3 * time.Millisecond
+	assert.Equal(t, "127.0.0.1", p.pick(now).IP)
+
+	// a banned IP is skipped and so is one that used up its weight in the current minute
+	p.ips[0].BannedUntil = now.Add(time.Minute)
+	p.ips[1].UsedWeight = defaultIPWeightLimit
+	p.ips[1].minute = now.Unix() / 60
+	assert.Equal(t, "127.0.0.3", p.pick(now).IP)
+
+	// once every IP is banned the one recovering first is used
+	p.ips[1].BannedUntil = now.Add(time.Second)
+	p.ips[2].BannedUntil = now.Add(time.Hour)
+	assert.Equal(t, "127.0.0.2", p.pick(now).IP)
+
+	// the transports keep the settings of the default transport
+	transport := p.ips[0].transport.(*http.Transport)
+	assert.NotNil(t, transport.Proxy)
+	assert.True(t, transport.ForceAttemptHTTP2)
+}
+
+func TestNewIPPoolInvalid(t *testing.T) {
+	_, err := NewIPPool()
+	assert.Error(t, err)
+	_, err = NewIPPool("localhost")
+	assert.EqualError(t, err, "invalid egress ip localhost")
+}
@@ -0,0 +1,164 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrPongTimeout is reported when no pong is received within the keepalive timeout
+var ErrPongTimeout = errors.New("websocket pong timeout")
+
+// WsMetrics receive the health measurements of websocket connections, conn is the name of the connection
+type WsMetrics interface {
+	// WsConnected is called when a connection is opened, opening a connection after one of the same name
+	// was closed is a reconnect
+	WsConnected(conn string)
+	// WsDisconnected is called when a connection is closed, err is nil when it was stopped by the client
+	WsDisconnected(conn string, err error)
+	// WsMessage is called for every message received with its size in bytes
+	WsMessage(conn string, size int)
+	// WsLatency is called with the delay between the exchange event time and the local receive time
+	WsLatency(conn string, latency time.Duration)
+	// WsPingRTT is called with the round trip time of a keepalive ping
+	WsPingRTT(conn string, rtt time.Duration)
+}
+
+// ObserveWsMessage report the size of a message received at now and, when it carries an event time, its latency
+func ObserveWsMessage(m WsMetrics, conn string, message []byte, now time.Time) {
+	m.WsMessage(conn, len(message))
+	if eventTime := WsEventTime(message); eventTime > 0 {
+		m.WsLatency(conn, now.Sub(time.Unix(0, eventTime*int64(time.Millisecond))))
+	}
+}
+
+var eventTimeKey = []byte(`"E":`)
+
+// WsEventTime return the first event time "E" of a message in milliseconds, 0 when it has none
+func WsEventTime(message []byte) int64 {
+	i := bytes.Index(message, eventTimeKey)
+	if i < 0 {
+		return 0
+	}
+	var eventTime int64
+	for _, b := range message[i+len(eventTimeKey):] {
+		if b < '0' || b > '9' {
+			if b == ' ' && eventTime == 0 {
+				continue
+			}
+			break
+		}
+		eventTime = eventTime*10 + int64(b-'0')
+	}
+	return eventTime
+}
+
+// WsAPIConnName is the metrics name prefix of the WS API connections
+const WsAPIConnName = "wsAPI"
+
+var wsAPIConns int64
+
+// NewWsAPIConnName return the metrics name of a new WS API client, numbered in the process and followed
+// by its local IP when it has one, e.g. wsAPI#2@10.0.0.1, so that two clients keep their own series
+func NewWsAPIConnName(localIP string) string {
+	name := WsConnInstanceName(WsAPIConnName, strconv.FormatInt(atomic.AddInt64(&wsAPIConns, 1), 10))
+	if localIP != "" {
+		name += "@" + localIP
+	}
+	return name
+}
+
+// WsConnInstanceName return the name of a connection followed by the discriminator of its instance,
+// e.g. bookTicker:20#race1 for the second connection of a race
+func WsConnInstanceName(name, instance string) string {
+	return name + "#" + instance
+}
+
+// WsMetricsInstance return m reporting the connections under the discriminator instance, so that the
+// connections of a race or pool opening the same stream keep their own series. It returns nil when m is nil
+func WsMetricsInstance(m WsMetrics, instance string) WsMetrics {
+	if m == nil {
+		return nil
+	}
+	return &instanceWsMetrics{m: m, instance: instance}
+}
+
+type instanceWsMetrics struct {
+	m        WsMetrics
+	instance string
+}
+
+func (m *instanceWsMetrics) WsConnected(conn string) {
+	m.m.WsConnected(WsConnInstanceName(conn, m.instance))
+}
+
+func (m *instanceWsMetrics) WsDisconnected(conn string, err error) {
+	m.m.WsDisconnected(WsConnInstanceName(conn, m.instance), err)
+}
+
+func (m *instanceWsMetrics) WsMessage(conn string, size int) {
+	m.m.WsMessage(WsConnInstanceName(conn, m.instance), size)
+}
+
+func (m *instanceWsMetrics) WsLatency(conn string, latency time.Duration) {
+	m.m.WsLatency(WsConnInstanceName(conn, m.instance), latency)
+}
+
+func (m *instanceWsMetrics) WsPingRTT(conn string, rtt time.Duration) {
+	m.m.WsPingRTT(WsConnInstanceName(conn, m.instance), rtt)
+}
+
+// WsConnName return the default metrics name of a market stream endpoint, the stream type and the number
+// of streams, e.g. bookTicker:20, so that the names stay few whatever the symbols. Streams of several types
+// are named combined and endpoints without a stream ws
+func WsConnName(endpoint string) string {
+	var streams []string
+	if i := strings.Index(endpoint, "streams="); i >= 0 {
+		query := endpoint[i+len("streams="):]
+		if j := strings.IndexByte(query, '&'); j >= 0 {
+			query = query[:j]
+		}
+		streams = strings.Split(query, "/")
+	} else {
+		streams = []string{endpoint[strings.LastIndexByte(endpoint, '/')+1:]}
+	}
+	name := ""
+	for _, stream := range streams {
+		streamType := wsStreamType(stream)
+		switch {
+		case streamType == "":
+			return "ws"
+		case name == "":
+			name = streamType
+		case name != streamType:
+			name = "combined"
+		}
+	}
+	return name + ":" + strconv.Itoa(len(streams))
+}
+
+// wsStreamType return the type of a stream name, e.g. depth for btcusdt@depth@100ms and !bookTicker for !bookTicker
+func wsStreamType(stream string) string {
+	if !strings.HasPrefix(stream, "!") {
+		i := strings.IndexByte(stream, '@')
+		if i < 0 {
+			return ""
+		}
+		stream = stream[i+1:]
+	}
+	if i := strings.IndexByte(stream, '@'); i >= 0 {
+		stream = stream[:i]
+	}
+	return stream
+}
+
+// UserDataConnName return the metrics name of a user data stream, only a prefix of the listen key is kept
+func UserDataConnName(listenKey string) string {
+	if len(listenKey) > 8 {
+		listenKey = listenKey[:8]
+	}
+	return "userData:" + listenKey
+}
@@ -0,0 +1,169 @@
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWsEventTime(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		name    string
+		message string
+		want    int64
+	}{
+		{
+			name:    "event",
+			message: `{"e":"aggTrade","E":1591261134288,"s":"BTCUSDT"}`,
+			want:    1591261134288,
+		},
+		{
+			name:    "combined event with spaces",
+			message: `{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade", "E": 1591261134288}}`,
+			want:    1591261134288,
+		},
+		{
+			name:    "no event time",
+			message: `{"result":null,"id":1}`,
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(tt.want, WsEventTime([]byte(tt.message)), tt.name)
+	}
+}
+
+func TestWsConnName(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{endpoint: "wss://stream.binance.com:9443/ws/btcusdt@aggTrade", want: "aggTrade:1"},
+		{endpoint: "wss://fstream.binance.com/ws/btcusdt@markPrice@1s", want: "markPrice:1"},
+		{endpoint: "wss://fstream.binance.com/ws/!bookTicker", want: "!bookTicker:1"},
+		{endpoint: "wss://fstream.binance.com/ws/!markPrice@arr@1s", want: "!markPrice:1"},
+		{endpoint: "wss://stream.binance.com:9443/stream?streams=btcusdt@bookTicker/ethusdt@bookTicker/bnbusdt@bookTicker", want: "bookTicker:3"},
+		{endpoint: "wss://fstream.binance.com/stream?streams=btcusdt@depth@100ms/ethusdt@depth20", want: "combined:2"},
+		{endpoint: "wss://stream.binance.com:9443/stream?streams=btcusdt@kline_1m/ethusdt@kline_1m&timeUnit=MICROSECOND", want: "kline_1m:2"},
+		{endpoint: "wss://fstream.binance.com/ws/pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1", want: "ws"},
+	}
+	for _, tt := range tests {
+		assert.Equal(tt.want, WsConnName(tt.endpoint), tt.endpoint)
+	}
+}
+
+func TestUserDataConnName(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("userData:pqia91ma", UserDataConnName("pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"))
+	assert.Equal("userData:abc", UserDataConnName("abc"))
+}
+
+func TestPrometheusWsMetrics(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Unix(1591261134, 500*int64(time.Millisecond))
+	m := NewPrometheusWsMetrics("binance")
+	m.now = func() time.Time { return now }
+
+	m.WsConnected("btcusdt@aggTrade")
+	ObserveWsMessage(m, "btcusdt@aggTrade", []byte(`{"e":"aggTrade","E":1591261134450}`), now)
+	ObserveWsMessage(m, "btcusdt@aggTrade", []byte(`{"e":"aggTrade","E":1591261134490}`), now)
+	m.WsPingRTT("btcusdt@aggTrade", 20*time.Millisecond)
+	m.WsDisconnected("btcusdt@aggTrade", ErrPongTimeout)
+	m.WsConnected("btcusdt@aggTrade")
+	m.WsConnected("userData:abc")
+	m.WsDisconnected("userData:abc", nil)
+	now = now.Add(2 * time.Second)
+
+	stats := m.Stats()
+	assert.Len(stats, 2)
+	s := stats[0]
+	assert.Equal("btcusdt@aggTrade", s.Conn)
+	assert.True(s.Up)
+	assert.Equal(int64(2), s.Connects)
+	assert.Equal(int64(1), s.Reconnects)
+	assert.Equal(int64(1), s.Disconnects)
+	assert.Equal(int64(1), s.Errors)
+	assert.Equal(int64(2), s.Messages)
+	assert.Equal(int64(68), s.Bytes)
+	assert.InDelta(0.2, s.MessageRate, 1e-9)
+	assert.Equal(2*time.Second, s.LastMessageAge)
+	assert.Equal(20*time.Millisecond, s.PingRTT)
+	assert.Equal(10*time.Millisecond, s.LatencyP50)
+	assert.Equal(10*time.Millisecond, s.LatencyP90)
+	assert.False(stats[1].Up)
+	assert.Equal(int64(0), stats[1].Errors)
+
+	var b bytes.Buffer
+	_, err := m.WriteTo(&b)
+	assert.NoError(err)
+	out := b.String()
+	assert.Contains(out, "# TYPE binance_ws_up gauge\n")
+	assert.Contains(out, "binance_ws_up{conn=\"btcusdt@aggTrade\"} 1\n")
+	assert.Contains(out, "binance_ws_up{conn=\"userData:abc\"} 0\n")
+	assert.Contains(out, "binance_ws_reconnects_total{conn=\"btcusdt@aggTrade\"} 1\n")
+	assert.Contains(out, "binance_ws_errors_total{conn=\"btcusdt@aggTrade\"} 1\n")
+	assert.Contains(out, "binance_ws_latency_seconds{conn=\"btcusdt@aggTrade\",quantile=\"0.99\"} 0.01\n")
+	assert.Contains(out, "binance_ws_latency_seconds_count{conn=\"btcusdt@aggTrade\"} 2\n")
+}
+
+func TestPrometheusWsMetricsConcurrentConns(t *testing.T) {
+	assert := assert.New(t)
+	m := NewPrometheusWsMetrics("binance")
+
+	// connections sharing a name are not reconnects and keep it up until the last one closes
+	m.WsConnected("bookTicker:20")
+	m.WsConnected("bookTicker:20")
+	m.WsDisconnected("bookTicker:20", ErrPongTimeout)
+	s := m.Stats()[0]
+	assert.True(s.Up)
+	assert.Equal(int64(0), s.Reconnects)
+
+	m.WsConnected("bookTicker:20")
+	m.WsDisconnected("bookTicker:20", nil)
+	m.WsDisconnected("bookTicker:20", nil)
+	s = m.Stats()[0]
+	assert.False(s.Up)
+	assert.Equal(int64(3), s.Connects)
+	assert.Equal(int64(1), s.Reconnects)
+}
+
+func TestWsMetricsInstance(t *testing.T) {
+	assert := assert.New(t)
+	m := NewPrometheusWsMetrics("binance")
+	WsMetricsInstance(m, "race0@10.0.0.1").WsConnected("bookTicker:20")
+	WsMetricsInstance(m, "race1").WsMessage("bookTicker:20", 10)
+	stats := m.Stats()
+	assert.Len(stats, 2)
+	assert.Equal("bookTicker:20#race0@10.0.0.1", stats[0].Conn)
+	assert.True(stats[0].Up)
+	assert.Equal("bookTicker:20#race1", stats[1].Conn)
+	assert.Equal(int64(1), stats[1].Messages)
+	assert.Nil(WsMetricsInstance(nil, "race0"))
+}
+
+func TestNewWsAPIConnName(t *testing.T) {
+	assert := assert.New(t)
+	first, second := NewWsAPIConnName(""), NewWsAPIConnName("10.0.0.1")
+	assert.True(strings.HasPrefix(first, "wsAPI#"))
+	assert.True(strings.HasSuffix(second, "@10.0.0.1"))
+	assert.NotEqual(first, strings.TrimSuffix(second, "@10.0.0.1"))
+}
+
+func TestPrometheusWsMetricsRate(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Unix(1591261134, 0)
+	m := NewPrometheusWsMetrics("binance")
+	m.now = func() time.Time { return now }
+	for i := 0; i < 20; i++ {
+		m.WsMessage("trade", 10)
+		now = now.Add(time.Second)
+	}
+	assert.InDelta(0.9, m.Stats()[0].MessageRate, 1e-9)
+	now = now.Add(time.Minute)
+	assert.Equal(0.0, m.Stats()[0].MessageRate)
+}
@@ -0,0 +1,269 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Redacted replaces the value of secret headers and query parameters in logs and traces
+const Redacted = "[REDACTED]"
+
+var (
+	// RedactedHeaders are the request headers never logged
+	RedactedHeaders = []string{"X-MBX-APIKEY"}
+	// RedactedParams are the query and form parameters never logged
+	RedactedParams = []string{"signature", "listenKey"}
+)
+
+// APIResponse define the response of a REST call with its body already read
+type APIResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// APIHandler send a REST request
+type APIHandler func(req *http.Request) (*APIResponse, error)
+
+// APIMiddleware wrap the APIHandler sending the requests of a client, to observe or modify requests and responses
+type APIMiddleware func(next APIHandler) APIHandler
+
+// NewAPIHandler init the APIHandler sending requests with do and reading the response body
+func NewAPIHandler(do func(req *http.Request) (*http.Response, error)) APIHandler {
+	return func(req *http.Request) (res *APIResponse, err error) {
+		r, err := do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			cerr := r.Body.Close()
+			// Only overwrite the retured error if the original error was nil and an
+			// error occurred while closing the body.
+			if err == nil && cerr != nil {
+				err = cerr
+			}
+		}()
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &APIResponse{StatusCode: r.StatusCode, Header: r.Header, Body: data}, nil
+	}
+}
+
+// ChainAPIMiddlewares wrap handler with middlewares, the first middleware sees the request first
+func ChainAPIMiddlewares(handler APIHandler, middlewares ...APIMiddleware) APIHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// APICall define the outcome of a REST call
+type APICall struct {
+	Method string
+	// Endpoint is the path of the request, e.g. /api/v3/order
+	Endpoint   string
+	StatusCode int
+	// Code is the error code of the API error returned, 0 on success
+	Code int64
+	// Message is the message of the API error returned
+	Message    string
+	Latency    time.Duration
+	UsedWeight int64
+	// Err is the transport error, API errors are reported by StatusCode and Code
+	Err error
+}
+
+func newAPICall(req *http.Request, res *APIResponse, err error, latency time.Duration) APICall {
+	call := APICall{
+		Method:   req.Method,
+		Endpoint: req.URL.Path,
+		Latency:  latency,
+		Err:      err,
+	}
+	if res != nil {
+		call.StatusCode = res.StatusCode
+		call.UsedWeight = UsedWeight(res.Header)
+		if res.StatusCode >= http.StatusBadRequest {
+			apiErr := new(APIError)
+			if json.Unmarshal(res.Body, apiErr) == nil {
+				call.Code, call.Message = apiErr.Code, apiErr.Message
+			}
+		}
+	}
+	return call
+}
+
+// UsedWeight return the request weight used in the current minute reported by the response header, 0 when missing
+func UsedWeight(header http.Header) int64 {
+	for _, key := range []string{"X-Mbx-Used-Weight-1m", "X-Mbx-Used-Weight"} {
+		if v := header.Get(key); v != "" {
+			weight, _ := strconv.ParseInt(v, 10, 64)
+			return weight
+		}
+	}
+	return 0
+}
+
+// RedactURL return u as a string without the values of RedactedParams
+func RedactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = RedactQuery(u.RawQuery)
+	return redacted.String()
+}
+
+// RedactQuery return the encoded query without the values of RedactedParams, the order of the parameters is kept
+func RedactQuery(query string) string {
+	if query == "" {
+		return query
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key := param
+		if j := strings.IndexByte(param, '='); j >= 0 {
+			key = param[:j]
+		}
+		for _, secret := range RedactedParams {
+			if strings.EqualFold(key, secret) {
+				params[i] = key + "=" + Redacted
+			}
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// RedactHeader return a copy of header without the values of RedactedHeaders
+func RedactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range RedactedHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, Redacted)
+		}
+	}
+	return redacted
+}
+
+// StructuredLogger log alternating keys and values, go-kit loggers implement it
+type StructuredLogger interface {
+	Log(keyvals ...interface{}) error
+}
+
+type logfmtLogger struct {
+	logger *log.Logger
+}
+
+// NewLogfmtLogger init a StructuredLogger writing key=value lines to logger
+func NewLogfmtLogger(logger *log.Logger) StructuredLogger {
+	return &logfmtLogger{logger: logger}
+}
+
+func (l *logfmtLogger) Log(keyvals ...interface{}) error {
+	var b strings.Builder
+	for i := 0; i < len(keyvals); i += 2 {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		var value interface{} = "MISSING"
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		v := fmt.Sprint(value)
+		if strings.ContainsAny(v, " \"=") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(&b, "%v=%s", keyvals[i], v)
+	}
+	l.logger.Print(b.String())
+	return nil
+}
+
+// LoggingMiddleware log every REST call to logger, the secret headers and parameters are redacted
+func LoggingMiddleware(logger StructuredLogger) APIMiddleware {
+	return func(next APIHandler) APIHandler {
+		return func(req *http.Request) (*APIResponse, error) {
+			start := time.Now()
+			res, err := next(req)
+			call := newAPICall(req, res, err, time.Since(start))
+			keyvals := []interface{}{
+				"method", call.Method,
+				"url", RedactURL(req.URL),
+				"status", call.StatusCode,
+				"latency", call.Latency,
+				"weight", call.UsedWeight,
+			}
+			if call.Code != 0 {
+				keyvals = append(keyvals, "code", call.Code)
+			}
+			if err != nil {
+				keyvals = append(keyvals, "err", err)
+			}
+			logger.Log(keyvals...)
+			return res, err
+		}
+	}
+}
+
+// APIMetrics receive the outcome of every REST call
+type APIMetrics interface {
+	APICall(call APICall)
+}
+
+// MetricsMiddleware report every REST call to metrics
+func MetricsMiddleware(metrics APIMetrics) APIMiddleware {
+	return func(next APIHandler) APIHandler {
+		return func(req *http.Request) (*APIResponse, error) {
+			start := time.Now()
+			res, err := next(req)
+			metrics.APICall(newAPICall(req, res, err, time.Since(start)))
+			return res, err
+		}
+	}
+}
+
+// Span define a traced operation, it is modeled after OpenTelemetry spans so that they can be adapted easily
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Tracer start spans, the returned context carries the span to the inner middlewares and the transport
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// TracingMiddleware trace every REST call with a span named after its method and endpoint
+func TracingMiddleware(tracer Tracer) APIMiddleware {
+	return func(next APIHandler) APIHandler {
+		return func(req *http.Request) (*APIResponse, error) {
+			ctx, span := tracer.Start(req.Context(), req.Method+" "+req.URL.Path)
+			defer span.End()
+			span.SetAttribute("http.method", req.Method)
+			span.SetAttribute("http.url", RedactURL(req.URL))
+			start := time.Now()
+			res, err := next(req.WithContext(ctx))
+			call := newAPICall(req, res, err, time.Since(start))
+			if res != nil {
+				span.SetAttribute("http.status_code", call.StatusCode)
+				span.SetAttribute("binance.used_weight", call.UsedWeight)
+			}
+			if call.Code != 0 {
+				span.SetAttribute("binance.error_code", call.Code)
+				span.RecordError(&APIError{Code: call.Code, Message: call.Message})
+			}
+			if err != nil {
+				span.RecordError(err)
+			}
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAPIHandler(statusCode int, body string, err error) APIHandler {
+	return NewAPIHandler(func(req *http.Request) (*http.Response, error) {
+		if err != nil {
+			return nil, err
+		}
+		header := http.Header{}
+		header.Set("X-Mbx-Used-Weight-1m", "42")
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+}
+
+func newTestAPIRequest() *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "https://fapi.binance.com/fapi/v1/order?symbol=BTCUSDT&timestamp=1&signature=abcdef", nil)
+	req.Header.Set("X-MBX-APIKEY", "secretApiKey")
+	return req
+}
+
+func TestChainAPIMiddlewares(t *testing.T) {
+	assert := assert.New(t)
+	var order []string
+	middleware := func(name string) APIMiddleware {
+		return func(next APIHandler) APIHandler {
+			return func(req *http.Request) (*APIResponse, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+	handler := ChainAPIMiddlewares(newTestAPIHandler(http.StatusOK, "{}", nil), middleware("first"), middleware("second"))
+	res, err := handler(newTestAPIRequest())
+	assert.NoError(err)
+	assert.Equal([]string{"first", "second"}, order)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal([]byte("{}"), res.Body)
+}
+
+func TestRedact(t *testing.T) {
+	assert := assert.New(t)
+	req := newTestAPIRequest()
+	assert.Equal("https://fapi.binance.com/fapi/v1/order?symbol=BTCUSDT&timestamp=1&signature=[REDACTED]", RedactURL(req.URL))
+	assert.Equal("listenKey=[REDACTED]", RedactQuery("listenKey=pqia91ma19a5s61cv6a81va65sdf19v8a65a1"))
+	header := RedactHeader(req.Header)
+	assert.Equal(Redacted, header.Get("X-MBX-APIKEY"))
+	assert.Equal("secretApiKey", req.Header.Get("X-MBX-APIKEY"))
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	assert := assert.New(t)
+	var b bytes.Buffer
+	logger := NewLogfmtLogger(log.New(&b, "", 0))
+	handler := LoggingMiddleware(logger)(newTestAPIHandler(http.StatusBadRequest, `{"code":-1121,"msg":"Invalid symbol."}`, nil))
+	_, err := handler(newTestAPIRequest())
+	assert.NoError(err)
+	line := b.String()
+	assert.True(strings.HasPrefix(line, `method=POST url="https://fapi.binance.com/fapi/v1/order?symbol=BTCUSDT&timestamp=1&signature=[REDACTED]" status=400 latency=`), line)
+	assert.Contains(line, " weight=42 code=-1121\n")
+	assert.NotContains(line, "abcdef")
+	assert.NotContains(line, "secretApiKey")
+}
+
+type testAPIMetrics struct {
+	calls []APICall
+}
+
+func (m *testAPIMetrics) APICall(call APICall) {
+	m.calls = append(m.calls, call)
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	assert := assert.New(t)
+	metrics := new(testAPIMetrics)
+	_, err := MetricsMiddleware(metrics)(newTestAPIHandler(http.StatusBadRequest, `{"code":-1121,"msg":"Invalid symbol."}`, nil))(newTestAPIRequest())
+	assert.NoError(err)
+	transportErr := errors.New("connection reset")
+	_, err = MetricsMiddleware(metrics)(newTestAPIHandler(0, "", transportErr))(newTestAPIRequest())
+	assert.Equal(transportErr, err)
+
+	assert.Len(metrics.calls, 2)
+	call := metrics.calls[0]
+	assert.Equal(http.MethodPost, call.Method)
+	assert.Equal("/fapi/v1/order", call.Endpoint)
+	assert.Equal(http.StatusBadRequest, call.StatusCode)
+	assert.Equal(int64(-1121), call.Code)
+	assert.Equal("Invalid symbol.", call.Message)
+	assert.Equal(int64(42), call.UsedWeight)
+	assert.NoError(call.Err)
+	assert.Equal(transportErr, metrics.calls[1].Err)
+
+	prom := NewPrometheusAPIMetrics("binance")
+	for _, call := range metrics.calls {
+		prom.APICall(call)
+	}
+	var b bytes.Buffer
+	_, err = prom.WriteTo(&b)
+	assert.NoError(err)
+	out := b.String()
+	assert.Contains(out, `binance_api_requests_total{method="POST",endpoint="/fapi/v1/order",status="0"} 1`+"\n")
+	assert.Contains(out, `binance_api_requests_total{method="POST",endpoint="/fapi/v1/order",status="400"} 1`+"\n")
+	assert.Contains(out, `binance_api_errors_total{method="POST",endpoint="/fapi/v1/order",code="-1121"} 1`+"\n")
+	assert.Contains(out, `binance_api_latency_seconds_count{method="POST",endpoint="/fapi/v1/order"} 2`+"\n")
+	assert.Contains(out, `binance_api_used_weight{method="POST",endpoint="/fapi/v1/order"} 42`+"\n")
+}
+
+type testSpanKey struct{}
+
+type testSpan struct {
+	name       string
+	attributes map[string]interface{}
+	errs       []error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &testSpan{name: name, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	assert := assert.New(t)
+	tracer := new(testTracer)
+	var inner interface{}
+	handler := TracingMiddleware(tracer)(func(req *http.Request) (*APIResponse, error) {
+		inner = req.Context().Value(testSpanKey{})
+		return newTestAPIHandler(http.StatusTeapot, `{"code":-1003,"msg":"banned"}`, nil)(req)
+	})
+	_, err := handler(newTestAPIRequest().WithContext(context.Background()))
+	assert.NoError(err)
+
+	assert.Len(tracer.spans, 1)
+	span := tracer.spans[0]
+	assert.Equal(span, inner)
+	assert.Equal("POST /fapi/v1/order", span.name)
+	assert.True(span.ended)
+	assert.Equal(http.StatusTeapot, span.attributes["http.status_code"])
+	assert.Equal(int64(-1003), span.attributes["binance.error_code"])
+	assert.Equal(int64(42), span.attributes["binance.used_weight"])
+	assert.NotContains(span.attributes["http.url"], "abcdef")
+	assert.Equal([]error{&APIError{Code: -1003, Message: "banned"}}, span.errs)
+}
+
+func TestUsedWeight(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(int64(0), UsedWeight(http.Header{}))
+	header := http.Header{}
+	header.Set("X-Mbx-Used-Weight", "7")
+	assert.Equal(int64(7), UsedWeight(header))
+}
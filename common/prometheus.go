@@ -0,0 +1,400 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// latencyWindow is the number of recent latencies the percentiles are computed from
+	latencyWindow = 1024
+	// rateWindow is the number of seconds the message rate is averaged over
+	rateWindow = 10
+)
+
+var latencyQuantiles = []float64{0.5, 0.9, 0.99}
+
+// WsConnStats define a snapshot of the metrics of a websocket connection
+type WsConnStats struct {
+	Conn        string
+	Up          bool
+	Connects    int64
+	Reconnects  int64
+	Disconnects int64
+	Errors      int64
+	Messages    int64
+	Bytes       int64
+	// MessageRate is the number of messages per second over the last 10 seconds
+	MessageRate    float64
+	LastMessageAge time.Duration
+	PingRTT        time.Duration
+	LatencyP50     time.Duration
+	LatencyP90     time.Duration
+	LatencyP99     time.Duration
+}
+
+type promWsConn struct {
+	// open is the number of connections of the name currently open, dropped the number of
+	// closed ones not opened again yet
+	open         int64
+	dropped      int64
+	connects     int64
+	reconnects   int64
+	disconnects  int64
+	errors       int64
+	messages     int64
+	bytes        int64
+	lastMessage  time.Time
+	pingRTT      time.Duration
+	latencies    []time.Duration
+	nextLatency  int
+	latencySum   time.Duration
+	latencyCount int64
+	seconds      [rateWindow]int64
+	lastSecond   int64
+}
+
+func (c *promWsConn) advance(second int64) {
+	if second <= c.lastSecond {
+		return
+	}
+	for s := c.lastSecond + 1; s <= second && s <= c.lastSecond+rateWindow; s++ {
+		c.seconds[s%rateWindow] = 0
+	}
+	c.lastSecond = second
+}
+
+func (c *promWsConn) rate(now time.Time) float64 {
+	c.advance(now.Unix())
+	var sum int64
+	for _, n := range c.seconds {
+		sum += n
+	}
+	return float64(sum) / rateWindow
+}
+
+func (c *promWsConn) quantiles() []time.Duration {
+	res := make([]time.Duration, len(latencyQuantiles))
+	if len(c.latencies) == 0 {
+		return res
+	}
+	sorted := append([]time.Duration(nil), c.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i, q := range latencyQuantiles {
+		res[i] = sorted[int(q*float64(len(sorted)-1))]
+	}
+	return res
+}
+
+// PrometheusWsMetrics implement WsMetrics and serve the measurements in the Prometheus text exposition format
+type PrometheusWsMetrics struct {
+	mu        sync.Mutex
+	namespace string
+	conns     map[string]*promWsConn
+	now       func() time.Time
+}
+
+// NewPrometheusWsMetrics init a PrometheusWsMetrics, the metric names are prefixed with namespace
+func NewPrometheusWsMetrics(namespace string) *PrometheusWsMetrics {
+	return &PrometheusWsMetrics{
+		namespace: namespace,
+		conns:     make(map[string]*promWsConn),
+		now:       time.Now,
+	}
+}
+
+func (m *PrometheusWsMetrics) conn(name string) *promWsConn {
+	c, ok := m.conns[name]
+	if !ok {
+		c = &promWsConn{lastSecond: m.now().Unix()}
+		m.conns[name] = c
+	}
+	return c
+}
+
+// WsConnected implement WsMetrics
+func (m *PrometheusWsMetrics) WsConnected(conn string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.conn(conn)
+	if c.dropped > 0 {
+		c.dropped--
+		c.reconnects++
+	}
+	c.open++
+	c.connects++
+}
+
+// WsDisconnected implement WsMetrics
+func (m *PrometheusWsMetrics) WsDisconnected(conn string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.conn(conn)
+	if c.open > 0 {
+		c.open--
+	}
+	c.dropped++
+	c.disconnects++
+	if err != nil {
+		c.errors++
+	}
+}
+
+// WsMessage implement WsMetrics
+func (m *PrometheusWsMetrics) WsMessage(conn string, size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	c := m.conn(conn)
+	c.messages++
+	c.bytes += int64(size)
+	c.lastMessage = now
+	c.advance(now.Unix())
+	c.seconds[now.Unix()%rateWindow]++
+}
+
+// WsLatency implement WsMetrics
+func (m *PrometheusWsMetrics) WsLatency(conn string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.conn(conn)
+	if len(c.latencies) < latencyWindow {
+		c.latencies = append(c.latencies, latency)
+	} else {
+		c.latencies[c.nextLatency] = latency
+		c.nextLatency = (c.nextLatency + 1) % latencyWindow
+	}
+	c.latencySum += latency
+	c.latencyCount++
+}
+
+// WsPingRTT implement WsMetrics
+func (m *PrometheusWsMetrics) WsPingRTT(conn string, rtt time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.conn(conn).pingRTT = rtt
+}
+
+// Stats return a snapshot of the connections sorted by name
+func (m *PrometheusWsMetrics) Stats() []WsConnStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	stats := make([]WsConnStats, 0, len(m.conns))
+	for name, c := range m.conns {
+		s := WsConnStats{
+			Conn:        name,
+			Up:          c.open > 0,
+			Connects:    c.connects,
+			Reconnects:  c.reconnects,
+			Disconnects: c.disconnects,
+			Errors:      c.errors,
+			Messages:    c.messages,
+			Bytes:       c.bytes,
+			MessageRate: c.rate(now),
+			PingRTT:     c.pingRTT,
+		}
+		if !c.lastMessage.IsZero() {
+			s.LastMessageAge = now.Sub(c.lastMessage)
+		}
+		q := c.quantiles()
+		s.LatencyP50, s.LatencyP90, s.LatencyP99 = q[0], q[1], q[2]
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Conn < stats[j].Conn })
+	return stats
+}
+
+// ServeHTTP write the metrics in the Prometheus text exposition format
+func (m *PrometheusWsMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(w)
+}
+
+// WriteTo write the metrics in the Prometheus text exposition format to w
+func (m *PrometheusWsMetrics) WriteTo(w io.Writer) (int64, error) {
+	stats := m.Stats()
+	m.mu.Lock()
+	sums := make(map[string][2]float64, len(m.conns))
+	for name, c := range m.conns {
+		sums[name] = [2]float64{c.latencySum.Seconds(), float64(c.latencyCount)}
+	}
+	m.mu.Unlock()
+
+	var b bytes.Buffer
+	metric := func(name, typ, help string, value func(s WsConnStats) float64) {
+		name = m.namespace + "_ws_" + name
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		for _, s := range stats {
+			fmt.Fprintf(&b, "%s{conn=\"%s\"} %g\n", name, escapeLabel(s.Conn), value(s))
+		}
+	}
+	boolValue := func(v bool) float64 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	metric("up", "gauge", "Whether a connection of the name is open.",
+		func(s WsConnStats) float64 { return boolValue(s.Up) })
+	metric("connects_total", "counter", "Connections opened.",
+		func(s WsConnStats) float64 { return float64(s.Connects) })
+	metric("reconnects_total", "counter", "Connections opened again after one of the name was closed.",
+		func(s WsConnStats) float64 { return float64(s.Reconnects) })
+	metric("disconnects_total", "counter", "Connections closed.",
+		func(s WsConnStats) float64 { return float64(s.Disconnects) })
+	metric("errors_total", "counter", "Connections closed by an error.",
+		func(s WsConnStats) float64 { return float64(s.Errors) })
+	metric("messages_total", "counter", "Messages received.",
+		func(s WsConnStats) float64 { return float64(s.Messages) })
+	metric("received_bytes_total", "counter", "Bytes received.",
+		func(s WsConnStats) float64 { return float64(s.Bytes) })
+	metric("messages_per_second", "gauge", "Messages per second over the last 10 seconds.",
+		func(s WsConnStats) float64 { return s.MessageRate })
+	metric("last_message_age_seconds", "gauge", "Seconds since the last message.",
+		func(s WsConnStats) float64 { return s.LastMessageAge.Seconds() })
+	metric("ping_rtt_seconds", "gauge", "Round trip time of the last keepalive ping.",
+		func(s WsConnStats) float64 { return s.PingRTT.Seconds() })
+
+	name := m.namespace + "_ws_latency_seconds"
+	fmt.Fprintf(&b, "# HELP %s Delay between the exchange event time and the local receive time.\n# TYPE %s summary\n", name, name)
+	for _, s := range stats {
+		conn := escapeLabel(s.Conn)
+		for i, v := range []time.Duration{s.LatencyP50, s.LatencyP90, s.LatencyP99} {
+			fmt.Fprintf(&b, "%s{conn=\"%s\",quantile=\"%g\"} %g\n", name, conn, latencyQuantiles[i], v.Seconds())
+		}
+		fmt.Fprintf(&b, "%s_sum{conn=\"%s\"} %g\n", name, conn, sums[s.Conn][0])
+		fmt.Fprintf(&b, "%s_count{conn=\"%s\"} %g\n", name, conn, sums[s.Conn][1])
+	}
+	return b.WriteTo(w)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+type promAPIKey struct {
+	method   string
+	endpoint string
+}
+
+type promAPIEndpoint struct {
+	requests   map[int]int64
+	errors     map[int64]int64
+	latencySum time.Duration
+	usedWeight int64
+}
+
+// PrometheusAPIMetrics implement APIMetrics and serve the REST calls per endpoint in the Prometheus text exposition format
+type PrometheusAPIMetrics struct {
+	mu        sync.Mutex
+	namespace string
+	endpoints map[promAPIKey]*promAPIEndpoint
+}
+
+// NewPrometheusAPIMetrics init a PrometheusAPIMetrics, the metric names are prefixed with namespace
+func NewPrometheusAPIMetrics(namespace string) *PrometheusAPIMetrics {
+	return &PrometheusAPIMetrics{
+		namespace: namespace,
+		endpoints: make(map[promAPIKey]*promAPIEndpoint),
+	}
+}
+
+// APICall implement APIMetrics
+func (m *PrometheusAPIMetrics) APICall(call APICall) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := promAPIKey{method: call.Method, endpoint: call.Endpoint}
+	e, ok := m.endpoints[key]
+	if !ok {
+		e = &promAPIEndpoint{requests: make(map[int]int64), errors: make(map[int64]int64)}
+		m.endpoints[key] = e
+	}
+	e.requests[call.StatusCode]++
+	if call.Code != 0 {
+		e.errors[call.Code]++
+	}
+	e.latencySum += call.Latency
+	if call.StatusCode != 0 {
+		e.usedWeight = call.UsedWeight
+	}
+}
+
+// ServeHTTP write the metrics in the Prometheus text exposition format
+func (m *PrometheusAPIMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(w)
+}
+
+// WriteTo write the metrics in the Prometheus text exposition format to w, a status of 0 counts transport errors
+func (m *PrometheusAPIMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]promAPIKey, 0, len(m.endpoints))
+	for key := range m.endpoints {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+	labels := func(key promAPIKey) string {
+		return fmt.Sprintf("method=\"%s\",endpoint=\"%s\"", escapeLabel(key.method), escapeLabel(key.endpoint))
+	}
+
+	var b bytes.Buffer
+	header := func(name, typ, help string) string {
+		name = m.namespace + "_api_" + name
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		return name
+	}
+	name := header("requests_total", "counter", "REST calls by HTTP status, 0 for transport errors.")
+	for _, key := range keys {
+		e := m.endpoints[key]
+		statuses := make([]int, 0, len(e.requests))
+		for status := range e.requests {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "%s{%s,status=\"%d\"} %d\n", name, labels(key), status, e.requests[status])
+		}
+	}
+	name = header("errors_total", "counter", "REST calls by API error code.")
+	for _, key := range keys {
+		e := m.endpoints[key]
+		codes := make([]int64, 0, len(e.errors))
+		for code := range e.errors {
+			codes = append(codes, code)
+		}
+		sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+		for _, code := range codes {
+			fmt.Fprintf(&b, "%s{%s,code=\"%d\"} %d\n", name, labels(key), code, e.errors[code])
+		}
+	}
+	name = header("latency_seconds", "summary", "Duration of the REST calls.")
+	for _, key := range keys {
+		e := m.endpoints[key]
+		var count int64
+		for _, n := range e.requests {
+			count += n
+		}
+		fmt.Fprintf(&b, "%s_sum{%s} %g\n", name, labels(key), e.latencySum.Seconds())
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", name, labels(key), count)
+	}
+	name = header("used_weight", "gauge", "Request weight used in the current minute after the last call.")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s{%s} %d\n", name, labels(key), m.endpoints[key].usedWeight)
+	}
+	return b.WriteTo(w)
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var gReqID = time.Now().UnixNano() / 1e6
+
+// GetReqID return a process wide unique id for a websocket API request
+func GetReqID() string {
+	return strconv.FormatInt(atomic.AddInt64(&gReqID, 1), 10)
+}
+
+// StructToMap convert a websocket API request struct to its params through its json tags
+func StructToMap(i interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	j, _ := json.Marshal(i)
+	_ = json.Unmarshal(j, &m)
+
+	return m
+}
+
+// MakeQueryString join params sorted by key into the payload signed by websocket API requests
+func MakeQueryString(params map[string]interface{}) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var payloadBuilder strings.Builder
+	for _, key := range keys {
+		payloadBuilder.WriteString(fmt.Sprintf("%s=%v&", key, params[key]))
+	}
+	return strings.TrimRight(payloadBuilder.String(), "&")
+}
+
+// ParsePrivateKey parse a PEM encoded PKCS #8 private key, such as an Ed25519 API key
+func ParsePrivateKey(apiSecret string) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(apiSecret))
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("failed to decode PEM block containing private key")
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey, nil
+}
+
+// SignPayload sign payload with privateKey and return the base64 encoded signature
+func SignPayload(payload string, privateKey crypto.PrivateKey) (string, error) {
+	key, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return "", fmt.Errorf("key does not implement crypto.Signer")
+	}
+
+	signature, err := key.Sign(nil, []byte(payload), crypto.Hash(0))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
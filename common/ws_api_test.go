@@ -0,0 +1,55 @@
+package common
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReqID(t *testing.T) {
+	first, err := strconv.ParseInt(GetReqID(), 10, 64)
+	require.NoError(t, err)
+	second, err := strconv.ParseInt(GetReqID(), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, first+1, second)
+}
+
+func TestStructToMap(t *testing.T) {
+	order := struct {
+		Symbol   string `json:"symbol"`
+		Quantity string `json:"quantity,omitempty"`
+		Price    string `json:"price,omitempty"`
+	}{Symbol: "BTCUSD_PERP", Quantity: "1"}
+	assert.Equal(t, map[string]interface{}{"symbol": "BTCUSD_PERP", "quantity": "1"}, StructToMap(order))
+}
+
+func TestMakeQueryString(t *testing.T) {
+	params := map[string]interface{}{"timestamp": int64(1728980000000), "apiKey": "key", "symbol": "BTCUSD_PERP"}
+	assert.Equal(t, "apiKey=key&symbol=BTCUSD_PERP&timestamp=1728980000000", MakeQueryString(params))
+	assert.Equal(t, "", MakeQueryString(nil))
+}
+
+func TestSignPayload(t *testing.T) {
+	publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	privateKey, err := ParsePrivateKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+	require.NoError(t, err)
+	signature, err := SignPayload("apiKey=key&timestamp=1728980000000", privateKey)
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(signature)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, []byte("apiKey=key&timestamp=1728980000000"), raw))
+
+	_, err = ParsePrivateKey("not a pem key")
+	assert.Error(t, err)
+}
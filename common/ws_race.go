@@ -0,0 +1,355 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// raceHistory is the number of first arrivals kept to measure the lag of the other connections
+	raceHistory = 4096
+	// raceResetGap is the regression of an ID taken as a restart of its sequence rather than a late duplicate
+	raceResetGap = 1000000
+	// raceKeyExpiry is how long a key is kept without a new event, no duplicate is expected that late
+	raceKeyExpiry = time.Minute
+
+	defaultRaceRedialMin = time.Second
+	defaultRaceRedialMax = time.Minute
+)
+
+// WsRaceConn define the statistics of a connection of a WsRace
+type WsRaceConn struct {
+	IP string
+	// Name is the discriminator of the connection in the metrics, the race name and its index
+	// followed by its IP, e.g. race0@10.0.0.1
+	Name string
+	// Events is the number of events received
+	Events int64
+	// Wins is the number of events this connection delivered first
+	Wins int64
+	// Lag is the smoothed delay behind the first arrival of the events it lost
+	Lag   time.Duration
+	Alive bool
+	lags  int64
+}
+
+// WinRate return the share of the received events this connection delivered first
+func (c WsRaceConn) WinRate() float64 {
+	if c.Events == 0 {
+		return 0
+	}
+	return float64(c.Wins) / float64(c.Events)
+}
+
+type raceKey struct {
+	key string
+	id  int64
+}
+
+type raceLast struct {
+	id int64
+	at time.Time
+}
+
+// WsRaceServeFunc start the stream of a race on conn, dialer is nil when conn uses the default route
+type WsRaceServeFunc func(conn *WsRaceConn, dialer *websocket.Dialer, errHandler func(err error)) (doneC, stopC chan struct{}, err error)
+
+// WsRace run the same stream on several connections and deliver each event once, from the first
+// connection receiving it. Events are keyed by symbol and their update or trade ID, which grow on every event,
+// an ID far below the last one restarts the sequence of its key and keys without events for a minute are dropped.
+// Deliveries are serialized so the handler is never called concurrently and receives the events of a key
+// in ID order, a slow handler delays the other connections. Dead connections are redialed until the race is stopped.
+// A WsRace serves a single stream
+type WsRace struct {
+	mu        sync.Mutex
+	deliverMu sync.Mutex
+	conns     []*WsRaceConn
+	last      map[string]*raceLast
+	lastSweep time.Time
+	arrivals  map[raceKey]time.Time
+	order     []raceKey
+	redialMin time.Duration
+	redialMax time.Duration
+}
+
+// NewWsRace init a WsRace with a connection from each local IP, an empty IP uses the default route
+// so that NewWsRace("", "") races two connections on the same interface
+func NewWsRace(ips ...string) *WsRace {
+	r := &WsRace{
+		last:      make(map[string]*raceLast),
+		arrivals:  make(map[raceKey]time.Time),
+		redialMin: defaultRaceRedialMin,
+		redialMax: defaultRaceRedialMax,
+	}
+	for _, ip := range ips {
+		r.conns = append(r.conns, &WsRaceConn{IP: ip})
+	}
+	r.SetName("race")
+	return r
+}
+
+// SetName set the prefix of the connection names reported to the metrics, defaults to race
+func (r *WsRace) SetName(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, conn := range r.conns {
+		conn.Name = name + strconv.Itoa(i)
+		if conn.IP != "" {
+			conn.Name += "@" + conn.IP
+		}
+	}
+}
+
+// Conns return a snapshot of the connection statistics
+func (r *WsRace) Conns() []WsRaceConn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conns := make([]WsRaceConn, 0, len(r.conns))
+	for _, conn := range r.conns {
+		conns = append(conns, *conn)
+	}
+	return conns
+}
+
+// SetRedialBackoff set the delays between the redials of a dead connection, doubling from min up to max,
+// defaults to 1s and 1m
+func (r *WsRace) SetRedialBackoff(min, max time.Duration) {
+	r.mu.Lock()
+	r.redialMin, r.redialMax = min, max
+	r.mu.Unlock()
+}
+
+// Serve start serve on every connection of the race and redial the ones exiting until stopC is closed,
+// doneC is closed once all of them have exited. err is only returned when no connection started
+func (r *WsRace) Serve(serve WsRaceServeFunc, errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	dones := make([]chan struct{}, len(r.conns))
+	stops := make([]chan struct{}, len(r.conns))
+	started := 0
+	for i := range r.conns {
+		var e error
+		dones[i], stops[i], e = r.dial(i, serve, errHandler)
+		if e != nil {
+			err = e
+			continue
+		}
+		started++
+	}
+	if started == 0 {
+		return nil, nil, err
+	}
+	return ServeGroup(len(r.conns), func(i int) (chan struct{}, chan struct{}, error) {
+		doneC, stopC := r.redial(i, dones[i], stops[i], serve, errHandler)
+		return doneC, stopC, nil
+	}, func(i int) {})
+}
+
+func (r *WsRace) dial(i int, serve WsRaceServeFunc, errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	conn := r.conns[i]
+	var dialer *websocket.Dialer
+	if conn.IP != "" {
+		dialer = NewEgressDialer(conn.IP, "", nil)
+	}
+	r.setAlive(conn, true)
+	connDone, connStop, err := serve(conn, dialer, func(err error) {
+		errHandler(fmt.Errorf("race connection %d: %w", i, err))
+	})
+	if err != nil {
+		r.setAlive(conn, false)
+		err = fmt.Errorf("race connection %d: %w", i, err)
+		errHandler(err)
+		return nil, nil, err
+	}
+	return connDone, connStop, nil
+}
+
+// redial keep the connection i open until stopC is closed, connDone is nil when its first dial failed
+func (r *WsRace) redial(i int, connDone, connStop chan struct{}, serve WsRaceServeFunc, errHandler func(err error)) (doneC, stopC chan struct{}) {
+	doneC = make(chan struct{})
+	stopC = make(chan struct{})
+	go func() {
+		defer close(doneC)
+		conn := r.conns[i]
+		r.mu.Lock()
+		redialMin, redialMax := r.redialMin, r.redialMax
+		r.mu.Unlock()
+		backoff := redialMin
+		for {
+			if connDone != nil {
+				select {
+				case <-stopC:
+					close(connStop)
+					<-connDone
+					r.setAlive(conn, false)
+					return
+				case <-connDone:
+					r.setAlive(conn, false)
+				}
+			}
+			timer := time.NewTimer(backoff)
+			select {
+			case <-stopC:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			var err error
+			connDone, connStop, err = r.dial(i, serve, errHandler)
+			if err != nil {
+				connDone = nil
+				backoff *= 2
+				if backoff > redialMax {
+					backoff = redialMax
+				}
+				continue
+			}
+			backoff = redialMin
+		}
+	}()
+	return doneC, stopC
+}
+
+// Deliver record an event of key and id received on conn and call deliver if it is its first arrival
+func (r *WsRace) Deliver(conn *WsRaceConn, key string, id int64, deliver func()) {
+	r.deliverMu.Lock()
+	defer r.deliverMu.Unlock()
+	if r.first(conn, key, id) {
+		deliver()
+	}
+}
+
+func (r *WsRace) setAlive(conn *WsRaceConn, alive bool) {
+	r.mu.Lock()
+	conn.Alive = alive
+	r.mu.Unlock()
+}
+
+// first record an event received on conn and report whether it is its first arrival
+func (r *WsRace) first(conn *WsRaceConn, key string, id int64) bool {
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conn.Events++
+	k := raceKey{key: key, id: id}
+	last, ok := r.last[key]
+	if !ok || id > last.id || last.id-id > raceResetGap {
+		r.last[key] = &raceLast{id: id, at: now}
+		r.expire(now)
+		conn.Wins++
+		r.arrivals[k] = now
+		r.order = append(r.order, k)
+		if len(r.order) > raceHistory {
+			delete(r.arrivals, r.order[0])
+			r.order = r.order[1:]
+		}
+		return true
+	}
+	if arrival, ok := r.arrivals[k]; ok {
+		lag := now.Sub(arrival)
+		if conn.lags == 0 {
+			conn.Lag = lag
+		} else {
+			conn.Lag = (conn.Lag*15 + lag) / 16
+		}
+		conn.lags++
+	}
+	return false
+}
+
+// expire drop the keys without a new event for raceKeyExpiry, at most once per raceKeyExpiry
+func (r *WsRace) expire(now time.Time) {
+	if now.Sub(r.lastSweep) < raceKeyExpiry {
+		return
+	}
+	r.lastSweep = now
+	for key, last := range r.last {
+		if now.Sub(last.at) >= raceKeyExpiry {
+			delete(r.last, key)
+		}
+	}
+}
+
+// NewEgressDialer return a dialer connecting from localIP to serverAddr, the host of the endpoint is dialed
+// when serverAddr is empty and onConnect, if set, receives the duration of the TCP connect.
+// A dialer pinned to serverAddr connects directly, the proxy of the environment would hide the path
+func NewEgressDialer(localIP, serverAddr string, onConnect func(connectTime time.Duration)) *websocket.Dialer {
+	dialer := &websocket.Dialer{
+		NetDial: func(network, addr string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 10 * time.Second}
+			if localIP != "" {
+				localAddr, err := net.ResolveTCPAddr("tcp", localIP+":0")
+				if err != nil {
+					return nil, err
+				}
+				d.LocalAddr = localAddr
+			}
+			if serverAddr != "" {
+				_, port, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err
+				}
+				addr = net.JoinHostPort(serverAddr, port)
+			}
+			start := time.Now()
+			conn, err := d.Dial(network, addr)
+			if err == nil && onConnect != nil {
+				onConnect(time.Since(start))
+			}
+			return conn, err
+		},
+		HandshakeTimeout: 45 * time.Second,
+	}
+	if serverAddr == "" {
+		dialer.Proxy = http.ProxyFromEnvironment
+	}
+	return dialer
+}
+
+// ServeGroup start n connections with serve and merge their channels, onDone is called when the connection i exits,
+// doneC is closed once every started connection has exited and closing stopC stops all of them.
+// Connections failing to start are skipped, err is only returned when none of them started
+func ServeGroup(n int, serve func(i int) (doneC, stopC chan struct{}, err error), onDone func(i int)) (doneC, stopC chan struct{}, err error) {
+	var wg sync.WaitGroup
+	var stops []chan struct{}
+	for i := 0; i < n; i++ {
+		connDone, connStop, e := serve(i)
+		if e != nil {
+			err = e
+			continue
+		}
+		stops = append(stops, connStop)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-connDone
+			onDone(i)
+		}(i)
+	}
+	if len(stops) == 0 {
+		return nil, nil, err
+	}
+	allDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(allDone)
+	}()
+	doneC = make(chan struct{})
+	stopC = make(chan struct{})
+	go func() {
+		defer close(doneC)
+		select {
+		case <-stopC:
+			for _, connStop := range stops {
+				close(connStop)
+			}
+			<-allDone
+		case <-allDone:
+		}
+	}()
+	return doneC, stopC, nil
+}
@@ -0,0 +1,185 @@
+package common
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWsRaceDeliver(t *testing.T) {
+	assert := assert.New(t)
+	r := NewWsRace("", "", "")
+	var running int32
+	var delivered []int64
+	var wg sync.WaitGroup
+	for _, conn := range r.conns {
+		wg.Add(1)
+		go func(conn *WsRaceConn) {
+			defer wg.Done()
+			for id := int64(1); id <= 100; id++ {
+				id := id
+				r.Deliver(conn, "BTCUSDT", id, func() {
+					// deliveries never overlap
+					assert.Equal(int32(1), atomic.AddInt32(&running, 1))
+					time.Sleep(time.Microsecond)
+					delivered = append(delivered, id)
+					atomic.AddInt32(&running, -1)
+				})
+			}
+		}(conn)
+	}
+	wg.Wait()
+
+	assert.Len(delivered, 100)
+	for i, id := range delivered {
+		assert.Equal(int64(i+1), id)
+	}
+	var events, wins int64
+	for _, conn := range r.Conns() {
+		events += conn.Events
+		wins += conn.Wins
+	}
+	assert.Equal(int64(300), events)
+	assert.Equal(int64(100), wins)
+}
+
+func TestWsRaceKeyReset(t *testing.T) {
+	assert := assert.New(t)
+	r := NewWsRace("", "")
+	var delivered []int64
+	deliver := func(conn *WsRaceConn, id int64) {
+		r.Deliver(conn, "BTCUSDT", id, func() {
+			delivered = append(delivered, id)
+		})
+	}
+	deliver(r.conns[0], 5000000)
+	deliver(r.conns[1], 5000000)
+	deliver(r.conns[1], 4999990)
+	// the sequence restarted far below the last ID
+	deliver(r.conns[0], 10)
+	deliver(r.conns[1], 10)
+	deliver(r.conns[1], 11)
+	assert.Equal([]int64{5000000, 10, 11}, delivered)
+
+	// a key without events for raceKeyExpiry is dropped on the next sweep
+	r.last["BTCUSDT"].at = time.Now().Add(-raceKeyExpiry)
+	r.lastSweep = time.Time{}
+	r.Deliver(r.conns[0], "ETHUSDT", 1, func() {})
+	assert.NotContains(r.last, "BTCUSDT")
+	deliver(r.conns[0], 3)
+	assert.Equal([]int64{5000000, 10, 11, 3}, delivered)
+}
+
+func TestWsRaceRedial(t *testing.T) {
+	assert := assert.New(t)
+	r := NewWsRace("", "")
+	r.SetRedialBackoff(time.Millisecond, 4*time.Millisecond)
+	var mu sync.Mutex
+	dials := make([]int, 2)
+	kills := make([]chan struct{}, 2)
+	doneC, stopC, err := r.Serve(func(conn *WsRaceConn, dialer *websocket.Dialer, errHandler func(err error)) (chan struct{}, chan struct{}, error) {
+		i := 0
+		if conn == r.conns[1] {
+			i = 1
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		dials[i]++
+		// the second connection fails its first two dials
+		if i == 1 && dials[i] <= 2 {
+			return nil, nil, errors.New("dial error")
+		}
+		kill := make(chan struct{})
+		kills[i] = kill
+		doneC := make(chan struct{})
+		stopC := make(chan struct{})
+		go func() {
+			select {
+			case <-stopC:
+			case <-kill:
+			}
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}, func(err error) {})
+	assert.NoError(err)
+
+	assert.Eventually(func() bool {
+		return r.Conns()[1].Alive
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	close(kills[0])
+	mu.Unlock()
+	assert.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return dials[0] == 2 && r.Conns()[0].Alive
+	}, time.Second, time.Millisecond)
+
+	close(stopC)
+	<-doneC
+	for _, conn := range r.Conns() {
+		assert.False(conn.Alive)
+	}
+	mu.Lock()
+	assert.Equal([]int{2, 3}, dials)
+	mu.Unlock()
+
+	_, _, err = NewWsRace("").Serve(func(conn *WsRaceConn, dialer *websocket.Dialer, errHandler func(err error)) (chan struct{}, chan struct{}, error) {
+		return nil, nil, errors.New("dial error")
+	}, func(err error) {})
+	assert.EqualError(err, "race connection 0: dial error")
+}
+
+func TestServeGroup(t *testing.T) {
+	assert := assert.New(t)
+	var mu sync.Mutex
+	var exited []int
+	kills := []chan struct{}{make(chan struct{}), nil, make(chan struct{})}
+	doneC, stopC, err := ServeGroup(3, func(i int) (chan struct{}, chan struct{}, error) {
+		if kills[i] == nil {
+			return nil, nil, errors.New("dial error")
+		}
+		doneC := make(chan struct{})
+		stopC := make(chan struct{})
+		go func() {
+			select {
+			case <-stopC:
+			case <-kills[i]:
+			}
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}, func(i int) {
+		mu.Lock()
+		exited = append(exited, i)
+		mu.Unlock()
+	})
+	assert.NoError(err)
+
+	close(kills[0])
+	assert.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(exited) == 1
+	}, time.Second, time.Millisecond)
+	close(stopC)
+	<-doneC
+	assert.Equal([]int{0, 2}, exited)
+
+	_, _, err = ServeGroup(1, func(i int) (chan struct{}, chan struct{}, error) {
+		return nil, nil, errors.New("dial error")
+	}, func(i int) {})
+	assert.EqualError(err, "dial error")
+}
+
+func TestNewEgressDialer(t *testing.T) {
+	assert := assert.New(t)
+	assert.NotNil(NewEgressDialer("127.0.0.1", "", nil).Proxy)
+	assert.Nil(NewEgressDialer("127.0.0.1", "10.0.0.1", nil).Proxy)
+}
@@ -0,0 +1,519 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+)
+
+// ErrConvertQuoteExpired is returned when accepting a quote whose validTimestamp has passed
+var ErrConvertQuoteExpired = errors.New("convert quote expired")
+
+// ConvertQuoteValidTime define how long a convert quote can be accepted
+type ConvertQuoteValidTime string
+
+// ConvertWalletType define the wallets funding a convert
+type ConvertWalletType string
+
+// ConvertLimitExpiredType define how long a convert limit order stays open
+type ConvertLimitExpiredType string
+
+// Convert enums
+const (
+	ConvertQuoteValidTime10s ConvertQuoteValidTime = "10s"
+	ConvertQuoteValidTime30s ConvertQuoteValidTime = "30s"
+	ConvertQuoteValidTime1m  ConvertQuoteValidTime = "1m"
+	ConvertQuoteValidTime2m  ConvertQuoteValidTime = "2m"
+
+	ConvertWalletTypeSpot        ConvertWalletType = "SPOT"
+	ConvertWalletTypeFunding     ConvertWalletType = "FUNDING"
+	ConvertWalletTypeSpotFunding ConvertWalletType = "SPOT_FUNDING"
+
+	ConvertLimitExpiredType1D  ConvertLimitExpiredType = "1_D"
+	ConvertLimitExpiredType3D  ConvertLimitExpiredType = "3_D"
+	ConvertLimitExpiredType7D  ConvertLimitExpiredType = "7_D"
+	ConvertLimitExpiredType30D ConvertLimitExpiredType = "30_D"
+)
+
+// ConvertExchangeInfoService list the convertible pairs and their amount limits
+type ConvertExchangeInfoService struct {
+	c         *Client
+	fromAsset *string
+	toAsset   *string
+}
+
+// FromAsset set fromAsset
+func (s *ConvertExchangeInfoService) FromAsset(fromAsset string) *ConvertExchangeInfoService {
+	s.fromAsset = &fromAsset
+	return s
+}
+
+// ToAsset set toAsset
+func (s *ConvertExchangeInfoService) ToAsset(toAsset string) *ConvertExchangeInfoService {
+	s.toAsset = &toAsset
+	return s
+}
+
+// Do send request
+func (s *ConvertExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (res []*ConvertPair, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/convert/exchangeInfo",
+	}
+	if s.fromAsset != nil {
+		r.setParam("fromAsset", *s.fromAsset)
+	}
+	if s.toAsset != nil {
+		r.setParam("toAsset", *s.toAsset)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]*ConvertPair, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertPair define a convertible pair
+type ConvertPair struct {
+	FromAsset          string `json:"fromAsset"`
+	ToAsset            string `json:"toAsset"`
+	FromAssetMinAmount string `json:"fromAssetMinAmount"`
+	FromAssetMaxAmount string `json:"fromAssetMaxAmount"`
+	ToAssetMinAmount   string `json:"toAssetMinAmount"`
+	ToAssetMaxAmount   string `json:"toAssetMaxAmount"`
+}
+
+// ConvertAssetInfoService list the precision of the convertible assets
+type ConvertAssetInfoService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ConvertAssetInfoService) Do(ctx context.Context, opts ...RequestOption) (res []*ConvertAssetInfo, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/convert/assetInfo",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]*ConvertAssetInfo, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertAssetInfo define the precision of a convertible asset
+type ConvertAssetInfo struct {
+	Asset    string `json:"asset"`
+	Fraction int    `json:"fraction"`
+}
+
+// ConvertGetQuoteService request a quote to convert fromAsset to toAsset, either FromAmount or ToAmount is required
+type ConvertGetQuoteService struct {
+	c          *Client
+	fromAsset  string
+	toAsset    string
+	fromAmount *string
+	toAmount   *string
+	walletType *ConvertWalletType
+	validTime  *ConvertQuoteValidTime
+}
+
+// FromAsset set fromAsset
+func (s *ConvertGetQuoteService) FromAsset(fromAsset string) *ConvertGetQuoteService {
+	s.fromAsset = fromAsset
+	return s
+}
+
+// ToAsset set toAsset
+func (s *ConvertGetQuoteService) ToAsset(toAsset string) *ConvertGetQuoteService {
+	s.toAsset = toAsset
+	return s
+}
+
+// FromAmount set fromAmount, the amount deducted from fromAsset
+func (s *ConvertGetQuoteService) FromAmount(fromAmount string) *ConvertGetQuoteService {
+	s.fromAmount = &fromAmount
+	return s
+}
+
+// ToAmount set toAmount, the amount of toAsset received
+func (s *ConvertGetQuoteService) ToAmount(toAmount string) *ConvertGetQuoteService {
+	s.toAmount = &toAmount
+	return s
+}
+
+// WalletType set walletType, default ConvertWalletTypeSpot
+func (s *ConvertGetQuoteService) WalletType(walletType ConvertWalletType) *ConvertGetQuoteService {
+	s.walletType = &walletType
+	return s
+}
+
+// ValidTime set validTime, default ConvertQuoteValidTime10s
+func (s *ConvertGetQuoteService) ValidTime(validTime ConvertQuoteValidTime) *ConvertGetQuoteService {
+	s.validTime = &validTime
+	return s
+}
+
+// Do send request
+func (s *ConvertGetQuoteService) Do(ctx context.Context, opts ...RequestOption) (res *ConvertQuote, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/convert/getQuote",
+		secType:  secTypeSigned,
+	}
+	r.setParam("fromAsset", s.fromAsset)
+	r.setParam("toAsset", s.toAsset)
+	if s.fromAmount != nil {
+		r.setParam("fromAmount", *s.fromAmount)
+	}
+	if s.toAmount != nil {
+		r.setParam("toAmount", *s.toAmount)
+	}
+	if s.walletType != nil {
+		r.setParam("walletType", *s.walletType)
+	}
+	if s.validTime != nil {
+		r.setParam("validTime", *s.validTime)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ConvertQuote)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertQuote define a convert quote, it can be accepted until ValidTimestamp
+type ConvertQuote struct {
+	QuoteID        string `json:"quoteId"`
+	Ratio          string `json:"ratio"`
+	InverseRatio   string `json:"inverseRatio"`
+	ValidTimestamp int64  `json:"validTimestamp"`
+	ToAmount       string `json:"toAmount"`
+	FromAmount     string `json:"fromAmount"`
+}
+
+// ExpireTime return the time after which the quote can no longer be accepted
+func (q *ConvertQuote) ExpireTime() time.Time {
+	return time.Unix(0, q.ValidTimestamp*int64(time.Millisecond))
+}
+
+// Expired return whether the quote can no longer be accepted at t
+func (q *ConvertQuote) Expired(t time.Time) bool {
+	return !t.Before(q.ExpireTime())
+}
+
+// ConvertAcceptQuoteService accept a convert quote
+type ConvertAcceptQuoteService struct {
+	c       *Client
+	quoteID string
+	quote   *ConvertQuote
+}
+
+// QuoteID set quoteId
+func (s *ConvertAcceptQuoteService) QuoteID(quoteID string) *ConvertAcceptQuoteService {
+	s.quoteID = quoteID
+	s.quote = nil
+	return s
+}
+
+// Quote set the quote to accept, Do fails with ErrConvertQuoteExpired without sending the request once it expired
+func (s *ConvertAcceptQuoteService) Quote(quote *ConvertQuote) *ConvertAcceptQuoteService {
+	s.quoteID = quote.QuoteID
+	s.quote = quote
+	return s
+}
+
+// Do send request
+func (s *ConvertAcceptQuoteService) Do(ctx context.Context, opts ...RequestOption) (res *ConvertAcceptQuoteResponse, err error) {
+	// the quote expires on the exchange clock
+	if s.quote != nil && s.quote.Expired(time.Unix(0, (currentTimestamp()-s.c.TimeOffset)*int64(time.Millisecond))) {
+		return nil, ErrConvertQuoteExpired
+	}
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/convert/acceptQuote",
+		secType:  secTypeSigned,
+	}
+	r.setParam("quoteId", s.quoteID)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		if quoteExpired(err) {
+			return nil, ErrConvertQuoteExpired
+		}
+		return nil, err
+	}
+	res = new(ConvertAcceptQuoteResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// quoteExpired report whether err is the exchange rejecting a quote accepted after it expired,
+// the convert errors don't have a dedicated code so the message is matched
+func quoteExpired(err error) bool {
+	apiErr, ok := err.(*common.APIError)
+	if !ok {
+		return false
+	}
+	msg := strings.ToLower(apiErr.Message)
+	return strings.Contains(msg, "quote") && strings.Contains(msg, "expire")
+}
+
+// ConvertAcceptQuoteResponse define the response of accepting a convert quote
+type ConvertAcceptQuoteResponse struct {
+	OrderID     string `json:"orderId"`
+	CreateTime  int64  `json:"createTime"`
+	OrderStatus string `json:"orderStatus"`
+}
+
+// ConvertOrderStatusService get the status of a convert order, either OrderID or QuoteID is required
+type ConvertOrderStatusService struct {
+	c       *Client
+	orderID *string
+	quoteID *string
+}
+
+// OrderID set orderId
+func (s *ConvertOrderStatusService) OrderID(orderID string) *ConvertOrderStatusService {
+	s.orderID = &orderID
+	return s
+}
+
+// QuoteID set quoteId
+func (s *ConvertOrderStatusService) QuoteID(quoteID string) *ConvertOrderStatusService {
+	s.quoteID = &quoteID
+	return s
+}
+
+// Do send request
+func (s *ConvertOrderStatusService) Do(ctx context.Context, opts ...RequestOption) (res *ConvertOrderStatus, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/convert/orderStatus",
+		secType:  secTypeSigned,
+	}
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.quoteID != nil {
+		r.setParam("quoteId", *s.quoteID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ConvertOrderStatus)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertOrderStatus define the status of a convert order
+type ConvertOrderStatus struct {
+	OrderID      int64  `json:"orderId"`
+	OrderStatus  string `json:"orderStatus"`
+	FromAsset    string `json:"fromAsset"`
+	FromAmount   string `json:"fromAmount"`
+	ToAsset      string `json:"toAsset"`
+	ToAmount     string `json:"toAmount"`
+	Ratio        string `json:"ratio"`
+	InverseRatio string `json:"inverseRatio"`
+	CreateTime   int64  `json:"createTime"`
+}
+
+// ConvertLimitPlaceOrderService place a convert limit order, either BaseAmount or QuoteAmount is required
+type ConvertLimitPlaceOrderService struct {
+	c           *Client
+	baseAsset   string
+	quoteAsset  string
+	limitPrice  string
+	side        SideType
+	expiredType ConvertLimitExpiredType
+	baseAmount  *string
+	quoteAmount *string
+	walletType  *ConvertWalletType
+}
+
+// BaseAsset set baseAsset
+func (s *ConvertLimitPlaceOrderService) BaseAsset(baseAsset string) *ConvertLimitPlaceOrderService {
+	s.baseAsset = baseAsset
+	return s
+}
+
+// QuoteAsset set quoteAsset
+func (s *ConvertLimitPlaceOrderService) QuoteAsset(quoteAsset string) *ConvertLimitPlaceOrderService {
+	s.quoteAsset = quoteAsset
+	return s
+}
+
+// LimitPrice set limitPrice, in quoteAsset per baseAsset
+func (s *ConvertLimitPlaceOrderService) LimitPrice(limitPrice string) *ConvertLimitPlaceOrderService {
+	s.limitPrice = limitPrice
+	return s
+}
+
+// Side set side
+func (s *ConvertLimitPlaceOrderService) Side(side SideType) *ConvertLimitPlaceOrderService {
+	s.side = side
+	return s
+}
+
+// ExpiredType set expiredType
+func (s *ConvertLimitPlaceOrderService) ExpiredType(expiredType ConvertLimitExpiredType) *ConvertLimitPlaceOrderService {
+	s.expiredType = expiredType
+	return s
+}
+
+// BaseAmount set baseAmount
+func (s *ConvertLimitPlaceOrderService) BaseAmount(baseAmount string) *ConvertLimitPlaceOrderService {
+	s.baseAmount = &baseAmount
+	return s
+}
+
+// QuoteAmount set quoteAmount
+func (s *ConvertLimitPlaceOrderService) QuoteAmount(quoteAmount string) *ConvertLimitPlaceOrderService {
+	s.quoteAmount = &quoteAmount
+	return s
+}
+
+// WalletType set walletType, default ConvertWalletTypeSpot
+func (s *ConvertLimitPlaceOrderService) WalletType(walletType ConvertWalletType) *ConvertLimitPlaceOrderService {
+	s.walletType = &walletType
+	return s
+}
+
+// Do send request
+func (s *ConvertLimitPlaceOrderService) Do(ctx context.Context, opts ...RequestOption) (res *ConvertLimitOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/convert/limit/placeOrder",
+		secType:  secTypeSigned,
+	}
+	r.setParam("baseAsset", s.baseAsset)
+	r.setParam("quoteAsset", s.quoteAsset)
+	r.setParam("limitPrice", s.limitPrice)
+	r.setParam("side", s.side)
+	r.setParam("expiredType", s.expiredType)
+	if s.baseAmount != nil {
+		r.setParam("baseAmount", *s.baseAmount)
+	}
+	if s.quoteAmount != nil {
+		r.setParam("quoteAmount", *s.quoteAmount)
+	}
+	if s.walletType != nil {
+		r.setParam("walletType", *s.walletType)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ConvertLimitOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertLimitCancelOrderService cancel a convert limit order
+type ConvertLimitCancelOrderService struct {
+	c       *Client
+	orderID int64
+}
+
+// OrderID set orderId
+func (s *ConvertLimitCancelOrderService) OrderID(orderID int64) *ConvertLimitCancelOrderService {
+	s.orderID = orderID
+	return s
+}
+
+// Do send request
+func (s *ConvertLimitCancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *ConvertLimitOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/convert/limit/cancelOrder",
+		secType:  secTypeSigned,
+	}
+	r.setParam("orderId", s.orderID)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ConvertLimitOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertLimitOrderResponse define the response of placing or canceling a convert limit order
+type ConvertLimitOrderResponse struct {
+	OrderID int64  `json:"orderId"`
+	Status  string `json:"status"`
+}
+
+// ConvertLimitOpenOrdersService list the open convert limit orders
+type ConvertLimitOpenOrdersService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ConvertLimitOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*ConvertLimitOrder, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/convert/limit/queryOpenOrders",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	list := struct {
+		List []*ConvertLimitOrder `json:"list"`
+	}{}
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.List, nil
+}
+
+// ConvertLimitOrder define an open convert limit order
+type ConvertLimitOrder struct {
+	QuoteID          string `json:"quoteId"`
+	OrderID          int64  `json:"orderId"`
+	OrderStatus      string `json:"orderStatus"`
+	FromAsset        string `json:"fromAsset"`
+	FromAmount       string `json:"fromAmount"`
+	ToAsset          string `json:"toAsset"`
+	ToAmount         string `json:"toAmount"`
+	Ratio            string `json:"ratio"`
+	InverseRatio     string `json:"inverseRatio"`
+	CreateTime       int64  `json:"createTime"`
+	ExpiredTimestamp int64  `json:"expiredTimestamp"`
+}
@@ -0,0 +1,320 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type convertServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestConvertService(t *testing.T) {
+	suite.Run(t, new(convertServiceTestSuite))
+}
+
+func (s *convertServiceTestSuite) TestExchangeInfo() {
+	data := []byte(`[
+    {
+        "fromAsset": "BTC",
+        "toAsset": "USDT",
+        "fromAssetMinAmount": "0.0004",
+        "fromAssetMaxAmount": "50",
+        "toAssetMinAmount": "20",
+        "toAssetMaxAmount": "2500000"
+    }
+]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"fromAsset": "BTC",
+			"toAsset":   "USDT",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertExchangeInfoService().FromAsset("BTC").ToAsset("USDT").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*ConvertPair{{
+		FromAsset:          "BTC",
+		ToAsset:            "USDT",
+		FromAssetMinAmount: "0.0004",
+		FromAssetMaxAmount: "50",
+		ToAssetMinAmount:   "20",
+		ToAssetMaxAmount:   "2500000",
+	}}, res)
+}
+
+func (s *convertServiceTestSuite) TestAssetInfo() {
+	data := []byte(`[
+    {
+        "asset": "BTC",
+        "fraction": 8
+    },
+    {
+        "asset": "SHIB",
+        "fraction": 2
+    }
+]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertAssetInfoService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*ConvertAssetInfo{{Asset: "BTC", Fraction: 8}, {Asset: "SHIB", Fraction: 2}}, res)
+}
+
+func (s *convertServiceTestSuite) TestGetQuote() {
+	data := []byte(`{
+    "quoteId": "12415572564",
+    "ratio": "38163.7",
+    "inverseRatio": "0.0000262",
+    "validTimestamp": 1623319461670,
+    "toAmount": "3816.37",
+    "fromAmount": "0.1"
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"fromAsset":  "BTC",
+			"toAsset":    "USDT",
+			"fromAmount": "0.1",
+			"walletType": "SPOT_FUNDING",
+			"validTime":  "30s",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertGetQuoteService().
+		FromAsset("BTC").
+		ToAsset("USDT").
+		FromAmount("0.1").
+		WalletType(ConvertWalletTypeSpotFunding).
+		ValidTime(ConvertQuoteValidTime30s).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&ConvertQuote{
+		QuoteID:        "12415572564",
+		Ratio:          "38163.7",
+		InverseRatio:   "0.0000262",
+		ValidTimestamp: 1623319461670,
+		ToAmount:       "3816.37",
+		FromAmount:     "0.1",
+	}, res)
+	r.Equal(time.Unix(1623319461, 670*int64(time.Millisecond)), res.ExpireTime())
+	r.False(res.Expired(time.Unix(1623319461, 0)))
+	r.True(res.Expired(res.ExpireTime()))
+}
+
+func (s *convertServiceTestSuite) TestAcceptQuote() {
+	data := []byte(`{
+    "orderId": "933256278426274426",
+    "createTime": 1623381330472,
+    "orderStatus": "PROCESS"
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"quoteId": "933256278426274426",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	quote := &ConvertQuote{
+		QuoteID:        "933256278426274426",
+		ValidTimestamp: time.Now().Add(10*time.Second).UnixNano() / int64(time.Millisecond),
+	}
+	res, err := s.client.NewConvertAcceptQuoteService().Quote(quote).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&ConvertAcceptQuoteResponse{
+		OrderID:     "933256278426274426",
+		CreateTime:  1623381330472,
+		OrderStatus: "PROCESS",
+	}, res)
+}
+
+func (s *convertServiceTestSuite) TestAcceptExpiredQuote() {
+	quote := &ConvertQuote{
+		QuoteID:        "933256278426274426",
+		ValidTimestamp: time.Now().Add(-time.Second).UnixNano() / int64(time.Millisecond),
+	}
+	_, err := s.client.NewConvertAcceptQuoteService().Quote(quote).Do(newContext())
+	r := s.r()
+	r.Equal(ErrConvertQuoteExpired, err)
+	s.client.AssertNotCalled(s.T(), "do", anyHTTPRequest())
+}
+
+func (s *convertServiceTestSuite) TestAcceptQuoteExpiredOnServerClock() {
+	// the local clock is 5s behind the exchange so the quote already expired there
+	s.client.TimeOffset = -5000
+	quote := &ConvertQuote{
+		QuoteID:        "933256278426274426",
+		ValidTimestamp: time.Now().Add(2*time.Second).UnixNano() / int64(time.Millisecond),
+	}
+	_, err := s.client.NewConvertAcceptQuoteService().Quote(quote).Do(newContext())
+	s.r().Equal(ErrConvertQuoteExpired, err)
+	s.client.AssertNotCalled(s.T(), "do", anyHTTPRequest())
+}
+
+func (s *convertServiceTestSuite) TestAcceptQuoteExpiredByExchange() {
+	s.mockDo([]byte(`{"code":345103,"msg":"Your quote has expired. Please try again."}`), nil, http.StatusBadRequest)
+	defer s.assertDo()
+
+	_, err := s.client.NewConvertAcceptQuoteService().QuoteID("933256278426274426").Do(newContext())
+	s.r().Equal(ErrConvertQuoteExpired, err)
+}
+
+func (s *convertServiceTestSuite) TestAcceptQuoteAPIError() {
+	s.mockDo([]byte(`{"code":-1102,"msg":"Mandatory parameter 'quoteId' was not sent."}`), nil, http.StatusBadRequest)
+	defer s.assertDo()
+
+	_, err := s.client.NewConvertAcceptQuoteService().Do(newContext())
+	s.r().True(common.IsAPIError(err))
+}
+
+func (s *convertServiceTestSuite) TestOrderStatus() {
+	data := []byte(`{
+    "orderId": 933256278426274426,
+    "orderStatus": "SUCCESS",
+    "fromAsset": "BTC",
+    "fromAmount": "0.00054414",
+    "toAsset": "USDT",
+    "toAmount": "20",
+    "ratio": "36755",
+    "inverseRatio": "0.00002721",
+    "createTime": 1623381330472
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"quoteId": "12415572564",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertOrderStatusService().QuoteID("12415572564").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&ConvertOrderStatus{
+		OrderID:      933256278426274426,
+		OrderStatus:  "SUCCESS",
+		FromAsset:    "BTC",
+		FromAmount:   "0.00054414",
+		ToAsset:      "USDT",
+		ToAmount:     "20",
+		Ratio:        "36755",
+		InverseRatio: "0.00002721",
+		CreateTime:   1623381330472,
+	}, res)
+}
+
+func (s *convertServiceTestSuite) TestLimitPlaceOrder() {
+	data := []byte(`{
+    "orderId": 1603680255057330400,
+    "status": "PROCESS"
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"baseAsset":   "BNB",
+			"quoteAsset":  "USDT",
+			"limitPrice":  "250",
+			"side":        "BUY",
+			"expiredType": "7_D",
+			"quoteAmount": "25",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertLimitPlaceOrderService().
+		BaseAsset("BNB").
+		QuoteAsset("USDT").
+		LimitPrice("250").
+		Side(SideTypeBuy).
+		ExpiredType(ConvertLimitExpiredType7D).
+		QuoteAmount("25").
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&ConvertLimitOrderResponse{OrderID: 1603680255057330400, Status: "PROCESS"}, res)
+}
+
+func (s *convertServiceTestSuite) TestLimitCancelOrder() {
+	data := []byte(`{
+    "orderId": 1603680255057330400,
+    "status": "CANCELED"
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"orderId": 1603680255057330400,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertLimitCancelOrderService().OrderID(1603680255057330400).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&ConvertLimitOrderResponse{OrderID: 1603680255057330400, Status: "CANCELED"}, res)
+}
+
+func (s *convertServiceTestSuite) TestLimitOpenOrders() {
+	data := []byte(`{
+    "list": [
+        {
+            "quoteId": "18sdf87kh9df",
+            "orderId": 1150901289839,
+            "orderStatus": "SUCCESS",
+            "fromAsset": "BNB",
+            "fromAmount": "10",
+            "toAsset": "USDT",
+            "toAmount": "2317.89",
+            "ratio": "231.789",
+            "inverseRatio": "0.00431427",
+            "createTime": 1614089498000,
+            "expiredTimestamp": 1614099498000
+        }
+    ]
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertLimitOpenOrdersService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*ConvertLimitOrder{{
+		QuoteID:          "18sdf87kh9df",
+		OrderID:          1150901289839,
+		OrderStatus:      "SUCCESS",
+		FromAsset:        "BNB",
+		FromAmount:       "10",
+		ToAsset:          "USDT",
+		ToAmount:         "2317.89",
+		Ratio:            "231.789",
+		InverseRatio:     "0.00431427",
+		CreateTime:       1614089498000,
+		ExpiredTimestamp: 1614099498000,
+	}}, res)
+}
@@ -7,7 +7,6 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
@@ -64,6 +63,9 @@ type UserDataEventType string
 // UserDataEventReasonType define reason type for user data event
 type UserDataEventReasonType string
 
+// ForceOrderCloseType define reason type for force order
+type ForceOrderCloseType string
+
 // Endpoints
 const (
 	baseApiMainUrl    = "https://dapi.binance.com"
@@ -159,6 +161,9 @@ const (
 	UserDataEventReasonTypeOptionsPremiumFee   UserDataEventReasonType = "OPTIONS_PREMIUM_FEE"
 	UserDataEventReasonTypeOptionsSettleProfit UserDataEventReasonType = "OPTIONS_SETTLE_PROFIT"
 
+	ForceOrderCloseTypeLiquidation ForceOrderCloseType = "LIQUIDATION"
+	ForceOrderCloseTypeADL         ForceOrderCloseType = "ADL"
+
 	timestampKey  = "timestamp"
 	signatureKey  = "signature"
 	recvWindowKey = "recvWindow"
@@ -176,14 +181,6 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getApiEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getApiEndpoint() string {
-	if UseTestnet {
-		return baseApiTestnetUrl
-	}
-	return baseApiMainUrl
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
@@ -198,6 +195,13 @@ func NewClient(apiKey, secretKey string) *Client {
 	}
 }
 
+// NewClientWithEnvironment initialize an API client instance sending requests to the REST API of env.
+func NewClientWithEnvironment(apiKey, secretKey string, env *Environment) *Client {
+	c := NewClient(apiKey, secretKey)
+	c.BaseURL = env.BaseURL
+	return c
+}
+
 // NewClientWithIP initialize an API client instance with API key, secret key and local IP.
 func NewClientWithIP(apiKey, secretKey, ip string) *Client {
 	parsedIP := net.ParseIP(ip)
@@ -245,6 +249,14 @@ type Client struct {
 	Logger     *log.Logger
 	TimeOffset int64
 	do         doFunc
+
+	middlewares []common.APIMiddleware
+}
+
+// Use add middlewares around the REST calls of the client, the first middleware sees the request first
+func (c *Client) Use(middlewares ...common.APIMiddleware) *Client {
+	c.middlewares = append(c.middlewares, middlewares...)
+	return c
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -300,10 +312,13 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
+	// the signature and the listen key never reach the logs
+	debugURL := fullURL
 	if queryString != "" {
+		debugURL = fmt.Sprintf("%s?%s", fullURL, common.RedactQuery(queryString))
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.debug("full url: %s, body: %s", debugURL, common.RedactQuery(bodyString))
 
 	r.fullURL = fullURL
 	r.header = header
@@ -322,28 +337,17 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.debug("request: %s %s, header: %v", req.Method, common.RedactURL(req.URL), common.RedactHeader(req.Header))
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
+	res, err := common.ChainAPIMiddlewares(common.NewAPIHandler(f), c.middlewares...)(req)
 	if err != nil {
 		return []byte{}, err
 	}
-	data, err = ioutil.ReadAll(res.Body)
-	if err != nil {
-		return []byte{}, err
-	}
-	defer func() {
-		cerr := res.Body.Close()
-		// Only overwrite the retured error if the original error was nil and an
-		// error occurred while closing the body.
-		if err == nil && cerr != nil {
-			err = cerr
-		}
-	}()
-	c.debug("response: %#v", res)
+	data = res.Body
+	c.debug("response header: %v", res.Header)
 	c.debug("response body: %s", string(data))
 	c.debug("response status code: %d", res.StatusCode)
 
@@ -502,3 +506,118 @@ func (c *Client) NewGetPositionModeService() *GetPositionModeService {
 func (c *Client) NewCommissionRateService() *CommissionRateService {
 	return &CommissionRateService{c: c}
 }
+
+// NewDepthService init depth service
+func (c *Client) NewDepthService() *DepthService {
+	return &DepthService{c: c}
+}
+
+// NewAggTradesService init aggregate trades service
+func (c *Client) NewAggTradesService() *AggTradesService {
+	return &AggTradesService{c: c}
+}
+
+// NewRecentTradesService init recent trades service
+func (c *Client) NewRecentTradesService() *RecentTradesService {
+	return &RecentTradesService{c: c}
+}
+
+// NewHistoricalTradesService init historical trades service
+func (c *Client) NewHistoricalTradesService() *HistoricalTradesService {
+	return &HistoricalTradesService{c: c}
+}
+
+// NewPremiumIndexService init premium index service
+func (c *Client) NewPremiumIndexService() *PremiumIndexService {
+	return &PremiumIndexService{c: c}
+}
+
+// NewFundingRateService init funding rate service
+func (c *Client) NewFundingRateService() *FundingRateService {
+	return &FundingRateService{c: c}
+}
+
+// NewMarkPriceKlinesService init mark price klines service
+func (c *Client) NewMarkPriceKlinesService() *MarkPriceKlinesService {
+	return &MarkPriceKlinesService{c: c}
+}
+
+// NewPremiumIndexKlinesService init premium index klines service
+func (c *Client) NewPremiumIndexKlinesService() *PremiumIndexKlinesService {
+	return &PremiumIndexKlinesService{c: c}
+}
+
+// NewGetOpenInterestService init open interest service
+func (c *Client) NewGetOpenInterestService() *GetOpenInterestService {
+	return &GetOpenInterestService{c: c}
+}
+
+// NewOpenInterestStatisticsService init open interest statistics service
+func (c *Client) NewOpenInterestStatisticsService() *OpenInterestStatisticsService {
+	return &OpenInterestStatisticsService{c: c}
+}
+
+// NewLongShortRatioService init long short ratio service
+func (c *Client) NewLongShortRatioService() *LongShortRatioService {
+	return &LongShortRatioService{c: c}
+}
+
+// NewBasisService init basis service
+func (c *Client) NewBasisService() *BasisService {
+	return &BasisService{c: c}
+}
+
+// NewListAccountTradeService init list account trade service
+func (c *Client) NewListAccountTradeService() *ListAccountTradeService {
+	return &ListAccountTradeService{c: c}
+}
+
+// NewGetIncomeHistoryService init get income history service
+func (c *Client) NewGetIncomeHistoryService() *GetIncomeHistoryService {
+	return &GetIncomeHistoryService{c: c}
+}
+
+// NewGetLeverageBracketService init leverage brackets service
+func (c *Client) NewGetLeverageBracketService() *GetLeverageBracketService {
+	return &GetLeverageBracketService{c: c}
+}
+
+// NewListUserLiquidationOrdersService init list user's liquidation orders service
+func (c *Client) NewListUserLiquidationOrdersService() *ListUserLiquidationOrdersService {
+	return &ListUserLiquidationOrdersService{c: c}
+}
+
+// NewGetADLQuantileService init ADL quantile service
+func (c *Client) NewGetADLQuantileService() *GetADLQuantileService {
+	return &GetADLQuantileService{c: c}
+}
+
+// NewGetOrderAmendmentHistoryService init order amendment history service
+func (c *Client) NewGetOrderAmendmentHistoryService() *GetOrderAmendmentHistoryService {
+	return &GetOrderAmendmentHistoryService{c: c}
+}
+
+// NewCreateBatchOrdersService init creating batch order service
+func (c *Client) NewCreateBatchOrdersService() *CreateBatchOrdersService {
+	return &CreateBatchOrdersService{c: c}
+}
+
+// NewCancelMultipleOrdersService init cancel multiple orders service
+func (c *Client) NewCancelMultipleOrdersService() *CancelMultipleOrdersService {
+	return &CancelMultipleOrdersService{c: c}
+}
+
+// NewModifyOrderService init modify order service
+func (c *Client) NewModifyOrderService() *ModifyOrderService {
+	return &ModifyOrderService{c: c}
+}
+
+// NewModifyBatchOrdersService init modify batch orders service
+func (c *Client) NewModifyBatchOrdersService() *ModifyBatchOrdersService {
+	return &ModifyBatchOrdersService{c: c}
+}
+
+// NewCountdownCancelAllService init countdown cancel all service
+func (c *Client) NewCountdownCancelAllService() *CountdownCancelAllService {
+	return &CountdownCancelAllService{c: c}
+}
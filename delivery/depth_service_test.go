@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type depthServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestDepthService(t *testing.T) {
+	suite.Run(t, new(depthServiceTestSuite))
+}
+
+func (s *depthServiceTestSuite) TestDepth() {
+	data := []byte(`{
+		"lastUpdateId": 16769853,
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"E": 1591250106370,
+		"T": 1591250106368,
+		"bids": [
+			[
+				"9638.0",
+				"431"
+			]
+		],
+		"asks": [
+			[
+				"9638.2",
+				"12"
+			]
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_PERP"
+	limit := 5
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol": symbol,
+			"limit":  limit,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewDepthService().Symbol(symbol).Limit(limit).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	e := &DepthResponse{
+		LastUpdateID: 16769853,
+		Symbol:       "BTCUSD_PERP",
+		Pair:         "BTCUSD",
+		Time:         1591250106370,
+		TradeTime:    1591250106368,
+		Bids: []Bid{
+			{
+				Price:    "9638.0",
+				Quantity: "431",
+			},
+		},
+		Asks: []Ask{
+			{
+				Price:    "9638.2",
+				Quantity: "12",
+			},
+		},
+	}
+	s.assertDepthResponseEqual(e, res)
+}
+
+func (s *depthServiceTestSuite) assertDepthResponseEqual(e, a *DepthResponse) {
+	r := s.r()
+	r.Equal(e.LastUpdateID, a.LastUpdateID, "LastUpdateID")
+	r.Equal(e.Symbol, a.Symbol, "Symbol")
+	r.Equal(e.Pair, a.Pair, "Pair")
+	r.Equal(e.Time, a.Time, "Time")
+	r.Equal(e.TradeTime, a.TradeTime, "TradeTime")
+	r.Len(a.Bids, len(e.Bids))
+	for i := 0; i < len(a.Bids); i++ {
+		r.Equal(e.Bids[i].Price, a.Bids[i].Price, "Price")
+		r.Equal(e.Bids[i].Quantity, a.Bids[i].Quantity, "Quantity")
+	}
+	r.Len(a.Asks, len(e.Asks))
+	for i := 0; i < len(a.Asks); i++ {
+		r.Equal(e.Asks[i].Price, a.Asks[i].Price, "Price")
+		r.Equal(e.Asks[i].Quantity, a.Asks[i].Quantity, "Quantity")
+	}
+}
@@ -0,0 +1,228 @@
+package delivery
+
+import (
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+)
+
+// Environment define the endpoints and connection settings used by a Client, a ClientWs
+// and the websocket streams, the package level variables are only used to build the default one
+type Environment struct {
+	// BaseURL is the base url of the REST API
+	BaseURL string
+	// WsURL is the base endpoint of the raw streams
+	WsURL string
+	// CombinedURL is the base endpoint of the combined streams
+	CombinedURL string
+	// WsAPIURL is the endpoint of the WS API
+	WsAPIURL string
+	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
+	WebsocketKeepalive bool
+	// WebsocketTimeout is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
+	WebsocketTimeout time.Duration
+	// Dialer is used as is to open websocket connections when it is set
+	Dialer *websocket.Dialer
+	// Metrics receives the health measurements of the websocket connections when it is set
+	Metrics common.WsMetrics
+}
+
+// MainnetEnvironment return the production environment
+func MainnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiMainUrl,
+		WsURL:            baseWsMainUrl,
+		CombinedURL:      baseWsCombinedMainURL,
+		WsAPIURL:         baseTradingWsUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// TestnetEnvironment return the testnet environment
+func TestnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiTestnetUrl,
+		WsURL:            baseWsTestnetUrl,
+		CombinedURL:      baseWsCombinedTestnetURL,
+		WsAPIURL:         baseTradingWsTestUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// IntranetEnvironment return the colo intranet environment, the REST and WS API stay on the public endpoints
+func IntranetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiMainUrl,
+		WsURL:            baseWsInternalMainURL,
+		CombinedURL:      baseWsInternalCombinedMainURL,
+		WsAPIURL:         baseTradingWsUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// DefaultEnvironment return the environment selected by the UseTestnet, UseIntranet,
+// WebsocketKeepalive, WebsocketTimeout and WebsocketMetrics package variables
+func DefaultEnvironment() *Environment {
+	var e *Environment
+	switch {
+	case UseTestnet:
+		e = TestnetEnvironment()
+	case UseIntranet:
+		e = IntranetEnvironment()
+	default:
+		e = MainnetEnvironment()
+	}
+	e.WebsocketKeepalive = WebsocketKeepalive
+	e.WebsocketTimeout = WebsocketTimeout
+	e.Metrics = WebsocketMetrics
+	return e
+}
+
+// getApiEndpoint return the base endpoint of the Rest API according the UseTestnet flag
+func getApiEndpoint() string {
+	return DefaultEnvironment().BaseURL
+}
+
+// getTradingWsEndpoint return the base endpoint of the WS API according the UseTestnet flag
+func getTradingWsEndpoint() string {
+	return DefaultEnvironment().WsAPIURL
+}
+
+func (e *Environment) getWsEndpoint() string {
+	return e.WsURL
+}
+
+func (e *Environment) getCombinedEndpoint() string {
+	return e.CombinedURL
+}
+
+func (e *Environment) newWsConfig(endpoint string) *WsConfig {
+	return &WsConfig{
+		Endpoint:  endpoint,
+		Keepalive: e.WebsocketKeepalive,
+		Timeout:   e.WebsocketTimeout,
+		Dialer:    e.Dialer,
+		Metrics:   e.Metrics,
+	}
+}
+
+// WsAggTradeServe is a wrapper around DefaultEnvironment().WsAggTradeServe
+func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsIndexPriceServe is a wrapper around DefaultEnvironment().WsIndexPriceServe
+func WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsIndexPriceServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServe is a wrapper around DefaultEnvironment().WsMarkPriceServe
+func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarkPriceServe(symbol, handler, errHandler)
+}
+
+// WsPairMarkPriceServe is a wrapper around DefaultEnvironment().WsPairMarkPriceServe
+func WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsPairMarkPriceServe(handler, errHandler)
+}
+
+// WsKlineServe is a wrapper around DefaultEnvironment().WsKlineServe
+func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsContinuousKlineServe is a wrapper around DefaultEnvironment().WsContinuousKlineServe
+func WsContinuousKlineServe(pair string, contractType string, interval string, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsContinuousKlineServe(pair, contractType, interval, handler, errHandler)
+}
+
+// WsIndexPriceKlineServe is a wrapper around DefaultEnvironment().WsIndexPriceKlineServe
+func WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsIndexPriceKlineServe(pair, interval, handler, errHandler)
+}
+
+// WsMarkPriceKlineServe is a wrapper around DefaultEnvironment().WsMarkPriceKlineServe
+func WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarkPriceKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsMiniMarketTickerServe is a wrapper around DefaultEnvironment().WsMiniMarketTickerServe
+func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMiniMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe is a wrapper around DefaultEnvironment().WsAllMiniMarketTickerServe
+func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMiniMarketTickerServe(handler, errHandler)
+}
+
+// WsMarketTickerServe is a wrapper around DefaultEnvironment().WsMarketTickerServe
+func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMarketTickerServe is a wrapper around DefaultEnvironment().WsAllMarketTickerServe
+func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarketTickerServe(handler, errHandler)
+}
+
+// WsBookTickerServe is a wrapper around DefaultEnvironment().WsBookTickerServe
+func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllBookTickerServe is a wrapper around DefaultEnvironment().WsAllBookTickerServe
+func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsCombinedBookTickerServe is a wrapper around DefaultEnvironment().WsCombinedBookTickerServe
+func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeWithIP is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeWithIP
+func WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeWithIP(ip, symbols, handler, errHandler)
+}
+
+// WsLiquidationOrderServe is a wrapper around DefaultEnvironment().WsLiquidationOrderServe
+func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsLiquidationOrderServe(symbol, handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe is a wrapper around DefaultEnvironment().WsAllLiquidationOrderServe
+func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllLiquidationOrderServe(handler, errHandler)
+}
+
+// WsPartialDepthServe is a wrapper around DefaultEnvironment().WsPartialDepthServe
+func WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate is a wrapper around DefaultEnvironment().WsPartialDepthServeWithRate
+func WsPartialDepthServeWithRate(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsPartialDepthServeWithRate(symbol, levels, rate, handler, errHandler)
+}
+
+// WsDiffDepthServe is a wrapper around DefaultEnvironment().WsDiffDepthServe
+func WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsDiffDepthServe(symbol, handler, errHandler)
+}
+
+// WsDiffDepthServeWithRate is a wrapper around DefaultEnvironment().WsDiffDepthServeWithRate
+func WsDiffDepthServeWithRate(symbol string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsDiffDepthServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsUserDataServe is a wrapper around DefaultEnvironment().WsUserDataServe
+func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServeWithIP is a wrapper around DefaultEnvironment().WsUserDataServeWithIP
+func WsUserDataServeWithIP(ip string, listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServeWithIP(ip, listenKey, handler, errHandler)
+}
@@ -0,0 +1,59 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// GetLeverageBracketService get notional and leverage brackets
+type GetLeverageBracketService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *GetLeverageBracketService) Symbol(symbol string) *GetLeverageBracketService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *GetLeverageBracketService) Do(ctx context.Context, opts ...RequestOption) (res []*LeverageBracket, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v2/leverageBracket",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*LeverageBracket{}, err
+	}
+	res = make([]*LeverageBracket, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*LeverageBracket{}, err
+	}
+	return res, nil
+}
+
+// LeverageBracket define the leverage bracket
+type LeverageBracket struct {
+	Symbol       string    `json:"symbol"`
+	NotionalCoef float64   `json:"notionalCoef"`
+	Brackets     []Bracket `json:"brackets"`
+}
+
+// Bracket define the bracket, caps and floors are in contract quantity
+type Bracket struct {
+	Bracket         int     `json:"bracket"`
+	InitialLeverage int     `json:"initialLeverage"`
+	QtyCap          float64 `json:"qtyCap"`
+	// QtyFloor is sent as qtylFloor by the exchange
+	QtyFloor         float64 `json:"qtylFloor"`
+	MaintMarginRatio float64 `json:"maintMarginRatio"`
+	Cum              float64 `json:"cum"`
+}
@@ -0,0 +1,80 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type leverageBracketServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestLeverageBracketService(t *testing.T) {
+	suite.Run(t, new(leverageBracketServiceTestSuite))
+}
+
+func (s *leverageBracketServiceTestSuite) TestGetLeverageBracket() {
+	data := []byte(`[
+		{
+			"symbol": "BTCUSD_PERP",
+			"notionalCoef": 1.50,
+			"brackets": [
+				{
+					"bracket": 1,
+					"initialLeverage": 125,
+					"qtyCap": 50,
+					"qtylFloor": 0,
+					"maintMarginRatio": 0.004,
+					"cum": 0.0
+				},
+				{
+					"bracket": 2,
+					"initialLeverage": 100,
+					"qtyCap": 100,
+					"qtylFloor": 50,
+					"maintMarginRatio": 0.005,
+					"cum": 0.05
+				}
+			]
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_PERP"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol": symbol,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetLeverageBracketService().Symbol(symbol).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &LeverageBracket{
+		Symbol:       symbol,
+		NotionalCoef: 1.5,
+		Brackets: []Bracket{
+			{
+				Bracket:          1,
+				InitialLeverage:  125,
+				QtyCap:           50,
+				QtyFloor:         0,
+				MaintMarginRatio: 0.004,
+				Cum:              0,
+			},
+			{
+				Bracket:          2,
+				InitialLeverage:  100,
+				QtyCap:           100,
+				QtyFloor:         50,
+				MaintMarginRatio: 0.005,
+				Cum:              0.05,
+			},
+		},
+	}
+	r.Equal(e, res[0])
+}
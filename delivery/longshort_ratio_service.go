@@ -0,0 +1,184 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// LongShortRatioService list global long/short account ratio of a pair.
+type LongShortRatioService struct {
+	c         *Client
+	pair      string
+	period    string
+	limit     *int
+	startTime *int64
+	endTime   *int64
+}
+
+// Pair set pair
+func (s *LongShortRatioService) Pair(pair string) *LongShortRatioService {
+	s.pair = pair
+	return s
+}
+
+// Period set period interval
+func (s *LongShortRatioService) Period(period string) *LongShortRatioService {
+	s.period = period
+	return s
+}
+
+// Limit set limit
+func (s *LongShortRatioService) Limit(limit int) *LongShortRatioService {
+	s.limit = &limit
+	return s
+}
+
+// StartTime set startTime
+func (s *LongShortRatioService) StartTime(startTime int64) *LongShortRatioService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *LongShortRatioService) EndTime(endTime int64) *LongShortRatioService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *LongShortRatioService) Do(ctx context.Context, opts ...RequestOption) (res []*LongShortRatio, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/futures/data/globalLongShortAccountRatio",
+	}
+
+	r.setParam("pair", s.pair)
+	r.setParam("period", s.period)
+
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*LongShortRatio{}, err
+	}
+
+	res = make([]*LongShortRatio, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*LongShortRatio{}, err
+	}
+
+	return res, nil
+}
+
+// LongShortRatio define long/short account ratio
+type LongShortRatio struct {
+	Pair           string `json:"pair"`
+	LongShortRatio string `json:"longShortRatio"`
+	LongAccount    string `json:"longAccount"`
+	ShortAccount   string `json:"shortAccount"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
+// BasisService list basis data of a pair.
+type BasisService struct {
+	c            *Client
+	pair         string
+	contractType string
+	period       string
+	limit        *int
+	startTime    *int64
+	endTime      *int64
+}
+
+// Pair set pair
+func (s *BasisService) Pair(pair string) *BasisService {
+	s.pair = pair
+	return s
+}
+
+// ContractType set contractType, one of CURRENT_QUARTER, NEXT_QUARTER and PERPETUAL
+func (s *BasisService) ContractType(contractType string) *BasisService {
+	s.contractType = contractType
+	return s
+}
+
+// Period set period interval
+func (s *BasisService) Period(period string) *BasisService {
+	s.period = period
+	return s
+}
+
+// Limit set limit
+func (s *BasisService) Limit(limit int) *BasisService {
+	s.limit = &limit
+	return s
+}
+
+// StartTime set startTime
+func (s *BasisService) StartTime(startTime int64) *BasisService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *BasisService) EndTime(endTime int64) *BasisService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *BasisService) Do(ctx context.Context, opts ...RequestOption) (res []*Basis, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/futures/data/basis",
+	}
+
+	r.setParam("pair", s.pair)
+	r.setParam("contractType", s.contractType)
+	r.setParam("period", s.period)
+
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Basis{}, err
+	}
+
+	res = make([]*Basis, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Basis{}, err
+	}
+
+	return res, nil
+}
+
+// Basis define basis between futures and index price
+type Basis struct {
+	Pair                string `json:"pair"`
+	ContractType        string `json:"contractType"`
+	IndexPrice          string `json:"indexPrice"`
+	FuturesPrice        string `json:"futuresPrice"`
+	Basis               string `json:"basis"`
+	BasisRate           string `json:"basisRate"`
+	AnnualizedBasisRate string `json:"annualizedBasisRate"`
+	Timestamp           int64  `json:"timestamp"`
+}
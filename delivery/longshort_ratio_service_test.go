@@ -0,0 +1,101 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type longShortRatioServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestLongShortRatioService(t *testing.T) {
+	suite.Run(t, new(longShortRatioServiceTestSuite))
+}
+
+func (s *longShortRatioServiceTestSuite) TestLongShortRatio() {
+	data := []byte(`[
+		{
+			"pair": "BTCUSD",
+			"longShortRatio": "1.1152",
+			"longAccount": "0.5272",
+			"shortAccount": "0.4728",
+			"timestamp": 1583139600000
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	pair := "BTCUSD"
+	period := "15m"
+	limit := 1
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"pair":   pair,
+			"period": period,
+			"limit":  limit,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewLongShortRatioService().Pair(pair).
+		Period(period).Limit(limit).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &LongShortRatio{
+		Pair:           pair,
+		LongShortRatio: "1.1152",
+		LongAccount:    "0.5272",
+		ShortAccount:   "0.4728",
+		Timestamp:      1583139600000,
+	}
+	r.Equal(e, res[0])
+}
+
+func (s *longShortRatioServiceTestSuite) TestBasis() {
+	data := []byte(`[
+		{
+			"indexPrice": "29269.93972727",
+			"contractType": "CURRENT_QUARTER",
+			"basisRate": "0.0024",
+			"futuresPrice": "29341.3",
+			"annualizedBasisRate": "0.0283",
+			"basis": "71.36027273",
+			"pair": "BTCUSD",
+			"timestamp": 1653381600000
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	pair := "BTCUSD"
+	contractType := "CURRENT_QUARTER"
+	period := "5m"
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"pair":         pair,
+			"contractType": contractType,
+			"period":       period,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewBasisService().Pair(pair).
+		ContractType(contractType).Period(period).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &Basis{
+		Pair:                pair,
+		ContractType:        contractType,
+		IndexPrice:          "29269.93972727",
+		FuturesPrice:        "29341.3",
+		Basis:               "71.36027273",
+		BasisRate:           "0.0024",
+		AnnualizedBasisRate: "0.0283",
+		Timestamp:           1653381600000,
+	}
+	r.Equal(e, res[0])
+}
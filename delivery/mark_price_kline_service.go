@@ -0,0 +1,161 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// MarkPriceKlinesService list mark price klines
+type MarkPriceKlinesService struct {
+	c         *Client
+	symbol    string
+	interval  string
+	limit     *int
+	startTime *int64
+	endTime   *int64
+}
+
+// Symbol set symbol
+func (mpks *MarkPriceKlinesService) Symbol(symbol string) *MarkPriceKlinesService {
+	mpks.symbol = symbol
+	return mpks
+}
+
+// Interval set interval
+func (mpks *MarkPriceKlinesService) Interval(interval string) *MarkPriceKlinesService {
+	mpks.interval = interval
+	return mpks
+}
+
+// Limit set limit
+func (mpks *MarkPriceKlinesService) Limit(limit int) *MarkPriceKlinesService {
+	mpks.limit = &limit
+	return mpks
+}
+
+// StartTime set startTime
+func (mpks *MarkPriceKlinesService) StartTime(startTime int64) *MarkPriceKlinesService {
+	mpks.startTime = &startTime
+	return mpks
+}
+
+// EndTime set endTime
+func (mpks *MarkPriceKlinesService) EndTime(endTime int64) *MarkPriceKlinesService {
+	mpks.endTime = &endTime
+	return mpks
+}
+
+// Do send request
+func (mpks *MarkPriceKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/markPriceKlines",
+	}
+	r.setParam("symbol", mpks.symbol)
+	r.setParam("interval", mpks.interval)
+	if mpks.limit != nil {
+		r.setParam("limit", *mpks.limit)
+	}
+	if mpks.startTime != nil {
+		r.setParam("startTime", *mpks.startTime)
+	}
+	if mpks.endTime != nil {
+		r.setParam("endTime", *mpks.endTime)
+	}
+	data, err := mpks.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Kline{}, err
+	}
+	return parsePriceKlines(data)
+}
+
+// PremiumIndexKlinesService list premium index klines
+type PremiumIndexKlinesService struct {
+	c         *Client
+	symbol    string
+	interval  string
+	limit     *int
+	startTime *int64
+	endTime   *int64
+}
+
+// Symbol set symbol
+func (piks *PremiumIndexKlinesService) Symbol(symbol string) *PremiumIndexKlinesService {
+	piks.symbol = symbol
+	return piks
+}
+
+// Interval set interval
+func (piks *PremiumIndexKlinesService) Interval(interval string) *PremiumIndexKlinesService {
+	piks.interval = interval
+	return piks
+}
+
+// Limit set limit
+func (piks *PremiumIndexKlinesService) Limit(limit int) *PremiumIndexKlinesService {
+	piks.limit = &limit
+	return piks
+}
+
+// StartTime set startTime
+func (piks *PremiumIndexKlinesService) StartTime(startTime int64) *PremiumIndexKlinesService {
+	piks.startTime = &startTime
+	return piks
+}
+
+// EndTime set endTime
+func (piks *PremiumIndexKlinesService) EndTime(endTime int64) *PremiumIndexKlinesService {
+	piks.endTime = &endTime
+	return piks
+}
+
+// Do send request
+func (piks *PremiumIndexKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/premiumIndexKlines",
+	}
+	r.setParam("symbol", piks.symbol)
+	r.setParam("interval", piks.interval)
+	if piks.limit != nil {
+		r.setParam("limit", *piks.limit)
+	}
+	if piks.startTime != nil {
+		r.setParam("startTime", *piks.startTime)
+	}
+	if piks.endTime != nil {
+		r.setParam("endTime", *piks.endTime)
+	}
+	data, err := piks.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Kline{}, err
+	}
+	return parsePriceKlines(data)
+}
+
+// parsePriceKlines decode price-only klines, volume fields are always zero for these endpoints
+func parsePriceKlines(data []byte) (res []*Kline, err error) {
+	j, err := newJSON(data)
+	if err != nil {
+		return []*Kline{}, err
+	}
+	num := len(j.MustArray())
+	res = make([]*Kline, num)
+	for i := 0; i < num; i++ {
+		item := j.GetIndex(i)
+		if len(item.MustArray()) < 11 {
+			err = fmt.Errorf("invalid kline response")
+			return []*Kline{}, err
+		}
+		res[i] = &Kline{
+			OpenTime:  item.GetIndex(0).MustInt64(),
+			Open:      item.GetIndex(1).MustString(),
+			High:      item.GetIndex(2).MustString(),
+			Low:       item.GetIndex(3).MustString(),
+			Close:     item.GetIndex(4).MustString(),
+			CloseTime: item.GetIndex(6).MustInt64(),
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,144 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type markPriceServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestMarkPriceService(t *testing.T) {
+	suite.Run(t, new(markPriceServiceTestSuite))
+}
+
+func (s *markPriceServiceTestSuite) TestPremiumIndex() {
+	data := []byte(`[
+		{
+			"symbol": "BTCUSD_PERP",
+			"pair": "BTCUSD",
+			"markPrice": "11029.69574559",
+			"indexPrice": "10979.14437500",
+			"estimatedSettlePrice": "10981.74168236",
+			"lastFundingRate": "0.00071003",
+			"interestRate": "0.00010000",
+			"nextFundingTime": 1596096000000,
+			"time": 1596094042000
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	pair := "BTCUSD"
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"pair": pair,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewPremiumIndexService().Pair(pair).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &PremiumIndex{
+		Symbol:               "BTCUSD_PERP",
+		Pair:                 "BTCUSD",
+		MarkPrice:            "11029.69574559",
+		IndexPrice:           "10979.14437500",
+		EstimatedSettlePrice: "10981.74168236",
+		LastFundingRate:      "0.00071003",
+		InterestRate:         "0.00010000",
+		NextFundingTime:      1596096000000,
+		Time:                 1596094042000,
+	}
+	r.Equal(e, res[0])
+}
+
+func (s *markPriceServiceTestSuite) TestFundingRate() {
+	data := []byte(`[
+		{
+			"symbol": "BTCUSD_PERP",
+			"fundingTime": 1596038400000,
+			"fundingRate": "-0.00300000"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_PERP"
+	startTime := int64(1576566020000)
+	endTime := int64(1676566020000)
+	limit := 10
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol":    symbol,
+			"startTime": startTime,
+			"endTime":   endTime,
+			"limit":     limit,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewFundingRateService().Symbol(symbol).
+		StartTime(startTime).EndTime(endTime).Limit(limit).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &FundingRate{
+		Symbol:      symbol,
+		FundingTime: 1596038400000,
+		FundingRate: "-0.00300000",
+	}
+	r.Equal(e, res[0])
+}
+
+func (s *markPriceServiceTestSuite) TestMarkPriceKlines() {
+	data := []byte(`[
+		[
+			1591256460000,
+			"9653.29201333",
+			"9654.56401333",
+			"9653.07367333",
+			"9653.07367333",
+			"0",
+			1591256519999,
+			"0",
+			12,
+			"0",
+			"0",
+			"0"
+		]
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_200626"
+	interval := "1m"
+	limit := 1
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol":   symbol,
+			"interval": interval,
+			"limit":    limit,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewMarkPriceKlinesService().Symbol(symbol).
+		Interval(interval).Limit(limit).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &Kline{
+		OpenTime:  1591256460000,
+		Open:      "9653.29201333",
+		High:      "9654.56401333",
+		Low:       "9653.07367333",
+		Close:     "9653.07367333",
+		CloseTime: 1591256519999,
+	}
+	r.Equal(e, res[0])
+}
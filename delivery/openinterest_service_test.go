@@ -0,0 +1,89 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type openInterestServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestOpenInterestService(t *testing.T) {
+	suite.Run(t, new(openInterestServiceTestSuite))
+}
+
+func (s *openInterestServiceTestSuite) TestGetOpenInterest() {
+	data := []byte(`{
+		"symbol": "BTCUSD_200626",
+		"pair": "BTCUSD",
+		"openInterest": "15004",
+		"contractType": "CURRENT_QUARTER",
+		"time": 1591261042378
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_200626"
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol": symbol,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetOpenInterestService().Symbol(symbol).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	e := &OpenInterest{
+		Symbol:       symbol,
+		Pair:         "BTCUSD",
+		OpenInterest: "15004",
+		ContractType: "CURRENT_QUARTER",
+		Time:         1591261042378,
+	}
+	r.Equal(e, res)
+}
+
+func (s *openInterestServiceTestSuite) TestOpenInterestStatistics() {
+	data := []byte(`[
+		{
+			"pair": "BTCUSD",
+			"contractType": "CURRENT_QUARTER",
+			"sumOpenInterest": "20403",
+			"sumOpenInterestValue": "176196512.23400000",
+			"timestamp": 1591261042378
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	pair := "BTCUSD"
+	contractType := "CURRENT_QUARTER"
+	period := "5m"
+	limit := 10
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"pair":         pair,
+			"contractType": contractType,
+			"period":       period,
+			"limit":        limit,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewOpenInterestStatisticsService().Pair(pair).
+		ContractType(contractType).Period(period).Limit(limit).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &OpenInterestStatistic{
+		Pair:                 pair,
+		ContractType:         contractType,
+		SumOpenInterest:      "20403",
+		SumOpenInterestValue: "176196512.23400000",
+		Timestamp:            1591261042378,
+	}
+	r.Equal(e, res[0])
+}
@@ -3,7 +3,11 @@ package delivery
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/dictxwang/go-binance/common"
 )
 
 // CreateOrderService create order
@@ -621,3 +625,584 @@ type LiquidationOrder struct {
 	Side             SideType        `json:"side"`
 	Time             int64           `json:"time"`
 }
+
+// ListUserLiquidationOrdersService lists user's liquidation orders
+type ListUserLiquidationOrdersService struct {
+	c             *Client
+	symbol        *string
+	autoCloseType *ForceOrderCloseType
+	startTime     *int64
+	endTime       *int64
+	limit         *int
+}
+
+// Symbol set symbol
+func (s *ListUserLiquidationOrdersService) Symbol(symbol string) *ListUserLiquidationOrdersService {
+	s.symbol = &symbol
+	return s
+}
+
+// AutoCloseType set autoCloseType
+func (s *ListUserLiquidationOrdersService) AutoCloseType(autoCloseType ForceOrderCloseType) *ListUserLiquidationOrdersService {
+	s.autoCloseType = &autoCloseType
+	return s
+}
+
+// StartTime set startTime
+func (s *ListUserLiquidationOrdersService) StartTime(startTime int64) *ListUserLiquidationOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListUserLiquidationOrdersService) EndTime(endTime int64) *ListUserLiquidationOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *ListUserLiquidationOrdersService) Limit(limit int) *ListUserLiquidationOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListUserLiquidationOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*UserLiquidationOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/forceOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.autoCloseType != nil {
+		r.setParam("autoCloseType", *s.autoCloseType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UserLiquidationOrder{}, err
+	}
+	res = make([]*UserLiquidationOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UserLiquidationOrder{}, err
+	}
+	return res, nil
+}
+
+// UserLiquidationOrder defines user's liquidation order
+type UserLiquidationOrder struct {
+	OrderID          int64            `json:"orderId"`
+	Symbol           string           `json:"symbol"`
+	Pair             string           `json:"pair"`
+	Status           OrderStatusType  `json:"status"`
+	ClientOrderID    string           `json:"clientOrderId"`
+	Price            string           `json:"price"`
+	AvgPrice         string           `json:"avgPrice"`
+	OrigQuantity     string           `json:"origQty"`
+	ExecutedQuantity string           `json:"executedQty"`
+	CumBase          string           `json:"cumBase"`
+	TimeInForce      TimeInForceType  `json:"timeInForce"`
+	Type             OrderType        `json:"type"`
+	ReduceOnly       bool             `json:"reduceOnly"`
+	ClosePosition    bool             `json:"closePosition"`
+	Side             SideType         `json:"side"`
+	PositionSide     PositionSideType `json:"positionSide"`
+	StopPrice        string           `json:"stopPrice"`
+	WorkingType      WorkingType      `json:"workingType"`
+	PriceProtect     bool             `json:"priceProtect"`
+	OrigType         OrderType        `json:"origType"`
+	Time             int64            `json:"time"`
+	UpdateTime       int64            `json:"updateTime"`
+}
+
+// GetOrderAmendmentHistoryService get order modify history
+type GetOrderAmendmentHistoryService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+	startTime         *int64
+	endTime           *int64
+	limit             *int
+}
+
+// Symbol set symbol
+func (s *GetOrderAmendmentHistoryService) Symbol(symbol string) *GetOrderAmendmentHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *GetOrderAmendmentHistoryService) OrderID(orderID int64) *GetOrderAmendmentHistoryService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *GetOrderAmendmentHistoryService) OrigClientOrderID(origClientOrderID string) *GetOrderAmendmentHistoryService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// StartTime set startTime
+func (s *GetOrderAmendmentHistoryService) StartTime(startTime int64) *GetOrderAmendmentHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *GetOrderAmendmentHistoryService) EndTime(endTime int64) *GetOrderAmendmentHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *GetOrderAmendmentHistoryService) Limit(limit int) *GetOrderAmendmentHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *GetOrderAmendmentHistoryService) Do(ctx context.Context, opts ...RequestOption) (res []*OrderAmendment, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/orderAmendment",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OrderAmendment{}, err
+	}
+	res = make([]*OrderAmendment, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*OrderAmendment{}, err
+	}
+	return res, nil
+}
+
+// OrderAmendment define a single modification of an order
+type OrderAmendment struct {
+	AmendmentID   int64           `json:"amendmentId"`
+	Symbol        string          `json:"symbol"`
+	Pair          string          `json:"pair"`
+	OrderID       int64           `json:"orderId"`
+	ClientOrderID string          `json:"clientOrderId"`
+	Time          int64           `json:"time"`
+	Amendment     AmendmentDetail `json:"amendment"`
+}
+
+// AmendmentDetail define what changed in an order amendment
+type AmendmentDetail struct {
+	Price        AmendmentChange `json:"price"`
+	OrigQuantity AmendmentChange `json:"origQty"`
+	Count        int             `json:"count"`
+}
+
+// AmendmentChange define the value before and after an amendment
+type AmendmentChange struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// CreateBatchOrdersService place multiple orders, at most 5 orders per request
+type CreateBatchOrdersService struct {
+	c      *Client
+	orders []*CreateOrderService
+}
+
+// CreateBatchOrdersResponse contains the response from CreateBatchOrders operation
+type CreateBatchOrdersResponse struct {
+	// Total number of messages in the response
+	N int
+	// List of orders which were placed successfully which can have a length between 0 and N
+	Orders []*Order
+	// List of errors of length N, where each item corresponds to a nil value if
+	// the order from that specific index was placed successfully OR an non-nil *APIError if there was an error with
+	// the order at that index
+	Errors []error
+}
+
+// OrderList set the orders to place
+func (s *CreateBatchOrdersService) OrderList(orders []*CreateOrderService) *CreateBatchOrdersService {
+	s.orders = orders
+	return s
+}
+
+// Do send request
+func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *CreateBatchOrdersResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/dapi/v1/batchOrders",
+		secType:  secTypeSigned,
+	}
+
+	orders := []params{}
+	for _, order := range s.orders {
+		m := params{
+			"symbol":           order.symbol,
+			"side":             order.side,
+			"type":             order.orderType,
+			"quantity":         order.quantity,
+			"newOrderRespType": order.newOrderRespType,
+		}
+		if order.positionSide != nil {
+			m["positionSide"] = *order.positionSide
+		}
+		if order.timeInForce != nil {
+			m["timeInForce"] = *order.timeInForce
+		}
+		if order.reduceOnly != nil {
+			m["reduceOnly"] = *order.reduceOnly
+		}
+		if order.price != nil {
+			m["price"] = *order.price
+		}
+		if order.newClientOrderID != nil {
+			m["newClientOrderId"] = *order.newClientOrderID
+		}
+		if order.stopPrice != nil {
+			m["stopPrice"] = *order.stopPrice
+		}
+		if order.workingType != nil {
+			m["workingType"] = *order.workingType
+		}
+		if order.priceProtect != nil {
+			m["priceProtect"] = *order.priceProtect
+		}
+		if order.activationPrice != nil {
+			m["activationPrice"] = *order.activationPrice
+		}
+		if order.callbackRate != nil {
+			m["callbackRate"] = *order.callbackRate
+		}
+		if order.closePosition != nil {
+			m["closePosition"] = *order.closePosition
+		}
+		orders = append(orders, m)
+	}
+	b, err := json.Marshal(orders)
+	if err != nil {
+		return &CreateBatchOrdersResponse{}, err
+	}
+	r.setFormParam("batchOrders", string(b))
+
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return &CreateBatchOrdersResponse{}, err
+	}
+	n, orderList, errs, err := parseBatchOrders(data)
+	if err != nil {
+		return nil, err
+	}
+	return &CreateBatchOrdersResponse{
+		N:      n,
+		Orders: orderList,
+		Errors: errs,
+	}, nil
+}
+
+// parseBatchOrders split a batch response into successful orders and per-item errors
+func parseBatchOrders(data []byte) (n int, orders []*Order, errs []error, err error) {
+	rawMessages := make([]*json.RawMessage, 0)
+	err = json.Unmarshal(data, &rawMessages)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	n = len(rawMessages)
+	errs = make([]error, n)
+	for i, j := range rawMessages {
+		// check if response is an API error
+		e := new(common.APIError)
+		if err := json.Unmarshal(*j, e); err != nil {
+			return 0, nil, nil, err
+		}
+		if e.Code > 0 || e.Message != "" {
+			errs[i] = e
+			continue
+		}
+		o := new(Order)
+		if err := json.Unmarshal(*j, o); err != nil {
+			return 0, nil, nil, err
+		}
+		orders = append(orders, o)
+	}
+	return n, orders, errs, nil
+}
+
+// CancelMultipleOrdersService cancel a list of orders
+type CancelMultipleOrdersService struct {
+	c                     *Client
+	symbol                string
+	orderIDList           []int64
+	origClientOrderIDList []string
+}
+
+// Symbol set symbol
+func (s *CancelMultipleOrdersService) Symbol(symbol string) *CancelMultipleOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderIDList set orderIdList
+func (s *CancelMultipleOrdersService) OrderIDList(orderIDList []int64) *CancelMultipleOrdersService {
+	s.orderIDList = orderIDList
+	return s
+}
+
+// OrigClientOrderIDList set origClientOrderIdList
+func (s *CancelMultipleOrdersService) OrigClientOrderIDList(origClientOrderIDList []string) *CancelMultipleOrdersService {
+	s.origClientOrderIDList = origClientOrderIDList
+	return s
+}
+
+// Do send request
+func (s *CancelMultipleOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*CancelOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/dapi/v1/batchOrders",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.orderIDList != nil {
+		// convert a slice of integers to a string e.g. [1 2 3] => "[1,2,3]"
+		orderIDListString := strings.Join(strings.Fields(fmt.Sprint(s.orderIDList)), ",")
+		r.setFormParam("orderIdList", orderIDListString)
+	}
+	if s.origClientOrderIDList != nil {
+		b, err := json.Marshal(s.origClientOrderIDList)
+		if err != nil {
+			return []*CancelOrderResponse{}, err
+		}
+		r.setFormParam("origClientOrderIdList", string(b))
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CancelOrderResponse{}, err
+	}
+	res = make([]*CancelOrderResponse, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CancelOrderResponse{}, err
+	}
+	return res, nil
+}
+
+// ModifyOrderService modify price or quantity of a LIMIT order in place
+type ModifyOrderService struct {
+	c                 *Client
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          *string
+	price             *string
+}
+
+// Symbol set symbol
+func (s *ModifyOrderService) Symbol(symbol string) *ModifyOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *ModifyOrderService) Side(side SideType) *ModifyOrderService {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *ModifyOrderService) OrderID(orderID int64) *ModifyOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *ModifyOrderService) OrigClientOrderID(origClientOrderID string) *ModifyOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Quantity set quantity
+func (s *ModifyOrderService) Quantity(quantity string) *ModifyOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// Price set price
+func (s *ModifyOrderService) Price(price string) *ModifyOrderService {
+	s.price = &price
+	return s
+}
+
+func (s *ModifyOrderService) params() params {
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	return m
+}
+
+// Do send request
+func (s *ModifyOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/dapi/v1/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(s.params())
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ModifyBatchOrdersService modify multiple orders, at most 5 orders per request
+type ModifyBatchOrdersService struct {
+	c      *Client
+	orders []*ModifyOrderService
+}
+
+// ModifyBatchOrdersResponse contains the response from ModifyBatchOrders operation
+type ModifyBatchOrdersResponse struct {
+	// Total number of messages in the response
+	N int
+	// List of orders which were modified successfully which can have a length between 0 and N
+	Orders []*Order
+	// List of errors of length N, where each item corresponds to a nil value if
+	// the order from that specific index was modified successfully OR an non-nil *APIError if there was an error with
+	// the order at that index
+	Errors []error
+}
+
+// OrderList set the modifications to apply
+func (s *ModifyBatchOrdersService) OrderList(orders []*ModifyOrderService) *ModifyBatchOrdersService {
+	s.orders = orders
+	return s
+}
+
+// Do send request
+func (s *ModifyBatchOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *ModifyBatchOrdersResponse, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/dapi/v1/batchOrders",
+		secType:  secTypeSigned,
+	}
+	orders := make([]params, 0, len(s.orders))
+	for _, order := range s.orders {
+		orders = append(orders, order.params())
+	}
+	b, err := json.Marshal(orders)
+	if err != nil {
+		return &ModifyBatchOrdersResponse{}, err
+	}
+	r.setFormParam("batchOrders", string(b))
+
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return &ModifyBatchOrdersResponse{}, err
+	}
+	n, orderList, errs, err := parseBatchOrders(data)
+	if err != nil {
+		return nil, err
+	}
+	return &ModifyBatchOrdersResponse{
+		N:      n,
+		Orders: orderList,
+		Errors: errs,
+	}, nil
+}
+
+// CountdownCancelAllService cancel all open orders of a symbol once the countdown expires,
+// call it repeatedly as a heartbeat and set countdownTime to 0 to disable the timer
+type CountdownCancelAllService struct {
+	c             *Client
+	symbol        string
+	countdownTime int64
+}
+
+// Symbol set symbol
+func (s *CountdownCancelAllService) Symbol(symbol string) *CountdownCancelAllService {
+	s.symbol = symbol
+	return s
+}
+
+// CountdownTime set countdown time in milliseconds
+func (s *CountdownCancelAllService) CountdownTime(countdownTime int64) *CountdownCancelAllService {
+	s.countdownTime = countdownTime
+	return s
+}
+
+// Do send request
+func (s *CountdownCancelAllService) Do(ctx context.Context, opts ...RequestOption) (res *CountdownCancelAll, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/dapi/v1/countdownCancelAll",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"symbol":        s.symbol,
+		"countdownTime": s.countdownTime,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CountdownCancelAll)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CountdownCancelAll define countdown cancel all response
+type CountdownCancelAll struct {
+	Symbol        string `json:"symbol"`
+	CountdownTime string `json:"countdownTime"`
+}
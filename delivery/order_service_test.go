@@ -3,6 +3,7 @@ package delivery
 import (
 	"testing"
 
+	"github.com/dictxwang/go-binance/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -598,3 +599,384 @@ func (s *orderServiceTestSuite) assertLiquidationEqual(e, a *LiquidationOrder) {
 	r.Equal(e.Side, a.Side, "Side")
 	r.Equal(e.Time, a.Time, "Time")
 }
+
+func (s *orderServiceTestSuite) TestListUserLiquidationOrders() {
+	data := []byte(`[
+		{
+			"orderId": 165123080,
+			"symbol": "BTCUSD_200925",
+			"pair": "BTCUSD",
+			"status": "FILLED",
+			"clientOrderId": "autoclose-1596542005017000006",
+			"price": "11326.9",
+			"avgPrice": "11326.9",
+			"origQty": "1",
+			"executedQty": "1",
+			"cumBase": "0.00882854",
+			"timeInForce": "IOC",
+			"type": "LIMIT",
+			"reduceOnly": false,
+			"closePosition": false,
+			"side": "SELL",
+			"positionSide": "BOTH",
+			"stopPrice": "0",
+			"workingType": "CONTRACT_PRICE",
+			"priceProtect": false,
+			"origType": "LIMIT",
+			"time": 1596542005019,
+			"updateTime": 1596542005050
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_200925"
+	autoCloseType := ForceOrderCloseTypeLiquidation
+	limit := 10
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":        symbol,
+			"autoCloseType": autoCloseType,
+			"limit":         limit,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListUserLiquidationOrdersService().Symbol(symbol).
+		AutoCloseType(autoCloseType).Limit(limit).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &UserLiquidationOrder{
+		OrderID:          165123080,
+		Symbol:           symbol,
+		Pair:             "BTCUSD",
+		Status:           OrderStatusTypeFilled,
+		ClientOrderID:    "autoclose-1596542005017000006",
+		Price:            "11326.9",
+		AvgPrice:         "11326.9",
+		OrigQuantity:     "1",
+		ExecutedQuantity: "1",
+		CumBase:          "0.00882854",
+		TimeInForce:      TimeInForceTypeIOC,
+		Type:             OrderTypeLimit,
+		Side:             SideTypeSell,
+		PositionSide:     PositionSideTypeBoth,
+		StopPrice:        "0",
+		WorkingType:      WorkingTypeContractPrice,
+		OrigType:         OrderTypeLimit,
+		Time:             1596542005019,
+		UpdateTime:       1596542005050,
+	}
+	r.Equal(e, res[0])
+}
+
+func (s *orderServiceTestSuite) TestGetOrderAmendmentHistory() {
+	data := []byte(`[
+		{
+			"amendmentId": 5363,
+			"symbol": "BTCUSD_PERP",
+			"pair": "BTCUSD",
+			"orderId": 20072994037,
+			"clientOrderId": "LJ9R4QZDihCaS8UAOOLpgW",
+			"time": 1629184560899,
+			"amendment": {
+				"price": {
+					"before": "30004",
+					"after": "30003.2"
+				},
+				"origQty": {
+					"before": "1",
+					"after": "1"
+				},
+				"count": 3
+			}
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_PERP"
+	orderID := int64(20072994037)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":  symbol,
+			"orderId": orderID,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetOrderAmendmentHistoryService().Symbol(symbol).
+		OrderID(orderID).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &OrderAmendment{
+		AmendmentID:   5363,
+		Symbol:        symbol,
+		Pair:          "BTCUSD",
+		OrderID:       orderID,
+		ClientOrderID: "LJ9R4QZDihCaS8UAOOLpgW",
+		Time:          1629184560899,
+		Amendment: AmendmentDetail{
+			Price:        AmendmentChange{Before: "30004", After: "30003.2"},
+			OrigQuantity: AmendmentChange{Before: "1", After: "1"},
+			Count:        3,
+		},
+	}
+	r.Equal(e, res[0])
+}
+
+func (s *orderServiceTestSuite) TestCreateBatchOrders() {
+	data := []byte(`[
+		{
+			"code": -2014,
+			"msg": "API-key format invalid."
+		},
+		{
+			"clientOrderId": "testOrder",
+			"cumQty": "0",
+			"cumBase": "0",
+			"executedQty": "0",
+			"orderId": 22542179,
+			"avgPrice": "0.0",
+			"origQty": "10",
+			"price": "9000",
+			"reduceOnly": false,
+			"side": "BUY",
+			"positionSide": "BOTH",
+			"status": "NEW",
+			"stopPrice": "0",
+			"symbol": "BTCUSD_200925",
+			"pair": "BTCUSD",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"origType": "LIMIT",
+			"updateTime": 1566818724722,
+			"workingType": "CONTRACT_PRICE",
+			"priceProtect": false
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"batchOrders": `[{"newOrderRespType":"","price":"9000","quantity":"10","side":"BUY","symbol":"BTCUSD_200925","timeInForce":"GTC","type":"LIMIT"},{"newOrderRespType":"","quantity":"1","side":"SELL","symbol":"BTCUSD_200925","type":"MARKET"}]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateBatchOrdersService().OrderList([]*CreateOrderService{
+		s.client.NewCreateOrderService().Symbol("BTCUSD_200925").Side(SideTypeBuy).
+			Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("10").Price("9000"),
+		s.client.NewCreateOrderService().Symbol("BTCUSD_200925").Side(SideTypeSell).
+			Type(OrderTypeMarket).Quantity("1"),
+	}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	e := &CreateBatchOrdersResponse{
+		N: 2,
+		Orders: []*Order{
+			{
+				AvgPrice:         "0.0",
+				ClientOrderID:    "testOrder",
+				CumBase:          "0",
+				ExecutedQuantity: "0",
+				OrderID:          22542179,
+				OrigQuantity:     "10",
+				OrigType:         OrderTypeLimit,
+				Price:            "9000",
+				Side:             SideTypeBuy,
+				PositionSide:     PositionSideTypeBoth,
+				Status:           OrderStatusTypeNew,
+				StopPrice:        "0",
+				Symbol:           "BTCUSD_200925",
+				Pair:             "BTCUSD",
+				TimeInForce:      TimeInForceTypeGTC,
+				Type:             OrderTypeLimit,
+				UpdateTime:       1566818724722,
+				WorkingType:      WorkingTypeContractPrice,
+			},
+		},
+		Errors: []error{
+			&common.APIError{
+				Code:    -2014,
+				Message: "API-key format invalid.",
+			},
+			nil,
+		},
+	}
+	r.EqualValues(e, res)
+}
+
+func (s *orderServiceTestSuite) TestCancelMultipleOrders() {
+	data := []byte(`[
+		{
+			"clientOrderId": "myOrder1",
+			"cumQty": "0",
+			"cumBase": "0",
+			"executedQty": "0",
+			"orderId": 283194212,
+			"origQty": "11",
+			"origType": "TRAILING_STOP_MARKET",
+			"price": "0",
+			"reduceOnly": false,
+			"side": "BUY",
+			"positionSide": "SHORT",
+			"status": "CANCELED",
+			"stopPrice": "9300",
+			"closePosition": false,
+			"symbol": "BTCUSD_200925",
+			"pair": "BTCUSD",
+			"timeInForce": "GTC",
+			"type": "TRAILING_STOP_MARKET",
+			"activatePrice": "9020",
+			"priceRate": "0.3",
+			"workingType": "CONTRACT_PRICE",
+			"priceProtect": false,
+			"updateTime": 1571110484038
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_200925"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                symbol,
+			"orderIdList":           "[283194212,283194213]",
+			"origClientOrderIdList": `["myOrder1","myOrder2"]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCancelMultipleOrdersService().Symbol(symbol).
+		OrderIDList([]int64{283194212, 283194213}).
+		OrigClientOrderIDList([]string{"myOrder1", "myOrder2"}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal(int64(283194212), res[0].OrderID)
+	r.Equal(OrderStatusTypeCanceled, res[0].Status)
+	r.Equal("BTCUSD", res[0].Pair)
+}
+
+func (s *orderServiceTestSuite) TestModifyOrder() {
+	data := []byte(`{
+		"orderId": 20072994037,
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"status": "NEW",
+		"clientOrderId": "LJ9R4QZDihCaS8UAOOLpgW",
+		"price": "30005",
+		"avgPrice": "0.0",
+		"origQty": "1",
+		"executedQty": "0",
+		"cumQty": "0",
+		"cumBase": "0",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"reduceOnly": false,
+		"closePosition": false,
+		"side": "BUY",
+		"positionSide": "LONG",
+		"stopPrice": "0",
+		"workingType": "CONTRACT_PRICE",
+		"priceProtect": false,
+		"origType": "LIMIT",
+		"updateTime": 1629182711600
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_PERP"
+	orderID := int64(20072994037)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":   symbol,
+			"side":     SideTypeBuy,
+			"orderId":  orderID,
+			"quantity": "1",
+			"price":    "30005",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyOrderService().Symbol(symbol).Side(SideTypeBuy).
+		OrderID(orderID).Quantity("1").Price("30005").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(orderID, res.OrderID)
+	r.Equal("30005", res.Price)
+	r.Equal("1", res.OrigQuantity)
+	r.Equal(PositionSideTypeLong, res.PositionSide)
+	r.Equal(int64(1629182711600), res.UpdateTime)
+}
+
+func (s *orderServiceTestSuite) TestModifyBatchOrders() {
+	data := []byte(`[
+		{
+			"orderId": 20072994037,
+			"symbol": "BTCUSD_PERP",
+			"pair": "BTCUSD",
+			"status": "NEW",
+			"price": "30005",
+			"origQty": "1",
+			"side": "BUY",
+			"type": "LIMIT"
+		},
+		{
+			"code": -4028,
+			"msg": "Price less than min price."
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"batchOrders": `[{"orderId":20072994037,"price":"30005","quantity":"1","side":"BUY","symbol":"BTCUSD_PERP"},{"origClientOrderId":"abc","price":"0.1","quantity":"2","side":"SELL","symbol":"BTCUSD_PERP"}]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyBatchOrdersService().OrderList([]*ModifyOrderService{
+		s.client.NewModifyOrderService().Symbol("BTCUSD_PERP").Side(SideTypeBuy).
+			OrderID(20072994037).Quantity("1").Price("30005"),
+		s.client.NewModifyOrderService().Symbol("BTCUSD_PERP").Side(SideTypeSell).
+			OrigClientOrderID("abc").Quantity("2").Price("0.1"),
+	}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(2, res.N)
+	r.Len(res.Orders, 1)
+	r.Equal(int64(20072994037), res.Orders[0].OrderID)
+	r.Nil(res.Errors[0])
+	r.Equal(&common.APIError{Code: -4028, Message: "Price less than min price."}, res.Errors[1])
+}
+
+func (s *orderServiceTestSuite) TestCountdownCancelAll() {
+	data := []byte(`{
+		"symbol": "BTCUSD_200925",
+		"countdownTime": "100000"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSD_200925"
+	countdownTime := int64(100000)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":        symbol,
+			"countdownTime": countdownTime,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCountdownCancelAllService().Symbol(symbol).
+		CountdownTime(countdownTime).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&CountdownCancelAll{Symbol: symbol, CountdownTime: "100000"}, res)
+}
@@ -65,3 +65,43 @@ type PositionRisk struct {
 	IsAutoAddMargin  string `json:"isAutoAddMargin"`
 	PositionSide     string `json:"positionSide"`
 }
+
+// GetADLQuantileService get position ADL quantile estimation
+type GetADLQuantileService struct {
+	c      *Client
+	symbol *string
+}
+
+// Symbol set symbol
+func (s *GetADLQuantileService) Symbol(symbol string) *GetADLQuantileService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *GetADLQuantileService) Do(ctx context.Context, opts ...RequestOption) (res []*ADLQuantile, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/adlQuantile",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	res = make([]*ADLQuantile, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	return res, nil
+}
+
+// ADLQuantile define ADL quantile of a symbol, keyed by position side (LONG, SHORT, BOTH, HEDGE)
+type ADLQuantile struct {
+	Symbol      string           `json:"symbol"`
+	ADLQuantile map[string]int64 `json:"adlQuantile"`
+}
@@ -131,3 +131,44 @@ func (s *positionRiskServiceTestSuite) assertPositionRiskEqual(e, a *PositionRis
 	r.Equal(e.UnRealizedProfit, a.UnRealizedProfit, "UnRealizedProfit")
 	r.Equal(e.PositionSide, a.PositionSide, "PositionSide")
 }
+
+func (s *positionRiskServiceTestSuite) TestGetADLQuantile() {
+	data := []byte(`[
+		{
+			"symbol": "BTCUSD_200925",
+			"adlQuantile": {
+				"LONG": 3,
+				"SHORT": 3,
+				"HEDGE": 0
+			}
+		},
+		{
+			"symbol": "BTCUSD_201225",
+			"adlQuantile": {
+				"LONG": 1,
+				"SHORT": 2,
+				"BOTH": 0
+			}
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetADLQuantileService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 2)
+	r.Equal(&ADLQuantile{
+		Symbol:      "BTCUSD_200925",
+		ADLQuantile: map[string]int64{"LONG": 3, "SHORT": 3, "HEDGE": 0},
+	}, res[0])
+	r.Equal(&ADLQuantile{
+		Symbol:      "BTCUSD_201225",
+		ADLQuantile: map[string]int64{"LONG": 1, "SHORT": 2, "BOTH": 0},
+	}, res[1])
+}
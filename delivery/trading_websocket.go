@@ -0,0 +1,809 @@
+package delivery
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	redialTick = 2 * time.Second
+	writeWait  = 3 * time.Second
+	PingPeriod = 15 * time.Second
+)
+
+// WS API methods supported by the COIN-M trading websocket
+const (
+	wsMethodLogon           = "session.logon"
+	wsMethodOrderPlace      = "order.place"
+	wsMethodOrderModify     = "order.modify"
+	wsMethodOrderCancel     = "order.cancel"
+	wsMethodOrderStatus     = "order.status"
+	wsMethodAccountStatus   = "account.status"
+	wsMethodAccountBalance  = "account.balance"
+	wsMethodAccountPosition = "account.position"
+)
+
+type (
+	ErrorDetail struct {
+		Code int64  `json:"code"`
+		Msg  string `json:"msg,omitempty"`
+	}
+
+	Basic struct {
+		ID     string                 `json:"id"`
+		Status int                    `json:"status"`
+		Result map[string]interface{} `json:"result,omitempty"`
+		Error  *ErrorDetail           `json:"error,omitempty"`
+	}
+
+	BasicArray struct {
+		ID     string                   `json:"id"`
+		Status int                      `json:"status"`
+		Result []map[string]interface{} `json:"result,omitempty"`
+		Error  *ErrorDetail             `json:"error,omitempty"`
+	}
+
+	Error struct {
+		ID     string       `json:"id,omitempty"`
+		Status int64        `json:"status,omitempty"`
+		Error  *ErrorDetail `json:"error,omitempty"`
+	}
+
+	LoginResult struct {
+		APIKey           string `json:"apiKey"`
+		AuthorizedSince  int64  `json:"authorizedSince"`
+		ConnectedSince   int64  `json:"connectedSince"`
+		ReturnRateLimits bool   `json:"returnRateLimits"`
+		ServerTime       int64  `json:"serverTime"`
+	}
+
+	LoginResp struct {
+		ID     string      `json:"id,omitempty"`
+		Status int64       `json:"status,omitempty"`
+		Result LoginResult `json:"result,omitempty"`
+	}
+
+	OrderResult struct {
+		OrderId       int64  `json:"orderId,omitempty"`
+		Symbol        string `json:"symbol,omitempty"`
+		Pair          string `json:"pair,omitempty"`
+		Status        string `json:"status,omitempty"`
+		ClientOrderId string `json:"clientOrderId,omitempty"`
+		Price         string `json:"price,omitempty"`
+		AvgPrice      string `json:"avgPrice,omitempty"`
+		OrigQty       string `json:"origQty,omitempty"`
+		ExecutedQty   string `json:"executedQty,omitempty"`
+		CumQty        string `json:"cumQty,omitempty"`
+		CumBase       string `json:"cumBase,omitempty"`
+		TimeInForce   string `json:"timeInForce,omitempty"`
+		Type          string `json:"type,omitempty"`
+		ReduceOnly    bool   `json:"reduceOnly,omitempty"`
+		ClosePosition bool   `json:"closePosition,omitempty"`
+		Side          string `json:"side,omitempty"`
+		PositionSide  string `json:"positionSide,omitempty"`
+		StopPrice     string `json:"stopPrice,omitempty"`
+		WorkingType   string `json:"workingType,omitempty"`
+		PriceProtect  bool   `json:"priceProtect,omitempty"`
+		OrigType      string `json:"origType,omitempty"`
+		PriceMatch    string `json:"priceMatch,omitempty"`
+		UpdateTime    int64  `json:"updateTime,omitempty"`
+		ActivatePrice string `json:"activatePrice,omitempty"`
+		PriceRate     string `json:"priceRate,omitempty"`
+	}
+
+	OrderResp struct {
+		ID     string      `json:"id,omitempty"`
+		Status int64       `json:"status,omitempty"`
+		Result OrderResult `json:"result,omitempty"`
+	}
+
+	BalanceResult struct {
+		AccountAlias       string `json:"accountAlias"`
+		Asset              string `json:"asset"`
+		Balance            string `json:"balance"`
+		WithdrawAvailable  string `json:"withdrawAvailable"`
+		CrossWalletBalance string `json:"crossWalletBalance"`
+		CrossUnPnl         string `json:"crossUnPnl"`
+		AvailableBalance   string `json:"availableBalance"`
+		UpdateTime         int64  `json:"updateTime"`
+	}
+
+	BalanceResp struct {
+		ID     string          `json:"id,omitempty"`
+		Status int64           `json:"status,omitempty"`
+		Result []BalanceResult `json:"result,omitempty"`
+	}
+
+	PositionResult struct {
+		Symbol           string `json:"symbol"`
+		PositionAmt      string `json:"positionAmt"`
+		EntryPrice       string `json:"entryPrice"`
+		BreakEvenPrice   string `json:"breakEvenPrice"`
+		MarkPrice        string `json:"markPrice"`
+		UnRealizedProfit string `json:"unRealizedProfit"`
+		LiquidationPrice string `json:"liquidationPrice"`
+		Leverage         string `json:"leverage"`
+		MaxQty           string `json:"maxQty"`
+		MarginType       string `json:"marginType"`
+		IsolatedMargin   string `json:"isolatedMargin"`
+		IsAutoAddMargin  string `json:"isAutoAddMargin"`
+		PositionSide     string `json:"positionSide"`
+		NotionalValue    string `json:"notionalValue"`
+		IsolatedWallet   string `json:"isolatedWallet"`
+		UpdateTime       int64  `json:"updateTime"`
+	}
+
+	PositionResp struct {
+		ID     string           `json:"id,omitempty"`
+		Status int64            `json:"status,omitempty"`
+		Result []PositionResult `json:"result,omitempty"`
+	}
+
+	AccountStatusResp struct {
+		ID     string   `json:"id,omitempty"`
+		Status int64    `json:"status,omitempty"`
+		Result *Account `json:"result,omitempty"`
+	}
+)
+
+func (a *Basic) GetResult(k string) (interface{}, bool) {
+	v, ok := a.Result[k]
+	return v, ok
+}
+
+// ClientWs define the COIN-M websocket API trading client
+type ClientWs struct {
+	url                   string
+	apiKey                string
+	secretKey             string
+	privateKey            crypto.PrivateKey
+	ctx                   context.Context
+	Cancel                context.CancelFunc
+	DoneChan              chan interface{}
+	ErrChan               chan *Error
+	LoginChan             chan *LoginResp
+	OrderRespChan         chan *OrderResp
+	BalanceRespChan       chan *BalanceResp
+	PositionRespChan      chan *PositionResp
+	AccountStatusRespChan chan *AccountStatusResp
+	sendChan              chan []byte
+	pending               sync.Map
+	LocalIP               string
+	resolver              *net.Resolver
+	dialer                *websocket.Dialer
+	metrics               common.WsMetrics
+	name                  string
+
+	// mu guards the connection and session state shared by the caller, sender,
+	// receiver and process goroutines
+	mu            sync.Mutex
+	conn          *websocket.Conn
+	closed        bool
+	authRequested *time.Time
+	authorized    bool
+	lastTransmit  *time.Time
+}
+
+// NewTradingWsClient init a websocket API trading client, secretKey is the PEM encoded Ed25519 private key
+func NewTradingWsClient(ctx context.Context, apiKey, secretKey, localIP string) (*ClientWs, error) {
+	privateKey, err := common.ParsePrivateKey(secretKey)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c := &ClientWs{
+		url:        getTradingWsEndpoint(),
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		privateKey: privateKey,
+		ctx:        ctx,
+		Cancel:     cancel,
+		sendChan:   make(chan []byte, 3),
+		DoneChan:   make(chan interface{}, 32),
+		LocalIP:    localIP,
+		name:       common.NewWsAPIConnName(localIP),
+		metrics:    WebsocketMetrics,
+	}
+	return c, nil
+}
+
+// NewTradingWsClientWithEnvironment is similar to NewTradingWsClient, but it connects to the WS API of env
+func NewTradingWsClientWithEnvironment(ctx context.Context, apiKey, secretKey, localIP string, env *Environment) (*ClientWs, error) {
+	c, err := NewTradingWsClient(ctx, apiKey, secretKey, localIP)
+	if err != nil {
+		return nil, err
+	}
+	c.url = env.WsAPIURL
+	c.dialer = env.Dialer
+	c.metrics = env.Metrics
+	return c, nil
+}
+
+// SetMetrics set the metrics receiving the health of the connection, defaults to WebsocketMetrics
+func (c *ClientWs) SetMetrics(metrics common.WsMetrics) {
+	c.metrics = metrics
+}
+
+// SetName set the name of the connection reported to the metrics, defaults to wsAPI numbered in the process
+// and followed by the local IP, e.g. wsAPI#1@10.0.0.1
+func (c *ClientWs) SetName(name string) {
+	c.name = name
+}
+
+func (c *ClientWs) SetResolver(resolver *net.Resolver) {
+	c.resolver = resolver
+}
+
+func (c *ClientWs) SetChannels(errCh chan *Error, lCh chan *LoginResp, osCh chan *OrderResp) {
+	c.ErrChan = errCh
+	c.LoginChan = lCh
+	c.OrderRespChan = osCh
+}
+
+// SetAccountChannels set the channels receiving account.balance, account.position and account.status results
+func (c *ClientWs) SetAccountChannels(bCh chan *BalanceResp, pCh chan *PositionResp, aCh chan *AccountStatusResp) {
+	c.BalanceRespChan = bCh
+	c.PositionRespChan = pCh
+	c.AccountStatusRespChan = aCh
+}
+
+func (c *ClientWs) Send(method string, args map[string]interface{}, extras ...map[string]string) error {
+	if method != wsMethodLogon {
+		err := c.Connect()
+		if err == nil {
+			err = c.WaitForAuthorization()
+			if err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+	}
+
+	if _, ok := args["timestamp"]; !ok {
+		args["timestamp"] = time.Now().UnixMilli()
+	}
+
+	reqID := GetReqID()
+	data := map[string]interface{}{
+		"id":     reqID,
+		"method": method,
+		"params": args,
+	}
+
+	for _, extra := range extras {
+		for k, v := range extra {
+			data[k] = v
+		}
+	}
+
+	j, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.pending.Store(reqID, method)
+	c.sendChan <- j
+	return nil
+}
+
+func (c *ClientWs) Connect() error {
+	if c.CheckConnect() {
+		return nil
+	}
+
+	err := c.dial()
+	if err == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(redialTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err = c.dial()
+			if err == nil {
+				return nil
+			}
+		case <-c.ctx.Done():
+			return c.handleCancel("connect")
+		}
+	}
+}
+
+// CheckConnect into the server
+func (c *ClientWs) CheckConnect() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn != nil && !c.closed
+}
+
+// IsAuthorized report whether the session logon succeeded on the current connection
+func (c *ClientWs) IsAuthorized() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.authorized
+}
+
+func (c *ClientWs) setAuthorized(authorized bool) {
+	c.mu.Lock()
+	c.authorized = authorized
+	c.mu.Unlock()
+}
+
+// WaitForAuthorization waits for the auth response and try to log in if it was needed
+func (c *ClientWs) WaitForAuthorization() error {
+	if c.IsAuthorized() {
+		return nil
+	}
+
+	if err := c.Login(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(time.Millisecond * 300)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if c.IsAuthorized() {
+				return nil
+			}
+		case <-c.ctx.Done():
+			return c.handleCancel("authorization")
+		}
+	}
+}
+
+func (c *ClientWs) Login() error {
+	c.mu.Lock()
+	if c.authorized || (c.authRequested != nil && time.Since(*c.authRequested).Seconds() < 30) {
+		c.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	c.authRequested = &now
+	c.mu.Unlock()
+
+	args := map[string]interface{}{
+		"apiKey":    c.apiKey,
+		"timestamp": time.Now().UnixMilli(), // use the current time in milliseconds
+	}
+
+	payload := common.MakeQueryString(args)
+	signature, err := common.SignPayload(payload, c.privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign payload: %w", err)
+	}
+	args["signature"] = signature
+
+	reqID := GetReqID()
+	data := map[string]interface{}{
+		"id":     reqID,
+		"method": wsMethodLogon,
+		"params": args,
+	}
+
+	j, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.pending.Store(reqID, wsMethodLogon)
+	c.sendChan <- j
+	return nil
+}
+
+func (c *ClientWs) dial() error {
+	var dialer websocket.Dialer
+	if c.dialer != nil {
+		dialer = *c.dialer
+	} else if c.LocalIP != "" {
+		dialer = websocket.Dialer{
+			NetDial: func(network, addr string) (net.Conn, error) {
+				localAddr, err := net.ResolveTCPAddr("tcp", c.LocalIP+":0")
+				if err != nil {
+					return nil, err
+				}
+				d := net.Dialer{
+					LocalAddr: localAddr,
+					Resolver:  net.DefaultResolver,
+				}
+				if c.resolver != nil {
+					d.Resolver = c.resolver
+				}
+				return d.Dial(network, addr)
+			},
+			HandshakeTimeout:  45 * time.Second,
+			EnableCompression: false,
+		}
+	} else {
+		dialer = websocket.Dialer{
+			Proxy:             http.ProxyFromEnvironment,
+			HandshakeTimeout:  45 * time.Second,
+			EnableCompression: false,
+		}
+	}
+	conn, res, err := dialer.Dial(c.url, nil)
+	if err != nil {
+		var statusCode int
+		if res != nil {
+			statusCode = res.StatusCode
+		}
+
+		return fmt.Errorf("error %d: %w", statusCode, err)
+	}
+	conn.SetReadLimit(655350)
+	defer res.Body.Close()
+
+	c.mu.Lock()
+	c.conn = conn
+	c.closed = false
+	c.mu.Unlock()
+	if c.metrics != nil {
+		c.metrics.WsConnected(c.name)
+	}
+
+	go func() {
+		defer c.closeConn(conn)
+		err := c.receiver(conn)
+		if err != nil && !strings.Contains(err.Error(), "operation cancelled: receiver") {
+			c.sendError(err)
+		}
+	}()
+
+	go func() {
+		defer c.closeConn(conn)
+		err := c.sender(conn)
+		if err != nil {
+			if !strings.Contains(err.Error(), "operation cancelled: sender") {
+				c.sendError(err)
+			}
+			c.setAuthorized(false)
+		}
+	}()
+
+	return nil
+}
+
+// closeConn cancel the client and close conn once its sender or receiver exits
+func (c *ClientWs) closeConn(conn *websocket.Conn) {
+	c.Cancel()
+	conn.Close()
+	c.mu.Lock()
+	if c.conn == conn {
+		c.closed = true
+	}
+	c.mu.Unlock()
+}
+
+func (c *ClientWs) sendError(err error) {
+	if c.ErrChan == nil {
+		return
+	}
+	c.ErrChan <- &Error{
+		Error: &ErrorDetail{
+			Code: 111,
+			Msg:  err.Error(),
+		},
+	}
+}
+
+func (c *ClientWs) sender(conn *websocket.Conn) error {
+	ticker := time.NewTicker(time.Millisecond * 300)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case data := <-c.sendChan:
+			if string(data) == "ping" {
+				deadline := time.Now().Add(10 * time.Second)
+				err := conn.WriteControl(websocket.PingMessage, []byte{}, deadline)
+				if err != nil {
+					return fmt.Errorf("failed to send ping to conn, error: %w", err)
+				}
+			} else {
+				err := conn.SetWriteDeadline(time.Now().Add(writeWait))
+				if err != nil {
+					return fmt.Errorf("failed to set write deadline for ws connection, error: %w", err)
+				}
+
+				err = conn.WriteMessage(websocket.TextMessage, data)
+				if err != nil {
+					if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.ClosePolicyViolation) {
+						return fmt.Errorf("connection closed, error: %w", err)
+					}
+
+					return fmt.Errorf("failed to send request: %w", err)
+				}
+			}
+
+		case <-ticker.C:
+			c.mu.Lock()
+			lastTransmit := c.lastTransmit
+			c.mu.Unlock()
+			if lastTransmit == nil || time.Since(*lastTransmit) > PingPeriod {
+				go func() {
+					c.sendChan <- []byte("ping")
+				}()
+			}
+
+		case <-c.ctx.Done():
+			return c.handleCancel("sender")
+		}
+	}
+}
+
+func (c *ClientWs) receiver(conn *websocket.Conn) error {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return c.handleCancel("receiver")
+		default:
+			mt, data, err := conn.ReadMessage()
+			if err != nil {
+				if c.metrics != nil {
+					c.metrics.WsDisconnected(c.name, err)
+				}
+				return fmt.Errorf("failed to read message from ws connection, error: %w", err)
+			}
+
+			now := time.Now()
+			c.mu.Lock()
+			c.lastTransmit = &now
+			c.mu.Unlock()
+			if c.metrics != nil {
+				common.ObserveWsMessage(c.metrics, c.name, data, now)
+			}
+
+			if mt == websocket.TextMessage && string(data) != "pong" {
+				if isArrayResult(data) {
+					e := &BasicArray{}
+					if err := json.Unmarshal(data, e); err != nil {
+						return fmt.Errorf("failed to unmarshal message from ws, error: %w", err)
+					}
+					go c.processArray(data, e)
+				} else {
+					e := &Basic{}
+					if err := json.Unmarshal(data, e); err != nil {
+						return fmt.Errorf("failed to unmarshal message from ws, error: %w", err)
+					}
+					go c.process(data, e)
+				}
+			}
+		}
+	}
+}
+
+func isArrayResult(data []byte) bool {
+	return strings.Contains(string(data), "\"result\": [") ||
+		strings.Contains(string(data), "\"result\":[")
+}
+
+func (c *ClientWs) handleCancel(msg string) error {
+	go func() {
+		c.DoneChan <- msg
+	}()
+
+	return fmt.Errorf("operation cancelled: %s", msg)
+}
+
+// takeMethod return and forget the method of a pending request
+func (c *ClientWs) takeMethod(id string) string {
+	v, ok := c.pending.LoadAndDelete(id)
+	if !ok {
+		return ""
+	}
+	return v.(string)
+}
+
+func (c *ClientWs) process(data []byte, e *Basic) bool {
+	method := c.takeMethod(e.ID)
+
+	if e.Error != nil {
+		e := Error{}
+		_ = json.Unmarshal(data, &e)
+		if method == wsMethodLogon {
+			c.mu.Lock()
+			c.authRequested = nil
+			c.mu.Unlock()
+		}
+		go func() {
+			if c.ErrChan != nil {
+				c.ErrChan <- &e
+			}
+		}()
+		return true
+	}
+
+	if e.Result == nil {
+		return false
+	}
+
+	if _, ok := e.GetResult("authorizedSince"); ok || method == wsMethodLogon {
+		// logon request
+		c.mu.Lock()
+		if c.authRequested != nil && time.Since(*c.authRequested).Seconds() > 30 {
+			c.authRequested = nil
+			c.mu.Unlock()
+			_ = c.Login()
+			return false
+		}
+		c.authorized = true
+		c.mu.Unlock()
+
+		e := LoginResp{}
+		_ = json.Unmarshal(data, &e)
+		go func() {
+			if c.LoginChan != nil {
+				c.LoginChan <- &e
+			}
+		}()
+		return true
+	}
+
+	switch method {
+	case wsMethodAccountStatus:
+		e := AccountStatusResp{}
+		_ = json.Unmarshal(data, &e)
+		go func() {
+			if c.AccountStatusRespChan != nil {
+				c.AccountStatusRespChan <- &e
+			}
+		}()
+		return true
+	case wsMethodOrderPlace, wsMethodOrderModify, wsMethodOrderCancel, wsMethodOrderStatus:
+	default:
+		// unknown request id, fall back to the shape of the result
+		if _, ok := e.GetResult("orderId"); !ok {
+			return false
+		}
+	}
+
+	o := OrderResp{}
+	_ = json.Unmarshal(data, &o)
+	go func() {
+		if c.OrderRespChan != nil {
+			c.OrderRespChan <- &o
+		}
+	}()
+	return true
+}
+
+func (c *ClientWs) processArray(data []byte, e *BasicArray) bool {
+	method := c.takeMethod(e.ID)
+
+	if e.Error != nil {
+		e := Error{}
+		_ = json.Unmarshal(data, &e)
+		go func() {
+			if c.ErrChan != nil {
+				c.ErrChan <- &e
+			}
+		}()
+		return true
+	}
+
+	switch method {
+	case wsMethodAccountBalance:
+		e := BalanceResp{}
+		_ = json.Unmarshal(data, &e)
+		go func() {
+			if c.BalanceRespChan != nil {
+				c.BalanceRespChan <- &e
+			}
+		}()
+		return true
+	case wsMethodAccountPosition:
+		e := PositionResp{}
+		_ = json.Unmarshal(data, &e)
+		go func() {
+			if c.PositionRespChan != nil {
+				c.PositionRespChan <- &e
+			}
+		}()
+		return true
+	}
+	return false
+}
+
+type WsPlaceOrder struct {
+	NewClientOrderId string `json:"newClientOrderId,omitempty"`
+	Symbol           string `json:"symbol"`
+	Side             string `json:"side"`
+	PositionSide     string `json:"positionSide,omitempty"`
+	Type             string `json:"type"`
+	TimeInForce      string `json:"timeInForce,omitempty"`
+	Quantity         string `json:"quantity,omitempty"`
+	Price            string `json:"price,omitempty"`
+	StopPrice        string `json:"stopPrice,omitempty"`
+	ReduceOnly       bool   `json:"reduceOnly,omitempty"`
+	ClosePosition    bool   `json:"closePosition,omitempty"`
+	ActivationPrice  string `json:"activationPrice,omitempty"`
+	CallbackRate     string `json:"callbackRate,omitempty"`
+	WorkingType      string `json:"workingType,omitempty"`
+	PriceProtect     bool   `json:"priceProtect,omitempty"`
+	PriceMatch       string `json:"priceMatch,omitempty"`
+	NewOrderRespType string `json:"newOrderRespType,omitempty"`
+	Timestamp        int64  `json:"timestamp,omitempty"`
+}
+
+type WsModifyOrder struct {
+	Symbol            string `json:"symbol"`
+	OrderID           int64  `json:"orderId,omitempty"`
+	OrigClientOrderId string `json:"origClientOrderId,omitempty"`
+	Side              string `json:"side"`
+	Quantity          string `json:"quantity"`
+	Price             string `json:"price,omitempty"`
+	PriceMatch        string `json:"priceMatch,omitempty"`
+	Timestamp         int64  `json:"timestamp,omitempty"`
+}
+
+type WsCancelOrder struct {
+	Symbol            string `json:"symbol"`
+	OrderID           int64  `json:"orderId,omitempty"`
+	OrigClientOrderId string `json:"origClientOrderId,omitempty"`
+	Timestamp         int64  `json:"timestamp,omitempty"`
+}
+
+type WsQueryOrder struct {
+	Symbol            string `json:"symbol"`
+	OrderID           int64  `json:"orderId,omitempty"`
+	OrigClientOrderId string `json:"origClientOrderId,omitempty"`
+	Timestamp         int64  `json:"timestamp,omitempty"`
+}
+
+type WsQueryPosition struct {
+	MarginAsset string `json:"marginAsset,omitempty"`
+	Pair        string `json:"pair,omitempty"`
+	Timestamp   int64  `json:"timestamp,omitempty"`
+}
+
+func (c *ClientWs) PlaceOrder(order *WsPlaceOrder) error {
+	return c.Send(wsMethodOrderPlace, common.StructToMap(order))
+}
+
+func (c *ClientWs) ModifyOrder(order *WsModifyOrder) error {
+	return c.Send(wsMethodOrderModify, common.StructToMap(order))
+}
+
+func (c *ClientWs) CancelOrder(order *WsCancelOrder) error {
+	return c.Send(wsMethodOrderCancel, common.StructToMap(order))
+}
+
+func (c *ClientWs) QueryOrder(order *WsQueryOrder) error {
+	return c.Send(wsMethodOrderStatus, common.StructToMap(order))
+}
+
+// QueryAccountStatus request account.status, the result is pushed to AccountStatusRespChan
+func (c *ClientWs) QueryAccountStatus() error {
+	return c.Send(wsMethodAccountStatus, map[string]interface{}{})
+}
+
+// QueryAccountBalance request account.balance, the result is pushed to BalanceRespChan
+func (c *ClientWs) QueryAccountBalance() error {
+	return c.Send(wsMethodAccountBalance, map[string]interface{}{})
+}
+
+// QueryAccountPosition request account.position, the result is pushed to PositionRespChan
+func (c *ClientWs) QueryAccountPosition(query *WsQueryPosition) error {
+	return c.Send(wsMethodAccountPosition, common.StructToMap(query))
+}
+
+// GetReqID return a unique id for a websocket API request
+func GetReqID() string {
+	return common.GetReqID()
+}
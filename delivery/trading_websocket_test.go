@@ -0,0 +1,219 @@
+package delivery
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsAPIRequest struct {
+	ID     string                 `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+type tradingWsTestSuite struct {
+	suite.Suite
+	publicKey ed25519.PublicKey
+	server    *httptest.Server
+	requests  chan *wsAPIRequest
+	// responses return the raw response to a request, keyed by method
+	responses map[string]func(req *wsAPIRequest) string
+	client    *ClientWs
+}
+
+func TestTradingWs(t *testing.T) {
+	suite.Run(t, new(tradingWsTestSuite))
+}
+
+func (s *tradingWsTestSuite) SetupTest() {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+	s.publicKey = publicKey
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	s.Require().NoError(err)
+	secretKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	s.requests = make(chan *wsAPIRequest, 16)
+	s.responses = map[string]func(req *wsAPIRequest) string{
+		wsMethodLogon: func(req *wsAPIRequest) string {
+			return fmt.Sprintf(`{"id":"%s","status":200,"result":{"apiKey":"dummyAPIKey","authorizedSince":1728980000000,"connectedSince":1728979000000,"returnRateLimits":false,"serverTime":1728980000001}}`, req.ID)
+		},
+	}
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			req := new(wsAPIRequest)
+			if json.Unmarshal(data, req) != nil {
+				continue
+			}
+			s.requests <- req
+			if respond, ok := s.responses[req.Method]; ok {
+				c.WriteMessage(websocket.TextMessage, []byte(respond(req)))
+			}
+		}
+	}))
+
+	s.client, err = NewTradingWsClient(context.Background(), "dummyAPIKey", secretKey, "")
+	s.Require().NoError(err)
+	s.client.url = "ws" + strings.TrimPrefix(s.server.URL, "http")
+	s.client.SetMetrics(nil)
+	s.client.SetChannels(make(chan *Error, 4), make(chan *LoginResp, 4), make(chan *OrderResp, 4))
+	s.client.SetAccountChannels(make(chan *BalanceResp, 4), make(chan *PositionResp, 4), make(chan *AccountStatusResp, 4))
+}
+
+func (s *tradingWsTestSuite) TearDownTest() {
+	s.client.Cancel()
+	s.server.Close()
+}
+
+// nextRequest return the next request received by the server with method
+func (s *tradingWsTestSuite) nextRequest(method string) *wsAPIRequest {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case req := <-s.requests:
+			if req.Method == method {
+				return req
+			}
+		case <-timeout:
+			s.FailNow("no request received", method)
+			return nil
+		}
+	}
+}
+
+func receive[T any](s *tradingWsTestSuite, ch chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		s.FailNow("no response received")
+		var zero T
+		return zero
+	}
+}
+
+func (s *tradingWsTestSuite) TestLogon() {
+	s.responses[wsMethodOrderStatus] = func(req *wsAPIRequest) string {
+		return fmt.Sprintf(`{"id":"%s","status":200,"result":{"orderId":328999071,"symbol":"BTCUSD_PERP","status":"NEW"}}`, req.ID)
+	}
+	s.Require().NoError(s.client.QueryOrder(&WsQueryOrder{Symbol: "BTCUSD_PERP", OrderID: 328999071}))
+
+	logon := s.nextRequest(wsMethodLogon)
+	s.Equal("dummyAPIKey", logon.Params["apiKey"])
+	signature, err := base64.StdEncoding.DecodeString(logon.Params["signature"].(string))
+	s.Require().NoError(err)
+	payload := fmt.Sprintf("apiKey=dummyAPIKey&timestamp=%.0f", logon.Params["timestamp"].(float64))
+	s.True(ed25519.Verify(s.publicKey, []byte(payload), signature))
+
+	login := receive(s, s.client.LoginChan)
+	s.Equal(logon.ID, login.ID)
+	s.Equal(int64(1728980000000), login.Result.AuthorizedSince)
+	s.True(s.client.IsAuthorized())
+
+	// requests are only sent once the session is logged on
+	s.nextRequest(wsMethodOrderStatus)
+	order := receive(s, s.client.OrderRespChan)
+	s.Equal(int64(328999071), order.Result.OrderId)
+}
+
+func (s *tradingWsTestSuite) TestResponseRouting() {
+	s.responses[wsMethodAccountStatus] = func(req *wsAPIRequest) string {
+		return fmt.Sprintf(`{"id":"%s","status":200,"result":{"feeTier":2,"canTrade":true,"assets":[],"positions":[]}}`, req.ID)
+	}
+	s.responses[wsMethodAccountBalance] = func(req *wsAPIRequest) string {
+		return fmt.Sprintf(`{"id":"%s","status":200,"result":[{"accountAlias":"fWAuTiuXoCuXmY","asset":"BTC","balance":"0.00241628","availableBalance":"0.00241628","updateTime":1728980000000}]}`, req.ID)
+	}
+	s.responses[wsMethodAccountPosition] = func(req *wsAPIRequest) string {
+		return fmt.Sprintf(`{"id":"%s","status":200,"result":[{"symbol":"BTCUSD_PERP","positionAmt":"2","leverage":"20","positionSide":"BOTH","updateTime":1728980000000}]}`, req.ID)
+	}
+	s.responses[wsMethodOrderPlace] = func(req *wsAPIRequest) string {
+		return fmt.Sprintf(`{"id":"%s","status":200,"result":{"orderId":328999071,"symbol":"BTCUSD_PERP","status":"NEW","clientOrderId":"%s"}}`,
+			req.ID, req.Params["newClientOrderId"])
+	}
+
+	r := s.Require()
+	r.NoError(s.client.QueryAccountStatus())
+	status := s.nextRequest(wsMethodAccountStatus)
+	account := receive(s, s.client.AccountStatusRespChan)
+	r.Equal(status.ID, account.ID)
+	r.Equal(2, account.Result.FeeTier)
+
+	r.NoError(s.client.QueryAccountBalance())
+	balance := s.nextRequest(wsMethodAccountBalance)
+	balances := receive(s, s.client.BalanceRespChan)
+	r.Equal(balance.ID, balances.ID)
+	r.Equal("0.00241628", balances.Result[0].Balance)
+
+	r.NoError(s.client.QueryAccountPosition(&WsQueryPosition{Pair: "BTCUSD"}))
+	position := s.nextRequest(wsMethodAccountPosition)
+	r.Equal("BTCUSD", position.Params["pair"])
+	positions := receive(s, s.client.PositionRespChan)
+	r.Equal(position.ID, positions.ID)
+	r.Equal("2", positions.Result[0].PositionAmt)
+
+	r.NoError(s.client.PlaceOrder(&WsPlaceOrder{NewClientOrderId: "abc", Symbol: "BTCUSD_PERP", Side: "BUY", Type: "MARKET", Quantity: "1"}))
+	place := s.nextRequest(wsMethodOrderPlace)
+	order := receive(s, s.client.OrderRespChan)
+	r.Equal(place.ID, order.ID)
+	r.Equal("abc", order.Result.ClientOrderId)
+
+	_, pending := s.client.pending.Load(place.ID)
+	r.False(pending)
+	r.Empty(s.client.ErrChan)
+}
+
+func (s *tradingWsTestSuite) TestError() {
+	s.responses[wsMethodOrderCancel] = func(req *wsAPIRequest) string {
+		return fmt.Sprintf(`{"id":"%s","status":400,"error":{"code":-2011,"msg":"Unknown order sent."}}`, req.ID)
+	}
+	s.Require().NoError(s.client.CancelOrder(&WsCancelOrder{Symbol: "BTCUSD_PERP", OrderID: 1}))
+	cancel := s.nextRequest(wsMethodOrderCancel)
+
+	e := receive(s, s.client.ErrChan)
+	s.Equal(cancel.ID, e.ID)
+	s.Equal(int64(400), e.Status)
+	s.Equal(&ErrorDetail{Code: -2011, Msg: "Unknown order sent."}, e.Error)
+	s.Empty(s.client.OrderRespChan)
+}
+
+func (s *tradingWsTestSuite) TestLogonError() {
+	s.responses[wsMethodLogon] = func(req *wsAPIRequest) string {
+		return fmt.Sprintf(`{"id":"%s","status":401,"error":{"code":-1022,"msg":"Signature for this request is not valid."}}`, req.ID)
+	}
+	s.Require().NoError(s.client.Connect())
+	s.Require().NoError(s.client.Login())
+	s.nextRequest(wsMethodLogon)
+
+	e := receive(s, s.client.ErrChan)
+	s.Equal(int64(-1022), e.Error.Code)
+	s.False(s.client.IsAuthorized())
+	s.Require().Eventually(func() bool {
+		s.client.mu.Lock()
+		defer s.client.mu.Unlock()
+		return s.client.authRequested == nil
+	}, time.Second, 10*time.Millisecond)
+}
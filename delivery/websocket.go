@@ -2,8 +2,10 @@ package delivery
 
 import (
 	"net/http"
+	"sync/atomic"
 	"time"
 
+	"github.com/dictxwang/go-binance/common"
 	"github.com/gorilla/websocket"
 )
 
@@ -15,14 +17,22 @@ type ErrHandler func(err error)
 
 // WsConfig webservice configuration
 type WsConfig struct {
-	Endpoint string
-	IP       string
+	Endpoint  string
+	IP        string
+	Keepalive bool
+	Timeout   time.Duration
+	Dialer    *websocket.Dialer
+	Name      string
+	Metrics   common.WsMetrics
 }
 
-func newWsConfig(endpoint string) *WsConfig {
-	return &WsConfig{
-		Endpoint: endpoint,
+// name return the name of the connection reported to the metrics, the endpoint
+// would give a metric per symbol set so it defaults to the stream type and count
+func (cfg *WsConfig) name() string {
+	if cfg.Name != "" {
+		return cfg.Name
 	}
+	return common.WsConnName(cfg.Endpoint)
 }
 
 func (cfg *WsConfig) WithIP(ip string) {
@@ -35,12 +45,19 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 		HandshakeTimeout:  45 * time.Second,
 		EnableCompression: false,
 	}
+	if cfg.Dialer != nil {
+		Dialer = *cfg.Dialer
+	}
 
 	c, _, err := Dialer.Dial(cfg.Endpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 	c.SetReadLimit(655350)
+	name := cfg.name()
+	if cfg.Metrics != nil {
+		cfg.Metrics.WsConnected(name)
+	}
 	doneC = make(chan struct{})
 	stopC = make(chan struct{})
 	go func() {
@@ -48,8 +65,13 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 		// websocket.Conn.ReadMessage or when the stopC channel is
 		// closed by the client.
 		defer close(doneC)
-		if WebsocketKeepalive {
-			keepAlive(c, WebsocketTimeout)
+		timedOut := func() bool { return false }
+		if cfg.Keepalive {
+			timedOut = keepAlive(c, cfg.Timeout, func(rtt time.Duration) {
+				if cfg.Metrics != nil {
+					cfg.Metrics.WsPingRTT(name, rtt)
+				}
+			})
 		}
 		// Wait for the stopC channel to be closed.  We do that in a
 		// separate goroutine because ReadMessage is a blocking
@@ -66,23 +88,44 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 		for {
 			_, message, err := c.ReadMessage()
 			if err != nil {
+				if timedOut() {
+					err = common.ErrPongTimeout
+				}
+				if cfg.Metrics != nil {
+					if silent {
+						cfg.Metrics.WsDisconnected(name, nil)
+					} else {
+						cfg.Metrics.WsDisconnected(name, err)
+					}
+				}
 				if !silent {
 					errHandler(err)
 				}
 				return
 			}
+			if cfg.Metrics != nil {
+				common.ObserveWsMessage(cfg.Metrics, name, message, time.Now())
+			}
 			handler(message)
 		}
 	}()
 	return
 }
 
-func keepAlive(c *websocket.Conn, timeout time.Duration) {
+// keepAlive ping c every timeout and close it when no pong was received in time, onRTT receives the round
+// trip time of every pong and the returned function reports whether the connection was closed by a timeout
+func keepAlive(c *websocket.Conn, timeout time.Duration, onRTT func(rtt time.Duration)) (timedOut func() bool) {
 	ticker := time.NewTicker(timeout)
 
-	lastResponse := time.Now()
+	var lastPing int64
+	lastResponse := time.Now().UnixNano()
+	var expired int32
 	c.SetPongHandler(func(msg string) error {
-		lastResponse = time.Now()
+		now := time.Now()
+		atomic.StoreInt64(&lastResponse, now.UnixNano())
+		if ping := atomic.LoadInt64(&lastPing); ping > 0 {
+			onRTT(now.Sub(time.Unix(0, ping)))
+		}
 		return nil
 	})
 
@@ -90,15 +133,20 @@ func keepAlive(c *websocket.Conn, timeout time.Duration) {
 		defer ticker.Stop()
 		for {
 			deadline := time.Now().Add(10 * time.Second)
+			atomic.StoreInt64(&lastPing, time.Now().UnixNano())
 			err := c.WriteControl(websocket.PingMessage, []byte{}, deadline)
 			if err != nil {
 				return
 			}
 			<-ticker.C
-			if time.Since(lastResponse) > timeout {
+			if time.Since(time.Unix(0, atomic.LoadInt64(&lastResponse))) > timeout {
+				atomic.StoreInt32(&expired, 1)
 				c.Close()
 				return
 			}
 		}
 	}()
+	return func() bool {
+		return atomic.LoadInt32(&expired) == 1
+	}
 }
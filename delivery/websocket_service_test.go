@@ -110,6 +110,32 @@ func (s *websocketServiceTestSuite) assertWsAggTradeEvent(e, a *WsAggTradeEvent)
 }
 
 // https://binance-docs.github.io/apidocs/delivery/en/#index-price-stream
+func (s *websocketServiceTestSuite) TestEnvironmentAggTradeServe() {
+	var cfgs []*WsConfig
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, innerErr error) {
+		cfgs = append(cfgs, cfg)
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+	handler := func(event *WsAggTradeEvent) {}
+	errHandler := func(err error) {}
+
+	env := IntranetEnvironment()
+	env.WebsocketKeepalive = true
+	env.WebsocketTimeout = 10 * time.Second
+	_, _, err := env.WsAggTradeServe("BTCUSD_PERP", handler, errHandler)
+	s.r().NoError(err)
+
+	_, _, err = WsAggTradeServe("BTCUSD_PERP", handler, errHandler)
+	s.r().NoError(err)
+
+	s.r().Len(cfgs, 2)
+	s.r().Equal("wss://dstream-mm.binance.com/ws/btcusd_perp@aggTrade", cfgs[0].Endpoint)
+	s.r().True(cfgs[0].Keepalive)
+	s.r().Equal(10*time.Second, cfgs[0].Timeout)
+	s.r().Equal("wss://dstream.binance.com/ws/btcusd_perp@aggTrade", cfgs[1].Endpoint)
+	s.r().Equal(WebsocketKeepalive, cfgs[1].Keepalive)
+}
+
 func (s *websocketServiceTestSuite) TestIndexPriceServe() {
 	data := []byte(`{
 		"e": "indexPriceUpdate",
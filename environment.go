@@ -0,0 +1,247 @@
+package binance
+
+import (
+	"net"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+)
+
+// Environment define the endpoints and connection settings used by a Client, a ClientWs
+// and the websocket streams, the package level variables are only used to build the default one
+type Environment struct {
+	// BaseURL is the base url of the REST API
+	BaseURL string
+	// WsURL is the base endpoint of the raw streams
+	WsURL string
+	// CombinedURL is the base endpoint of the combined streams
+	CombinedURL string
+	// WsAPIURL is the endpoint of the WS API
+	WsAPIURL string
+	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
+	WebsocketKeepalive bool
+	// WebsocketTimeout is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
+	WebsocketTimeout time.Duration
+	// Dialer is used as is to open websocket connections when it is set
+	Dialer *websocket.Dialer
+	// Metrics receives the health measurements of the websocket connections when it is set
+	Metrics common.WsMetrics
+}
+
+// MainnetEnvironment return the production environment
+func MainnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          BaseAPIMainURL,
+		WsURL:            BaseWsMainURL,
+		CombinedURL:      BaseCombinedMainURL,
+		WsAPIURL:         baseTradingWsUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// TestnetEnvironment return the testnet environment
+func TestnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          BaseAPITestnetURL,
+		WsURL:            BaseWsTestnetURL,
+		CombinedURL:      BaseCombinedTestnetURL,
+		WsAPIURL:         baseTradingWsTestUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// DefaultEnvironment return the environment selected by the UseTestnet, WebsocketKeepalive,
+// WebsocketTimeout and WebsocketMetrics package variables
+func DefaultEnvironment() *Environment {
+	e := MainnetEnvironment()
+	if UseTestnet {
+		e = TestnetEnvironment()
+	}
+	e.WebsocketKeepalive = WebsocketKeepalive
+	e.WebsocketTimeout = WebsocketTimeout
+	e.Metrics = WebsocketMetrics
+	return e
+}
+
+// getAPIEndpoint return the base endpoint of the Rest API according the UseTestnet flag
+func getAPIEndpoint() string {
+	return DefaultEnvironment().BaseURL
+}
+
+// getTradingWsEndpoint return the base endpoint of the WS API according the UseTestnet flag
+func getTradingWsEndpoint() string {
+	return DefaultEnvironment().WsAPIURL
+}
+
+func (e *Environment) getWsEndpoint() string {
+	return e.WsURL
+}
+
+func (e *Environment) getCombinedEndpoint() string {
+	return e.CombinedURL
+}
+
+func (e *Environment) getTradingWsEndpoint() string {
+	return e.WsAPIURL
+}
+
+func (e *Environment) newWsConfig(endpoint string) *WsConfig {
+	return &WsConfig{
+		Endpoint:  endpoint,
+		Keepalive: e.WebsocketKeepalive,
+		Timeout:   e.WebsocketTimeout,
+		Dialer:    e.Dialer,
+		Metrics:   e.Metrics,
+	}
+}
+
+// WsPartialDepthServe is a wrapper around DefaultEnvironment().WsPartialDepthServe
+func WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServe100Ms is a wrapper around DefaultEnvironment().WsPartialDepthServe100Ms
+func WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsPartialDepthServe100Ms(symbol, levels, handler, errHandler)
+}
+
+// WsCombinedPartialDepthServe is a wrapper around DefaultEnvironment().WsCombinedPartialDepthServe
+func WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedPartialDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsDepthServe is a wrapper around DefaultEnvironment().WsDepthServe
+func WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsDepthServe(symbol, handler, errHandler)
+}
+
+// WsDepthServe100Ms is a wrapper around DefaultEnvironment().WsDepthServe100Ms
+func WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsDepthServe100Ms(symbol, handler, errHandler)
+}
+
+// WsCombinedDepthServe is a wrapper around DefaultEnvironment().WsCombinedDepthServe
+func WsCombinedDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedDepthServe(symbols, handler, errHandler)
+}
+
+// WsCombinedDepthServe100Ms is a wrapper around DefaultEnvironment().WsCombinedDepthServe100Ms
+func WsCombinedDepthServe100Ms(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedDepthServe100Ms(symbols, handler, errHandler)
+}
+
+// WsCombinedKlineServe is a wrapper around DefaultEnvironment().WsCombinedKlineServe
+func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsKlineServe is a wrapper around DefaultEnvironment().WsKlineServe
+func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsAggTradeServe is a wrapper around DefaultEnvironment().WsAggTradeServe
+func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsCombinedAggTradeServe is a wrapper around DefaultEnvironment().WsCombinedAggTradeServe
+func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedAggTradeServe(symbols, handler, errHandler)
+}
+
+// WsTradeServe is a wrapper around DefaultEnvironment().WsTradeServe
+func WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsTradeServe(symbol, handler, errHandler)
+}
+
+// WsCombinedTradeServe is a wrapper around DefaultEnvironment().WsCombinedTradeServe
+func WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedTradeServe(symbols, handler, errHandler)
+}
+
+// WsUserDataServe is a wrapper around DefaultEnvironment().WsUserDataServe
+func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServeWithIp is a wrapper around DefaultEnvironment().WsUserDataServeWithIp
+func WsUserDataServeWithIp(listenKey string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServeWithIp(listenKey, localIP, handler, errHandler)
+}
+
+// WsUserDataServeWithListenToken is a wrapper around DefaultEnvironment().WsUserDataServeWithListenToken
+func WsUserDataServeWithListenToken(listenToken string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
+	return DefaultEnvironment().WsUserDataServeWithListenToken(listenToken, handler, errHandler)
+}
+
+// WsUserDataServeWithListenTokenAndIp is a wrapper around DefaultEnvironment().WsUserDataServeWithListenTokenAndIp
+func WsUserDataServeWithListenTokenAndIp(listenToken string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
+	return DefaultEnvironment().WsUserDataServeWithListenTokenAndIp(listenToken, localIP, handler, errHandler)
+}
+
+// WsCombinedMarketStatServe is a wrapper around DefaultEnvironment().WsCombinedMarketStatServe
+func WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedMarketStatServe(symbols, handler, errHandler)
+}
+
+// WsMarketStatServe is a wrapper around DefaultEnvironment().WsMarketStatServe
+func WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarketStatServe(symbol, handler, errHandler)
+}
+
+// WsAllMarketsStatServe is a wrapper around DefaultEnvironment().WsAllMarketsStatServe
+func WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarketsStatServe(handler, errHandler)
+}
+
+// WsAllMiniMarketsStatServe is a wrapper around DefaultEnvironment().WsAllMiniMarketsStatServe
+func WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMiniMarketsStatServe(handler, errHandler)
+}
+
+// WsMarketRollingWindowStatServe is a wrapper around DefaultEnvironment().WsMarketRollingWindowStatServe
+func WsMarketRollingWindowStatServe(symbol string, windowSize string, handler WsMarketRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarketRollingWindowStatServe(symbol, windowSize, handler, errHandler)
+}
+
+// WsAllMarketsRollingWindowStatServe is a wrapper around DefaultEnvironment().WsAllMarketsRollingWindowStatServe
+func WsAllMarketsRollingWindowStatServe(windowSize string, handler WsAllMarketsRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarketsRollingWindowStatServe(windowSize, handler, errHandler)
+}
+
+// WsAvgPriceServe is a wrapper around DefaultEnvironment().WsAvgPriceServe
+func WsAvgPriceServe(symbol string, handler WsAvgPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAvgPriceServe(symbol, handler, errHandler)
+}
+
+// WsBookTickerServe is a wrapper around DefaultEnvironment().WsBookTickerServe
+func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsCombinedBookTickerServe is a wrapper around DefaultEnvironment().WsCombinedBookTickerServe
+func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeWithIntranet is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeWithIntranet
+func WsCombinedBookTickerServeWithIntranet(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeWithIntranet(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeWithIP is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeWithIP
+func WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeWithIP(ip, symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeWithIPAndResolver is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeWithIPAndResolver
+func WsCombinedBookTickerServeWithIPAndResolver(sourceIP string, resolver *net.Resolver, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeWithIPAndResolver(sourceIP, resolver, symbols, handler, errHandler)
+}
+
+// WsAllBookTickerServe is a wrapper around DefaultEnvironment().WsAllBookTickerServe
+func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllBookTickerServe(handler, errHandler)
+}
@@ -0,0 +1,149 @@
+// Package execution slices large parent orders into child orders on the client side,
+// by time (TWAP), participation rate (POV) or visible size (iceberg).
+package execution
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SideType define side type of parent order
+type SideType string
+
+// StatusType define status of parent order
+type StatusType string
+
+// Global enums
+const (
+	SideTypeBuy  SideType = "BUY"
+	SideTypeSell SideType = "SELL"
+
+	StatusTypeNew      StatusType = "NEW"
+	StatusTypeWorking  StatusType = "WORKING"
+	StatusTypePaused   StatusType = "PAUSED"
+	StatusTypeCanceled StatusType = "CANCELED"
+	StatusTypeFilled   StatusType = "FILLED"
+)
+
+// ErrChildOrderNotFound is returned by Venue.QueryOrder when the venue has no order with the client order id
+var ErrChildOrderNotFound = errors.New("execution: child order not found")
+
+// Venue define the exchange a parent order is executed on
+type Venue interface {
+	// Filters return the trading rules of symbol
+	Filters(ctx context.Context, symbol string) (*Filters, error)
+	// PlaceOrder send a child order
+	PlaceOrder(ctx context.Context, order *ChildOrder) error
+	// CancelOrder cancel a child order by its client order id
+	CancelOrder(ctx context.Context, symbol, clientOrderID string) error
+	// QueryOrder return the state of a child order by its client order id
+	QueryOrder(ctx context.Context, symbol, clientOrderID string) (*ChildOrderStatus, error)
+}
+
+// ChildOrder define a child order sent to the venue, an empty price means a market order
+type ChildOrder struct {
+	Symbol        string
+	Side          SideType
+	ClientOrderID string
+	Quantity      string
+	Price         string
+	// Passive orders rest on the book (GTC), others take liquidity and expire immediately (IOC)
+	Passive bool
+}
+
+// ChildOrderStatus define the state of a child order queried from the venue
+type ChildOrderStatus struct {
+	ExecutedQuantity float64
+	AvgPrice         float64
+	// Final is set once the child order is no longer working on the book
+	Final bool
+}
+
+// Quote define best bid and ask of symbol
+type Quote struct {
+	BidPrice    float64
+	BidQuantity float64
+	AskPrice    float64
+	AskQuantity float64
+}
+
+// OrderUpdate define an execution report of a child order
+type OrderUpdate struct {
+	ClientOrderID      string
+	LastFilledQuantity float64
+	LastFilledPrice    float64
+	// Final is set once the child order is no longer working on the book
+	Final bool
+}
+
+// Filters define the trading rules of symbol that child orders must respect
+type Filters struct {
+	TickSize    float64
+	StepSize    float64
+	MinQuantity float64
+	MaxQuantity float64
+	MinNotional float64
+}
+
+// RoundQuantity round quantity down to the step size and cap it to the max quantity
+func (f *Filters) RoundQuantity(quantity float64) float64 {
+	if f.MaxQuantity > 0 && quantity > f.MaxQuantity {
+		quantity = f.MaxQuantity
+	}
+	if f.StepSize > 0 {
+		quantity = math.Floor(quantity/f.StepSize+1e-9) * f.StepSize
+	}
+	return quantity
+}
+
+// RoundPrice round price to the tick size, down for buy and up for sell so the limit is never exceeded
+func (f *Filters) RoundPrice(side SideType, price float64) float64 {
+	if f.TickSize <= 0 {
+		return price
+	}
+	if side == SideTypeBuy {
+		return math.Floor(price/f.TickSize+1e-9) * f.TickSize
+	}
+	return math.Ceil(price/f.TickSize-1e-9) * f.TickSize
+}
+
+// Allowed check whether a child order of quantity at price passes the filters,
+// price is the reference price for market orders
+func (f *Filters) Allowed(quantity, price float64) bool {
+	if quantity <= 0 || quantity < f.MinQuantity {
+		return false
+	}
+	if f.MinNotional > 0 && price > 0 && quantity*price < f.MinNotional {
+		return false
+	}
+	return true
+}
+
+// FormatQuantity format quantity with the precision of the step size
+func (f *Filters) FormatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', precision(f.StepSize), 64)
+}
+
+// FormatPrice format price with the precision of the tick size
+func (f *Filters) FormatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', precision(f.TickSize), 64)
+}
+
+func precision(step float64) int {
+	if step <= 0 {
+		return -1
+	}
+	s := strconv.FormatFloat(step, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
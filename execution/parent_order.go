@@ -0,0 +1,535 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrParentOrderDone is returned when acting on a parent order that is canceled or filled
+	ErrParentOrderDone = errors.New("execution: parent order is done")
+	// ErrInvalidInterval is returned by Start when the evaluation interval is not positive
+	ErrInvalidInterval = errors.New("execution: interval must be positive")
+	// ErrChildOrdersRejected is reported when the parent order is paused after repeated child order rejections
+	ErrChildOrdersRejected = errors.New("execution: parent order paused after repeated child order rejections")
+)
+
+// State define the execution state an algo decides the next child order from
+type State struct {
+	Quantity     float64
+	Filled       float64
+	Working      float64
+	Elapsed      time.Duration
+	MarketVolume float64
+}
+
+// Algo decide how much of the parent order should have been sent at a point of time
+type Algo interface {
+	// Target return the cumulative quantity that should be filled or working by now
+	Target(s *State) float64
+	// Passive return whether child orders rest on the book instead of crossing the spread
+	Passive() bool
+}
+
+// TWAP send the parent order evenly over duration
+type TWAP struct {
+	Duration time.Duration
+}
+
+// Target implement Algo
+func (a TWAP) Target(s *State) float64 {
+	if a.Duration <= 0 || s.Elapsed >= a.Duration {
+		return s.Quantity
+	}
+	return s.Quantity * float64(s.Elapsed) / float64(a.Duration)
+}
+
+// Passive implement Algo
+func (a TWAP) Passive() bool {
+	return false
+}
+
+// POV keep the executed quantity at a share of the market volume traded since start
+type POV struct {
+	// Rate participation rate, e.g. 0.1 for 10%
+	Rate float64
+}
+
+// Target implement Algo
+func (a POV) Target(s *State) float64 {
+	return math.Min(s.Quantity, s.MarketVolume*a.Rate)
+}
+
+// Passive implement Algo
+func (a POV) Passive() bool {
+	return false
+}
+
+// Iceberg show at most DisplayQuantity on the book and refill it as it gets filled
+type Iceberg struct {
+	DisplayQuantity float64
+}
+
+// Target implement Algo
+func (a Iceberg) Target(s *State) float64 {
+	return math.Min(s.Quantity, s.Filled+a.DisplayQuantity)
+}
+
+// Passive implement Algo
+func (a Iceberg) Passive() bool {
+	return true
+}
+
+// Progress define a snapshot of parent order execution
+type Progress struct {
+	Status   StatusType
+	Quantity float64
+	Filled   float64
+	Working  float64
+	AvgPrice float64
+	Children int
+}
+
+type childOrder struct {
+	quantity float64
+	filled   float64
+	// unknown is set when placing the order timed out or failed in transport, it may still have reached the venue
+	unknown bool
+}
+
+// ParentOrder slice a large order into child orders sent to a venue
+type ParentOrder struct {
+	venue         Venue
+	symbol        string
+	side          SideType
+	quantity      float64
+	algo          Algo
+	limitPrice    float64
+	interval      time.Duration
+	minInterval   time.Duration
+	idPrefix      string
+	filters       *Filters
+	errHandler    func(err error)
+	maxRejections int
+
+	mu           sync.Mutex
+	status       StatusType
+	quote        Quote
+	marketVolume float64
+	filled       float64
+	notional     float64
+	children     map[string]*childOrder
+	seq          int
+	rejections   int
+	lastSent     time.Time
+	elapsed      time.Duration
+	resumedAt    time.Time
+	stopC        chan struct{}
+	doneC        chan struct{}
+}
+
+// NewParentOrder init a parent order of quantity on symbol executed by algo
+func NewParentOrder(venue Venue, symbol string, side SideType, quantity float64, algo Algo) *ParentOrder {
+	return &ParentOrder{
+		venue:         venue,
+		symbol:        symbol,
+		side:          side,
+		quantity:      quantity,
+		algo:          algo,
+		interval:      time.Second,
+		minInterval:   100 * time.Millisecond,
+		idPrefix:      "x-exec-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		maxRejections: 3,
+		status:        StatusTypeNew,
+		children:      make(map[string]*childOrder),
+	}
+}
+
+// LimitPrice set the worst price child orders may be sent at
+func (p *ParentOrder) LimitPrice(limitPrice float64) *ParentOrder {
+	p.limitPrice = limitPrice
+	return p
+}
+
+// Interval set how often the algo is evaluated, default 1s, it must be positive
+func (p *ParentOrder) Interval(interval time.Duration) *ParentOrder {
+	p.interval = interval
+	return p
+}
+
+// MinInterval set the minimum time between two child orders to stay within order rate limits, default 100ms
+func (p *ParentOrder) MinInterval(minInterval time.Duration) *ParentOrder {
+	p.minInterval = minInterval
+	return p
+}
+
+// ClientOrderIDPrefix set the prefix of child client order ids, child updates are matched on it
+func (p *ParentOrder) ClientOrderIDPrefix(prefix string) *ParentOrder {
+	p.idPrefix = prefix
+	return p
+}
+
+// Filters set the symbol filters instead of loading them from the venue on start
+func (p *ParentOrder) Filters(filters *Filters) *ParentOrder {
+	p.filters = filters
+	return p
+}
+
+// MaxRejections set the number of consecutive child orders rejected by the venue after which the parent
+// order is paused, default 3, 0 never pauses
+func (p *ParentOrder) MaxRejections(maxRejections int) *ParentOrder {
+	p.maxRejections = maxRejections
+	return p
+}
+
+// OnError set the handler called when a child order fails to be sent or canceled
+func (p *ParentOrder) OnError(errHandler func(err error)) *ParentOrder {
+	p.errHandler = errHandler
+	return p
+}
+
+// Symbol return symbol of the parent order
+func (p *ParentOrder) Symbol() string {
+	return p.symbol
+}
+
+// Start load symbol filters and start slicing from a goroutine,
+// doneC is closed once the parent order is filled, canceled or ctx is done.
+// When ctx is done the parent order is canceled and its working child orders are left on the venue
+func (p *ParentOrder) Start(ctx context.Context) (doneC chan struct{}, err error) {
+	if p.interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	if p.filters == nil {
+		p.filters, err = p.venue.Filters(ctx, p.symbol)
+		if err != nil {
+			return nil, err
+		}
+	}
+	p.mu.Lock()
+	if p.status != StatusTypeNew {
+		p.mu.Unlock()
+		return nil, ErrParentOrderDone
+	}
+	p.status = StatusTypeWorking
+	p.resumedAt = time.Now()
+	p.stopC = make(chan struct{})
+	p.doneC = make(chan struct{})
+	p.mu.Unlock()
+
+	go p.run(ctx)
+	return p.doneC, nil
+}
+
+func (p *ParentOrder) run(ctx context.Context) {
+	defer close(p.doneC)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	p.step(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			p.mu.Lock()
+			switch p.status {
+			case StatusTypeWorking:
+				p.elapsed += time.Since(p.resumedAt)
+				p.status = StatusTypeCanceled
+			case StatusTypePaused:
+				p.status = StatusTypeCanceled
+			}
+			p.mu.Unlock()
+			return
+		case <-p.stopC:
+			return
+		case <-ticker.C:
+			p.step(ctx)
+		}
+	}
+}
+
+// step send the next child order if the algo is behind its target
+func (p *ParentOrder) step(ctx context.Context) {
+	p.reconcile(ctx)
+	p.mu.Lock()
+	if p.status != StatusTypeWorking || time.Since(p.lastSent) < p.minInterval {
+		p.mu.Unlock()
+		return
+	}
+	state := p.state()
+	quantity := p.filters.RoundQuantity(p.algo.Target(state) - state.Filled - state.Working)
+	quantity = math.Min(quantity, p.filters.RoundQuantity(state.Quantity-state.Filled-state.Working))
+	price, ok := p.price()
+	if !ok || !p.filters.Allowed(quantity, p.referencePrice(price)) {
+		p.mu.Unlock()
+		return
+	}
+	p.seq++
+	order := &ChildOrder{
+		Symbol:        p.symbol,
+		Side:          p.side,
+		ClientOrderID: p.idPrefix + "-" + strconv.Itoa(p.seq),
+		Quantity:      p.filters.FormatQuantity(quantity),
+		Passive:       p.algo.Passive(),
+	}
+	if price > 0 {
+		order.Price = p.filters.FormatPrice(price)
+	}
+	p.children[order.ClientOrderID] = &childOrder{quantity: quantity}
+	p.lastSent = time.Now()
+	p.mu.Unlock()
+
+	err := p.venue.PlaceOrder(ctx, order)
+	if err == nil {
+		p.mu.Lock()
+		p.rejections = 0
+		p.mu.Unlock()
+		return
+	}
+	p.handleErr(err)
+	if !orderRejected(err) {
+		// a timed out order may still be working, keep counting it until
+		// an update or a query confirms its state so it is not sent twice
+		p.mu.Lock()
+		if child, ok := p.children[order.ClientOrderID]; ok {
+			child.unknown = true
+		}
+		p.mu.Unlock()
+		return
+	}
+	// the venue refused the order, it is not working and sending it again
+	// would likely be refused too, pause once it keeps happening
+	p.mu.Lock()
+	delete(p.children, order.ClientOrderID)
+	p.rejections++
+	pause := p.maxRejections > 0 && p.rejections >= p.maxRejections
+	if pause {
+		p.rejections = 0
+	}
+	p.mu.Unlock()
+	if pause && p.Pause(ctx) != ErrParentOrderDone {
+		p.handleErr(ErrChildOrdersRejected)
+	}
+}
+
+// reconcile query the child orders that failed to be placed,
+// a child is dropped once the venue confirms it has no such order
+func (p *ParentOrder) reconcile(ctx context.Context) {
+	p.mu.Lock()
+	var ids []string
+	for id, child := range p.children {
+		if child.unknown {
+			ids = append(ids, id)
+		}
+	}
+	p.mu.Unlock()
+	for _, id := range ids {
+		status, err := p.venue.QueryOrder(ctx, p.symbol, id)
+		if err != nil && err != ErrChildOrderNotFound {
+			p.handleErr(err)
+			continue
+		}
+		p.mu.Lock()
+		child, ok := p.children[id]
+		if ok && child.unknown {
+			child.unknown = false
+			switch {
+			case err == ErrChildOrderNotFound:
+				delete(p.children, id)
+			default:
+				// the query reports the cumulative quantity, updates seen before only count once
+				if quantity := status.ExecutedQuantity - child.filled; quantity > 0 {
+					child.filled += quantity
+					p.filled += quantity
+					p.notional += quantity * status.AvgPrice
+				}
+				if status.Final {
+					delete(p.children, id)
+				}
+			}
+			p.checkFilled()
+		}
+		p.mu.Unlock()
+	}
+}
+
+// price return the child limit price, 0 for a market order, false if no order can be priced yet
+func (p *ParentOrder) price() (float64, bool) {
+	var touch float64
+	passive := p.algo.Passive()
+	switch {
+	case p.side == SideTypeBuy && passive, p.side == SideTypeSell && !passive:
+		touch = p.quote.BidPrice
+	default:
+		touch = p.quote.AskPrice
+	}
+	price := touch
+	if p.limitPrice > 0 {
+		if price <= 0 || (p.side == SideTypeBuy && price > p.limitPrice) ||
+			(p.side == SideTypeSell && price < p.limitPrice) {
+			price = p.limitPrice
+		}
+	}
+	if price <= 0 {
+		// without a quote only aggressive children can go out, as market orders
+		return 0, !passive
+	}
+	return p.filters.RoundPrice(p.side, price), true
+}
+
+func (p *ParentOrder) referencePrice(price float64) float64 {
+	if price > 0 {
+		return price
+	}
+	if p.side == SideTypeBuy {
+		return p.quote.AskPrice
+	}
+	return p.quote.BidPrice
+}
+
+func (p *ParentOrder) state() *State {
+	s := &State{
+		Quantity:     p.quantity,
+		Filled:       p.filled,
+		Elapsed:      p.elapsed,
+		MarketVolume: p.marketVolume,
+	}
+	if p.status == StatusTypeWorking {
+		s.Elapsed += time.Since(p.resumedAt)
+	}
+	for _, child := range p.children {
+		s.Working += child.quantity - child.filled
+	}
+	return s
+}
+
+// OnBookTicker update best bid and ask of symbol
+func (p *ParentOrder) OnBookTicker(quote Quote) {
+	p.mu.Lock()
+	p.quote = quote
+	p.mu.Unlock()
+}
+
+// OnMarketTrade add a market trade of symbol to the traded volume seen since start
+func (p *ParentOrder) OnMarketTrade(quantity float64) {
+	p.mu.Lock()
+	if p.status != StatusTypeNew {
+		p.marketVolume += quantity
+	}
+	p.mu.Unlock()
+}
+
+// OnOrderUpdate apply an execution report, updates of other orders are ignored
+func (p *ParentOrder) OnOrderUpdate(update OrderUpdate) {
+	p.mu.Lock()
+	child, ok := p.children[update.ClientOrderID]
+	if !ok {
+		p.mu.Unlock()
+		return
+	}
+	child.unknown = false
+	if update.LastFilledQuantity > 0 {
+		child.filled += update.LastFilledQuantity
+		p.filled += update.LastFilledQuantity
+		p.notional += update.LastFilledQuantity * update.LastFilledPrice
+	}
+	if update.Final {
+		delete(p.children, update.ClientOrderID)
+	}
+	p.checkFilled()
+	p.mu.Unlock()
+}
+
+// checkFilled stop the parent order once no child is working and the rest is too small to send,
+// p.mu must be held
+func (p *ParentOrder) checkFilled() {
+	done := p.status == StatusTypeWorking && len(p.children) == 0 &&
+		!p.filters.Allowed(p.filters.RoundQuantity(p.quantity-p.filled), p.referencePrice(0))
+	if done {
+		p.status = StatusTypeFilled
+		close(p.stopC)
+	}
+}
+
+// Pause stop sending child orders and cancel the working ones
+func (p *ParentOrder) Pause(ctx context.Context) error {
+	p.mu.Lock()
+	if p.status != StatusTypeWorking {
+		p.mu.Unlock()
+		return ErrParentOrderDone
+	}
+	p.status = StatusTypePaused
+	p.elapsed += time.Since(p.resumedAt)
+	p.mu.Unlock()
+	return p.cancelChildren(ctx)
+}
+
+// Resume continue sending child orders after Pause, the paused time is not counted by the algo
+func (p *ParentOrder) Resume() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status != StatusTypePaused {
+		return ErrParentOrderDone
+	}
+	p.status = StatusTypeWorking
+	p.resumedAt = time.Now()
+	return nil
+}
+
+// Cancel stop the parent order and cancel its working child orders
+func (p *ParentOrder) Cancel(ctx context.Context) error {
+	p.mu.Lock()
+	if p.status != StatusTypeWorking && p.status != StatusTypePaused {
+		p.mu.Unlock()
+		return ErrParentOrderDone
+	}
+	p.status = StatusTypeCanceled
+	close(p.stopC)
+	p.mu.Unlock()
+	return p.cancelChildren(ctx)
+}
+
+func (p *ParentOrder) cancelChildren(ctx context.Context) error {
+	p.mu.Lock()
+	ids := make([]string, 0, len(p.children))
+	for id := range p.children {
+		ids = append(ids, id)
+	}
+	p.mu.Unlock()
+	var firstErr error
+	for _, id := range ids {
+		if err := p.venue.CancelOrder(ctx, p.symbol, id); err != nil {
+			p.handleErr(err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Progress return a snapshot of the execution
+func (p *ParentOrder) Progress() Progress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := Progress{
+		Status:   p.status,
+		Quantity: p.quantity,
+		Filled:   p.filled,
+		Working:  p.state().Working,
+		Children: p.seq,
+	}
+	if p.filled > 0 {
+		res.AvgPrice = p.notional / p.filled
+	}
+	return res
+}
+
+func (p *ParentOrder) handleErr(err error) {
+	if p.errHandler != nil {
+		p.errHandler(err)
+	}
+}
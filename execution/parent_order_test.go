@@ -0,0 +1,261 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockVenue struct {
+	mu       sync.Mutex
+	orders   []*ChildOrder
+	canceled []string
+	placeErr error
+	// statuses of child orders returned by QueryOrder, missing ones are not found
+	statuses map[string]*ChildOrderStatus
+	queried  []string
+}
+
+func (v *mockVenue) Filters(ctx context.Context, symbol string) (*Filters, error) {
+	return &Filters{TickSize: 0.01, StepSize: 0.001, MinQuantity: 0.001, MinNotional: 5}, nil
+}
+
+func (v *mockVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.orders = append(v.orders, order)
+	return v.placeErr
+}
+
+func (v *mockVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.canceled = append(v.canceled, clientOrderID)
+	return nil
+}
+
+func (v *mockVenue) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*ChildOrderStatus, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.queried = append(v.queried, clientOrderID)
+	status, ok := v.statuses[clientOrderID]
+	if !ok {
+		return nil, ErrChildOrderNotFound
+	}
+	return status, nil
+}
+
+func newTestParentOrder(v *mockVenue, algo Algo) *ParentOrder {
+	p := NewParentOrder(v, "BTCUSDT", SideTypeBuy, 1, algo).MinInterval(0).ClientOrderIDPrefix("t")
+	p.filters, _ = v.Filters(context.Background(), "BTCUSDT")
+	p.status = StatusTypeWorking
+	p.resumedAt = time.Now()
+	p.stopC = make(chan struct{})
+	p.OnBookTicker(Quote{BidPrice: 100, AskPrice: 100.5})
+	return p
+}
+
+func TestFilters(t *testing.T) {
+	assert := assert.New(t)
+	f := &Filters{TickSize: 0.01, StepSize: 0.001, MinQuantity: 0.001, MaxQuantity: 10, MinNotional: 5}
+	assert.InDelta(0.123, f.RoundQuantity(0.1239), 1e-12)
+	assert.InDelta(10, f.RoundQuantity(12), 1e-12)
+	assert.Equal("0.123", f.FormatQuantity(f.RoundQuantity(0.1239)))
+	assert.Equal("100.12", f.FormatPrice(f.RoundPrice(SideTypeBuy, 100.129)))
+	assert.Equal("100.13", f.FormatPrice(f.RoundPrice(SideTypeSell, 100.121)))
+	assert.False(f.Allowed(0.01, 100))
+	assert.True(f.Allowed(0.05, 100))
+}
+
+func TestTWAPSlices(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{}
+	p := newTestParentOrder(v, TWAP{Duration: 10 * time.Second})
+	p.elapsed = 5*time.Second - time.Since(p.resumedAt)
+
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+	assert.Equal("t-1", v.orders[0].ClientOrderID)
+	assert.Equal("0.500", v.orders[0].Quantity)
+	assert.Equal("100.50", v.orders[0].Price)
+	assert.False(v.orders[0].Passive)
+
+	// nothing more is due until the child reports back
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+
+	p.OnOrderUpdate(OrderUpdate{ClientOrderID: "t-1", LastFilledQuantity: 0.2, LastFilledPrice: 100.5, Final: true})
+	progress := p.Progress()
+	assert.Equal(StatusTypeWorking, progress.Status)
+	assert.InDelta(0.2, progress.Filled, 1e-12)
+	assert.InDelta(0, progress.Working, 1e-12)
+	assert.InDelta(100.5, progress.AvgPrice, 1e-12)
+
+	p.step(context.Background())
+	assert.Len(v.orders, 2)
+	assert.Equal("0.300", v.orders[1].Quantity)
+}
+
+func TestIcebergRefill(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{}
+	p := newTestParentOrder(v, Iceberg{DisplayQuantity: 0.4}).LimitPrice(99.5)
+
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+	assert.Equal("0.400", v.orders[0].Quantity)
+	assert.Equal("99.50", v.orders[0].Price)
+	assert.True(v.orders[0].Passive)
+
+	p.OnOrderUpdate(OrderUpdate{ClientOrderID: "t-1", LastFilledQuantity: 0.4, LastFilledPrice: 99.5, Final: true})
+	p.step(context.Background())
+	p.OnOrderUpdate(OrderUpdate{ClientOrderID: "t-2", LastFilledQuantity: 0.4, LastFilledPrice: 99.5, Final: true})
+	p.step(context.Background())
+	assert.Len(v.orders, 3)
+	assert.Equal("0.200", v.orders[2].Quantity)
+
+	p.OnOrderUpdate(OrderUpdate{ClientOrderID: "t-3", LastFilledQuantity: 0.2, LastFilledPrice: 99.5, Final: true})
+	assert.Equal(StatusTypeFilled, p.Progress().Status)
+}
+
+func TestPOVPauseCancel(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{}
+	p := newTestParentOrder(v, POV{Rate: 0.1})
+
+	p.step(context.Background())
+	assert.Len(v.orders, 0)
+
+	p.OnMarketTrade(3)
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+	assert.Equal("0.300", v.orders[0].Quantity)
+
+	assert.NoError(p.Pause(context.Background()))
+	assert.Equal([]string{"t-1"}, v.canceled)
+	p.OnMarketTrade(3)
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+
+	assert.NoError(p.Resume())
+	assert.NoError(p.Cancel(context.Background()))
+	assert.Equal(StatusTypeCanceled, p.Progress().Status)
+	assert.Equal(ErrParentOrderDone, p.Resume())
+}
+
+func TestStartInvalidInterval(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		p := NewParentOrder(v, "BTCUSDT", SideTypeBuy, 1, TWAP{Duration: time.Minute}).Interval(interval)
+		_, err := p.Start(context.Background())
+		assert.Equal(ErrInvalidInterval, err)
+		assert.Equal(StatusTypeNew, p.Progress().Status)
+	}
+}
+
+func TestPlaceOrderUnknown(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{placeErr: errors.New("timeout"), statuses: map[string]*ChildOrderStatus{}}
+	var errs []error
+	p := newTestParentOrder(v, TWAP{Duration: 10 * time.Second}).OnError(func(err error) {
+		errs = append(errs, err)
+	})
+	p.elapsed = 5*time.Second - time.Since(p.resumedAt)
+
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+	assert.Len(errs, 1)
+	// the failed child still counts as working, nothing is sent twice
+	assert.InDelta(0.5, p.Progress().Working, 1e-12)
+	v.placeErr = nil
+	v.statuses["t-1"] = &ChildOrderStatus{ExecutedQuantity: 0.1, AvgPrice: 100.5}
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+	assert.Equal([]string{"t-1"}, v.queried)
+	assert.InDelta(0.1, p.Progress().Filled, 1e-12)
+
+	// once confirmed the child is tracked by its updates only
+	p.step(context.Background())
+	assert.Len(v.queried, 1)
+	p.OnOrderUpdate(OrderUpdate{ClientOrderID: "t-1", LastFilledQuantity: 0.1, LastFilledPrice: 100.5, Final: true})
+	progress := p.Progress()
+	assert.InDelta(0.2, progress.Filled, 1e-12)
+	assert.InDelta(0, progress.Working, 1e-12)
+
+	p.step(context.Background())
+	assert.Len(v.orders, 2)
+	assert.Equal("0.300", v.orders[1].Quantity)
+}
+
+func TestPlaceOrderNotFound(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{placeErr: errors.New("timeout")}
+	p := newTestParentOrder(v, TWAP{Duration: 10 * time.Second})
+	p.elapsed = 5*time.Second - time.Since(p.resumedAt)
+
+	p.step(context.Background())
+	assert.InDelta(0.5, p.Progress().Working, 1e-12)
+
+	// the venue has no such order, the child is dropped and sent again
+	v.placeErr = nil
+	p.step(context.Background())
+	assert.Equal([]string{"t-1"}, v.queried)
+	assert.Len(v.orders, 2)
+	assert.Equal("t-2", v.orders[1].ClientOrderID)
+	assert.Equal("0.500", v.orders[1].Quantity)
+}
+
+func TestPlaceOrderRejected(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{placeErr: &common.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}}
+	var errs []error
+	p := newTestParentOrder(v, TWAP{Duration: 10 * time.Second}).MaxRejections(2).OnError(func(err error) {
+		errs = append(errs, err)
+	})
+	p.elapsed = 5*time.Second - time.Since(p.resumedAt)
+
+	// a rejected child is not working and is not queried
+	p.step(context.Background())
+	assert.Len(v.orders, 1)
+	assert.Equal([]error{v.placeErr}, errs)
+	assert.InDelta(0, p.Progress().Working, 1e-12)
+	p.step(context.Background())
+	assert.Len(v.orders, 2)
+	assert.Empty(v.queried)
+
+	// repeated rejections pause the parent order
+	assert.Equal(StatusTypePaused, p.Progress().Status)
+	assert.Equal([]error{v.placeErr, v.placeErr, ErrChildOrdersRejected}, errs)
+	p.step(context.Background())
+	assert.Len(v.orders, 2)
+}
+
+func TestPlaceOrderBackendTimeout(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{placeErr: &common.APIError{Code: -1007, Message: "Timeout waiting for response from backend server."}}
+	p := newTestParentOrder(v, TWAP{Duration: 10 * time.Second})
+	p.elapsed = 5*time.Second - time.Since(p.resumedAt)
+
+	p.step(context.Background())
+	assert.InDelta(0.5, p.Progress().Working, 1e-12)
+	assert.Equal(StatusTypeWorking, p.Progress().Status)
+}
+
+func TestStartContextDone(t *testing.T) {
+	assert := assert.New(t)
+	v := &mockVenue{}
+	p := NewParentOrder(v, "BTCUSDT", SideTypeBuy, 1, TWAP{Duration: time.Minute}).Interval(time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	doneC, err := p.Start(ctx)
+	assert.NoError(err)
+	cancel()
+	<-doneC
+	assert.Equal(StatusTypeCanceled, p.Progress().Status)
+	assert.Equal(ErrParentOrderDone, p.Cancel(context.Background()))
+}
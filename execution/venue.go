@@ -0,0 +1,418 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	binance "github.com/dictxwang/go-binance"
+	"github.com/dictxwang/go-binance/common"
+	"github.com/dictxwang/go-binance/delivery"
+	"github.com/dictxwang/go-binance/futures"
+)
+
+// SpotVenue execute parent orders on spot
+type SpotVenue struct {
+	c *binance.Client
+}
+
+// NewSpotVenue init spot venue
+func NewSpotVenue(c *binance.Client) *SpotVenue {
+	return &SpotVenue{c: c}
+}
+
+// Filters implement Venue
+func (v *SpotVenue) Filters(ctx context.Context, symbol string) (*Filters, error) {
+	info, err := v.c.NewExchangeInfoService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range info.Symbols {
+		if s.Symbol != symbol {
+			continue
+		}
+		f := &Filters{}
+		if lot := s.LotSizeFilter(); lot != nil {
+			f.StepSize = parseFloat(lot.StepSize)
+			f.MinQuantity = parseFloat(lot.MinQuantity)
+			f.MaxQuantity = parseFloat(lot.MaxQuantity)
+		}
+		if price := s.PriceFilter(); price != nil {
+			f.TickSize = parseFloat(price.TickSize)
+		}
+		if notional := s.NotionalFilter(); notional != nil {
+			f.MinNotional = parseFloat(notional.MinNotional)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("execution: symbol %s not found", symbol)
+}
+
+// PlaceOrder implement Venue
+func (v *SpotVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	s := v.c.NewCreateOrderService().Symbol(order.Symbol).
+		Side(binance.SideType(order.Side)).
+		Quantity(order.Quantity).
+		NewClientOrderID(order.ClientOrderID)
+	switch {
+	case order.Price == "":
+		s.Type(binance.OrderTypeMarket)
+	case order.Passive:
+		s.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).Price(order.Price)
+	default:
+		s.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeIOC).Price(order.Price)
+	}
+	_, err := s.Do(ctx)
+	return err
+}
+
+// CancelOrder implement Venue
+func (v *SpotVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	_, err := v.c.NewCancelOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	return err
+}
+
+// QueryOrder implement Venue
+func (v *SpotVenue) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*ChildOrderStatus, error) {
+	order, err := v.c.NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	if err != nil {
+		return nil, queryOrderErr(err)
+	}
+	status := &ChildOrderStatus{
+		ExecutedQuantity: parseFloat(order.ExecutedQuantity),
+		Final:            isFinalStatus(string(order.Status)),
+	}
+	if status.ExecutedQuantity > 0 {
+		status.AvgPrice = parseFloat(order.CummulativeQuoteQuantity) / status.ExecutedQuantity
+	}
+	return status, nil
+}
+
+// Serve feed p with the book ticker and aggregate trade streams of its symbol and the user data stream of listenKey
+func (v *SpotVenue) Serve(p *ParentOrder, listenKey string, errHandler binance.ErrHandler) (stopC chan struct{}, err error) {
+	var stops []chan struct{}
+	_, stop, err := binance.WsBookTickerServe(p.Symbol(), func(event *binance.WsBookTickerEvent) {
+		p.OnBookTicker(Quote{
+			BidPrice:    parseFloat(event.BestBidPrice),
+			BidQuantity: parseFloat(event.BestBidQty),
+			AskPrice:    parseFloat(event.BestAskPrice),
+			AskQuantity: parseFloat(event.BestAskQty),
+		})
+	}, errHandler)
+	if err != nil {
+		return nil, err
+	}
+	stops = append(stops, stop)
+	_, stop, err = binance.WsAggTradeServe(p.Symbol(), func(event *binance.WsAggTradeEvent) {
+		p.OnMarketTrade(parseFloat(event.Quantity))
+	}, errHandler)
+	if err != nil {
+		stopAll(stops)
+		return nil, err
+	}
+	stops = append(stops, stop)
+	_, stop, err = binance.WsUserDataServe(listenKey, SpotUserDataHandler(p), errHandler)
+	if err != nil {
+		stopAll(stops)
+		return nil, err
+	}
+	return mergeStops(append(stops, stop)), nil
+}
+
+// SpotUserDataHandler route the spot execution reports of p's child orders to p
+func SpotUserDataHandler(p *ParentOrder) binance.WsUserDataHandler {
+	return func(event *binance.WsUserDataEvent) {
+		if event.Event != binance.UserDataEventTypeExecutionReport {
+			return
+		}
+		o := event.OrderUpdate
+		clientOrderID := o.ClientOrderId
+		if o.OrigCustomOrderId != "" {
+			// cancel reports carry the id of the cancel request in c
+			clientOrderID = o.OrigCustomOrderId
+		}
+		p.OnOrderUpdate(OrderUpdate{
+			ClientOrderID:      clientOrderID,
+			LastFilledQuantity: parseFloat(o.LatestVolume),
+			LastFilledPrice:    parseFloat(o.LatestPrice),
+			Final:              isFinalStatus(o.Status),
+		})
+	}
+}
+
+// FuturesVenue execute parent orders on USDⓈ-M futures
+type FuturesVenue struct {
+	c *futures.Client
+}
+
+// NewFuturesVenue init futures venue
+func NewFuturesVenue(c *futures.Client) *FuturesVenue {
+	return &FuturesVenue{c: c}
+}
+
+// Filters implement Venue
+func (v *FuturesVenue) Filters(ctx context.Context, symbol string) (*Filters, error) {
+	info, err := v.c.NewExchangeInfoService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range info.Symbols {
+		if s.Symbol != symbol {
+			continue
+		}
+		f := &Filters{}
+		if lot := s.LotSizeFilter(); lot != nil {
+			f.StepSize = parseFloat(lot.StepSize)
+			f.MinQuantity = parseFloat(lot.MinQuantity)
+			f.MaxQuantity = parseFloat(lot.MaxQuantity)
+		}
+		if price := s.PriceFilter(); price != nil {
+			f.TickSize = parseFloat(price.TickSize)
+		}
+		if notional := s.MinNotionalFilter(); notional != nil {
+			f.MinNotional = parseFloat(notional.Notional)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("execution: symbol %s not found", symbol)
+}
+
+// PlaceOrder implement Venue
+func (v *FuturesVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	s := v.c.NewCreateOrderService().Symbol(order.Symbol).
+		Side(futures.SideType(order.Side)).
+		Quantity(order.Quantity).
+		NewClientOrderID(order.ClientOrderID)
+	switch {
+	case order.Price == "":
+		s.Type(futures.OrderTypeMarket)
+	case order.Passive:
+		s.Type(futures.OrderTypeLimit).TimeInForce(futures.TimeInForceTypeGTC).Price(order.Price)
+	default:
+		s.Type(futures.OrderTypeLimit).TimeInForce(futures.TimeInForceTypeIOC).Price(order.Price)
+	}
+	_, err := s.Do(ctx)
+	return err
+}
+
+// CancelOrder implement Venue
+func (v *FuturesVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	_, err := v.c.NewCancelOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	return err
+}
+
+// QueryOrder implement Venue
+func (v *FuturesVenue) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*ChildOrderStatus, error) {
+	order, err := v.c.NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	if err != nil {
+		return nil, queryOrderErr(err)
+	}
+	return &ChildOrderStatus{
+		ExecutedQuantity: parseFloat(order.ExecutedQuantity),
+		AvgPrice:         parseFloat(order.AvgPrice),
+		Final:            isFinalStatus(string(order.Status)),
+	}, nil
+}
+
+// Serve feed p with the book ticker and aggregate trade streams of its symbol and the user data stream of listenKey
+func (v *FuturesVenue) Serve(p *ParentOrder, listenKey string, errHandler futures.ErrHandler) (stopC chan struct{}, err error) {
+	var stops []chan struct{}
+	_, stop, err := futures.WsBookTickerServe(p.Symbol(), func(event *futures.WsBookTickerEvent) {
+		p.OnBookTicker(Quote{
+			BidPrice:    parseFloat(event.BestBidPrice),
+			BidQuantity: parseFloat(event.BestBidQty),
+			AskPrice:    parseFloat(event.BestAskPrice),
+			AskQuantity: parseFloat(event.BestAskQty),
+		})
+	}, errHandler)
+	if err != nil {
+		return nil, err
+	}
+	stops = append(stops, stop)
+	_, stop, err = futures.WsAggTradeServe(p.Symbol(), func(event *futures.WsAggTradeEvent) {
+		p.OnMarketTrade(parseFloat(event.Quantity))
+	}, errHandler)
+	if err != nil {
+		stopAll(stops)
+		return nil, err
+	}
+	stops = append(stops, stop)
+	_, stop, err = futures.WsUserDataServe(listenKey, FuturesUserDataHandler(p), errHandler)
+	if err != nil {
+		stopAll(stops)
+		return nil, err
+	}
+	return mergeStops(append(stops, stop)), nil
+}
+
+// FuturesUserDataHandler route the futures order trade updates of p's child orders to p
+func FuturesUserDataHandler(p *ParentOrder) futures.WsUserDataHandler {
+	return func(event *futures.WsUserDataEvent) {
+		if event.Event != futures.UserDataEventTypeOrderTradeUpdate {
+			return
+		}
+		o := event.OrderTradeUpdate
+		p.OnOrderUpdate(OrderUpdate{
+			ClientOrderID:      o.ClientOrderID,
+			LastFilledQuantity: parseFloat(o.LastFilledQty),
+			LastFilledPrice:    parseFloat(o.LastFilledPrice),
+			Final:              isFinalStatus(string(o.Status)),
+		})
+	}
+}
+
+// DeliveryVenue execute parent orders on COIN-M futures, quantities are in contracts
+type DeliveryVenue struct {
+	c *delivery.Client
+}
+
+// NewDeliveryVenue init delivery venue
+func NewDeliveryVenue(c *delivery.Client) *DeliveryVenue {
+	return &DeliveryVenue{c: c}
+}
+
+// Filters implement Venue
+func (v *DeliveryVenue) Filters(ctx context.Context, symbol string) (*Filters, error) {
+	info, err := v.c.NewExchangeInfoService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range info.Symbols {
+		if s.Symbol != symbol {
+			continue
+		}
+		f := &Filters{}
+		if lot := s.LotSizeFilter(); lot != nil {
+			f.StepSize = parseFloat(lot.StepSize)
+			f.MinQuantity = parseFloat(lot.MinQuantity)
+			f.MaxQuantity = parseFloat(lot.MaxQuantity)
+		}
+		if price := s.PriceFilter(); price != nil {
+			f.TickSize = parseFloat(price.TickSize)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("execution: symbol %s not found", symbol)
+}
+
+// PlaceOrder implement Venue
+func (v *DeliveryVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	s := v.c.NewCreateOrderService().Symbol(order.Symbol).
+		Side(delivery.SideType(order.Side)).
+		Quantity(order.Quantity).
+		NewClientOrderID(order.ClientOrderID)
+	switch {
+	case order.Price == "":
+		s.Type(delivery.OrderTypeMarket)
+	case order.Passive:
+		s.Type(delivery.OrderTypeLimit).TimeInForce(delivery.TimeInForceTypeGTC).Price(order.Price)
+	default:
+		s.Type(delivery.OrderTypeLimit).TimeInForce(delivery.TimeInForceTypeIOC).Price(order.Price)
+	}
+	_, err := s.Do(ctx)
+	return err
+}
+
+// CancelOrder implement Venue
+func (v *DeliveryVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	_, err := v.c.NewCancelOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	return err
+}
+
+// QueryOrder implement Venue
+func (v *DeliveryVenue) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*ChildOrderStatus, error) {
+	order, err := v.c.NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	if err != nil {
+		return nil, queryOrderErr(err)
+	}
+	return &ChildOrderStatus{
+		ExecutedQuantity: parseFloat(order.ExecutedQuantity),
+		AvgPrice:         parseFloat(order.AvgPrice),
+		Final:            isFinalStatus(string(order.Status)),
+	}, nil
+}
+
+// Serve feed p with the book ticker and aggregate trade streams of its symbol and the user data stream of listenKey
+func (v *DeliveryVenue) Serve(p *ParentOrder, listenKey string, errHandler delivery.ErrHandler) (stopC chan struct{}, err error) {
+	var stops []chan struct{}
+	_, stop, err := delivery.WsBookTickerServe(p.Symbol(), func(event *delivery.WsBookTickerEvent) {
+		p.OnBookTicker(Quote{
+			BidPrice:    parseFloat(event.BestBidPrice),
+			BidQuantity: parseFloat(event.BestBidQty),
+			AskPrice:    parseFloat(event.BestAskPrice),
+			AskQuantity: parseFloat(event.BestAskQty),
+		})
+	}, errHandler)
+	if err != nil {
+		return nil, err
+	}
+	stops = append(stops, stop)
+	_, stop, err = delivery.WsAggTradeServe(p.Symbol(), func(event *delivery.WsAggTradeEvent) {
+		p.OnMarketTrade(parseFloat(event.Quantity))
+	}, errHandler)
+	if err != nil {
+		stopAll(stops)
+		return nil, err
+	}
+	stops = append(stops, stop)
+	_, stop, err = delivery.WsUserDataServe(listenKey, DeliveryUserDataHandler(p), errHandler)
+	if err != nil {
+		stopAll(stops)
+		return nil, err
+	}
+	return mergeStops(append(stops, stop)), nil
+}
+
+// DeliveryUserDataHandler route the delivery order trade updates of p's child orders to p
+func DeliveryUserDataHandler(p *ParentOrder) delivery.WsUserDataHandler {
+	return func(event *delivery.WsUserDataEvent) {
+		if event.Event != delivery.UserDataEventTypeOrderTradeUpdate {
+			return
+		}
+		o := event.OrderTradeUpdate
+		p.OnOrderUpdate(OrderUpdate{
+			ClientOrderID:      o.ClientOrderID,
+			LastFilledQuantity: parseFloat(o.LastFilledQty),
+			LastFilledPrice:    parseFloat(o.LastFilledPrice),
+			Final:              isFinalStatus(string(o.Status)),
+		})
+	}
+}
+
+// queryOrderErr map the order does not exist error of the venue to ErrChildOrderNotFound
+func queryOrderErr(err error) error {
+	if apiErr, ok := err.(*common.APIError); ok && apiErr.Code == -2013 {
+		return ErrChildOrderNotFound
+	}
+	return err
+}
+
+// orderRejected report whether the venue refused a child order so that it is surely not working,
+// -1001 (disconnected) and -1007 (timeout) leave the order status unknown like a transport error
+func orderRejected(err error) bool {
+	apiErr, ok := err.(*common.APIError)
+	return ok && apiErr.Code != -1001 && apiErr.Code != -1007
+}
+
+func isFinalStatus(status string) bool {
+	switch status {
+	case "FILLED", "CANCELED", "EXPIRED", "REJECTED", "EXPIRED_IN_MATCH":
+		return true
+	}
+	return false
+}
+
+func stopAll(stops []chan struct{}) {
+	for _, stop := range stops {
+		close(stop)
+	}
+}
+
+// mergeStops return a channel closing every stop once it is closed
+func mergeStops(stops []chan struct{}) chan struct{} {
+	stopC := make(chan struct{})
+	go func() {
+		<-stopC
+		stopAll(stops)
+	}()
+	return stopC
+}
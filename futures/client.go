@@ -8,7 +8,6 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
@@ -57,6 +56,9 @@ type SideEffectType string
 // WorkingType define working type
 type WorkingType string
 
+// PriceMatchType define price match mode
+type PriceMatchType string
+
 // MarginType define margin type
 type MarginType string
 
@@ -130,6 +132,16 @@ const (
 	WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"
 	WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE"
 
+	PriceMatchTypeNone       PriceMatchType = "NONE"
+	PriceMatchTypeOpponent   PriceMatchType = "OPPONENT"
+	PriceMatchTypeOpponent5  PriceMatchType = "OPPONENT_5"
+	PriceMatchTypeOpponent10 PriceMatchType = "OPPONENT_10"
+	PriceMatchTypeOpponent20 PriceMatchType = "OPPONENT_20"
+	PriceMatchTypeQueue      PriceMatchType = "QUEUE"
+	PriceMatchTypeQueue5     PriceMatchType = "QUEUE_5"
+	PriceMatchTypeQueue10    PriceMatchType = "QUEUE_10"
+	PriceMatchTypeQueue20    PriceMatchType = "QUEUE_20"
+
 	SymbolStatusTypePreTrading   SymbolStatusType = "PRE_TRADING"
 	SymbolStatusTypeTrading      SymbolStatusType = "TRADING"
 	SymbolStatusTypePostTrading  SymbolStatusType = "POST_TRADING"
@@ -205,17 +217,6 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getApiEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getApiEndpoint() string {
-	if UseTestnet {
-		return baseApiTestnetUrl
-	}
-	if UseIntranet {
-		return baseApiInternalUrl
-	}
-	return baseApiMainUrl
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
@@ -230,6 +231,20 @@ func NewClient(apiKey, secretKey string) *Client {
 	}
 }
 
+// NewClientWithEnvironment initialize an API client instance sending requests to the REST API of env.
+func NewClientWithEnvironment(apiKey, secretKey string, env *Environment) *Client {
+	c := NewClient(apiKey, secretKey)
+	c.BaseURL = env.BaseURL
+	return c
+}
+
+// NewClientWithIPPool initialize an API client instance sending requests from the egress IPs of pool.
+func NewClientWithIPPool(apiKey, secretKey string, pool *IPPool) *Client {
+	c := NewClientWithEnvironment(apiKey, secretKey, pool.env)
+	c.HTTPClient = &http.Client{Transport: pool}
+	return c
+}
+
 // NewClientWithIP initialize an API client instance with API key, secret key and local IP.
 func NewClientWithIP(apiKey, secretKey, ip string) *Client {
 	parsedIP := net.ParseIP(ip)
@@ -299,6 +314,14 @@ type Client struct {
 	Logger     *log.Logger
 	TimeOffset int64
 	do         doFunc
+
+	middlewares []common.APIMiddleware
+}
+
+// Use add middlewares around the REST calls of the client, the first middleware sees the request first
+func (c *Client) Use(middlewares ...common.APIMiddleware) *Client {
+	c.middlewares = append(c.middlewares, middlewares...)
+	return c
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -354,10 +377,13 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
+	// the signature and the listen key never reach the logs
+	debugURL := fullURL
 	if queryString != "" {
+		debugURL = fmt.Sprintf("%s?%s", fullURL, common.RedactQuery(queryString))
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.debug("full url: %s, body: %s", debugURL, common.RedactQuery(bodyString))
 
 	r.fullURL = fullURL
 	r.header = header
@@ -376,28 +402,17 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.debug("request: %s %s, header: %v", req.Method, common.RedactURL(req.URL), common.RedactHeader(req.Header))
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
+	res, err := common.ChainAPIMiddlewares(common.NewAPIHandler(f), c.middlewares...)(req)
 	if err != nil {
 		return []byte{}, &http.Header{}, err
 	}
-	data, err = ioutil.ReadAll(res.Body)
-	if err != nil {
-		return []byte{}, &http.Header{}, err
-	}
-	defer func() {
-		cerr := res.Body.Close()
-		// Only overwrite the retured error if the original error was nil and an
-		// error occurred while closing the body.
-		if err == nil && cerr != nil {
-			err = cerr
-		}
-	}()
-	c.debug("response: %#v", res)
+	data = res.Body
+	c.debug("response header: %v", res.Header)
 	c.debug("response body: %s", string(data))
 	c.debug("response status code: %d", res.StatusCode)
 
@@ -493,6 +508,35 @@ func (c *Client) NewCreateBatchOrdersService() *CreateBatchOrdersService {
 	return &CreateBatchOrdersService{c: c}
 }
 
+// NewModifyOrderService init modify order service
+func (c *Client) NewModifyOrderService() *ModifyOrderService {
+	return &ModifyOrderService{c: c}
+}
+
+// NewModifyBatchOrdersService init modify batch orders service
+func (c *Client) NewModifyBatchOrdersService() *ModifyBatchOrdersService {
+	return &ModifyBatchOrdersService{c: c}
+}
+
+// NewGetOrderAmendmentHistoryService init get order amendment history service
+func (c *Client) NewGetOrderAmendmentHistoryService() *GetOrderAmendmentHistoryService {
+	return &GetOrderAmendmentHistoryService{c: c}
+}
+
+// NewCountdownCancelAllService init countdown cancel all service
+func (c *Client) NewCountdownCancelAllService() *CountdownCancelAllService {
+	return &CountdownCancelAllService{c: c}
+}
+
+// NewCountdownHeartbeat init a heartbeat refreshing the countdown cancel all timer of symbols
+func (c *Client) NewCountdownHeartbeat(countdownTime int64, symbols ...string) *CountdownHeartbeat {
+	return &CountdownHeartbeat{
+		c:             c,
+		countdownTime: countdownTime,
+		symbols:       symbols,
+	}
+}
+
 // NewGetOrderService init get order service
 func (c *Client) NewGetOrderService() *GetOrderService {
 	return &GetOrderService{c: c}
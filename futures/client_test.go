@@ -6,7 +6,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"testing"
 
+	"github.com/dictxwang/go-binance/common"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -158,3 +160,43 @@ func (s *baseTestSuite) assertTradeV3Equal(e, a *TradeV3) {
 	r.Equal(e.IsMaker, a.IsMaker, "IsMaker")
 	r.Equal(e.IsBestMatch, a.IsBestMatch, "IsBestMatch")
 }
+
+type clientTestSuite struct {
+	baseTestSuite
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(clientTestSuite))
+}
+
+type recordingAPIMetrics struct {
+	calls []common.APICall
+}
+
+func (m *recordingAPIMetrics) APICall(call common.APICall) {
+	m.calls = append(m.calls, call)
+}
+
+func (s *clientTestSuite) TestUse() {
+	data := []byte(`{"code":-2011,"msg":"Unknown order sent."}`)
+	s.mockDo(data, nil, http.StatusBadRequest)
+	defer s.assertDo()
+
+	var apiKeys []string
+	metrics := new(recordingAPIMetrics)
+	s.client.Use(func(next common.APIHandler) common.APIHandler {
+		return func(req *http.Request) (*common.APIResponse, error) {
+			apiKeys = append(apiKeys, req.Header.Get("X-MBX-APIKEY"))
+			return next(req)
+		}
+	}, common.MetricsMiddleware(metrics))
+
+	_, err := s.client.NewCancelOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	s.r().Error(err)
+	s.r().True(common.IsAPIError(err))
+	s.r().Equal([]string{s.apiKey}, apiKeys)
+	s.r().Len(metrics.calls, 1)
+	s.r().Equal(http.MethodDelete, metrics.calls[0].Method)
+	s.r().Equal("/fapi/v1/order", metrics.calls[0].Endpoint)
+	s.r().Equal(int64(-2011), metrics.calls[0].Code)
+}
@@ -0,0 +1,113 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidCountdownTime is returned when the countdown time of a heartbeat is not positive
+	ErrInvalidCountdownTime = errors.New("countdown time must be positive")
+	// ErrInvalidCountdownInterval is returned when the interval is negative or does not refresh
+	// the countdown before it expires
+	ErrInvalidCountdownInterval = errors.New("countdown interval must be positive and shorter than the countdown time")
+)
+
+// CountdownErrHandler handle a failed countdown refresh of symbol
+type CountdownErrHandler func(symbol string, err error)
+
+// CountdownHeartbeat keep the countdown cancel all timer of symbols armed from a goroutine,
+// all open orders of a symbol are canceled by the exchange if the process stops refreshing it
+type CountdownHeartbeat struct {
+	c             *Client
+	countdownTime int64
+	interval      time.Duration
+	symbols       []string
+	disarmOnStop  bool
+	errHandler    CountdownErrHandler
+}
+
+// Interval set how often the countdown is refreshed, defaults to a third of the countdown time,
+// it must be shorter than the countdown time
+func (h *CountdownHeartbeat) Interval(interval time.Duration) *CountdownHeartbeat {
+	h.interval = interval
+	return h
+}
+
+// DisarmOnStop set whether the countdown is disabled when the heartbeat is stopped,
+// otherwise open orders are canceled once the last countdown expires
+func (h *CountdownHeartbeat) DisarmOnStop(disarmOnStop bool) *CountdownHeartbeat {
+	h.disarmOnStop = disarmOnStop
+	return h
+}
+
+// OnError set the handler called when refreshing the countdown of a symbol fails
+func (h *CountdownHeartbeat) OnError(errHandler CountdownErrHandler) *CountdownHeartbeat {
+	h.errHandler = errHandler
+	return h
+}
+
+// Start refresh the countdown immediately and then on every interval until ctx is done
+// or stopC is closed, doneC is closed once the heartbeat has exited
+func (h *CountdownHeartbeat) Start(ctx context.Context) (doneC, stopC chan struct{}, err error) {
+	interval, err := h.tickInterval()
+	if err != nil {
+		return nil, nil, err
+	}
+	doneC = make(chan struct{})
+	stopC = make(chan struct{})
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		h.refresh(ctx, h.countdownTime)
+		for {
+			select {
+			case <-ctx.Done():
+				h.stop()
+				return
+			case <-stopC:
+				h.stop()
+				return
+			case <-ticker.C:
+				h.refresh(ctx, h.countdownTime)
+			}
+		}
+	}()
+	return doneC, stopC, nil
+}
+
+func (h *CountdownHeartbeat) tickInterval() (time.Duration, error) {
+	if h.countdownTime <= 0 {
+		return 0, ErrInvalidCountdownTime
+	}
+	countdown := time.Duration(h.countdownTime) * time.Millisecond
+	if h.interval == 0 {
+		return countdown / 3, nil
+	}
+	if h.interval < 0 || h.interval >= countdown {
+		return 0, ErrInvalidCountdownInterval
+	}
+	return h.interval, nil
+}
+
+func (h *CountdownHeartbeat) stop() {
+	if !h.disarmOnStop {
+		return
+	}
+	// the caller context may already be done, disarm with a fresh one
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	h.refresh(ctx, 0)
+}
+
+func (h *CountdownHeartbeat) refresh(ctx context.Context, countdownTime int64) {
+	for _, symbol := range h.symbols {
+		_, err := h.c.NewCountdownCancelAllService().Symbol(symbol).
+			CountdownTime(countdownTime).Do(ctx)
+		if err != nil && h.errHandler != nil {
+			h.errHandler(symbol, err)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package futures
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+)
+
+// Environment define the endpoints and connection settings used by a Client, a ClientWs
+// and the websocket streams, the package level variables are only used to build the default one
+type Environment struct {
+	// BaseURL is the base url of the REST API
+	BaseURL string
+	// WsURL is the base endpoint of the raw streams
+	WsURL string
+	// CombinedURL is the base endpoint of the combined streams
+	CombinedURL string
+	// WsAPIURL is the endpoint of the WS API
+	WsAPIURL string
+	// CategoryWsURL is the format of the categorized raw stream endpoint, e.g. "wss://fstream.binance.com/%s/ws",
+	// streams use WsURL when it is empty
+	CategoryWsURL string
+	// CategoryCombinedURL is the format of the categorized combined stream endpoint,
+	// streams use CombinedURL when it is empty
+	CategoryCombinedURL string
+	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
+	WebsocketKeepalive bool
+	// WebsocketTimeout is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
+	WebsocketTimeout time.Duration
+	// Dialer is used as is to open websocket connections when it is set
+	Dialer *websocket.Dialer
+	// Metrics receives the health measurements of the websocket connections when it is set
+	Metrics common.WsMetrics
+}
+
+// MainnetEnvironment return the production environment
+func MainnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiMainUrl,
+		WsURL:            baseWsMainUrl,
+		CombinedURL:      baseCombinedMainURL,
+		WsAPIURL:         baseTradingWsUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// TestnetEnvironment return the testnet environment
+func TestnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiTestnetUrl,
+		WsURL:            baseWsTestnetUrl,
+		CombinedURL:      baseCombinedTestnetURL,
+		WsAPIURL:         baseTradingWsTestUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// IntranetEnvironment return the colo intranet environment
+func IntranetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiInternalUrl,
+		WsURL:            baseInternalWsMainURL,
+		CombinedURL:      baseInternalCombinedMainURL,
+		WsAPIURL:         baseInternalTradingWsUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// UseCategoryEndpoints switch the streams to the categorized endpoints (public/market/private),
+// the testnet has no categorized endpoints and is left unchanged
+func (e *Environment) UseCategoryEndpoints() *Environment {
+	switch e.WsURL {
+	case baseWsMainUrl:
+		e.CategoryWsURL = "wss://fstream.binance.com/%s/ws"
+		e.CategoryCombinedURL = "wss://fstream.binance.com/%s/stream?streams="
+	case baseInternalWsMainURL:
+		e.CategoryWsURL = "wss://fstream-mm.binance.com/%s/ws"
+		e.CategoryCombinedURL = "wss://fstream-mm.binance.com/%s/stream?streams="
+	}
+	return e
+}
+
+// DefaultEnvironment return the environment selected by the UseTestnet, UseIntranet,
+// UseNewWsEndpoint, WebsocketKeepalive, WebsocketTimeout and WebsocketMetrics package variables
+func DefaultEnvironment() *Environment {
+	var e *Environment
+	switch {
+	case UseTestnet:
+		e = TestnetEnvironment()
+	case UseIntranet:
+		e = IntranetEnvironment()
+	default:
+		e = MainnetEnvironment()
+	}
+	if UseNewWsEndpoint {
+		e.UseCategoryEndpoints()
+	}
+	e.WebsocketKeepalive = WebsocketKeepalive
+	e.WebsocketTimeout = WebsocketTimeout
+	e.Metrics = WebsocketMetrics
+	return e
+}
+
+// getApiEndpoint return the base endpoint of the Rest API according the UseTestnet flag
+func getApiEndpoint() string {
+	return DefaultEnvironment().BaseURL
+}
+
+// getTradingWsEndpoint return the base endpoint of the WS API according the UseTestnet flag
+func getTradingWsEndpoint() string {
+	return DefaultEnvironment().WsAPIURL
+}
+
+// wsEndpointWithCategory returns the WS endpoint with category path when categorized endpoints are enabled.
+func (e *Environment) wsEndpointWithCategory(category string) string {
+	if e.CategoryWsURL == "" || category == "" {
+		return e.WsURL
+	}
+	return fmt.Sprintf(e.CategoryWsURL, category)
+}
+
+// combinedEndpointWithCategory returns the combined stream endpoint with category path when categorized endpoints are enabled.
+func (e *Environment) combinedEndpointWithCategory(category string) string {
+	if e.CategoryCombinedURL == "" || category == "" {
+		return e.CombinedURL
+	}
+	return fmt.Sprintf(e.CategoryCombinedURL, category)
+}
+
+// combinedIntranetEndpointWithCategory returns the intranet combined stream endpoint with category path.
+func (e *Environment) combinedIntranetEndpointWithCategory(category string) string {
+	if e.CategoryCombinedURL == "" || category == "" {
+		return getCombinedIntranetEndpoint()
+	}
+	return fmt.Sprintf("wss://fstream-mm.binance.com/%s/stream?streams=", category)
+}
+
+func (e *Environment) newWsConfig(endpoint string) *WsConfig {
+	return &WsConfig{
+		Endpoint:  endpoint,
+		Keepalive: e.WebsocketKeepalive,
+		Timeout:   e.WebsocketTimeout,
+		Dialer:    e.Dialer,
+		Metrics:   e.Metrics,
+	}
+}
+
+// WsAggTradeServe is a wrapper around DefaultEnvironment().WsAggTradeServe
+func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsCombinedAggTradeServe is a wrapper around DefaultEnvironment().WsCombinedAggTradeServe
+func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedAggTradeServe(symbols, handler, errHandler)
+}
+
+// WsCombinedAggTradeServeWithIP is a wrapper around DefaultEnvironment().WsCombinedAggTradeServeWithIP
+func WsCombinedAggTradeServeWithIP(sourceIP string, symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedAggTradeServeWithIP(sourceIP, symbols, handler, errHandler)
+}
+
+// WsMarkPriceServe is a wrapper around DefaultEnvironment().WsMarkPriceServe
+func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarkPriceServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServeWithRate is a wrapper around DefaultEnvironment().WsMarkPriceServeWithRate
+func WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarkPriceServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsCombinedMarkPriceServe is a wrapper around DefaultEnvironment().WsCombinedMarkPriceServe
+func WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedMarkPriceServe(symbols, handler, errHandler)
+}
+
+// WsCombinedMarkPriceServeWithRate is a wrapper around DefaultEnvironment().WsCombinedMarkPriceServeWithRate
+func WsCombinedMarkPriceServeWithRate(symbolLevels map[string]time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedMarkPriceServeWithRate(symbolLevels, handler, errHandler)
+}
+
+// WsAllMarkPriceServe is a wrapper around DefaultEnvironment().WsAllMarkPriceServe
+func WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarkPriceServe(handler, errHandler)
+}
+
+// WsAllMarkPriceServeWithIP is a wrapper around DefaultEnvironment().WsAllMarkPriceServeWithIP
+func WsAllMarkPriceServeWithIP(ip string, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarkPriceServeWithIP(ip, handler, errHandler)
+}
+
+// WsAllMarkPriceServeWithRate is a wrapper around DefaultEnvironment().WsAllMarkPriceServeWithRate
+func WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarkPriceServeWithRate(rate, handler, errHandler)
+}
+
+// WsAllMarkPriceServeWithRateWithIP is a wrapper around DefaultEnvironment().WsAllMarkPriceServeWithRateWithIP
+func WsAllMarkPriceServeWithRateWithIP(ip string, rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarkPriceServeWithRateWithIP(ip, rate, handler, errHandler)
+}
+
+// WsKlineServe is a wrapper around DefaultEnvironment().WsKlineServe
+func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsCombinedKlineServe is a wrapper around DefaultEnvironment().WsCombinedKlineServe
+func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsContinuousKlineServe is a wrapper around DefaultEnvironment().WsContinuousKlineServe
+func WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handler WsContinuousKlineHandler,
+	errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsContinuousKlineServe(subscribeArgs, handler, errHandler)
+}
+
+// WsCombinedContinuousKlineServe is a wrapper around DefaultEnvironment().WsCombinedContinuousKlineServe
+func WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubcribeArgs,
+	handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedContinuousKlineServe(subscribeArgsList, handler, errHandler)
+}
+
+// WsMiniMarketTickerServe is a wrapper around DefaultEnvironment().WsMiniMarketTickerServe
+func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMiniMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe is a wrapper around DefaultEnvironment().WsAllMiniMarketTickerServe
+func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMiniMarketTickerServe(handler, errHandler)
+}
+
+// WsMarketTickerServe is a wrapper around DefaultEnvironment().WsMarketTickerServe
+func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMarketTickerServe is a wrapper around DefaultEnvironment().WsAllMarketTickerServe
+func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarketTickerServe(handler, errHandler)
+}
+
+// WsBookTickerServe is a wrapper around DefaultEnvironment().WsBookTickerServe
+func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllBookTickerServe is a wrapper around DefaultEnvironment().WsAllBookTickerServe
+func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsCombinedBookTickerServe is a wrapper around DefaultEnvironment().WsCombinedBookTickerServe
+func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeIfIntranet is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeIfIntranet
+func WsCombinedBookTickerServeIfIntranet(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeIfIntranet(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeWithIP is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeWithIP
+func WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeWithIP(ip, symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeWithIPIfIntranet is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeWithIPIfIntranet
+func WsCombinedBookTickerServeWithIPIfIntranet(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeWithIPIfIntranet(ip, symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServeWithIPAndResolver is a wrapper around DefaultEnvironment().WsCombinedBookTickerServeWithIPAndResolver
+func WsCombinedBookTickerServeWithIPAndResolver(sourceIP string, resolver *net.Resolver, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServeWithIPAndResolver(sourceIP, resolver, symbols, handler, errHandler)
+}
+
+// WsLiquidationOrderServe is a wrapper around DefaultEnvironment().WsLiquidationOrderServe
+func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsLiquidationOrderServe(symbol, handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe is a wrapper around DefaultEnvironment().WsAllLiquidationOrderServe
+func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllLiquidationOrderServe(handler, errHandler)
+}
+
+// WsPartialDepthServe is a wrapper around DefaultEnvironment().WsPartialDepthServe
+func WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate is a wrapper around DefaultEnvironment().WsPartialDepthServeWithRate
+func WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsPartialDepthServeWithRate(symbol, levels, rate, handler, errHandler)
+}
+
+// WsDiffDepthServe is a wrapper around DefaultEnvironment().WsDiffDepthServe
+func WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsDiffDepthServe(symbol, handler, errHandler)
+}
+
+// WsCombinedDepthServe is a wrapper around DefaultEnvironment().WsCombinedDepthServe
+func WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsCombinedDiffDepthServe is a wrapper around DefaultEnvironment().WsCombinedDiffDepthServe
+func WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedDiffDepthServe(symbols, handler, errHandler)
+}
+
+// WsDiffDepthServeWithRate is a wrapper around DefaultEnvironment().WsDiffDepthServeWithRate
+func WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsDiffDepthServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsBLVTInfoServe is a wrapper around DefaultEnvironment().WsBLVTInfoServe
+func WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsBLVTInfoServe(name, handler, errHandler)
+}
+
+// WsBLVTKlineServe is a wrapper around DefaultEnvironment().WsBLVTKlineServe
+func WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsBLVTKlineServe(name, interval, handler, errHandler)
+}
+
+// WsCompositiveIndexServe is a wrapper around DefaultEnvironment().WsCompositiveIndexServe
+func WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCompositiveIndexServe(symbol, handler, errHandler)
+}
+
+// WsUserDataServe is a wrapper around DefaultEnvironment().WsUserDataServe
+func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServeWithIP is a wrapper around DefaultEnvironment().WsUserDataServeWithIP
+func WsUserDataServeWithIP(ip, listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServeWithIP(ip, listenKey, handler, errHandler)
+}
@@ -0,0 +1,104 @@
+package futures
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+)
+
+// EgressIP define the routing state of a local egress IP of an IPPool
+type EgressIP = common.EgressIP
+
+// WsPath define a market data connection from a local egress IP to a resolved server address
+type WsPath = common.WsPath
+
+// IPPool spread the REST requests and market data connections of a client across several local egress IPs,
+// the exchange limits are accounted per IP and the lowest latency path is preferred
+type IPPool struct {
+	pool *common.IPPool
+	env  *Environment
+}
+
+// NewIPPool init an IPPool sending from the given local IPs
+func NewIPPool(ips ...string) (*IPPool, error) {
+	pool, err := common.NewIPPool(ips...)
+	if err != nil {
+		return nil, err
+	}
+	return &IPPool{pool: pool, env: DefaultEnvironment()}, nil
+}
+
+// Environment set the environment of the REST API and the streams, defaults to DefaultEnvironment()
+func (p *IPPool) Environment(env *Environment) *IPPool {
+	p.env = env
+	return p
+}
+
+// Name set the prefix of the path names reported to the metrics, defaults to pool
+func (p *IPPool) Name(name string) *IPPool {
+	p.pool.SetName(name)
+	return p
+}
+
+// WeightLimit set the request weight per minute an IP may use before it is skipped
+func (p *IPPool) WeightLimit(weightLimit int64) *IPPool {
+	p.pool.SetWeightLimit(weightLimit)
+	return p
+}
+
+// MaxPaths set the max number of connections opened for a stream, defaults to every local IP and server address pair
+func (p *IPPool) MaxPaths(maxPaths int) *IPPool {
+	p.pool.SetMaxPaths(maxPaths)
+	return p
+}
+
+// StaleAfter set how long a path may miss the events delivered by other paths before it is
+// no longer delivered from, defaults to 1s
+func (p *IPPool) StaleAfter(staleAfter time.Duration) *IPPool {
+	p.pool.SetStaleAfter(staleAfter)
+	return p
+}
+
+// RoundTrip send the request from the egress IP with the best latency and weight headroom
+func (p *IPPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	return p.pool.RoundTrip(req)
+}
+
+// Stats return a snapshot of the egress IPs
+func (p *IPPool) Stats() []EgressIP {
+	return p.pool.Stats()
+}
+
+// WsPaths return a snapshot of the paths of the running streams
+func (p *IPPool) WsPaths() []WsPath {
+	return p.pool.WsPaths()
+}
+
+// WsCombinedBookTickerServe open the combined book ticker stream of symbols on every path of the pool,
+// events are delivered from the path with the lowest latency and the other paths are kept as hot standby
+func (p *IPPool) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	g, err := p.pool.NewWsPathGroup(p.env.combinedEndpointWithCategory(WsCategoryPublic))
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.serve(g, func(env *Environment, path *WsPath, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		return env.WsCombinedBookTickerServe(symbols, func(event *WsBookTickerEvent) {
+			g.Deliver(path, event.Time, event.Symbol, event.UpdateID, func() {
+				handler(event)
+			})
+		}, errHandler)
+	}, errHandler)
+}
+
+type wsPathServeFunc func(env *Environment, path *WsPath, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+
+func (p *IPPool) serve(g *common.WsPathGroup, serve wsPathServeFunc, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return g.Serve(func(path *WsPath, dialer *websocket.Dialer, errHandler func(err error)) (chan struct{}, chan struct{}, error) {
+		env := *p.env
+		env.Dialer = dialer
+		env.Metrics = common.WsMetricsInstance(env.Metrics, path.Name)
+		return serve(&env, path, errHandler)
+	}, errHandler)
+}
@@ -0,0 +1,192 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ipPoolTestSuite struct {
+	suite.Suite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+}
+
+func TestIPPool(t *testing.T) {
+	suite.Run(t, new(ipPoolTestSuite))
+}
+
+func (s *ipPoolTestSuite) SetupTest() {
+	s.origWsServe = wsServe
+}
+
+func (s *ipPoolTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+}
+
+func (s *ipPoolTestSuite) TestRoundTrip() {
+	var remoteIPs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		remoteIPs = append(remoteIPs, host)
+		switch host {
+		case "127.0.0.1":
+			w.Header().Set("X-Mbx-Used-Weight-1m", "2400")
+			w.Write([]byte("{}"))
+		default:
+			w.Header().Set("X-Mbx-Used-Weight-1m", "10")
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"code":-1003,"msg":"Too many requests"}`))
+		}
+	}))
+	defer server.Close()
+
+	pool, err := NewIPPool("127.0.0.1", "127.0.0.2")
+	s.Require().NoError(err)
+	env := MainnetEnvironment()
+	env.BaseURL = server.URL
+	client := NewClientWithIPPool("apiKey", "secretKey", pool.Environment(env))
+
+	err = client.NewPingService().Do(context.Background())
+	s.Require().NoError(err)
+	err = client.NewPingService().Do(context.Background())
+	s.Require().Error(err)
+
+	s.Require().Equal([]string{"127.0.0.1", "127.0.0.2"}, remoteIPs)
+	stats := pool.Stats()
+	s.Require().Len(stats, 2)
+	s.Require().Equal(int64(2400), stats[0].UsedWeight)
+	s.Require().Equal(int64(1), stats[0].Requests)
+	s.Require().True(stats[0].Latency > 0)
+	s.Require().True(stats[1].BannedUntil.After(time.Now().Add(20 * time.Second)))
+}
+
+func (s *ipPoolTestSuite) TestInvalidIP() {
+	_, err := NewIPPool("127.0.0.1", "localhost")
+	s.Require().EqualError(err, "invalid egress ip localhost")
+}
+
+// mockWsServe record the handler of every path, closing a kill channel ends its connection
+func (s *ipPoolTestSuite) mockWsServe() (handlers *[]WsHandler, kills *[]chan struct{}) {
+	handlers = new([]WsHandler)
+	kills = new([]chan struct{})
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.Require().NotNil(cfg.Dialer)
+		s.Require().Equal("wss://127.0.0.1/stream?streams=btcusdt@bookTicker", cfg.Endpoint)
+		kill := make(chan struct{})
+		*handlers = append(*handlers, handler)
+		*kills = append(*kills, kill)
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			select {
+			case <-stopC:
+			case <-kill:
+			}
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}
+	return handlers, kills
+}
+
+func bookTickerMessage(updateID int64, eventTime time.Time) []byte {
+	return []byte(fmt.Sprintf(`{"stream":"btcusdt@bookTicker","data":{"e":"bookTicker","u":%d,"E":%d,"s":"BTCUSDT"}}`,
+		updateID, eventTime.UnixNano()/int64(time.Millisecond)))
+}
+
+func (s *ipPoolTestSuite) TestWsCombinedBookTickerServe() {
+	handlerList, killList := s.mockWsServe()
+	event := func(updateID int64, lag time.Duration) []byte {
+		return []byte(fmt.Sprintf(`{"stream":"btcusdt@bookTicker","data":{"e":"bookTicker","u":%d,"E":%d,"s":"BTCUSDT"}}`,
+			updateID, time.Now().Add(-lag).UnixNano()/int64(time.Millisecond)))
+	}
+
+	pool, err := NewIPPool("127.0.0.1", "127.0.0.2")
+	s.Require().NoError(err)
+	env := MainnetEnvironment()
+	env.CombinedURL = "wss://127.0.0.1/stream?streams="
+	pool.Environment(env)
+
+	var delivered []int64
+	doneC, stopC, err := pool.WsCombinedBookTickerServe([]string{"BTCUSDT"}, func(event *WsBookTickerEvent) {
+		delivered = append(delivered, event.UpdateID)
+	}, func(err error) {})
+	s.Require().NoError(err)
+	handlers, kills := *handlerList, *killList
+	s.Require().Len(handlers, 2)
+	paths := pool.WsPaths()
+	s.Require().Equal("pool0@127.0.0.1", paths[0].Name)
+	s.Require().Equal("pool1@127.0.0.2", paths[1].Name)
+
+	handlers[0](event(1, 50*time.Millisecond))
+	// the second path is faster and takes over without replaying update 1
+	handlers[1](event(1, 5*time.Millisecond))
+	handlers[0](event(2, 50*time.Millisecond))
+	handlers[1](event(2, 5*time.Millisecond))
+	s.Require().Equal([]int64{1, 2}, delivered)
+
+	close(kills[1])
+	s.Require().Eventually(func() bool {
+		for _, path := range pool.WsPaths() {
+			if path.LocalIP == "127.0.0.2" {
+				return !path.Alive
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	handlers[0](event(3, 50*time.Millisecond))
+	s.Require().Equal([]int64{1, 2, 3}, delivered)
+
+	close(stopC)
+	<-doneC
+	s.Require().Empty(pool.WsPaths())
+}
+
+func (s *ipPoolTestSuite) TestWsStalePath() {
+	handlerList, _ := s.mockWsServe()
+	pool, err := NewIPPool("127.0.0.1", "127.0.0.2")
+	s.Require().NoError(err)
+	env := MainnetEnvironment()
+	env.CombinedURL = "wss://127.0.0.1/stream?streams="
+	pool.Environment(env).StaleAfter(50 * time.Millisecond)
+
+	var delivered []int64
+	doneC, stopC, err := pool.WsCombinedBookTickerServe([]string{"BTCUSDT"}, func(event *WsBookTickerEvent) {
+		delivered = append(delivered, event.UpdateID)
+	}, func(err error) {})
+	s.Require().NoError(err)
+	handlers := *handlerList
+	s.Require().Len(handlers, 2)
+
+	start := time.Now()
+	handlers[0](bookTickerMessage(1, start.Add(-50*time.Millisecond)))
+	handlers[1](bookTickerMessage(1, start.Add(-5*time.Millisecond)))
+	handlers[1](bookTickerMessage(2, start.Add(-2*time.Millisecond)))
+	handlers[0](bookTickerMessage(2, start.Add(-2*time.Millisecond)))
+	s.Require().Equal([]int64{1, 2}, delivered)
+
+	// the best path goes quiet, it keeps its latency but misses the events of the other path
+	handlers[0](bookTickerMessage(3, start.Add(-time.Millisecond)))
+	s.Require().Equal([]int64{1, 2}, delivered)
+	time.Sleep(60 * time.Millisecond)
+	handlers[0](bookTickerMessage(4, time.Now().Add(-50*time.Millisecond)))
+	s.Require().Equal([]int64{1, 2, 4}, delivered)
+
+	// once it catches up the faster path takes over again
+	handlers[1](bookTickerMessage(5, time.Now()))
+	handlers[0](bookTickerMessage(5, time.Now()))
+	s.Require().Equal([]int64{1, 2, 4, 5}, delivered)
+	for _, path := range pool.WsPaths() {
+		s.Require().True(path.BehindSince.IsZero(), path.String())
+	}
+
+	close(stopC)
+	<-doneC
+}
@@ -824,13 +824,6 @@ type CreateBatchOrdersResponse struct {
 	Errors []error
 }
 
-func newCreateBatchOrdersResponse(n int) *CreateBatchOrdersResponse {
-	return &CreateBatchOrdersResponse{
-		N:      n,
-		Errors: make([]error, n),
-	}
-}
-
 func (s *CreateBatchOrdersService) OrderList(orders []*CreateOrderService) *CreateBatchOrdersService {
 	s.orders = orders
 	return s
@@ -904,33 +897,349 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 		return &CreateBatchOrdersResponse{}, err
 	}
 
-	rawMessages := make([]*json.RawMessage, 0)
+	n, orderList, errs, err := parseBatchOrders(data)
+	if err != nil {
+		return nil, err
+	}
+	return &CreateBatchOrdersResponse{
+		N:      n,
+		Orders: orderList,
+		Errors: errs,
+	}, nil
+}
 
+// parseBatchOrders split a batch response into successful orders and per-item errors
+func parseBatchOrders(data []byte) (n int, orders []*Order, errs []error, err error) {
+	rawMessages := make([]*json.RawMessage, 0)
 	err = json.Unmarshal(data, &rawMessages)
 	if err != nil {
-		return &CreateBatchOrdersResponse{}, err
+		return 0, nil, nil, err
 	}
-
-	batchCreateOrdersResponse := newCreateBatchOrdersResponse(len(rawMessages))
+	n = len(rawMessages)
+	errs = make([]error, n)
 	for i, j := range rawMessages {
 		// check if response is an API error
 		e := new(common.APIError)
 		if err := json.Unmarshal(*j, e); err != nil {
-			return nil, err
+			return 0, nil, nil, err
 		}
-
 		if e.Code > 0 || e.Message != "" {
-			batchCreateOrdersResponse.Errors[i] = e
+			errs[i] = e
 			continue
 		}
-
 		o := new(Order)
 		if err := json.Unmarshal(*j, o); err != nil {
-			return nil, err
+			return 0, nil, nil, err
 		}
+		orders = append(orders, o)
+	}
+	return n, orders, errs, nil
+}
+
+// ModifyOrderService modify price or quantity of a LIMIT order in place
+type ModifyOrderService struct {
+	c                 *Client
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          *string
+	price             *string
+	priceMatch        *PriceMatchType
+}
+
+// Symbol set symbol
+func (s *ModifyOrderService) Symbol(symbol string) *ModifyOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *ModifyOrderService) Side(side SideType) *ModifyOrderService {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *ModifyOrderService) OrderID(orderID int64) *ModifyOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *ModifyOrderService) OrigClientOrderID(origClientOrderID string) *ModifyOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Quantity set quantity
+func (s *ModifyOrderService) Quantity(quantity string) *ModifyOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// Price set price
+func (s *ModifyOrderService) Price(price string) *ModifyOrderService {
+	s.price = &price
+	return s
+}
+
+// PriceMatch set priceMatch, can not be sent together with price
+func (s *ModifyOrderService) PriceMatch(priceMatch PriceMatchType) *ModifyOrderService {
+	s.priceMatch = &priceMatch
+	return s
+}
+
+func (s *ModifyOrderService) params() params {
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.priceMatch != nil {
+		m["priceMatch"] = *s.priceMatch
+	}
+	return m
+}
 
-		batchCreateOrdersResponse.Orders = append(batchCreateOrdersResponse.Orders, o)
+// Do send request
+func (s *ModifyOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/fapi/v1/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(s.params())
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
 	}
+	return res, nil
+}
+
+// ModifyBatchOrdersService modify multiple orders, at most 5 orders per request
+type ModifyBatchOrdersService struct {
+	c      *Client
+	orders []*ModifyOrderService
+}
+
+// ModifyBatchOrdersResponse contains the response from ModifyBatchOrders operation
+type ModifyBatchOrdersResponse struct {
+	// Total number of messages in the response
+	N int
+	// List of orders which were modified successfully which can have a length between 0 and N
+	Orders []*Order
+	// List of errors of length N, where each item corresponds to a nil value if
+	// the order from that specific index was modified successfully OR an non-nil *APIError if there was an error with
+	// the order at that index
+	Errors []error
+}
+
+// OrderList set the modifications to apply
+func (s *ModifyBatchOrdersService) OrderList(orders []*ModifyOrderService) *ModifyBatchOrdersService {
+	s.orders = orders
+	return s
+}
+
+// Do send request
+func (s *ModifyBatchOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *ModifyBatchOrdersResponse, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/fapi/v1/batchOrders",
+		secType:  secTypeSigned,
+	}
+	orders := make([]params, 0, len(s.orders))
+	for _, order := range s.orders {
+		orders = append(orders, order.params())
+	}
+	b, err := json.Marshal(orders)
+	if err != nil {
+		return &ModifyBatchOrdersResponse{}, err
+	}
+	r.setFormParam("batchOrders", string(b))
+
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return &ModifyBatchOrdersResponse{}, err
+	}
+	n, orderList, errs, err := parseBatchOrders(data)
+	if err != nil {
+		return nil, err
+	}
+	return &ModifyBatchOrdersResponse{
+		N:      n,
+		Orders: orderList,
+		Errors: errs,
+	}, nil
+}
+
+// GetOrderAmendmentHistoryService get order modify history
+type GetOrderAmendmentHistoryService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+	startTime         *int64
+	endTime           *int64
+	limit             *int
+}
+
+// Symbol set symbol
+func (s *GetOrderAmendmentHistoryService) Symbol(symbol string) *GetOrderAmendmentHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *GetOrderAmendmentHistoryService) OrderID(orderID int64) *GetOrderAmendmentHistoryService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *GetOrderAmendmentHistoryService) OrigClientOrderID(origClientOrderID string) *GetOrderAmendmentHistoryService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// StartTime set startTime
+func (s *GetOrderAmendmentHistoryService) StartTime(startTime int64) *GetOrderAmendmentHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *GetOrderAmendmentHistoryService) EndTime(endTime int64) *GetOrderAmendmentHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *GetOrderAmendmentHistoryService) Limit(limit int) *GetOrderAmendmentHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *GetOrderAmendmentHistoryService) Do(ctx context.Context, opts ...RequestOption) (res []*OrderAmendment, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/orderAmendment",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OrderAmendment{}, err
+	}
+	res = make([]*OrderAmendment, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*OrderAmendment{}, err
+	}
+	return res, nil
+}
+
+// OrderAmendment define a single modification of an order
+type OrderAmendment struct {
+	AmendmentID   int64           `json:"amendmentId"`
+	Symbol        string          `json:"symbol"`
+	Pair          string          `json:"pair"`
+	OrderID       int64           `json:"orderId"`
+	ClientOrderID string          `json:"clientOrderId"`
+	Time          int64           `json:"time"`
+	Amendment     AmendmentDetail `json:"amendment"`
+}
+
+// AmendmentDetail define what changed in an order amendment
+type AmendmentDetail struct {
+	Price        AmendmentChange `json:"price"`
+	OrigQuantity AmendmentChange `json:"origQty"`
+	Count        int             `json:"count"`
+}
+
+// AmendmentChange define the value before and after an amendment
+type AmendmentChange struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// CountdownCancelAllService cancel all open orders of a symbol once the countdown expires,
+// call it repeatedly as a heartbeat and set countdownTime to 0 to disable the timer
+type CountdownCancelAllService struct {
+	c             *Client
+	symbol        string
+	countdownTime int64
+}
+
+// Symbol set symbol
+func (s *CountdownCancelAllService) Symbol(symbol string) *CountdownCancelAllService {
+	s.symbol = symbol
+	return s
+}
+
+// CountdownTime set countdown time in milliseconds
+func (s *CountdownCancelAllService) CountdownTime(countdownTime int64) *CountdownCancelAllService {
+	s.countdownTime = countdownTime
+	return s
+}
+
+// Do send request
+func (s *CountdownCancelAllService) Do(ctx context.Context, opts ...RequestOption) (res *CountdownCancelAll, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/fapi/v1/countdownCancelAll",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"symbol":        s.symbol,
+		"countdownTime": s.countdownTime,
+	})
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CountdownCancelAll)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
-	return batchCreateOrdersResponse, nil
+// CountdownCancelAll define countdown cancel all response
+type CountdownCancelAll struct {
+	Symbol        string `json:"symbol"`
+	CountdownTime string `json:"countdownTime"`
 }
@@ -2,7 +2,9 @@ package futures
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dictxwang/go-binance/common"
 	"github.com/stretchr/testify/suite"
@@ -708,3 +710,270 @@ func (s *orderServiceTestSuite) TestCreateBatchOrders() {
 	}
 	r.EqualValues(e, res)
 }
+
+func (s *orderServiceTestSuite) TestModifyOrder() {
+	data := []byte(`{
+		"orderId": 20072994037,
+		"symbol": "BTCUSDT",
+		"pair": "BTCUSDT",
+		"status": "NEW",
+		"clientOrderId": "LJ9R4QZDihCaS8UAOOLpgW",
+		"price": "30005",
+		"avgPrice": "0.0",
+		"origQty": "1",
+		"executedQty": "0",
+		"cumQty": "0",
+		"cumQuote": "0",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"reduceOnly": false,
+		"closePosition": false,
+		"side": "BUY",
+		"positionSide": "LONG",
+		"stopPrice": "0",
+		"workingType": "CONTRACT_PRICE",
+		"priceProtect": false,
+		"origType": "LIMIT",
+		"priceMatch": "QUEUE",
+		"selfTradePreventionMode": "NONE",
+		"goodTillDate": 0,
+		"updateTime": 1629182711600
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	orderID := int64(20072994037)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":     symbol,
+			"side":       SideTypeBuy,
+			"orderId":    orderID,
+			"quantity":   "1",
+			"priceMatch": PriceMatchTypeQueue,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyOrderService().Symbol(symbol).Side(SideTypeBuy).
+		OrderID(orderID).Quantity("1").PriceMatch(PriceMatchTypeQueue).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(orderID, res.OrderID)
+	r.Equal("30005", res.Price)
+	r.Equal("1", res.OrigQuantity)
+	r.Equal("QUEUE", res.PriceMatch)
+	r.Equal(PositionSideTypeLong, res.PositionSide)
+	r.Equal(int64(1629182711600), res.UpdateTime)
+}
+
+func (s *orderServiceTestSuite) TestModifyBatchOrders() {
+	data := []byte(`[
+		{
+			"orderId": 20072994037,
+			"symbol": "BTCUSDT",
+			"status": "NEW",
+			"price": "30005",
+			"origQty": "1",
+			"side": "BUY",
+			"type": "LIMIT"
+		},
+		{
+			"code": -4028,
+			"msg": "Price less than min price."
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"batchOrders": `[{"orderId":20072994037,"price":"30005","quantity":"1","side":"BUY","symbol":"BTCUSDT"},{"origClientOrderId":"abc","price":"0.1","quantity":"2","side":"SELL","symbol":"BTCUSDT"}]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyBatchOrdersService().OrderList([]*ModifyOrderService{
+		s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			OrderID(20072994037).Quantity("1").Price("30005"),
+		s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+			OrigClientOrderID("abc").Quantity("2").Price("0.1"),
+	}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(2, res.N)
+	r.Len(res.Orders, 1)
+	r.Equal(int64(20072994037), res.Orders[0].OrderID)
+	r.Nil(res.Errors[0])
+	r.Equal(&common.APIError{Code: -4028, Message: "Price less than min price."}, res.Errors[1])
+}
+
+func (s *orderServiceTestSuite) TestGetOrderAmendmentHistory() {
+	data := []byte(`[
+		{
+			"amendmentId": 5363,
+			"symbol": "BTCUSDT",
+			"pair": "BTCUSDT",
+			"orderId": 20072994037,
+			"clientOrderId": "LJ9R4QZDihCaS8UAOOLpgW",
+			"time": 1629184560899,
+			"amendment": {
+				"price": {
+					"before": "30004",
+					"after": "30003.2"
+				},
+				"origQty": {
+					"before": "1",
+					"after": "1"
+				},
+				"count": 3
+			}
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	orderID := int64(20072994037)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":  symbol,
+			"orderId": orderID,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetOrderAmendmentHistoryService().Symbol(symbol).
+		OrderID(orderID).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &OrderAmendment{
+		AmendmentID:   5363,
+		Symbol:        symbol,
+		Pair:          "BTCUSDT",
+		OrderID:       orderID,
+		ClientOrderID: "LJ9R4QZDihCaS8UAOOLpgW",
+		Time:          1629184560899,
+		Amendment: AmendmentDetail{
+			Price:        AmendmentChange{Before: "30004", After: "30003.2"},
+			OrigQuantity: AmendmentChange{Before: "1", After: "1"},
+			Count:        3,
+		},
+	}
+	r.Equal(e, res[0])
+}
+
+func (s *orderServiceTestSuite) TestCountdownCancelAll() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"countdownTime": "100000"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	countdownTime := int64(100000)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":        symbol,
+			"countdownTime": countdownTime,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCountdownCancelAllService().Symbol(symbol).
+		CountdownTime(countdownTime).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	e := &CountdownCancelAll{
+		Symbol:        symbol,
+		CountdownTime: "100000",
+	}
+	r.Equal(e, res)
+}
+
+func (s *orderServiceTestSuite) TestCountdownHeartbeatOnError() {
+	s.mockDo(nil, fmt.Errorf("dummy error"))
+	defer s.assertDo()
+
+	errSymbolC := make(chan string, 1)
+	doneC, stopC, err := s.client.NewCountdownHeartbeat(60000, "BTCUSDT").
+		Interval(50 * time.Second).
+		OnError(func(symbol string, err error) {
+			errSymbolC <- symbol
+		}).
+		Start(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("BTCUSDT", <-errSymbolC)
+	close(stopC)
+	<-doneC
+}
+
+func (s *orderServiceTestSuite) TestCountdownHeartbeatInterval() {
+	tests := []struct {
+		name          string
+		countdownTime int64
+		interval      time.Duration
+		want          time.Duration
+		err           error
+	}{
+		{name: "derived", countdownTime: 60000, want: 20 * time.Second},
+		{name: "derived short", countdownTime: 2, want: 2 * time.Millisecond / 3},
+		{name: "explicit", countdownTime: 60000, interval: 10 * time.Second, want: 10 * time.Second},
+		{name: "zero countdown", countdownTime: 0, err: ErrInvalidCountdownTime},
+		{name: "negative countdown", countdownTime: -1, interval: time.Second, err: ErrInvalidCountdownTime},
+		{name: "negative interval", countdownTime: 60000, interval: -time.Second, err: ErrInvalidCountdownInterval},
+		{name: "interval too long", countdownTime: 60000, interval: time.Minute, err: ErrInvalidCountdownInterval},
+	}
+	for _, tt := range tests {
+		h := s.client.NewCountdownHeartbeat(tt.countdownTime, "BTCUSDT").Interval(tt.interval)
+		interval, err := h.tickInterval()
+		s.Equal(tt.err, err, tt.name)
+		s.Equal(tt.want, interval, tt.name)
+	}
+
+	_, _, err := s.client.NewCountdownHeartbeat(0, "BTCUSDT").Start(newContext())
+	s.r().Equal(ErrInvalidCountdownTime, err)
+}
+
+// mockCountdown record the countdown time of each countdown cancel all request
+func (s *orderServiceTestSuite) mockCountdown() chan string {
+	s.mockDo([]byte(`{"symbol": "BTCUSDT", "countdownTime": "60000"}`), nil)
+	countdownC := make(chan string, 8)
+	s.assertReq(func(r *request) {
+		countdownC <- r.form.Get("countdownTime")
+	})
+	return countdownC
+}
+
+func (s *orderServiceTestSuite) TestCountdownHeartbeatStop() {
+	countdownC := s.mockCountdown()
+	doneC, stopC, err := s.client.NewCountdownHeartbeat(60000, "BTCUSDT").
+		Interval(50 * time.Second).
+		DisarmOnStop(true).
+		Start(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("60000", <-countdownC)
+	close(stopC)
+	<-doneC
+	r.Equal("0", <-countdownC)
+}
+
+func (s *orderServiceTestSuite) TestCountdownHeartbeatContextDone() {
+	countdownC := s.mockCountdown()
+	ctx, cancel := context.WithCancel(newContext())
+	doneC, _, err := s.client.NewCountdownHeartbeat(60000, "BTCUSDT").
+		Interval(50 * time.Second).
+		DisarmOnStop(true).
+		Start(ctx)
+	r := s.r()
+	r.NoError(err)
+	r.Equal("60000", <-countdownC)
+	cancel()
+	<-doneC
+	r.Equal("0", <-countdownC)
+	r.Empty(countdownC)
+}
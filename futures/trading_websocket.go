@@ -3,20 +3,16 @@ package futures
 import (
 	"context"
 	"crypto"
-	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
-	"github.com/gorilla/websocket"
 	"net"
 	"net/http"
 	"os"
-	"sort"
-	"strconv"
 	"strings"
-	"sync/atomic"
 	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
 )
 
 const (
@@ -119,6 +115,9 @@ type ClientWs struct {
 	LocalIP       string
 	lastTransmit  *time.Time
 	resolver      *net.Resolver
+	dialer        *websocket.Dialer
+	metrics       common.WsMetrics
+	name          string
 }
 
 func NewTradingWsClient(ctx context.Context, apiKey, secretKey, localIP string) *ClientWs {
@@ -134,9 +133,11 @@ func NewTradingWsClient(ctx context.Context, apiKey, secretKey, localIP string)
 		sendChan:  make(chan []byte, 3),
 		DoneChan:  make(chan interface{}, 32),
 		LocalIP:   localIP,
+		name:      common.NewWsAPIConnName(localIP),
+		metrics:   WebsocketMetrics,
 	}
 
-	privateKey, err := parsePrivateKey(c.secretKey)
+	privateKey, err := common.ParsePrivateKey(c.secretKey)
 	if err != nil {
 		fmt.Errorf("failed to parse private key")
 		os.Exit(-1)
@@ -158,9 +159,10 @@ func NewTradingWsClientIfIntranet(ctx context.Context, apiKey, secretKey, localI
 		sendChan:  make(chan []byte, 3),
 		DoneChan:  make(chan interface{}, 32),
 		LocalIP:   localIP,
+		name:      common.NewWsAPIConnName(localIP),
 	}
 
-	privateKey, err := parsePrivateKey(c.secretKey)
+	privateKey, err := common.ParsePrivateKey(c.secretKey)
 	if err != nil {
 		fmt.Errorf("failed to parse private key")
 		os.Exit(-1)
@@ -169,6 +171,26 @@ func NewTradingWsClientIfIntranet(ctx context.Context, apiKey, secretKey, localI
 	return c
 }
 
+// NewTradingWsClientWithEnvironment is similar to NewTradingWsClient, but it connects to the WS API of env
+func NewTradingWsClientWithEnvironment(ctx context.Context, apiKey, secretKey, localIP string, env *Environment) *ClientWs {
+	c := NewTradingWsClient(ctx, apiKey, secretKey, localIP)
+	c.url = env.WsAPIURL
+	c.dialer = env.Dialer
+	c.metrics = env.Metrics
+	return c
+}
+
+// SetMetrics set the metrics receiving the health of the connection, defaults to WebsocketMetrics
+func (c *ClientWs) SetMetrics(metrics common.WsMetrics) {
+	c.metrics = metrics
+}
+
+// SetName set the name of the connection reported to the metrics, defaults to wsAPI numbered in the process
+// and followed by the local IP, e.g. wsAPI#1@10.0.0.1
+func (c *ClientWs) SetName(name string) {
+	c.name = name
+}
+
 func (c *ClientWs) SetResolver(resolver *net.Resolver) {
 	c.resolver = resolver
 }
@@ -288,8 +310,8 @@ func (c *ClientWs) Login() error {
 		"timestamp": time.Now().UnixMilli(), // use the current time in milliseconds
 	}
 
-	payload := makeQueryString(args)
-	signature, err := signPayload(payload, c.privateKey)
+	payload := common.MakeQueryString(args)
+	signature, err := common.SignPayload(payload, c.privateKey)
 
 	if err != nil {
 		fmt.Printf("Failed to sign payload: %v", err)
@@ -315,7 +337,9 @@ func (c *ClientWs) Login() error {
 
 func (c *ClientWs) dial() error {
 	var dialer websocket.Dialer
-	if c.LocalIP != "" {
+	if c.dialer != nil {
+		dialer = *c.dialer
+	} else if c.LocalIP != "" {
 		dialer = websocket.Dialer{
 			NetDial: func(network, addr string) (net.Conn, error) {
 				localAddr, err := net.ResolveTCPAddr("tcp", c.LocalIP+":0") // 替换为您的出口IP地址
@@ -404,6 +428,9 @@ func (c *ClientWs) dial() error {
 	}()
 
 	c.conn = conn
+	if c.metrics != nil {
+		c.metrics.WsConnected(c.name)
+	}
 	c.closed = false
 
 	return nil
@@ -472,11 +499,17 @@ func (c *ClientWs) receiver() error {
 		default:
 			mt, data, err := c.conn.ReadMessage()
 			if err != nil {
+				if c.metrics != nil {
+					c.metrics.WsDisconnected(c.name, err)
+				}
 				return fmt.Errorf("failed to read message from ws connection, error: %v\n", err)
 			}
 
 			now := time.Now()
 			c.lastTransmit = &now
+			if c.metrics != nil {
+				common.ObserveWsMessage(c.metrics, c.name, data, now)
+			}
 
 			if mt == websocket.TextMessage && string(data) != "pong" {
 				//fmt.Printf("Raw JSON data: %s\n", data)
@@ -580,72 +613,16 @@ type WsCancelOrder struct {
 }
 
 func (c *ClientWs) PlaceOrder(order *WsPlaceOrder) error {
-	args := s2m(order)
+	args := common.StructToMap(order)
 	return c.Send("order.place", args)
 }
 
 func (c *ClientWs) CancelOrder(order *WsCancelOrder) error {
-	args := s2m(order)
+	args := common.StructToMap(order)
 	return c.Send("order.cancel", args)
 }
 
-func s2m(i interface{}) map[string]interface{} {
-	m := make(map[string]interface{})
-	j, _ := json.Marshal(i)
-	_ = json.Unmarshal(j, &m)
-
-	return m
-}
-
-func getTimestampInMS() int64 {
-	return time.Now().UnixNano() / 1e6
-}
-
-var gClientOrderID = getTimestampInMS()
-
+// GetReqID return a unique id for a websocket API request
 func GetReqID() string {
-	atomic.AddInt64(&gClientOrderID, 1)
-	return strconv.FormatInt(atomic.LoadInt64(&gClientOrderID), 10)
-}
-
-func makeQueryString(params map[string]interface{}) string {
-	keys := make([]string, 0, len(params))
-	for key := range params {
-		keys = append(keys, key)
-	}
-	sort.Strings(keys)
-
-	var payloadBuilder strings.Builder
-	for _, key := range keys {
-		payloadBuilder.WriteString(fmt.Sprintf("%s=%v&", key, params[key]))
-	}
-	return strings.TrimRight(payloadBuilder.String(), "&")
-}
-
-func parsePrivateKey(apiSecret string) (crypto.PrivateKey, error) {
-	block, _ := pem.Decode([]byte(apiSecret))
-	if block == nil || block.Type != "PRIVATE KEY" {
-		return nil, fmt.Errorf("failed to decode PEM block containing private key")
-	}
-
-	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
-	if err != nil {
-		return nil, err
-	}
-
-	return privateKey, nil
-}
-
-func signPayload(payload string, privateKey crypto.PrivateKey) (string, error) {
-	key, ok := privateKey.(crypto.Signer)
-	if !ok {
-		return "", fmt.Errorf("key does not implement crypto.Signer")
-	}
-
-	signature, err := key.Sign(nil, []byte(payload), crypto.Hash(0))
-	if err != nil {
-		return "", err
-	}
-
-	return base64.StdEncoding.EncodeToString(signature), nil
+	return common.GetReqID()
 }
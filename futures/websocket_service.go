@@ -7,6 +7,8 @@ import (
 	"net"
 	"strings"
 	"time"
+
+	"github.com/dictxwang/go-binance/common"
 )
 
 // Endpoints
@@ -27,6 +29,8 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketMetrics receives the health measurements of the websocket connections when it is set
+	WebsocketMetrics common.WsMetrics
 	// UseTestnet switch all the WS streams from production to the testnet
 	UseTestnet  = false
 	UseIntranet = false
@@ -41,44 +45,11 @@ const (
 	WsCategoryPrivate = "private"
 )
 
-// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getWsEndpoint() string {
-	if UseTestnet {
-		return baseWsTestnetUrl
-	}
-	if UseIntranet {
-		return baseInternalWsMainURL
-	}
-	return baseWsMainUrl
-}
-
-// getCombinedEndpoint return the base endpoint of the combined stream according the UseTestnet flag
-func getCombinedEndpoint() string {
-	if UseTestnet {
-		return baseCombinedTestnetURL
-	}
-	if UseIntranet {
-		return baseInternalCombinedMainURL
-	}
-	return baseCombinedMainURL
-}
-
 // getCombinedIntranetEndpoint return the base intranet endpoint of the combined stream according the UseTestnet flag
 func getCombinedIntranetEndpoint() string {
 	return baseInternalCombinedMainURL
 }
 
-// getTradingWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getTradingWsEndpoint() string {
-	if UseTestnet {
-		return baseTradingWsTestUrl
-	}
-	if UseIntranet {
-		return baseInternalTradingWsUrl
-	}
-	return baseTradingWsUrl
-}
-
 func getTradingWsEndpointIfIntranet(useIntranet bool) string {
 	if useIntranet {
 		return baseInternalTradingWsUrl
@@ -87,42 +58,6 @@ func getTradingWsEndpointIfIntranet(useIntranet bool) string {
 	}
 }
 
-// wsEndpointWithCategory returns the WS endpoint with category path when UseNewWsEndpoint is enabled.
-func wsEndpointWithCategory(category string) string {
-	if !UseNewWsEndpoint || category == "" {
-		return getWsEndpoint()
-	}
-	if UseTestnet {
-		return baseWsTestnetUrl
-	}
-	if UseIntranet {
-		return fmt.Sprintf("wss://fstream-mm.binance.com/%s/ws", category)
-	}
-	return fmt.Sprintf("wss://fstream.binance.com/%s/ws", category)
-}
-
-// combinedEndpointWithCategory returns the combined stream endpoint with category path when UseNewWsEndpoint is enabled.
-func combinedEndpointWithCategory(category string) string {
-	if !UseNewWsEndpoint || category == "" {
-		return getCombinedEndpoint()
-	}
-	if UseTestnet {
-		return baseCombinedTestnetURL
-	}
-	if UseIntranet {
-		return fmt.Sprintf("wss://fstream-mm.binance.com/%s/stream?streams=", category)
-	}
-	return fmt.Sprintf("wss://fstream.binance.com/%s/stream?streams=", category)
-}
-
-// combinedIntranetEndpointWithCategory returns the intranet combined stream endpoint with category path.
-func combinedIntranetEndpointWithCategory(category string) string {
-	if !UseNewWsEndpoint || category == "" {
-		return getCombinedIntranetEndpoint()
-	}
-	return fmt.Sprintf("wss://fstream-mm.binance.com/%s/stream?streams=", category)
-}
-
 // WsAggTradeEvent define websocket aggTrde event.
 type WsAggTradeEvent struct {
 	Event            string `json:"e"`
@@ -141,9 +76,9 @@ type WsAggTradeEvent struct {
 type WsAggTradeHandler func(event *WsAggTradeEvent)
 
 // WsAggTradeServe serve websocket that push trade information that is aggregated for a single taker order.
-func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@aggTrade", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@aggTrade", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
 		err := json.Unmarshal(message, &event)
@@ -157,13 +92,13 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 }
 
 // WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbols
-func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryMarket)
+func (e *Environment) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryMarket)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -192,13 +127,13 @@ func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHan
 }
 
 // WsCombinedAggTradeServeWithIP is similar to WsAggTradeServe, but it handles multiple symbols
-func WsCombinedAggTradeServeWithIP(sourceIP string, symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryMarket)
+func (e *Environment) WsCombinedAggTradeServeWithIP(sourceIP string, symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryMarket)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	cfg.WithIP(sourceIP)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
@@ -242,8 +177,8 @@ type WsMarkPriceEvent struct {
 // WsMarkPriceHandler handle websocket that pushes price and funding rate for a single symbol.
 type WsMarkPriceHandler func(event *WsMarkPriceEvent)
 
-func wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceEvent)
 		err := json.Unmarshal(message, &event)
@@ -257,13 +192,13 @@ func wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler Er
 }
 
 // WsMarkPriceServe serve websocket that pushes price and funding rate for a single symbol.
-func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@markPrice", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
-	return wsMarkPriceServe(endpoint, handler, errHandler)
+func (e *Environment) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@markPrice", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
+	return e.wsMarkPriceServe(endpoint, handler, errHandler)
 }
 
 // WsMarkPriceServeWithRate serve websocket that pushes price and funding rate for a single symbol and rate.
-func WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	switch rate {
 	case 3 * time.Second:
@@ -273,12 +208,12 @@ func WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkP
 	default:
 		return nil, nil, errors.New("Invalid rate")
 	}
-	endpoint := fmt.Sprintf("%s/%s@markPrice%s", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol), rateStr)
-	return wsMarkPriceServe(endpoint, handler, errHandler)
+	endpoint := fmt.Sprintf("%s/%s@markPrice%s", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol), rateStr)
+	return e.wsMarkPriceServe(endpoint, handler, errHandler)
 }
 
-func wsCombinedMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsCombinedMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -303,19 +238,19 @@ func wsCombinedMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHa
 }
 
 // WsCombinedMarkPriceServe is similar to WsMarkPriceServe, but it handles multiple symbols
-func WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryMarket)
+func (e *Environment) WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryMarket)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@markPrice", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
 
-	return wsCombinedMarkPriceServe(endpoint, handler, errHandler)
+	return e.wsCombinedMarkPriceServe(endpoint, handler, errHandler)
 }
 
 // WsCombinedMarkPriceServeWithRate is similar to WsMarkPriceServeWithRate, but it for multiple symbols
-func WsCombinedMarkPriceServeWithRate(symbolLevels map[string]time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryMarket)
+func (e *Environment) WsCombinedMarkPriceServeWithRate(symbolLevels map[string]time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryMarket)
 	for symbol, rate := range symbolLevels {
 		var rateStr string
 		switch rate {
@@ -332,7 +267,7 @@ func WsCombinedMarkPriceServeWithRate(symbolLevels map[string]time.Duration, han
 
 	endpoint = endpoint[:len(endpoint)-1]
 
-	return wsCombinedMarkPriceServe(endpoint, handler, errHandler)
+	return e.wsCombinedMarkPriceServe(endpoint, handler, errHandler)
 }
 
 // WsAllMarkPriceEvent defines an array of websocket markPriceUpdate events.
@@ -341,8 +276,8 @@ type WsAllMarkPriceEvent []*WsMarkPriceEvent
 // WsAllMarkPriceHandler handle websocket that pushes price and funding rate for all symbol.
 type WsAllMarkPriceHandler func(event WsAllMarkPriceEvent)
 
-func wsAllMarkPriceServe(endpoint string, localIP string, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsAllMarkPriceServe(endpoint string, localIP string, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	if localIP != "" {
 		cfg.WithIP(localIP)
 	}
@@ -359,19 +294,19 @@ func wsAllMarkPriceServe(endpoint string, localIP string, handler WsAllMarkPrice
 }
 
 // WsAllMarkPriceServe serve websocket that pushes price and funding rate for all symbol.
-func WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!markPrice@arr", wsEndpointWithCategory(WsCategoryMarket))
-	return wsAllMarkPriceServe(endpoint, "", handler, errHandler)
+func (e *Environment) WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!markPrice@arr", e.wsEndpointWithCategory(WsCategoryMarket))
+	return e.wsAllMarkPriceServe(endpoint, "", handler, errHandler)
 }
 
 // WsAllMarkPriceServeWithIP serve websocket that pushes price and funding rate for all symbol.
-func WsAllMarkPriceServeWithIP(ip string, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!markPrice@arr", wsEndpointWithCategory(WsCategoryMarket))
-	return wsAllMarkPriceServe(endpoint, ip, handler, errHandler)
+func (e *Environment) WsAllMarkPriceServeWithIP(ip string, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!markPrice@arr", e.wsEndpointWithCategory(WsCategoryMarket))
+	return e.wsAllMarkPriceServe(endpoint, ip, handler, errHandler)
 }
 
 // WsAllMarkPriceServeWithRate serve websocket that pushes price and funding rate for all symbol and rate.
-func WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	switch rate {
 	case 3 * time.Second:
@@ -381,12 +316,12 @@ func WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandl
 	default:
 		return nil, nil, errors.New("Invalid rate")
 	}
-	endpoint := fmt.Sprintf("%s/!markPrice@arr%s", wsEndpointWithCategory(WsCategoryMarket), rateStr)
-	return wsAllMarkPriceServe(endpoint, "", handler, errHandler)
+	endpoint := fmt.Sprintf("%s/!markPrice@arr%s", e.wsEndpointWithCategory(WsCategoryMarket), rateStr)
+	return e.wsAllMarkPriceServe(endpoint, "", handler, errHandler)
 }
 
 // WsAllMarkPriceServeWithRateWithIP serve websocket that pushes price and funding rate for all symbol and rate.
-func WsAllMarkPriceServeWithRateWithIP(ip string, rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) WsAllMarkPriceServeWithRateWithIP(ip string, rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	switch rate {
 	case 3 * time.Second:
@@ -396,8 +331,8 @@ func WsAllMarkPriceServeWithRateWithIP(ip string, rate time.Duration, handler Ws
 	default:
 		return nil, nil, errors.New("Invalid rate")
 	}
-	endpoint := fmt.Sprintf("%s/!markPrice@arr%s", wsEndpointWithCategory(WsCategoryMarket), rateStr)
-	return wsAllMarkPriceServe(endpoint, ip, handler, errHandler)
+	endpoint := fmt.Sprintf("%s/!markPrice@arr%s", e.wsEndpointWithCategory(WsCategoryMarket), rateStr)
+	return e.wsAllMarkPriceServe(endpoint, ip, handler, errHandler)
 }
 
 // WsKlineEvent define websocket kline event
@@ -432,9 +367,9 @@ type WsKline struct {
 type WsKlineHandler func(event *WsKlineEvent)
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
-func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@kline_%s", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol), interval)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@kline_%s", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -448,13 +383,13 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 }
 
 // WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
-func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryMarket)
+func (e *Environment) WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryMarket)
 	for symbol, interval := range symbolIntervalPair {
 		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -521,11 +456,11 @@ type WsContinuousKlineSubcribeArgs struct {
 type WsContinuousKlineHandler func(event *WsContinuousKlineEvent)
 
 // WsContinuousKlineServe serve websocket continuous kline handler with a pair and contractType and interval like 15m, 30s
-func WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handler WsContinuousKlineHandler,
+func (e *Environment) WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handler WsContinuousKlineHandler,
 	errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(subscribeArgs.Pair),
+	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(subscribeArgs.Pair),
 		strings.ToLower(subscribeArgs.ContractType), subscribeArgs.Interval)
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsContinuousKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -539,15 +474,15 @@ func WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handle
 }
 
 // WsCombinedContinuousKlineServe is similar to WsContinuousKlineServe, but it handles multiple pairs of different contractType with its interval
-func WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubcribeArgs,
+func (e *Environment) WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubcribeArgs,
 	handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryMarket)
+	endpoint := e.combinedEndpointWithCategory(WsCategoryMarket)
 	for _, val := range subscribeArgsList {
 		endpoint += fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(val.Pair),
 			strings.ToLower(val.ContractType), val.Interval) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -588,9 +523,9 @@ type WsMiniMarketTickerEvent struct {
 type WsMiniMarketTickerHandler func(event *WsMiniMarketTickerEvent)
 
 // WsMiniMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
-func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@miniTicker", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@miniTicker", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMiniMarketTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -610,9 +545,9 @@ type WsAllMiniMarketTickerEvent []*WsMiniMarketTickerEvent
 type WsAllMiniMarketTickerHandler func(event WsAllMiniMarketTickerEvent)
 
 // WsAllMiniMarketTickerServe serve websocket that pushes price and funding rate for all markets.
-func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!miniTicker@arr", wsEndpointWithCategory(WsCategoryMarket))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!miniTicker@arr", e.wsEndpointWithCategory(WsCategoryMarket))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketTickerEvent
 		err := json.Unmarshal(message, &event)
@@ -651,9 +586,9 @@ type WsMarketTickerEvent struct {
 type WsMarketTickerHandler func(event *WsMarketTickerEvent)
 
 // WsMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
-func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@ticker", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@ticker", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarketTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -673,9 +608,9 @@ type WsAllMarketTickerEvent []*WsMarketTickerEvent
 type WsAllMarketTickerHandler func(event WsAllMarketTickerEvent)
 
 // WsAllMarketTickerServe serve websocket that pushes price and funding rate for all markets.
-func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!ticker@arr", wsEndpointWithCategory(WsCategoryMarket))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!ticker@arr", e.wsEndpointWithCategory(WsCategoryMarket))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickerEvent
 		err := json.Unmarshal(message, &event)
@@ -710,9 +645,9 @@ type WsCombinedBookTickerEvent struct {
 type WsBookTickerHandler func(event *WsBookTickerEvent)
 
 // WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
-func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@bookTicker", wsEndpointWithCategory(WsCategoryPublic), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@bookTicker", e.wsEndpointWithCategory(WsCategoryPublic), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -726,9 +661,9 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 }
 
 // WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
-func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!bookTicker", wsEndpointWithCategory(WsCategoryPublic))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!bookTicker", e.wsEndpointWithCategory(WsCategoryPublic))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -742,13 +677,13 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 }
 
 // WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
-func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryPublic)
+func (e *Environment) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryPublic)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
 		err := json.Unmarshal(message, event)
@@ -762,13 +697,13 @@ func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, er
 }
 
 // WsCombinedBookTickerServeIfIntranet is similar to WsCombinedBookTickerServeWithIntranet, but it is using intranet
-func WsCombinedBookTickerServeIfIntranet(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedIntranetEndpointWithCategory(WsCategoryPublic)
+func (e *Environment) WsCombinedBookTickerServeIfIntranet(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedIntranetEndpointWithCategory(WsCategoryPublic)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
 		err := json.Unmarshal(message, event)
@@ -782,13 +717,13 @@ func WsCombinedBookTickerServeIfIntranet(symbols []string, handler WsBookTickerH
 }
 
 // WsCombinedBookTickerServeWithIP is similar to WsCombinedBookTickerServe,  but it is using assigned IP to connect ws service
-func WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryPublic)
+func (e *Environment) WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryPublic)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	cfg.WithIP(ip)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
@@ -803,13 +738,13 @@ func WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBook
 }
 
 // WsCombinedBookTickerServeWithIPIfIntranet is similar to WsCombinedBookTickerServeWithIP,  but it is using intranet
-func WsCombinedBookTickerServeWithIPIfIntranet(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedIntranetEndpointWithCategory(WsCategoryPublic)
+func (e *Environment) WsCombinedBookTickerServeWithIPIfIntranet(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedIntranetEndpointWithCategory(WsCategoryPublic)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	cfg.WithIP(ip)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
@@ -824,14 +759,14 @@ func WsCombinedBookTickerServeWithIPIfIntranet(ip string, symbols []string, hand
 }
 
 // WsCombinedBookTickerServeWithIPAndResolver is similar to WsCombinedBookTickerServe, but it is using assigned sourceIP and resolver to connect ws service
-func WsCombinedBookTickerServeWithIPAndResolver(sourceIP string, resolver *net.Resolver, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryPublic)
+func (e *Environment) WsCombinedBookTickerServeWithIPAndResolver(sourceIP string, resolver *net.Resolver, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryPublic)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
 
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	cfg.WithIP(sourceIP)
 	cfg.WithResolver(resolver)
 
@@ -873,9 +808,9 @@ type WsLiquidationOrder struct {
 type WsLiquidationOrderHandler func(event *WsLiquidationOrderEvent)
 
 // WsLiquidationOrderServe serve websocket that pushes force liquidation order information for specific symbol.
-func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@forceOrder", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@forceOrder", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
 		err := json.Unmarshal(message, &event)
@@ -889,9 +824,9 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 }
 
 // WsAllLiquidationOrderServe serve websocket that pushes force liquidation order information for all symbols.
-func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!forceOrder@arr", wsEndpointWithCategory(WsCategoryMarket))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!forceOrder@arr", e.wsEndpointWithCategory(WsCategoryMarket))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
 		err := json.Unmarshal(message, &event)
@@ -920,37 +855,37 @@ type WsDepthEvent struct {
 // WsDepthHandler handle websocket depth event
 type WsDepthHandler func(event *WsDepthEvent)
 
-func wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	if levels != 5 && levels != 10 && levels != 20 {
 		return nil, nil, errors.New("Invalid levels")
 	}
 	levelsStr := fmt.Sprintf("%d", levels)
-	return wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
+	return e.wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
 }
 
 // WsPartialDepthServe serve websocket partial depth handler.
-func WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
+func (e *Environment) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
 }
 
 // WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
-func WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, &rate, handler, errHandler)
+func (e *Environment) WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsPartialDepthServe(symbol, levels, &rate, handler, errHandler)
 }
 
 // WsDiffDepthServe serve websocket diff. depth handler.
-func WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", nil, handler, errHandler)
+func (e *Environment) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsDepthServe(symbol, "", nil, handler, errHandler)
 }
 
 // WsCombinedDepthServe is similar to WsPartialDepthServe, but it for multiple symbols
-func WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryPublic)
+func (e *Environment) WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryPublic)
 	for s, l := range symbolLevels {
 		endpoint += fmt.Sprintf("%s@depth%s", strings.ToLower(s), l) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -990,13 +925,13 @@ func WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler
 }
 
 // WsCombinedDiffDepthServe is similar to WsDiffDepthServe, but it for multiple symbols
-func WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := combinedEndpointWithCategory(WsCategoryPublic)
+func (e *Environment) WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.combinedEndpointWithCategory(WsCategoryPublic)
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@depth", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -1036,11 +971,11 @@ func WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandl
 }
 
 // WsDiffDepthServeWithRate serve websocket diff. depth handler with rate.
-func WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", &rate, handler, errHandler)
+func (e *Environment) WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsDepthServe(symbol, "", &rate, handler, errHandler)
 }
 
-func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	if rate != nil {
 		switch *rate {
@@ -1054,8 +989,8 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 			return nil, nil, errors.New("Invalid rate")
 		}
 	}
-	endpoint := fmt.Sprintf("%s/%s@depth%s%s", wsEndpointWithCategory(WsCategoryPublic), strings.ToLower(symbol), levels, rateStr)
-	cfg := newWsConfig(endpoint)
+	endpoint := fmt.Sprintf("%s/%s@depth%s%s", e.wsEndpointWithCategory(WsCategoryPublic), strings.ToLower(symbol), levels, rateStr)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -1116,9 +1051,9 @@ type WsBLVTBasket struct {
 type WsBLVTInfoHandler func(event *WsBLVTInfoEvent)
 
 // WsBLVTInfoServe serve BLVT info stream
-func WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@tokenNav", wsEndpointWithCategory(WsCategoryMarket), strings.ToUpper(name))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@tokenNav", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToUpper(name))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTInfoEvent)
 		err := json.Unmarshal(message, &event)
@@ -1159,9 +1094,9 @@ type WsBLVTKline struct {
 type WsBLVTKlineHandler func(event *WsBLVTKlineEvent)
 
 // WsBLVTKlineServe serve BLVT kline stream
-func WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@nav_Kline_%s", wsEndpointWithCategory(WsCategoryMarket), strings.ToUpper(name), interval)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@nav_Kline_%s", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToUpper(name), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -1194,9 +1129,9 @@ type WsComposition struct {
 type WsCompositeIndexHandler func(event *WsCompositeIndexEvent)
 
 // WsCompositiveIndexServe serve composite index information for index symbols
-func WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@compositeIndex", wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@compositeIndex", e.wsEndpointWithCategory(WsCategoryMarket), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCompositeIndexEvent)
 		err := json.Unmarshal(message, event)
@@ -1308,9 +1243,10 @@ type WsAccountConfigUpdate struct {
 type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
-func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", wsEndpointWithCategory(WsCategoryPrivate), listenKey)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.wsEndpointWithCategory(WsCategoryPrivate), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
 	wsHandler := func(message []byte) {
 		event := new(WsUserDataEvent)
 		err := json.Unmarshal(message, event)
@@ -1324,9 +1260,10 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 }
 
 // WsUserDataServe serve user data handler with listen key
-func WsUserDataServeWithIP(ip, listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", wsEndpointWithCategory(WsCategoryPrivate), listenKey)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsUserDataServeWithIP(ip, listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.wsEndpointWithCategory(WsCategoryPrivate), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
 	cfg.WithIP(ip)
 
 	wsHandler := func(message []byte) {
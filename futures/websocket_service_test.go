@@ -95,6 +95,37 @@ func (s *websocketServiceTestSuite) TestAggTradeServe() {
 	<-doneC
 }
 
+func (s *websocketServiceTestSuite) TestEnvironmentAggTradeServe() {
+	var cfgs []*WsConfig
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, innerErr error) {
+		cfgs = append(cfgs, cfg)
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+	handler := func(event *WsAggTradeEvent) {}
+	errHandler := func(err error) {}
+
+	env := TestnetEnvironment()
+	env.WebsocketKeepalive = true
+	env.WebsocketTimeout = 10 * time.Second
+	_, _, err := env.WsAggTradeServe("BTCUSDT", handler, errHandler)
+	s.r().NoError(err)
+
+	_, _, err = MainnetEnvironment().UseCategoryEndpoints().WsAggTradeServe("BTCUSDT", handler, errHandler)
+	s.r().NoError(err)
+
+	_, _, err = WsAggTradeServe("BTCUSDT", handler, errHandler)
+	s.r().NoError(err)
+
+	s.r().Len(cfgs, 3)
+	s.r().Equal("wss://stream.binancefuture.com/ws/btcusdt@aggTrade", cfgs[0].Endpoint)
+	s.r().True(cfgs[0].Keepalive)
+	s.r().Equal(10*time.Second, cfgs[0].Timeout)
+	s.r().Equal("wss://fstream.binance.com/market/ws/btcusdt@aggTrade", cfgs[1].Endpoint)
+	s.r().False(cfgs[1].Keepalive)
+	s.r().Equal("wss://fstream.binance.com/ws/btcusdt@aggTrade", cfgs[2].Endpoint)
+	s.r().Equal(WebsocketTimeout, cfgs[2].Timeout)
+}
+
 func (s *websocketServiceTestSuite) TestCombinedAggTradeServe() {
 	data := []byte(`{
 			"stream":"btcusdt@aggTrade",
@@ -0,0 +1,81 @@
+package futures
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type websocketTestSuite struct {
+	suite.Suite
+}
+
+func TestWebsocket(t *testing.T) {
+	suite.Run(t, new(websocketTestSuite))
+}
+
+func (s *websocketTestSuite) TestWsServeMetrics() {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for i := 0; i < 2; i++ {
+			event := fmt.Sprintf(`{"e":"aggTrade","E":%d,"s":"BTCUSDT"}`, time.Now().UnixNano()/int64(time.Millisecond))
+			c.WriteMessage(websocket.TextMessage, []byte(event))
+		}
+		// answer the keepalive pings until the client goes away
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	metrics := common.NewPrometheusWsMetrics("binance")
+	cfg := &WsConfig{
+		Endpoint:  "ws" + strings.TrimPrefix(server.URL, "http"),
+		Name:      "btcusdt@aggTrade",
+		Keepalive: true,
+		Timeout:   time.Second,
+		Metrics:   metrics,
+	}
+	received := make(chan struct{}, 2)
+	doneC, stopC, err := wsServe(cfg, func(message []byte) {
+		received <- struct{}{}
+	}, func(err error) {
+		s.Fail("unexpected error", err)
+	})
+	s.Require().NoError(err)
+	<-received
+	<-received
+	s.Require().Eventually(func() bool {
+		return metrics.Stats()[0].PingRTT > 0
+	}, time.Second, 10*time.Millisecond)
+	close(stopC)
+	<-doneC
+
+	stats := metrics.Stats()
+	s.Require().Len(stats, 1)
+	s.Require().Equal("btcusdt@aggTrade", stats[0].Conn)
+	s.Require().False(stats[0].Up)
+	s.Require().Equal(int64(1), stats[0].Connects)
+	s.Require().Equal(int64(1), stats[0].Disconnects)
+	s.Require().Equal(int64(0), stats[0].Errors)
+	s.Require().Equal(int64(2), stats[0].Messages)
+
+	var b strings.Builder
+	_, err = metrics.WriteTo(&b)
+	s.Require().NoError(err)
+	s.Require().Contains(b.String(), "binance_ws_latency_seconds_count{conn=\"btcusdt@aggTrade\"} 2\n")
+}
@@ -0,0 +1,94 @@
+package futures
+
+import (
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+)
+
+// WsRaceConn is the connection statistics of a WsRace
+type WsRaceConn = common.WsRaceConn
+
+// WsRace race the futures streams of an Environment on several connections, see common.WsRace
+type WsRace struct {
+	race *common.WsRace
+	env  *Environment
+}
+
+// NewWsRace init a WsRace on DefaultEnvironment() with a connection from each local IP, see common.NewWsRace
+func NewWsRace(ips ...string) *WsRace {
+	return &WsRace{
+		race: common.NewWsRace(ips...),
+		env:  DefaultEnvironment(),
+	}
+}
+
+// Environment set the environment of the streams, defaults to DefaultEnvironment()
+func (r *WsRace) Environment(env *Environment) *WsRace {
+	r.env = env
+	return r
+}
+
+// Name set the prefix of the connection names in the metrics, see common.WsRace.SetName
+func (r *WsRace) Name(name string) *WsRace {
+	r.race.SetName(name)
+	return r
+}
+
+// RedialBackoff set the delays between the redials of a dead connection, see common.WsRace.SetRedialBackoff
+func (r *WsRace) RedialBackoff(min, max time.Duration) *WsRace {
+	r.race.SetRedialBackoff(min, max)
+	return r
+}
+
+// Conns return a snapshot of the connection statistics
+func (r *WsRace) Conns() []WsRaceConn {
+	return r.race.Conns()
+}
+
+// WsCombinedBookTickerServe race the combined book ticker stream of symbols, keyed by update ID
+func (r *WsRace) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return r.serve(func(env *Environment, conn *WsRaceConn, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		return env.WsCombinedBookTickerServe(symbols, func(event *WsBookTickerEvent) {
+			r.race.Deliver(conn, event.Symbol, event.UpdateID, func() {
+				handler(event)
+			})
+		}, errHandler)
+	}, errHandler)
+}
+
+// WsCombinedAggTradeServe race the combined aggregate trade stream of symbols, keyed by aggregate trade ID
+func (r *WsRace) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return r.serve(func(env *Environment, conn *WsRaceConn, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		return env.WsCombinedAggTradeServe(symbols, func(event *WsAggTradeEvent) {
+			r.race.Deliver(conn, event.Symbol, event.AggregateTradeID, func() {
+				handler(event)
+			})
+		}, errHandler)
+	}, errHandler)
+}
+
+// WsCombinedDiffDepthServe race the combined diff depth stream of symbols, keyed by final update ID
+func (r *WsRace) WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return r.serve(func(env *Environment, conn *WsRaceConn, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		return env.WsCombinedDiffDepthServe(symbols, func(event *WsDepthEvent) {
+			r.race.Deliver(conn, event.Symbol, event.LastUpdateID, func() {
+				handler(event)
+			})
+		}, errHandler)
+	}, errHandler)
+}
+
+type wsRaceServeFunc func(env *Environment, conn *WsRaceConn, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+
+func (r *WsRace) serve(serve wsRaceServeFunc, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return r.race.Serve(func(conn *WsRaceConn, dialer *websocket.Dialer, errHandler func(err error)) (chan struct{}, chan struct{}, error) {
+		env := *r.env
+		if dialer != nil {
+			env.Dialer = dialer
+		}
+		env.Metrics = common.WsMetricsInstance(env.Metrics, conn.Name)
+		return serve(&env, conn, errHandler)
+	}, errHandler)
+}
@@ -0,0 +1,137 @@
+package futures
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsRaceTestSuite struct {
+	suite.Suite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+	handlers    []WsHandler
+	kills       []chan struct{}
+	cfgs        []*WsConfig
+}
+
+func TestWsRace(t *testing.T) {
+	suite.Run(t, new(wsRaceTestSuite))
+}
+
+func (s *wsRaceTestSuite) SetupTest() {
+	s.origWsServe = wsServe
+	s.handlers = nil
+	s.kills = nil
+	s.cfgs = nil
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		kill := make(chan struct{})
+		s.handlers = append(s.handlers, handler)
+		s.kills = append(s.kills, kill)
+		s.cfgs = append(s.cfgs, cfg)
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			select {
+			case <-stopC:
+			case <-kill:
+			}
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}
+}
+
+func (s *wsRaceTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+}
+
+func (s *wsRaceTestSuite) TestWsCombinedAggTradeServe() {
+	trade := func(id int64) []byte {
+		return []byte(fmt.Sprintf(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","s":"BTCUSDT","a":%d}}`, id))
+	}
+	race := NewWsRace("", "", "")
+	var delivered []int64
+	doneC, stopC, err := race.WsCombinedAggTradeServe([]string{"BTCUSDT"}, func(event *WsAggTradeEvent) {
+		delivered = append(delivered, event.AggregateTradeID)
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.Require().Len(s.handlers, 3)
+
+	s.handlers[0](trade(1))
+	s.handlers[1](trade(1))
+	s.handlers[1](trade(2))
+	time.Sleep(2 * time.Millisecond)
+	s.handlers[0](trade(2))
+	s.handlers[2](trade(2))
+	s.Require().Equal([]int64{1, 2}, delivered)
+
+	// the race keeps delivering once a connection dies
+	close(s.kills[1])
+	s.Require().Eventually(func() bool {
+		return !race.Conns()[1].Alive
+	}, time.Second, time.Millisecond)
+	s.handlers[2](trade(3))
+	s.handlers[0](trade(3))
+	s.Require().Equal([]int64{1, 2, 3}, delivered)
+
+	conns := race.Conns()
+	s.Require().Equal(int64(3), conns[0].Events)
+	s.Require().Equal(int64(1), conns[0].Wins)
+	s.Require().InDelta(1.0/3, conns[0].WinRate(), 1e-9)
+	s.Require().True(conns[0].Lag > time.Millisecond)
+	s.Require().Equal(int64(1), conns[1].Wins)
+	s.Require().Equal(int64(1), conns[2].Wins)
+	s.Require().True(conns[2].Alive)
+
+	close(stopC)
+	<-doneC
+	for _, conn := range race.Conns() {
+		s.Require().False(conn.Alive)
+	}
+}
+
+func (s *wsRaceTestSuite) TestWsCombinedBookTickerServe() {
+	ticker := func(symbol string, id int64) []byte {
+		return []byte(fmt.Sprintf(`{"stream":"bookTicker","data":{"e":"bookTicker","s":"%s","u":%d}}`, symbol, id))
+	}
+	race := NewWsRace("", "")
+	var delivered []string
+	doneC, stopC, err := race.WsCombinedBookTickerServe([]string{"BTCUSDT", "ETHUSDT"}, func(event *WsBookTickerEvent) {
+		delivered = append(delivered, fmt.Sprintf("%s:%d", event.Symbol, event.UpdateID))
+	}, func(err error) {})
+	s.Require().NoError(err)
+
+	s.handlers[0](ticker("BTCUSDT", 10))
+	s.handlers[1](ticker("ETHUSDT", 10))
+	s.handlers[1](ticker("BTCUSDT", 10))
+	s.handlers[0](ticker("ETHUSDT", 10))
+	s.handlers[0](ticker("BTCUSDT", 9))
+	s.Require().Equal([]string{"BTCUSDT:10", "ETHUSDT:10"}, delivered)
+
+	close(stopC)
+	<-doneC
+}
+
+func (s *wsRaceTestSuite) TestMetricsNames() {
+	metrics := common.NewPrometheusWsMetrics("binance")
+	env := MainnetEnvironment()
+	env.Metrics = metrics
+	race := NewWsRace("", "127.0.0.1").Name("btc").Environment(env)
+	doneC, stopC, err := race.WsCombinedBookTickerServe([]string{"BTCUSDT"}, func(event *WsBookTickerEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+
+	for _, cfg := range s.cfgs {
+		cfg.Metrics.WsConnected(cfg.name())
+	}
+	stats := metrics.Stats()
+	s.Require().Len(stats, 2)
+	s.Require().Equal("bookTicker:1#btc0", stats[0].Conn)
+	s.Require().Equal("bookTicker:1#btc1@127.0.0.1", stats[1].Conn)
+	s.Require().Equal(int64(0), stats[1].Reconnects)
+
+	close(stopC)
+	<-doneC
+}
@@ -0,0 +1,137 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ipPoolTestSuite struct {
+	suite.Suite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+	endpoints   []string
+	handlers    []WsHandler
+}
+
+func TestIPPool(t *testing.T) {
+	suite.Run(t, new(ipPoolTestSuite))
+}
+
+func (s *ipPoolTestSuite) SetupTest() {
+	s.origWsServe = wsServe
+	s.endpoints = nil
+	s.handlers = nil
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.Require().NotNil(cfg.Dialer)
+		s.endpoints = append(s.endpoints, cfg.Endpoint)
+		s.handlers = append(s.handlers, handler)
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			<-stopC
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}
+}
+
+func (s *ipPoolTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+}
+
+func (s *ipPoolTestSuite) environment() *Environment {
+	env := MainnetEnvironment()
+	env.WsURL = "wss://127.0.0.1/ws"
+	env.CombinedURL = "wss://127.0.0.1/stream?streams="
+	return env
+}
+
+func (s *ipPoolTestSuite) TestRoundTrip() {
+	var remoteIPs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		remoteIPs = append(remoteIPs, host)
+		w.Header().Set("X-Mbx-Used-Weight-1m", "6000")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	pool, err := NewIPPool("127.0.0.1", "127.0.0.2")
+	s.Require().NoError(err)
+	env := s.environment()
+	env.BaseURL = server.URL
+	client := NewClientWithIPPool("apiKey", "secretKey", pool.Environment(env).WeightLimit(6000))
+
+	s.Require().NoError(client.NewPingService().Do(context.Background()))
+	s.Require().NoError(client.NewPingService().Do(context.Background()))
+
+	// the first IP used up its weight so the second one takes over
+	s.Require().Equal([]string{"127.0.0.1", "127.0.0.2"}, remoteIPs)
+	stats := pool.Stats()
+	s.Require().Len(stats, 2)
+	s.Require().Equal(int64(6000), stats[0].UsedWeight)
+	s.Require().Equal(int64(1), stats[1].Requests)
+}
+
+func (s *ipPoolTestSuite) TestWsCombinedBookTickerServe() {
+	event := func(updateID int64) []byte {
+		return []byte(fmt.Sprintf(`{"stream":"btcusdt@bookTicker","data":{"u":%d,"s":"BTCUSDT"}}`, updateID))
+	}
+	pool, err := NewIPPool("127.0.0.1", "127.0.0.2")
+	s.Require().NoError(err)
+
+	var delivered []int64
+	doneC, stopC, err := pool.Environment(s.environment()).Name("spot").WsCombinedBookTickerServe([]string{"BTCUSDT"}, func(event *WsBookTickerEvent) {
+		delivered = append(delivered, event.UpdateID)
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{
+		"wss://127.0.0.1/stream?streams=btcusdt@bookTicker",
+		"wss://127.0.0.1/stream?streams=btcusdt@bookTicker",
+	}, s.endpoints)
+	paths := pool.WsPaths()
+	s.Require().Len(paths, 2)
+	s.Require().Equal("spot0@127.0.0.1", paths[0].Name)
+	s.Require().Equal("spot1@127.0.0.2", paths[1].Name)
+
+	// each update is delivered once, from whichever path receives it first
+	s.handlers[0](event(1))
+	s.handlers[1](event(1))
+	s.handlers[1](event(2))
+	s.handlers[0](event(2))
+	s.handlers[0](event(3))
+	s.Require().Equal([]int64{1, 2, 3}, delivered)
+
+	close(stopC)
+	<-doneC
+	s.Require().Empty(pool.WsPaths())
+}
+
+func (s *ipPoolTestSuite) TestWsUserDataServe() {
+	pool, err := NewIPPool("127.0.0.1", "127.0.0.2")
+	s.Require().NoError(err)
+
+	var delivered []int64
+	doneC, stopC, err := pool.Environment(s.environment()).WsUserDataServe("listenKey", func(event *WsUserDataEvent) {
+		delivered = append(delivered, event.Time)
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"wss://127.0.0.1/ws/listenKey"}, s.endpoints)
+
+	eventTime := time.Now().UnixNano() / int64(time.Millisecond)
+	s.handlers[0]([]byte(fmt.Sprintf(`{"e":"balanceUpdate","E":%d,"a":"BTC","d":"1.0"}`, eventTime)))
+	s.Require().Equal([]int64{eventTime}, delivered)
+	paths := pool.WsPaths()
+	s.Require().Len(paths, 1)
+	s.Require().Equal("pool0@127.0.0.1", paths[0].Name)
+	s.Require().Equal(int64(1), paths[0].Events)
+
+	close(stopC)
+	<-doneC
+}
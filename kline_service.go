@@ -14,6 +14,7 @@ type KlinesService struct {
 	limit     *int
 	startTime *int64
 	endTime   *int64
+	timeZone  *string
 }
 
 // Symbol set symbol
@@ -46,6 +47,12 @@ func (s *KlinesService) EndTime(endTime int64) *KlinesService {
 	return s
 }
 
+// TimeZone set timeZone, the klines are interpreted in this offset from UTC, e.g. "-1:00" or "05:45", defaults to 0
+func (s *KlinesService) TimeZone(timeZone string) *KlinesService {
+	s.timeZone = &timeZone
+	return s
+}
+
 // Do send request
 func (s *KlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
 	r := &request{
@@ -63,10 +70,91 @@ func (s *KlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*K
 	if s.endTime != nil {
 		r.setParam("endTime", *s.endTime)
 	}
+	if s.timeZone != nil {
+		r.setParam("timeZone", *s.timeZone)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Kline{}, err
+	}
+	return parseKlines(data)
+}
+
+// UiKlinesService list klines modified for the presentation of candlestick charts
+type UiKlinesService struct {
+	c         *Client
+	symbol    string
+	interval  string
+	limit     *int
+	startTime *int64
+	endTime   *int64
+	timeZone  *string
+}
+
+// Symbol set symbol
+func (s *UiKlinesService) Symbol(symbol string) *UiKlinesService {
+	s.symbol = symbol
+	return s
+}
+
+// Interval set interval
+func (s *UiKlinesService) Interval(interval string) *UiKlinesService {
+	s.interval = interval
+	return s
+}
+
+// Limit set limit
+func (s *UiKlinesService) Limit(limit int) *UiKlinesService {
+	s.limit = &limit
+	return s
+}
+
+// StartTime set startTime
+func (s *UiKlinesService) StartTime(startTime int64) *UiKlinesService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *UiKlinesService) EndTime(endTime int64) *UiKlinesService {
+	s.endTime = &endTime
+	return s
+}
+
+// TimeZone set timeZone, the klines are interpreted in this offset from UTC, e.g. "-1:00" or "05:45", defaults to 0
+func (s *UiKlinesService) TimeZone(timeZone string) *UiKlinesService {
+	s.timeZone = &timeZone
+	return s
+}
+
+// Do send request
+func (s *UiKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/uiKlines",
+	}
+	r.setParam("symbol", s.symbol)
+	r.setParam("interval", s.interval)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.timeZone != nil {
+		r.setParam("timeZone", *s.timeZone)
+	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*Kline{}, err
 	}
+	return parseKlines(data)
+}
+
+func parseKlines(data []byte) (res []*Kline, err error) {
 	j, err := newJSON(data)
 	if err != nil {
 		return []*Kline{}, err
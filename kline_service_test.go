@@ -98,6 +98,75 @@ func (s *klineServiceTestSuite) TestKlines() {
 	s.assertKlineEqual(kline2, klines[1])
 }
 
+func (s *klineServiceTestSuite) TestUiKlines() {
+	data := []byte(`[
+        [
+            1499040000000,
+            "0.01634790",
+            "0.80000000",
+            "0.01575800",
+            "0.01577100",
+            "148976.11427815",
+            1499644799999,
+            "2434.19055334",
+            308,
+            "1756.87402397",
+            "28.46694368",
+            "0"
+        ]
+    ]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "LTCBTC"
+	interval := "1d"
+	timeZone := "08:00"
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol":   symbol,
+			"interval": interval,
+			"timeZone": timeZone,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	klines, err := s.client.NewUiKlinesService().Symbol(symbol).
+		Interval(interval).TimeZone(timeZone).Do(newContext())
+	s.r().NoError(err)
+	s.Len(klines, 1)
+	s.assertKlineEqual(&Kline{
+		OpenTime:                 1499040000000,
+		Open:                     "0.01634790",
+		High:                     "0.80000000",
+		Low:                      "0.01575800",
+		Close:                    "0.01577100",
+		Volume:                   "148976.11427815",
+		CloseTime:                1499644799999,
+		QuoteAssetVolume:         "2434.19055334",
+		TradeNum:                 308,
+		TakerBuyBaseAssetVolume:  "1756.87402397",
+		TakerBuyQuoteAssetVolume: "28.46694368",
+	}, klines[0])
+}
+
+func (s *klineServiceTestSuite) TestKlinesTimeZone() {
+	data := []byte(`[]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol":   "LTCBTC",
+			"interval": "1d",
+			"timeZone": "-1:00",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	klines, err := s.client.NewKlinesService().Symbol("LTCBTC").
+		Interval("1d").TimeZone("-1:00").Do(newContext())
+	s.r().NoError(err)
+	s.Len(klines, 0)
+}
+
 func (s *klineServiceTestSuite) assertKlineEqual(e, a *Kline) {
 	r := s.r()
 	r.Equal(e.OpenTime, a.OpenTime, "OpenTime")
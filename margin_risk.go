@@ -0,0 +1,396 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Margin levels at which the exchange acts on a margin account, the initial level is
+// the one required to borrow and depends on the leverage of the account
+const (
+	MarginLevelInitial     = 1.5
+	MarginLevelMarginCall  = 1.3
+	MarginLevelLiquidation = 1.1
+)
+
+// ErrInsufficientMarginBalance is returned when a simulated order spends more than the free balance
+// and does not borrow the difference
+var ErrInsufficientMarginBalance = errors.New("insufficient margin balance")
+
+// MarginRiskAsset define the balance of an asset in a margin risk simulation
+type MarginRiskAsset struct {
+	Asset    string
+	Free     float64
+	Locked   float64
+	Borrowed float64
+	Interest float64
+	// Price is in the quote asset of the simulation
+	Price float64
+	// BorrowLimit is the amount of the asset the account can borrow in total, 0 when unknown
+	BorrowLimit float64
+}
+
+// Total return the free and locked balance
+func (a *MarginRiskAsset) Total() float64 {
+	return a.Free + a.Locked
+}
+
+// Liability return the borrowed amount and interest
+func (a *MarginRiskAsset) Liability() float64 {
+	return a.Borrowed + a.Interest
+}
+
+// MarginOrderProposal define an order to simulate before sending it with CreateMarginOrderService,
+// commissions are not simulated
+type MarginOrderProposal struct {
+	BaseAsset  string
+	QuoteAsset string
+	Side       SideType
+	// Quantity is in the base asset
+	Quantity float64
+	// Price is the expected fill price in the quote asset
+	Price          float64
+	SideEffectType SideEffectType
+}
+
+type marginRiskAssets map[string]*MarginRiskAsset
+
+func (m marginRiskAssets) clone() marginRiskAssets {
+	res := make(marginRiskAssets, len(m))
+	for k, v := range m {
+		asset := *v
+		res[k] = &asset
+	}
+	return res
+}
+
+func (m marginRiskAssets) totalAsset() (total float64) {
+	for _, a := range m {
+		total += a.Total() * a.Price
+	}
+	return total
+}
+
+func (m marginRiskAssets) totalLiability() (total float64) {
+	for _, a := range m {
+		total += a.Liability() * a.Price
+	}
+	return total
+}
+
+func (m marginRiskAssets) marginLevel() float64 {
+	liability := m.totalLiability()
+	if liability <= 0 {
+		return math.Inf(1)
+	}
+	return m.totalAsset() / liability
+}
+
+// liquidationPrice return the price of asset at which the margin level falls to level,
+// the prices of the other assets being unchanged
+func (m marginRiskAssets) liquidationPrice(asset string, level float64) float64 {
+	a, ok := m[asset]
+	if !ok {
+		return 0
+	}
+	otherAsset := m.totalAsset() - a.Total()*a.Price
+	otherLiability := m.totalLiability() - a.Liability()*a.Price
+	// otherAsset + total*p = level*(otherLiability + liability*p)
+	d := a.Total() - level*a.Liability()
+	if d == 0 {
+		return 0
+	}
+	price := (level*otherLiability - otherAsset) / d
+	if price <= 0 {
+		return 0
+	}
+	return price
+}
+
+func (m marginRiskAssets) maxBorrow(asset string, level float64) float64 {
+	a, ok := m[asset]
+	if !ok || a.Price <= 0 || level <= 1 {
+		return 0
+	}
+	// borrowing adds the same value to assets and liabilities:
+	// (totalAsset + x) / (totalLiability + x) >= level
+	amount := (m.totalAsset() - level*m.totalLiability()) / (level - 1) / a.Price
+	if a.BorrowLimit > 0 {
+		amount = math.Min(amount, a.BorrowLimit-a.Borrowed)
+	}
+	return math.Max(amount, 0)
+}
+
+func (m marginRiskAssets) apply(order *MarginOrderProposal) error {
+	spent, spentAmount := order.QuoteAsset, order.Quantity*order.Price
+	received, receivedAmount := order.BaseAsset, order.Quantity
+	if order.Side == SideTypeSell {
+		spent, spentAmount, received, receivedAmount = received, receivedAmount, spent, spentAmount
+	}
+	from, ok := m[spent]
+	if !ok {
+		return fmt.Errorf("no price for margin asset %s", spent)
+	}
+	to, ok := m[received]
+	if !ok {
+		return fmt.Errorf("no price for margin asset %s", received)
+	}
+	if from.Free < spentAmount {
+		if order.SideEffectType != SideEffectTypeMarginBuy {
+			return ErrInsufficientMarginBalance
+		}
+		from.Borrowed += spentAmount - from.Free
+		from.Free = spentAmount
+	}
+	from.Free -= spentAmount
+	if order.SideEffectType == SideEffectTypeAutoRepay {
+		// interest is repaid before the principal
+		repaid := math.Min(receivedAmount, to.Interest)
+		to.Interest -= repaid
+		receivedAmount -= repaid
+		repaid = math.Min(receivedAmount, to.Borrowed)
+		to.Borrowed -= repaid
+		receivedAmount -= repaid
+	}
+	to.Free += receivedAmount
+	return nil
+}
+
+// CrossMarginRisk simulate the margin level of a cross margin account, values are in QuoteAsset
+type CrossMarginRisk struct {
+	QuoteAsset string
+	Assets     map[string]*MarginRiskAsset
+}
+
+// NewCrossMarginRisk init a simulation from the account and the price indexes of its assets
+// against quoteAsset, an error is returned when an asset held or borrowed has no price
+func NewCrossMarginRisk(account *MarginAccount, quoteAsset string, indexes []*MarginPriceIndex) (*CrossMarginRisk, error) {
+	prices := marginPrices(quoteAsset, indexes)
+	res := &CrossMarginRisk{
+		QuoteAsset: quoteAsset,
+		Assets:     make(map[string]*MarginRiskAsset, len(account.UserAssets)),
+	}
+	for _, a := range account.UserAssets {
+		asset := &MarginRiskAsset{
+			Asset:    a.Asset,
+			Free:     parseFloat(a.Free),
+			Locked:   parseFloat(a.Locked),
+			Borrowed: parseFloat(a.Borrowed),
+			Interest: parseFloat(a.Interest),
+		}
+		price, ok := prices[a.Asset]
+		if !ok {
+			if asset.Total() == 0 && asset.Liability() == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("no price for margin asset %s", a.Asset)
+		}
+		asset.Price = price
+		res.Assets[a.Asset] = asset
+	}
+	if _, ok := res.Assets[quoteAsset]; !ok {
+		res.Assets[quoteAsset] = &MarginRiskAsset{Asset: quoteAsset, Price: 1}
+	}
+	return res, nil
+}
+
+// SetBorrowLimits set the borrow limit of the assets from the cross margin data of the account VIP level
+func (r *CrossMarginRisk) SetBorrowLimits(data CrossMarginData) *CrossMarginRisk {
+	for _, d := range data {
+		if a, ok := r.Assets[d.Coin]; ok {
+			a.BorrowLimit = parseFloat(d.BorrowLimit)
+		}
+	}
+	return r
+}
+
+// TotalAsset return the value of the free and locked balances
+func (r *CrossMarginRisk) TotalAsset() float64 {
+	return marginRiskAssets(r.Assets).totalAsset()
+}
+
+// TotalLiability return the value of the borrowed amounts and interest
+func (r *CrossMarginRisk) TotalLiability() float64 {
+	return marginRiskAssets(r.Assets).totalLiability()
+}
+
+// MarginLevel return total asset over total liability, +Inf without liability
+func (r *CrossMarginRisk) MarginLevel() float64 {
+	return marginRiskAssets(r.Assets).marginLevel()
+}
+
+// Shock return a copy with the price of each asset of moves changed by its relative move, e.g. -0.1 for -10%
+func (r *CrossMarginRisk) Shock(moves map[string]float64) *CrossMarginRisk {
+	res := r.clone()
+	for asset, move := range moves {
+		if a, ok := res.Assets[asset]; ok {
+			a.Price *= 1 + move
+		}
+	}
+	return res
+}
+
+// ShockAll return a copy with the price of every asset but QuoteAsset changed by move
+func (r *CrossMarginRisk) ShockAll(move float64) *CrossMarginRisk {
+	res := r.clone()
+	for _, a := range res.Assets {
+		if a.Asset != r.QuoteAsset {
+			a.Price *= 1 + move
+		}
+	}
+	return res
+}
+
+// LiquidationPrice return the price of asset at which the margin level falls to level,
+// the other prices being unchanged, 0 when no positive price does
+func (r *CrossMarginRisk) LiquidationPrice(asset string, level float64) float64 {
+	return marginRiskAssets(r.Assets).liquidationPrice(asset, level)
+}
+
+// MaxBorrow return the additional amount of asset that can be borrowed while keeping
+// the margin level at or above level, capped by the borrow limit when known
+func (r *CrossMarginRisk) MaxBorrow(asset string, level float64) float64 {
+	return marginRiskAssets(r.Assets).maxBorrow(asset, level)
+}
+
+// ApplyOrder return a copy with order filled
+func (r *CrossMarginRisk) ApplyOrder(order *MarginOrderProposal) (*CrossMarginRisk, error) {
+	res := r.clone()
+	if err := marginRiskAssets(res.Assets).apply(order); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *CrossMarginRisk) clone() *CrossMarginRisk {
+	return &CrossMarginRisk{
+		QuoteAsset: r.QuoteAsset,
+		Assets:     marginRiskAssets(r.Assets).clone(),
+	}
+}
+
+// IsolatedMarginRisk simulate the margin level of an isolated margin pair, values are in QuoteAsset
+type IsolatedMarginRisk struct {
+	Symbol     string
+	BaseAsset  string
+	QuoteAsset string
+	// LiquidationLevel is the margin level the pair is liquidated at, it depends on the leverage
+	// of the pair and is taken from its liquidate rate, MarginLevelLiquidation when not reported
+	LiquidationLevel float64
+	Assets           map[string]*MarginRiskAsset
+}
+
+// NewIsolatedMarginRisk init a simulation of the pair, the index price of the pair is used when index is nil
+func NewIsolatedMarginRisk(pair *IsolatedMarginAsset, index *MarginPriceIndex) *IsolatedMarginRisk {
+	price := pair.IndexPrice
+	if index != nil {
+		price = index.Price
+	}
+	base := isolatedMarginRiskAsset(pair.BaseAsset)
+	base.Price = parseFloat(price)
+	quote := isolatedMarginRiskAsset(pair.QuoteAsset)
+	quote.Price = 1
+	liquidationLevel := parseFloat(pair.LiquidateRate)
+	if liquidationLevel <= 0 {
+		liquidationLevel = MarginLevelLiquidation
+	}
+	return &IsolatedMarginRisk{
+		Symbol:           pair.Symbol,
+		BaseAsset:        base.Asset,
+		QuoteAsset:       quote.Asset,
+		LiquidationLevel: liquidationLevel,
+		Assets: map[string]*MarginRiskAsset{
+			base.Asset:  base,
+			quote.Asset: quote,
+		},
+	}
+}
+
+// Price return the price of BaseAsset
+func (r *IsolatedMarginRisk) Price() float64 {
+	return r.Assets[r.BaseAsset].Price
+}
+
+// TotalAsset return the value of the free and locked balances
+func (r *IsolatedMarginRisk) TotalAsset() float64 {
+	return marginRiskAssets(r.Assets).totalAsset()
+}
+
+// TotalLiability return the value of the borrowed amounts and interest
+func (r *IsolatedMarginRisk) TotalLiability() float64 {
+	return marginRiskAssets(r.Assets).totalLiability()
+}
+
+// MarginLevel return total asset over total liability, +Inf without liability
+func (r *IsolatedMarginRisk) MarginLevel() float64 {
+	return marginRiskAssets(r.Assets).marginLevel()
+}
+
+// Shock return a copy with the price changed by move, e.g. -0.1 for -10%
+func (r *IsolatedMarginRisk) Shock(move float64) *IsolatedMarginRisk {
+	res := r.clone()
+	res.Assets[res.BaseAsset].Price *= 1 + move
+	return res
+}
+
+// LiquidationPrice return the price at which the margin level falls to level, 0 when no positive price does,
+// the LiquidationLevel of the pair is used when level is 0
+func (r *IsolatedMarginRisk) LiquidationPrice(level float64) float64 {
+	if level <= 0 {
+		level = r.LiquidationLevel
+	}
+	return marginRiskAssets(r.Assets).liquidationPrice(r.BaseAsset, level)
+}
+
+// MaxBorrow return the additional amount of asset that can be borrowed while keeping
+// the margin level at or above level
+func (r *IsolatedMarginRisk) MaxBorrow(asset string, level float64) float64 {
+	return marginRiskAssets(r.Assets).maxBorrow(asset, level)
+}
+
+// ApplyOrder return a copy with order filled, the order must be on the pair
+func (r *IsolatedMarginRisk) ApplyOrder(order *MarginOrderProposal) (*IsolatedMarginRisk, error) {
+	if order.BaseAsset != r.BaseAsset || order.QuoteAsset != r.QuoteAsset {
+		return nil, fmt.Errorf("order on %s%s does not match isolated pair %s", order.BaseAsset, order.QuoteAsset, r.Symbol)
+	}
+	res := r.clone()
+	if err := marginRiskAssets(res.Assets).apply(order); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *IsolatedMarginRisk) clone() *IsolatedMarginRisk {
+	res := *r
+	res.Assets = marginRiskAssets(r.Assets).clone()
+	return &res
+}
+
+func isolatedMarginRiskAsset(a IsolatedUserAsset) *MarginRiskAsset {
+	return &MarginRiskAsset{
+		Asset:    a.Asset,
+		Free:     parseFloat(a.Free),
+		Locked:   parseFloat(a.Locked),
+		Borrowed: parseFloat(a.Borrowed),
+		Interest: parseFloat(a.Interest),
+	}
+}
+
+// marginPrices return the price of the assets in quoteAsset from the indexes of <asset><quoteAsset> symbols
+func marginPrices(quoteAsset string, indexes []*MarginPriceIndex) map[string]float64 {
+	prices := map[string]float64{quoteAsset: 1}
+	for _, index := range indexes {
+		if asset := strings.TrimSuffix(index.Symbol, quoteAsset); asset != index.Symbol && asset != "" {
+			prices[asset] = parseFloat(index.Price)
+		}
+	}
+	return prices
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
@@ -0,0 +1,174 @@
+package binance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type marginRiskTestSuite struct {
+	suite.Suite
+}
+
+func TestMarginRisk(t *testing.T) {
+	suite.Run(t, new(marginRiskTestSuite))
+}
+
+func (s *marginRiskTestSuite) newCrossMarginRisk() *CrossMarginRisk {
+	account := &MarginAccount{
+		UserAssets: []UserAsset{
+			{Asset: "BTC", Free: "1", Locked: "0", Borrowed: "0", Interest: "0", NetAsset: "1"},
+			{Asset: "USDT", Free: "0", Locked: "0", Borrowed: "15000", Interest: "0", NetAsset: "-15000"},
+			{Asset: "ETH", Free: "0", Locked: "0", Borrowed: "0", Interest: "0", NetAsset: "0"},
+		},
+	}
+	indexes := []*MarginPriceIndex{
+		{Symbol: "BTCUSDT", Price: "30000", CalcTime: 1562046418000},
+	}
+	risk, err := NewCrossMarginRisk(account, "USDT", indexes)
+	s.Require().NoError(err)
+	return risk
+}
+
+func (s *marginRiskTestSuite) TestCrossMarginLevel() {
+	r := s.Require()
+	risk := s.newCrossMarginRisk()
+	r.Len(risk.Assets, 2)
+	r.Equal(30000.0, risk.TotalAsset())
+	r.Equal(15000.0, risk.TotalLiability())
+	r.Equal(2.0, risk.MarginLevel())
+
+	r.InDelta(1.5, risk.ShockAll(-0.25).MarginLevel(), 1e-9)
+	r.InDelta(1.8, risk.Shock(map[string]float64{"BTC": -0.1}).MarginLevel(), 1e-9)
+	r.Equal(2.0, risk.MarginLevel())
+	r.InDelta(16500, risk.LiquidationPrice("BTC", MarginLevelLiquidation), 1e-9)
+}
+
+func (s *marginRiskTestSuite) TestCrossMarginMaxBorrow() {
+	r := s.Require()
+	risk := s.newCrossMarginRisk()
+	r.InDelta(15000, risk.MaxBorrow("USDT", MarginLevelInitial), 1e-9)
+	r.InDelta(0.5, risk.MaxBorrow("BTC", MarginLevelInitial), 1e-9)
+
+	risk.SetBorrowLimits(CrossMarginData{
+		{VipLevel: 0, Coin: "USDT", Borrowable: true, BorrowLimit: "20000"},
+	})
+	r.InDelta(5000, risk.MaxBorrow("USDT", MarginLevelInitial), 1e-9)
+}
+
+func (s *marginRiskTestSuite) TestCrossMarginApplyOrder() {
+	r := s.Require()
+	risk := s.newCrossMarginRisk()
+	order := &MarginOrderProposal{
+		BaseAsset:  "BTC",
+		QuoteAsset: "USDT",
+		Side:       SideTypeBuy,
+		Quantity:   0.5,
+		Price:      30000,
+	}
+	_, err := risk.ApplyOrder(order)
+	r.Equal(ErrInsufficientMarginBalance, err)
+
+	order.SideEffectType = SideEffectTypeMarginBuy
+	bought, err := risk.ApplyOrder(order)
+	r.NoError(err)
+	r.Equal(30000.0, bought.Assets["USDT"].Borrowed)
+	r.Equal(1.5, bought.Assets["BTC"].Free)
+	r.InDelta(1.5, bought.MarginLevel(), 1e-9)
+	r.Equal(2.0, risk.MarginLevel())
+
+	sold, err := risk.ApplyOrder(&MarginOrderProposal{
+		BaseAsset:      "BTC",
+		QuoteAsset:     "USDT",
+		Side:           SideTypeSell,
+		Quantity:       0.6,
+		Price:          30000,
+		SideEffectType: SideEffectTypeAutoRepay,
+	})
+	r.NoError(err)
+	r.Equal(0.0, sold.Assets["USDT"].Borrowed)
+	r.InDelta(3000, sold.Assets["USDT"].Free, 1e-9)
+	r.True(math.IsInf(sold.MarginLevel(), 1))
+
+	_, err = risk.ApplyOrder(&MarginOrderProposal{BaseAsset: "ETH", QuoteAsset: "USDT", Side: SideTypeBuy, Quantity: 1, Price: 2000})
+	r.EqualError(err, "no price for margin asset ETH")
+}
+
+func (s *marginRiskTestSuite) TestCrossMarginMissingPrice() {
+	account := &MarginAccount{
+		UserAssets: []UserAsset{
+			{Asset: "ETH", Free: "1", Locked: "0", Borrowed: "0", Interest: "0", NetAsset: "1"},
+		},
+	}
+	_, err := NewCrossMarginRisk(account, "USDT", nil)
+	s.Require().EqualError(err, "no price for margin asset ETH")
+}
+
+func (s *marginRiskTestSuite) TestIsolatedMarginLong() {
+	r := s.Require()
+	pair := &IsolatedMarginAsset{
+		Symbol:     "BTCUSDT",
+		BaseAsset:  IsolatedUserAsset{Asset: "BTC", Free: "1", Locked: "0", Borrowed: "0", Interest: "0"},
+		QuoteAsset: IsolatedUserAsset{Asset: "USDT", Free: "0", Locked: "0", Borrowed: "19990", Interest: "10"},
+		IndexPrice: "29000",
+	}
+	risk := NewIsolatedMarginRisk(pair, &MarginPriceIndex{Symbol: "BTCUSDT", Price: "30000"})
+	r.Equal(30000.0, risk.Price())
+	r.Equal(1.5, risk.MarginLevel())
+	r.InDelta(1.35, risk.Shock(-0.1).MarginLevel(), 1e-9)
+	r.InDelta(22000, risk.LiquidationPrice(MarginLevelLiquidation), 1e-9)
+	r.InDelta(0, risk.MaxBorrow("USDT", MarginLevelInitial), 1e-9)
+
+	r.Equal(29000.0, NewIsolatedMarginRisk(pair, nil).Price())
+
+	repaid, err := risk.ApplyOrder(&MarginOrderProposal{
+		BaseAsset:      "BTC",
+		QuoteAsset:     "USDT",
+		Side:           SideTypeSell,
+		Quantity:       0.5,
+		Price:          30000,
+		SideEffectType: SideEffectTypeAutoRepay,
+	})
+	r.NoError(err)
+	r.Equal(0.0, repaid.Assets["USDT"].Interest)
+	r.Equal(5000.0, repaid.Assets["USDT"].Borrowed)
+	r.InDelta(3, repaid.MarginLevel(), 1e-9)
+
+	_, err = risk.ApplyOrder(&MarginOrderProposal{BaseAsset: "ETH", QuoteAsset: "USDT", Side: SideTypeBuy})
+	r.EqualError(err, "order on ETHUSDT does not match isolated pair BTCUSDT")
+}
+
+func (s *marginRiskTestSuite) TestIsolatedMarginShort() {
+	r := s.Require()
+	pair := &IsolatedMarginAsset{
+		Symbol:     "BTCUSDT",
+		BaseAsset:  IsolatedUserAsset{Asset: "BTC", Free: "0", Locked: "0", Borrowed: "1", Interest: "0"},
+		QuoteAsset: IsolatedUserAsset{Asset: "USDT", Free: "45000", Locked: "0", Borrowed: "0", Interest: "0"},
+		IndexPrice: "30000",
+	}
+	risk := NewIsolatedMarginRisk(pair, nil)
+	r.Equal(1.5, risk.MarginLevel())
+	r.InDelta(45000/1.1, risk.LiquidationPrice(MarginLevelLiquidation), 1e-9)
+	r.Less(risk.Shock(0.1).MarginLevel(), risk.MarginLevel())
+}
+
+func (s *marginRiskTestSuite) TestIsolatedMarginLiquidatePrice() {
+	r := s.Require()
+	pair := &IsolatedMarginAsset{
+		Symbol:         "BTCUSDT",
+		BaseAsset:      IsolatedUserAsset{Asset: "BTC", Free: "1", Locked: "0", Borrowed: "0", Interest: "0"},
+		QuoteAsset:     IsolatedUserAsset{Asset: "USDT", Free: "0", Locked: "0", Borrowed: "19990", Interest: "10"},
+		MarginRatio:    "10",
+		IndexPrice:     "30000",
+		LiquidatePrice: "21000.00000000",
+		LiquidateRate:  "1.05000000",
+	}
+	risk := NewIsolatedMarginRisk(pair, nil)
+	r.Equal(1.05, risk.LiquidationLevel)
+	r.InDelta(parseFloat(pair.LiquidatePrice), risk.LiquidationPrice(0), 1e-6)
+	r.InDelta(22000, risk.LiquidationPrice(MarginLevelLiquidation), 1e-9)
+
+	pair.LiquidateRate = ""
+	r.Equal(MarginLevelLiquidation, NewIsolatedMarginRisk(pair, nil).LiquidationLevel)
+}
@@ -8,7 +8,6 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
@@ -285,6 +284,14 @@ type Client struct {
 	Logger     *log.Logger
 	TimeOffset int64
 	do         doFunc
+
+	middlewares []common.APIMiddleware
+}
+
+// Use add middlewares around the REST calls of the client, the first middleware sees the request first
+func (c *Client) Use(middlewares ...common.APIMiddleware) *Client {
+	c.middlewares = append(c.middlewares, middlewares...)
+	return c
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -340,10 +347,13 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
+	// the signature and the listen key never reach the logs
+	debugURL := fullURL
 	if queryString != "" {
+		debugURL = fmt.Sprintf("%s?%s", fullURL, common.RedactQuery(queryString))
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.debug("full url: %s, body: %s", debugURL, common.RedactQuery(bodyString))
 
 	r.fullURL = fullURL
 	r.header = header
@@ -362,28 +372,17 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.debug("request: %s %s, header: %v", req.Method, common.RedactURL(req.URL), common.RedactHeader(req.Header))
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
+	res, err := common.ChainAPIMiddlewares(common.NewAPIHandler(f), c.middlewares...)(req)
 	if err != nil {
 		return []byte{}, &http.Header{}, err
 	}
-	data, err = ioutil.ReadAll(res.Body)
-	if err != nil {
-		return []byte{}, &http.Header{}, err
-	}
-	defer func() {
-		cerr := res.Body.Close()
-		// Only overwrite the retured error if the original error was nil and an
-		// error occurred while closing the body.
-		if err == nil && cerr != nil {
-			err = cerr
-		}
-	}()
-	c.debug("response: %#v", res)
+	data = res.Body
+	c.debug("response header: %v", res.Header)
 	c.debug("response body: %s", string(data))
 	c.debug("response status code: %d", res.StatusCode)
 
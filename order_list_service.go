@@ -0,0 +1,500 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+)
+
+// OrderListLeg define one order of an order list, e.g. the above/below leg of an OCO
+// or the working/pending order of an OTO
+type OrderListLeg struct {
+	orderType       OrderType
+	side            *SideType
+	quantity        *string
+	clientOrderID   *string
+	price           *string
+	stopPrice       *string
+	trailingDelta   *int64
+	icebergQuantity *string
+	timeInForce     *TimeInForceType
+	strategyID      *int64
+	strategyType    *int64
+}
+
+// NewOrderListLeg init an order list leg of orderType
+func NewOrderListLeg(orderType OrderType) *OrderListLeg {
+	return &OrderListLeg{orderType: orderType}
+}
+
+// Side set side, used by the working and pending legs of OTO and OTOCO
+func (l *OrderListLeg) Side(side SideType) *OrderListLeg {
+	l.side = &side
+	return l
+}
+
+// Quantity set quantity, used by the working and pending legs of OTO and OTOCO
+func (l *OrderListLeg) Quantity(quantity string) *OrderListLeg {
+	l.quantity = &quantity
+	return l
+}
+
+// ClientOrderID set clientOrderId of the leg
+func (l *OrderListLeg) ClientOrderID(clientOrderID string) *OrderListLeg {
+	l.clientOrderID = &clientOrderID
+	return l
+}
+
+// Price set price
+func (l *OrderListLeg) Price(price string) *OrderListLeg {
+	l.price = &price
+	return l
+}
+
+// StopPrice set stopPrice
+func (l *OrderListLeg) StopPrice(stopPrice string) *OrderListLeg {
+	l.stopPrice = &stopPrice
+	return l
+}
+
+// TrailingDelta set trailingDelta
+func (l *OrderListLeg) TrailingDelta(trailingDelta int64) *OrderListLeg {
+	l.trailingDelta = &trailingDelta
+	return l
+}
+
+// IcebergQuantity set icebergQty
+func (l *OrderListLeg) IcebergQuantity(icebergQuantity string) *OrderListLeg {
+	l.icebergQuantity = &icebergQuantity
+	return l
+}
+
+// TimeInForce set timeInForce
+func (l *OrderListLeg) TimeInForce(timeInForce TimeInForceType) *OrderListLeg {
+	l.timeInForce = &timeInForce
+	return l
+}
+
+// StrategyID set strategyId
+func (l *OrderListLeg) StrategyID(strategyID int64) *OrderListLeg {
+	l.strategyID = &strategyID
+	return l
+}
+
+// StrategyType set strategyType, values smaller than 1000000 are reserved
+func (l *OrderListLeg) StrategyType(strategyType int64) *OrderListLeg {
+	l.strategyType = &strategyType
+	return l
+}
+
+// params add the leg to m with every key prefixed, e.g. aboveType, workingPrice
+func (l *OrderListLeg) params(prefix string, m params) {
+	if l == nil {
+		return
+	}
+	m[prefix+"Type"] = l.orderType
+	if l.side != nil {
+		m[prefix+"Side"] = *l.side
+	}
+	if l.quantity != nil {
+		m[prefix+"Quantity"] = *l.quantity
+	}
+	if l.clientOrderID != nil {
+		m[prefix+"ClientOrderId"] = *l.clientOrderID
+	}
+	if l.price != nil {
+		m[prefix+"Price"] = *l.price
+	}
+	if l.stopPrice != nil {
+		m[prefix+"StopPrice"] = *l.stopPrice
+	}
+	if l.trailingDelta != nil {
+		m[prefix+"TrailingDelta"] = *l.trailingDelta
+	}
+	if l.icebergQuantity != nil {
+		m[prefix+"IcebergQty"] = *l.icebergQuantity
+	}
+	if l.timeInForce != nil {
+		m[prefix+"TimeInForce"] = *l.timeInForce
+	}
+	if l.strategyID != nil {
+		m[prefix+"StrategyId"] = *l.strategyID
+	}
+	if l.strategyType != nil {
+		m[prefix+"StrategyType"] = *l.strategyType
+	}
+}
+
+// CreateOrderListOCOService place a one-cancels-the-other order list,
+// one leg is placed above the last price and the other below
+type CreateOrderListOCOService struct {
+	c                 *Client
+	symbol            string
+	listClientOrderID *string
+	side              SideType
+	quantity          string
+	above             *OrderListLeg
+	below             *OrderListLeg
+	newOrderRespType  *NewOrderRespType
+}
+
+// Symbol set symbol
+func (s *CreateOrderListOCOService) Symbol(symbol string) *CreateOrderListOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateOrderListOCOService) ListClientOrderID(listClientOrderID string) *CreateOrderListOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// Side set side of both legs
+func (s *CreateOrderListOCOService) Side(side SideType) *CreateOrderListOCOService {
+	s.side = side
+	return s
+}
+
+// Quantity set quantity of both legs
+func (s *CreateOrderListOCOService) Quantity(quantity string) *CreateOrderListOCOService {
+	s.quantity = quantity
+	return s
+}
+
+// Above set the leg above the last price, one of STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER, TAKE_PROFIT, TAKE_PROFIT_LIMIT
+func (s *CreateOrderListOCOService) Above(above *OrderListLeg) *CreateOrderListOCOService {
+	s.above = above
+	return s
+}
+
+// Below set the leg below the last price, one of STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER, TAKE_PROFIT, TAKE_PROFIT_LIMIT
+func (s *CreateOrderListOCOService) Below(below *OrderListLeg) *CreateOrderListOCOService {
+	s.below = below
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderListOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+func (s *CreateOrderListOCOService) params() params {
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+	}
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	s.above.params("above", m)
+	s.below.params("below", m)
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	return m
+}
+
+// Do send request
+func (s *CreateOrderListOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	return createOrderList(ctx, s.c, "/api/v3/orderList/oco", s.params(), opts...)
+}
+
+// CreateOrderListOTOService place a one-triggers-the-other order list,
+// the pending order is placed once the working order is fully filled
+type CreateOrderListOTOService struct {
+	c                 *Client
+	symbol            string
+	listClientOrderID *string
+	working           *OrderListLeg
+	pending           *OrderListLeg
+	newOrderRespType  *NewOrderRespType
+}
+
+// Symbol set symbol
+func (s *CreateOrderListOTOService) Symbol(symbol string) *CreateOrderListOTOService {
+	s.symbol = symbol
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateOrderListOTOService) ListClientOrderID(listClientOrderID string) *CreateOrderListOTOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// Working set the working order, LIMIT or LIMIT_MAKER
+func (s *CreateOrderListOTOService) Working(working *OrderListLeg) *CreateOrderListOTOService {
+	s.working = working
+	return s
+}
+
+// Pending set the pending order triggered by the working order
+func (s *CreateOrderListOTOService) Pending(pending *OrderListLeg) *CreateOrderListOTOService {
+	s.pending = pending
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderListOTOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOTOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+func (s *CreateOrderListOTOService) params() params {
+	m := params{
+		"symbol": s.symbol,
+	}
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	s.working.params("working", m)
+	s.pending.params("pending", m)
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	return m
+}
+
+// Do send request
+func (s *CreateOrderListOTOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	return createOrderList(ctx, s.c, "/api/v3/orderList/oto", s.params(), opts...)
+}
+
+// CreateOrderListOTOCOService place a one-triggers-a-one-cancels-the-other order list,
+// the pending OCO is placed once the working order is fully filled
+type CreateOrderListOTOCOService struct {
+	c                 *Client
+	symbol            string
+	listClientOrderID *string
+	working           *OrderListLeg
+	pendingSide       SideType
+	pendingQuantity   string
+	pendingAbove      *OrderListLeg
+	pendingBelow      *OrderListLeg
+	newOrderRespType  *NewOrderRespType
+}
+
+// Symbol set symbol
+func (s *CreateOrderListOTOCOService) Symbol(symbol string) *CreateOrderListOTOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateOrderListOTOCOService) ListClientOrderID(listClientOrderID string) *CreateOrderListOTOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// Working set the working order, LIMIT or LIMIT_MAKER
+func (s *CreateOrderListOTOCOService) Working(working *OrderListLeg) *CreateOrderListOTOCOService {
+	s.working = working
+	return s
+}
+
+// PendingSide set side of both pending legs
+func (s *CreateOrderListOTOCOService) PendingSide(pendingSide SideType) *CreateOrderListOTOCOService {
+	s.pendingSide = pendingSide
+	return s
+}
+
+// PendingQuantity set quantity of both pending legs
+func (s *CreateOrderListOTOCOService) PendingQuantity(pendingQuantity string) *CreateOrderListOTOCOService {
+	s.pendingQuantity = pendingQuantity
+	return s
+}
+
+// PendingAbove set the pending leg above the last price
+func (s *CreateOrderListOTOCOService) PendingAbove(pendingAbove *OrderListLeg) *CreateOrderListOTOCOService {
+	s.pendingAbove = pendingAbove
+	return s
+}
+
+// PendingBelow set the pending leg below the last price
+func (s *CreateOrderListOTOCOService) PendingBelow(pendingBelow *OrderListLeg) *CreateOrderListOTOCOService {
+	s.pendingBelow = pendingBelow
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderListOTOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOTOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+func (s *CreateOrderListOTOCOService) params() params {
+	m := params{
+		"symbol":          s.symbol,
+		"pendingSide":     s.pendingSide,
+		"pendingQuantity": s.pendingQuantity,
+	}
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	s.working.params("working", m)
+	s.pendingAbove.params("pendingAbove", m)
+	s.pendingBelow.params("pendingBelow", m)
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	return m
+}
+
+// Do send request
+func (s *CreateOrderListOTOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	return createOrderList(ctx, s.c, "/api/v3/orderList/otoco", s.params(), opts...)
+}
+
+func createOrderList(ctx context.Context, c *Client, endpoint string, m params, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(m)
+	data, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateOrderListResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateOrderListResponse define create order list response with the report of each leg
+type CreateOrderListResponse struct {
+	OrderListID       int64             `json:"orderListId"`
+	ContingencyType   string            `json:"contingencyType"`
+	ListStatusType    string            `json:"listStatusType"`
+	ListOrderStatus   string            `json:"listOrderStatus"`
+	ListClientOrderID string            `json:"listClientOrderId"`
+	TransactionTime   int64             `json:"transactionTime"`
+	Symbol            string            `json:"symbol"`
+	Orders            []*OCOOrder       `json:"orders"`
+	OrderReports      []*OCOOrderReport `json:"orderReports"`
+}
+
+// GetOrderListService get an order list by orderListId or origClientOrderId
+type GetOrderListService struct {
+	c                 *Client
+	orderListID       *int64
+	origClientOrderID *string
+}
+
+// OrderListID set orderListId
+func (s *GetOrderListService) OrderListID(orderListID int64) *GetOrderListService {
+	s.orderListID = &orderListID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderId, the listClientOrderId of the order list
+func (s *GetOrderListService) OrigClientOrderID(origClientOrderID string) *GetOrderListService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *GetOrderListService) Do(ctx context.Context, opts ...RequestOption) (res *OrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/orderList",
+		secType:  secTypeSigned,
+	}
+	if s.orderListID != nil {
+		r.setParam("orderListId", *s.orderListID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(OrderList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListOrderListsService list all order lists
+type ListOrderListsService struct {
+	c         *Client
+	fromID    *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// FromID set fromId, startTime and endTime can not be used with it
+func (s *ListOrderListsService) FromID(fromID int64) *ListOrderListsService {
+	s.fromID = &fromID
+	return s
+}
+
+// StartTime set startTime
+func (s *ListOrderListsService) StartTime(startTime int64) *ListOrderListsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListOrderListsService) EndTime(endTime int64) *ListOrderListsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit, default 500, max 1000
+func (s *ListOrderListsService) Limit(limit int) *ListOrderListsService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListOrderListsService) Do(ctx context.Context, opts ...RequestOption) (res []*OrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/allOrderList",
+		secType:  secTypeSigned,
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OrderList{}, err
+	}
+	res = make([]*OrderList, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*OrderList{}, err
+	}
+	return res, nil
+}
+
+// OrderList define order list info
+type OrderList struct {
+	OrderListID       int64       `json:"orderListId"`
+	ContingencyType   string      `json:"contingencyType"`
+	ListStatusType    string      `json:"listStatusType"`
+	ListOrderStatus   string      `json:"listOrderStatus"`
+	ListClientOrderID string      `json:"listClientOrderId"`
+	TransactionTime   int64       `json:"transactionTime"`
+	Symbol            string      `json:"symbol"`
+	Orders            []*OCOOrder `json:"orders"`
+}
@@ -0,0 +1,258 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderListServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderListService(t *testing.T) {
+	suite.Run(t, new(orderListServiceTestSuite))
+}
+
+func (s *orderListServiceTestSuite) TestCreateOrderListOCO() {
+	data := []byte(`{
+		"orderListId": 1,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "lH1YDkuQKWiXVXHPSKYEIp",
+		"transactionTime": 1710485608839,
+		"symbol": "LTCBTC",
+		"orders": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 10,
+				"clientOrderId": "44nZvqpemY7sVYgPYbvPih"
+			},
+			{
+				"symbol": "LTCBTC",
+				"orderId": 11,
+				"clientOrderId": "NuMp0nVYnciDiFmVqfpBqK"
+			}
+		],
+		"orderReports": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 10,
+				"orderListId": 1,
+				"clientOrderId": "44nZvqpemY7sVYgPYbvPih",
+				"transactionTime": 1710485608839,
+				"price": "1.00000000",
+				"origQty": "5.00000000",
+				"executedQty": "0.00000000",
+				"cummulativeQuoteQty": "0.00000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "STOP_LOSS_LIMIT",
+				"side": "SELL",
+				"stopPrice": "1.00000000"
+			},
+			{
+				"symbol": "LTCBTC",
+				"orderId": 11,
+				"orderListId": 1,
+				"clientOrderId": "NuMp0nVYnciDiFmVqfpBqK",
+				"transactionTime": 1710485608839,
+				"price": "3.00000000",
+				"origQty": "5.00000000",
+				"executedQty": "0.00000000",
+				"cummulativeQuoteQty": "0.00000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "LIMIT_MAKER",
+				"side": "SELL"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":             "LTCBTC",
+			"side":               SideTypeSell,
+			"quantity":           "5",
+			"aboveType":          OrderTypeLimitMaker,
+			"abovePrice":         "3",
+			"belowType":          OrderTypeStopLossLimit,
+			"belowPrice":         "1",
+			"belowStopPrice":     "1",
+			"belowTimeInForce":   TimeInForceTypeGTC,
+			"belowClientOrderId": "myStop",
+			"newOrderRespType":   NewOrderRespTypeFULL,
+			"listClientOrderId":  "lH1YDkuQKWiXVXHPSKYEIp",
+			"aboveClientOrderId": "myTakeProfit",
+			"aboveIcebergQty":    "1",
+			"belowTrailingDelta": int64(100),
+			"belowStrategyType":  int64(1000000),
+			"aboveStrategyId":    int64(7),
+			"belowStrategyId":    int64(7),
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateOrderListOCOService().Symbol("LTCBTC").
+		ListClientOrderID("lH1YDkuQKWiXVXHPSKYEIp").Side(SideTypeSell).Quantity("5").
+		Above(NewOrderListLeg(OrderTypeLimitMaker).Price("3").ClientOrderID("myTakeProfit").
+			IcebergQuantity("1").StrategyID(7)).
+		Below(NewOrderListLeg(OrderTypeStopLossLimit).Price("1").StopPrice("1").
+			TimeInForce(TimeInForceTypeGTC).ClientOrderID("myStop").TrailingDelta(100).
+			StrategyID(7).StrategyType(1000000)).
+		NewOrderRespType(NewOrderRespTypeFULL).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1), res.OrderListID)
+	r.Equal("OCO", res.ContingencyType)
+	r.Len(res.Orders, 2)
+	r.Len(res.OrderReports, 2)
+	e := &OCOOrderReport{
+		Symbol:                   "LTCBTC",
+		OrderID:                  10,
+		OrderListID:              1,
+		ClientOrderID:            "44nZvqpemY7sVYgPYbvPih",
+		TransactionTime:          1710485608839,
+		Price:                    "1.00000000",
+		OrigQuantity:             "5.00000000",
+		ExecutedQuantity:         "0.00000000",
+		CummulativeQuoteQuantity: "0.00000000",
+		Status:                   OrderStatusTypeNew,
+		TimeInForce:              TimeInForceTypeGTC,
+		Type:                     OrderTypeStopLossLimit,
+		Side:                     SideTypeSell,
+		StopPrice:                "1.00000000",
+	}
+	r.Equal(e, res.OrderReports[0])
+}
+
+func (s *orderListServiceTestSuite) TestCreateOrderListOTOCO() {
+	data := []byte(`{
+		"orderListId": 2,
+		"contingencyType": "OTO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "RumwQpBaDctlUu5jyG5rs0",
+		"transactionTime": 1712291372842,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 20, "clientOrderId": "working"},
+			{"symbol": "LTCBTC", "orderId": 21, "clientOrderId": "above"},
+			{"symbol": "LTCBTC", "orderId": 22, "clientOrderId": "below"}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                    "LTCBTC",
+			"workingType":               OrderTypeLimit,
+			"workingSide":               SideTypeBuy,
+			"workingPrice":              "2",
+			"workingQuantity":           "1",
+			"workingTimeInForce":        TimeInForceTypeGTC,
+			"pendingSide":               SideTypeSell,
+			"pendingQuantity":           "1",
+			"pendingAboveType":          OrderTypeLimitMaker,
+			"pendingAbovePrice":         "3",
+			"pendingBelowType":          OrderTypeStopLoss,
+			"pendingBelowStopPrice":     "1.5",
+			"pendingBelowClientOrderId": "below",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateOrderListOTOCOService().Symbol("LTCBTC").
+		Working(NewOrderListLeg(OrderTypeLimit).Side(SideTypeBuy).Price("2").Quantity("1").
+			TimeInForce(TimeInForceTypeGTC)).
+		PendingSide(SideTypeSell).PendingQuantity("1").
+		PendingAbove(NewOrderListLeg(OrderTypeLimitMaker).Price("3")).
+		PendingBelow(NewOrderListLeg(OrderTypeStopLoss).StopPrice("1.5").ClientOrderID("below")).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(2), res.OrderListID)
+	r.Len(res.Orders, 3)
+	r.Equal(&OCOOrder{Symbol: "LTCBTC", OrderID: 22, ClientOrderID: "below"}, res.Orders[2])
+}
+
+func (s *orderListServiceTestSuite) TestGetOrderList() {
+	data := []byte(`{
+		"orderListId": 27,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "h2USkA5YQpaXHPIrkd96xE",
+		"transactionTime": 1565245656253,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "qD1gy3kc3Gx0rihm9Y3xwS"},
+			{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "ARzZ9I00CPM8i3NhmU9Ega"}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("orderListId", int64(27))
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetOrderListService().OrderListID(27).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	e := &OrderList{
+		OrderListID:       27,
+		ContingencyType:   "OCO",
+		ListStatusType:    "EXEC_STARTED",
+		ListOrderStatus:   "EXECUTING",
+		ListClientOrderID: "h2USkA5YQpaXHPIrkd96xE",
+		TransactionTime:   1565245656253,
+		Symbol:            "LTCBTC",
+		Orders: []*OCOOrder{
+			{Symbol: "LTCBTC", OrderID: 4, ClientOrderID: "qD1gy3kc3Gx0rihm9Y3xwS"},
+			{Symbol: "LTCBTC", OrderID: 5, ClientOrderID: "ARzZ9I00CPM8i3NhmU9Ega"},
+		},
+	}
+	r.Equal(e, res)
+}
+
+func (s *orderListServiceTestSuite) TestListOrderLists() {
+	data := []byte(`[
+		{
+			"orderListId": 29,
+			"contingencyType": "OCO",
+			"listStatusType": "EXEC_STARTED",
+			"listOrderStatus": "EXECUTING",
+			"listClientOrderId": "amEEAXryFzFwYF1FeRpUoZ",
+			"transactionTime": 1565245913483,
+			"symbol": "LTCBTC",
+			"orders": [
+				{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "oD7aesZqjEGlZrbtRpy5zB"},
+				{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "Jr1h6xirOxgeJOUuYQS7V3"}
+			]
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"startTime": int64(1565245000000),
+			"limit":     10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListOrderListsService().StartTime(1565245000000).Limit(10).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal(int64(29), res[0].OrderListID)
+	r.Len(res[0].Orders, 2)
+}
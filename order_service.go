@@ -4,6 +4,8 @@ import (
 	"context"
 	stdjson "encoding/json"
 	"net/http"
+
+	"github.com/dictxwang/go-binance/common"
 )
 
 // CreateOrderService create order
@@ -21,6 +23,7 @@ type CreateOrderService struct {
 	stopPrice        *string
 	trailingDelta    *string
 	icebergQuantity  *string
+	stpMode          *SelfTradePreventionModeType
 }
 
 // Symbol set symbol
@@ -95,6 +98,12 @@ func (s *CreateOrderService) NewOrderRespType(newOrderRespType NewOrderRespType)
 	return s
 }
 
+// SelfTradePreventionMode set selfTradePreventionMode, defaults to the default mode of the symbol
+func (s *CreateOrderService) SelfTradePreventionMode(mode SelfTradePreventionModeType) *CreateOrderService {
+	s.stpMode = &mode
+	return s
+}
+
 func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
 		method:   http.MethodPost,
@@ -133,6 +142,9 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.newOrderRespType != nil {
 		m["newOrderRespType"] = *s.newOrderRespType
 	}
+	if s.stpMode != nil {
+		m["selfTradePreventionMode"] = *s.stpMode
+	}
 	r.setFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -182,6 +194,16 @@ type CreateOrderResponse struct {
 	Fills                 []*Fill `json:"fills"`
 	MarginBuyBorrowAmount string  `json:"marginBuyBorrowAmount"` // for margin
 	MarginBuyBorrowAsset  string  `json:"marginBuyBorrowAsset"`
+
+	// for order placed through smart order routing
+	WorkingTime  int64  `json:"workingTime"`
+	WorkingFloor string `json:"workingFloor"`
+	UsedSor      bool   `json:"usedSor"`
+
+	// self-trade prevention, the prevented match is set when the order expired because of it
+	SelfTradePreventionMode SelfTradePreventionModeType `json:"selfTradePreventionMode"`
+	PreventedMatchID        int64                       `json:"preventedMatchId"`
+	PreventedQuantity       string                      `json:"preventedQuantity"`
 }
 
 // Fill may be returned in an array of fills in a CreateOrderResponse.
@@ -191,9 +213,13 @@ type Fill struct {
 	Quantity        string `json:"qty"`
 	Commission      string `json:"commission"`
 	CommissionAsset string `json:"commissionAsset"`
+	MatchType       string `json:"matchType"` // e.g. ONE_PARTY_TRADE_REPORT for SOR allocations
+	AllocID         int64  `json:"allocId"`
 }
 
 // CreateOCOService create order
+//
+// Deprecated: /api/v3/order/oco is deprecated, use CreateOrderListOCOService instead
 type CreateOCOService struct {
 	c                    *Client
 	symbol               string
@@ -532,6 +558,14 @@ type Order struct {
 	IsWorking                bool            `json:"isWorking"`
 	IsIsolated               bool            `json:"isIsolated"`
 	OrigQuoteOrderQuantity   string          `json:"origQuoteOrderQty"`
+	WorkingTime              int64           `json:"workingTime"`
+	WorkingFloor             string          `json:"workingFloor"`
+	UsedSor                  bool            `json:"usedSor"`
+
+	// self-trade prevention, the prevented match is set when the order expired because of it
+	SelfTradePreventionMode SelfTradePreventionModeType `json:"selfTradePreventionMode"`
+	PreventedMatchID        int64                       `json:"preventedMatchId"`
+	PreventedQuantity       string                      `json:"preventedQuantity"`
 }
 
 // ListOrdersService all account orders; active, canceled, or filled
@@ -815,3 +849,345 @@ type CancelOCOResponse struct {
 	Orders            []*OCOOrder       `json:"orders"`
 	OrderReports      []*OCOOrderReport `json:"orderReports"`
 }
+
+// CancelReplaceService cancel an existing order and place a new order on the same symbol
+type CancelReplaceService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	orderType               OrderType
+	cancelReplaceMode       CancelReplaceModeType
+	timeInForce             *TimeInForceType
+	quantity                *string
+	quoteOrderQuantity      *string
+	price                   *string
+	cancelNewClientOrderID  *string
+	cancelOrigClientOrderID *string
+	cancelOrderID           *int64
+	newClientOrderID        *string
+	stopPrice               *string
+	trailingDelta           *int64
+	icebergQuantity         *string
+	newOrderRespType        *NewOrderRespType
+	cancelRestrictions      *CancelRestrictionsType
+}
+
+// Symbol set symbol
+func (s *CancelReplaceService) Symbol(symbol string) *CancelReplaceService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CancelReplaceService) Side(side SideType) *CancelReplaceService {
+	s.side = side
+	return s
+}
+
+// Type set type
+func (s *CancelReplaceService) Type(orderType OrderType) *CancelReplaceService {
+	s.orderType = orderType
+	return s
+}
+
+// CancelReplaceMode set cancelReplaceMode, STOP_ON_FAILURE does not place the new order if the cancel fails
+func (s *CancelReplaceService) CancelReplaceMode(cancelReplaceMode CancelReplaceModeType) *CancelReplaceService {
+	s.cancelReplaceMode = cancelReplaceMode
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *CancelReplaceService) TimeInForce(timeInForce TimeInForceType) *CancelReplaceService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *CancelReplaceService) Quantity(quantity string) *CancelReplaceService {
+	s.quantity = &quantity
+	return s
+}
+
+// QuoteOrderQty set quoteOrderQty
+func (s *CancelReplaceService) QuoteOrderQty(quoteOrderQty string) *CancelReplaceService {
+	s.quoteOrderQuantity = &quoteOrderQty
+	return s
+}
+
+// Price set price
+func (s *CancelReplaceService) Price(price string) *CancelReplaceService {
+	s.price = &price
+	return s
+}
+
+// CancelNewClientOrderID set cancelNewClientOrderId, the id of the cancel request
+func (s *CancelReplaceService) CancelNewClientOrderID(cancelNewClientOrderID string) *CancelReplaceService {
+	s.cancelNewClientOrderID = &cancelNewClientOrderID
+	return s
+}
+
+// CancelOrigClientOrderID set cancelOrigClientOrderId, the client order id of the order to cancel
+func (s *CancelReplaceService) CancelOrigClientOrderID(cancelOrigClientOrderID string) *CancelReplaceService {
+	s.cancelOrigClientOrderID = &cancelOrigClientOrderID
+	return s
+}
+
+// CancelOrderID set cancelOrderId, the id of the order to cancel
+func (s *CancelReplaceService) CancelOrderID(cancelOrderID int64) *CancelReplaceService {
+	s.cancelOrderID = &cancelOrderID
+	return s
+}
+
+// NewClientOrderID set newClientOrderId of the new order
+func (s *CancelReplaceService) NewClientOrderID(newClientOrderID string) *CancelReplaceService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *CancelReplaceService) StopPrice(stopPrice string) *CancelReplaceService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// TrailingDelta set trailingDelta
+func (s *CancelReplaceService) TrailingDelta(trailingDelta int64) *CancelReplaceService {
+	s.trailingDelta = &trailingDelta
+	return s
+}
+
+// IcebergQuantity set icebergQty
+func (s *CancelReplaceService) IcebergQuantity(icebergQuantity string) *CancelReplaceService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CancelReplaceService) NewOrderRespType(newOrderRespType NewOrderRespType) *CancelReplaceService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// CancelRestrictions set cancelRestrictions, the cancel only succeeds if the order has the given status
+func (s *CancelReplaceService) CancelRestrictions(cancelRestrictions CancelRestrictionsType) *CancelReplaceService {
+	s.cancelRestrictions = &cancelRestrictions
+	return s
+}
+
+// Do send request, when the cancel or the new order fails the error is returned together
+// with a response reporting both results, e.g. the HTTP 409 partial failure of ALLOW_FAILURE
+func (s *CancelReplaceService) Do(ctx context.Context, opts ...RequestOption) (res *CancelReplaceResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/api/v3/order/cancelReplace",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":            s.symbol,
+		"side":              s.side,
+		"type":              s.orderType,
+		"cancelReplaceMode": s.cancelReplaceMode,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.quoteOrderQuantity != nil {
+		m["quoteOrderQty"] = *s.quoteOrderQuantity
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.cancelNewClientOrderID != nil {
+		m["cancelNewClientOrderId"] = *s.cancelNewClientOrderID
+	}
+	if s.cancelOrigClientOrderID != nil {
+		m["cancelOrigClientOrderId"] = *s.cancelOrigClientOrderID
+	}
+	if s.cancelOrderID != nil {
+		m["cancelOrderId"] = *s.cancelOrderID
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.trailingDelta != nil {
+		m["trailingDelta"] = *s.trailingDelta
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.cancelRestrictions != nil {
+		m["cancelRestrictions"] = *s.cancelRestrictions
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		if !common.IsAPIError(err) {
+			return nil, err
+		}
+		// failed cancel-replace requests carry both results in data
+		failure := new(struct {
+			Data *CancelReplaceResponse `json:"data"`
+		})
+		if e := json.Unmarshal(data, failure); e != nil || failure.Data == nil {
+			return nil, err
+		}
+		return failure.Data, err
+	}
+	res = new(CancelReplaceResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelReplaceResponse define cancel replace response, the cancel and the new order are reported separately
+type CancelReplaceResponse struct {
+	CancelResult     CancelReplaceResultType        `json:"cancelResult"`
+	NewOrderResult   CancelReplaceResultType        `json:"newOrderResult"`
+	CancelResponse   *CancelReplaceCancelResponse   `json:"cancelResponse"`
+	NewOrderResponse *CancelReplaceNewOrderResponse `json:"newOrderResponse"`
+}
+
+// CancelReplaceCancelResponse define the cancel part of cancel replace response,
+// Code and Message are set when the cancel failed
+type CancelReplaceCancelResponse struct {
+	CancelOrderResponse
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+// Err return the cancel error, nil if the cancel succeeded
+func (r *CancelReplaceCancelResponse) Err() error {
+	if r == nil || (r.Code == 0 && r.Message == "") {
+		return nil
+	}
+	return &common.APIError{Code: r.Code, Message: r.Message}
+}
+
+// CancelReplaceNewOrderResponse define the new order part of cancel replace response,
+// Code and Message are set when the new order failed
+type CancelReplaceNewOrderResponse struct {
+	CreateOrderResponse
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+// Err return the new order error, nil if the new order was placed
+func (r *CancelReplaceNewOrderResponse) Err() error {
+	if r == nil || (r.Code == 0 && r.Message == "") {
+		return nil
+	}
+	return &common.APIError{Code: r.Code, Message: r.Message}
+}
+
+// AmendOrderKeepPriorityService reduce the quantity of an open order without losing its queue priority
+type AmendOrderKeepPriorityService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+	newClientOrderID  *string
+	newQuantity       string
+}
+
+// Symbol set symbol
+func (s *AmendOrderKeepPriorityService) Symbol(symbol string) *AmendOrderKeepPriorityService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderId
+func (s *AmendOrderKeepPriorityService) OrderID(orderID int64) *AmendOrderKeepPriorityService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderId
+func (s *AmendOrderKeepPriorityService) OrigClientOrderID(origClientOrderID string) *AmendOrderKeepPriorityService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// NewClientOrderID set newClientOrderId, the client order id of the order after amendment
+func (s *AmendOrderKeepPriorityService) NewClientOrderID(newClientOrderID string) *AmendOrderKeepPriorityService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// NewQuantity set newQty, must be greater than 0 and less than the order quantity
+func (s *AmendOrderKeepPriorityService) NewQuantity(newQuantity string) *AmendOrderKeepPriorityService {
+	s.newQuantity = newQuantity
+	return s
+}
+
+// Do send request
+func (s *AmendOrderKeepPriorityService) Do(ctx context.Context, opts ...RequestOption) (res *AmendOrderKeepPriorityResponse, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/api/v3/order/amend/keepPriority",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol": s.symbol,
+		"newQty": s.newQuantity,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AmendOrderKeepPriorityResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AmendOrderKeepPriorityResponse define amend order keep priority response
+type AmendOrderKeepPriorityResponse struct {
+	TransactTime int64         `json:"transactTime"`
+	ExecutionID  int64         `json:"executionId"`
+	AmendedOrder *AmendedOrder `json:"amendedOrder"`
+	ListStatus   *OrderList    `json:"listStatus,omitempty"`
+}
+
+// AmendedOrder define the order after amendment
+type AmendedOrder struct {
+	Symbol                  string          `json:"symbol"`
+	OrderID                 int64           `json:"orderId"`
+	OrderListID             int64           `json:"orderListId"`
+	OrigClientOrderID       string          `json:"origClientOrderId"`
+	ClientOrderID           string          `json:"clientOrderId"`
+	Price                   string          `json:"price"`
+	Quantity                string          `json:"qty"`
+	ExecutedQuantity        string          `json:"executedQty"`
+	PreventedQuantity       string          `json:"preventedQty"`
+	QuoteOrderQuantity      string          `json:"quoteOrderQty"`
+	CumulativeQuoteQuantity string          `json:"cumulativeQuoteQty"`
+	Status                  OrderStatusType `json:"status"`
+	TimeInForce             TimeInForceType `json:"timeInForce"`
+	Type                    OrderType       `json:"type"`
+	Side                    SideType        `json:"side"`
+	WorkingTime             int64           `json:"workingTime"`
+	SelfTradePreventionMode string          `json:"selfTradePreventionMode"`
+}
@@ -1,8 +1,10 @@
 package binance
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/dictxwang/go-binance/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -84,6 +86,49 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 	s.r().NoError(err)
 }
 
+func (s *orderServiceTestSuite) TestCreateOrderSelfTradePrevention() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 2,
+		"orderListId": -1,
+		"clientOrderId": "myOrder2",
+		"transactTime": 1669101687094,
+		"price": "1.100000",
+		"origQty": "1.300000",
+		"executedQty": "0.000000",
+		"cummulativeQuoteQty": "0.000000",
+		"status": "EXPIRED_IN_MATCH",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "SELL",
+		"selfTradePreventionMode": "EXPIRE_TAKER",
+		"preventedMatchId": 1,
+		"preventedQuantity": "1.300000"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                  "BTCUSDT",
+			"side":                    SideTypeSell,
+			"type":                    OrderTypeLimit,
+			"timeInForce":             TimeInForceTypeGTC,
+			"quantity":                "1.300000",
+			"price":                   "1.100000",
+			"selfTradePreventionMode": SelfTradePreventionModeTypeExpireTaker,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("1.300000").Price("1.100000").
+		SelfTradePreventionMode(SelfTradePreventionModeTypeExpireTaker).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(OrderStatusExpiredInMatch, res.Status)
+	s.r().Equal(SelfTradePreventionModeTypeExpireTaker, res.SelfTradePreventionMode)
+	s.r().Equal(int64(1), res.PreventedMatchID)
+	s.r().Equal("1.300000", res.PreventedQuantity)
+}
+
 func (s *orderServiceTestSuite) TestCreateOrderFull() {
 	data := []byte(`{
 		"symbol": "LTCBTC",
@@ -1043,3 +1088,184 @@ func (s *baseOrderTestSuite) assertCancelOCOResponseEqual(e, a *CancelOCORespons
 		s.assertOCOOrderEqual(order, a.Orders[idx])
 	}
 }
+
+func (s *orderServiceTestSuite) TestCancelReplace() {
+	data := []byte(`{
+		"cancelResult": "SUCCESS",
+		"newOrderResult": "SUCCESS",
+		"cancelResponse": {
+			"symbol": "BTCUSDT",
+			"origClientOrderId": "DnLo3vTAQcjha43lAZhZ0y",
+			"orderId": 9,
+			"orderListId": -1,
+			"clientOrderId": "osxN3JXAtJvKvCqGeMWMVR",
+			"transactTime": 1684804350068,
+			"price": "0.01000000",
+			"origQty": "0.000100",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "CANCELED",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"side": "SELL"
+		},
+		"newOrderResponse": {
+			"symbol": "BTCUSDT",
+			"orderId": 10,
+			"orderListId": -1,
+			"clientOrderId": "wOceeeOzNORyLiQfw7jd8S",
+			"transactTime": 1652928801803,
+			"price": "0.02000000",
+			"origQty": "0.040000",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "NEW",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"side": "BUY",
+			"fills": []
+		}
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":            "BTCUSDT",
+			"side":              SideTypeBuy,
+			"type":              OrderTypeLimit,
+			"cancelReplaceMode": CancelReplaceModeTypeStopOnFailure,
+			"timeInForce":       TimeInForceTypeGTC,
+			"quantity":          "0.04",
+			"price":             "0.02",
+			"cancelOrderId":     int64(9),
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCancelReplaceService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).CancelReplaceMode(CancelReplaceModeTypeStopOnFailure).
+		TimeInForce(TimeInForceTypeGTC).Quantity("0.04").Price("0.02").
+		CancelOrderID(9).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(CancelReplaceResultTypeSuccess, res.CancelResult)
+	r.Equal(CancelReplaceResultTypeSuccess, res.NewOrderResult)
+	r.NoError(res.CancelResponse.Err())
+	r.NoError(res.NewOrderResponse.Err())
+	r.Equal(OrderStatusTypeCanceled, res.CancelResponse.Status)
+	r.Equal(int64(10), res.NewOrderResponse.OrderID)
+	r.Equal(OrderStatusTypeNew, res.NewOrderResponse.Status)
+}
+
+func (s *orderServiceTestSuite) TestCancelReplacePartialFailure() {
+	data := []byte(`{
+		"code": -2021,
+		"msg": "Order cancel-replace partially failed.",
+		"data": {
+			"cancelResult": "SUCCESS",
+			"newOrderResult": "FAILURE",
+			"cancelResponse": {
+				"symbol": "BTCUSDT",
+				"origClientOrderId": "86M8erehfExV8z2RC8Zo8k",
+				"orderId": 3,
+				"orderListId": -1,
+				"clientOrderId": "G1kLo6aDv2KGNTFcjfTSFq",
+				"price": "0.006123",
+				"origQty": "10000.000000",
+				"executedQty": "0.000000",
+				"cummulativeQuoteQty": "0.000000",
+				"status": "CANCELED",
+				"timeInForce": "GTC",
+				"type": "LIMIT_MAKER",
+				"side": "SELL"
+			},
+			"newOrderResponse": {
+				"code": -2010,
+				"msg": "Order would immediately match and take."
+			}
+		}
+	}`)
+	s.mockDo(data, nil, http.StatusConflict)
+	defer s.assertDo()
+
+	res, err := s.client.NewCancelReplaceService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimitMaker).CancelReplaceMode(CancelReplaceModeTypeAllowFailure).
+		Quantity("10000").Price("0.0061").CancelOrigClientOrderID("86M8erehfExV8z2RC8Zo8k").
+		Do(newContext())
+	r := s.r()
+	r.Error(err)
+	r.True(common.IsAPIError(err))
+	r.Equal(int64(-2021), err.(*common.APIError).Code)
+	r.NotNil(res)
+	r.Equal(CancelReplaceResultTypeSuccess, res.CancelResult)
+	r.Equal(CancelReplaceResultTypeFailure, res.NewOrderResult)
+	r.NoError(res.CancelResponse.Err())
+	r.Equal(int64(3), res.CancelResponse.OrderID)
+	r.Equal(&common.APIError{Code: -2010, Message: "Order would immediately match and take."},
+		res.NewOrderResponse.Err())
+}
+
+func (s *orderServiceTestSuite) TestAmendOrderKeepPriority() {
+	data := []byte(`{
+		"transactTime": 1741926410255,
+		"executionId": 75,
+		"amendedOrder": {
+			"symbol": "BTCUSDT",
+			"orderId": 33,
+			"orderListId": -1,
+			"origClientOrderId": "5xrgbMyg6z36NzBn2pbT8H",
+			"clientOrderId": "PFaq6hIHxqFENGfdtn4J6Q",
+			"price": "6.00000000",
+			"qty": "5.00000000",
+			"executedQty": "0.00000000",
+			"preventedQty": "0.00000000",
+			"quoteOrderQty": "0.00000000",
+			"cumulativeQuoteQty": "0.00000000",
+			"status": "NEW",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"side": "SELL",
+			"workingTime": 1741926410242,
+			"selfTradePreventionMode": "NONE"
+		}
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":  "BTCUSDT",
+			"orderId": int64(33),
+			"newQty":  "5",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewAmendOrderKeepPriorityService().Symbol("BTCUSDT").
+		OrderID(33).NewQuantity("5").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(75), res.ExecutionID)
+	r.Nil(res.ListStatus)
+	e := &AmendedOrder{
+		Symbol:                  "BTCUSDT",
+		OrderID:                 33,
+		OrderListID:             -1,
+		OrigClientOrderID:       "5xrgbMyg6z36NzBn2pbT8H",
+		ClientOrderID:           "PFaq6hIHxqFENGfdtn4J6Q",
+		Price:                   "6.00000000",
+		Quantity:                "5.00000000",
+		ExecutedQuantity:        "0.00000000",
+		PreventedQuantity:       "0.00000000",
+		QuoteOrderQuantity:      "0.00000000",
+		CumulativeQuoteQuantity: "0.00000000",
+		Status:                  OrderStatusTypeNew,
+		TimeInForce:             TimeInForceTypeGTC,
+		Type:                    OrderTypeLimit,
+		Side:                    SideTypeSell,
+		WorkingTime:             1741926410242,
+		SelfTradePreventionMode: "NONE",
+	}
+	r.Equal(e, res.AmendedOrder)
+}
@@ -255,3 +255,76 @@ func (s *AssetCollectionService) Do(ctx context.Context, opts ...RequestOption)
 	}
 	return res, nil
 }
+
+// GetNegativeBalanceInterestHistoryService query interest charged on negative balances of the portfolio margin account
+type GetNegativeBalanceInterestHistoryService struct {
+	c         *Client
+	asset     string
+	startTime *int64
+	endTime   *int64
+	size      *int64
+}
+
+// Asset set asset
+func (s *GetNegativeBalanceInterestHistoryService) Asset(asset string) *GetNegativeBalanceInterestHistoryService {
+	s.asset = asset
+	return s
+}
+
+// StartTime set start time
+func (s *GetNegativeBalanceInterestHistoryService) StartTime(startTime int64) *GetNegativeBalanceInterestHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *GetNegativeBalanceInterestHistoryService) EndTime(endTime int64) *GetNegativeBalanceInterestHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Size default:10 max:100
+func (s *GetNegativeBalanceInterestHistoryService) Size(size int64) *GetNegativeBalanceInterestHistoryService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *GetNegativeBalanceInterestHistoryService) Do(ctx context.Context, opts ...RequestOption) (res []*NegativeBalanceInterest, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/portfolio/interest-history",
+		secType:  secTypeSigned,
+	}
+	if s.asset != "" {
+		r.setParam("asset", s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*NegativeBalanceInterest{}, err
+	}
+	res = make([]*NegativeBalanceInterest, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*NegativeBalanceInterest{}, err
+	}
+	return res, nil
+}
+
+// NegativeBalanceInterest define interest charged on a negative balance
+type NegativeBalanceInterest struct {
+	Asset               string `json:"asset"`
+	Interest            string `json:"interest"`
+	InterestAccruedTime int64  `json:"interestAccruedTime"`
+	InterestRate        string `json:"interestRate"`
+	Principal           string `json:"principal"`
+}
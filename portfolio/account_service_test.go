@@ -0,0 +1,47 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type accountServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestAccountService(t *testing.T) {
+	suite.Run(t, new(accountServiceTestSuite))
+}
+
+func (s *accountServiceTestSuite) TestGetNegativeBalanceInterestHistory() {
+	data := []byte(`[{
+		"asset": "USDT",
+		"interest": "24.4440",
+		"interestAccruedTime": 1670227200000,
+		"interestRate": "0.0001164",
+		"principal": "210000"
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/portfolio/interest-history").setParams(params{
+			"asset":     "USDT",
+			"startTime": 1670198400000,
+			"endTime":   1670284800000,
+			"size":      10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetNegativeBalanceInterestHistoryService().Asset("USDT").
+		StartTime(1670198400000).EndTime(1670284800000).Size(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*NegativeBalanceInterest{{
+		Asset:               "USDT",
+		Interest:            "24.4440",
+		InterestAccruedTime: 1670227200000,
+		InterestRate:        "0.0001164",
+		Principal:           "210000",
+	}}, res)
+}
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"github.com/bitly/go-simplejson"
 	"github.com/dictxwang/go-binance/common"
-	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
@@ -51,6 +50,15 @@ type SymbolType string
 
 type SideEffectType string
 
+// StrategyType define conditional order strategy type
+type StrategyType string
+
+// WorkingType define working type
+type WorkingType string
+
+// PriceMatchType define price match mode
+type PriceMatchType string
+
 //
 //// SymbolStatusType define symbol status type
 //type SymbolStatusType string
@@ -61,9 +69,6 @@ type SideEffectType string
 //// SideEffectType define side effect type for orders
 //type SideEffectType string
 //
-//// WorkingType define working type
-//type WorkingType string
-//
 //// MarginType define margin type
 //type MarginType string
 //
@@ -76,9 +81,11 @@ type UserDataEventType string
 // UserDataEventReasonType define reason type for user data event
 type UserDataEventReasonType string
 
-//
-//// ForceOrderCloseType define reason type for force order
-//type ForceOrderCloseType string
+// ForceOrderCloseType define reason type for force order
+type ForceOrderCloseType string
+
+// IncomeType define income type of income history
+type IncomeType string
 
 type BNBTransferSide string
 
@@ -101,6 +108,25 @@ const (
 	OrderTypeMarket      OrderType = "MARKET"
 	OrderTypeLiquidation OrderType = "LIQUIDATION"
 
+	StrategyTypeStop               StrategyType = "STOP"
+	StrategyTypeStopMarket         StrategyType = "STOP_MARKET"
+	StrategyTypeTakeProfit         StrategyType = "TAKE_PROFIT"
+	StrategyTypeTakeProfitMarket   StrategyType = "TAKE_PROFIT_MARKET"
+	StrategyTypeTrailingStopMarket StrategyType = "TRAILING_STOP_MARKET"
+
+	WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"
+	WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE"
+
+	PriceMatchTypeNone       PriceMatchType = "NONE"
+	PriceMatchTypeOpponent   PriceMatchType = "OPPONENT"
+	PriceMatchTypeOpponent5  PriceMatchType = "OPPONENT_5"
+	PriceMatchTypeOpponent10 PriceMatchType = "OPPONENT_10"
+	PriceMatchTypeOpponent20 PriceMatchType = "OPPONENT_20"
+	PriceMatchTypeQueue      PriceMatchType = "QUEUE"
+	PriceMatchTypeQueue5     PriceMatchType = "QUEUE_5"
+	PriceMatchTypeQueue10    PriceMatchType = "QUEUE_10"
+	PriceMatchTypeQueue20    PriceMatchType = "QUEUE_20"
+
 	TimeInForceTypeGTC TimeInForceType = "GTC" // Good Till Cancel
 	TimeInForceTypeIOC TimeInForceType = "IOC" // Immediate or Cancel
 	TimeInForceTypeFOK TimeInForceType = "FOK" // Fill or Kill
@@ -135,9 +161,6 @@ const (
 	//
 	//SymbolTypeFuture SymbolType = "FUTURE"
 	//
-	//WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"
-	//WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE"
-	//
 	//SymbolStatusTypePreTrading   SymbolStatusType = "PRE_TRADING"
 	//SymbolStatusTypeTrading      SymbolStatusType = "TRADING"
 	//SymbolStatusTypePostTrading  SymbolStatusType = "POST_TRADING"
@@ -167,7 +190,14 @@ const (
 	UserDataEventTypeAccountUpdate    UserDataEventType = "ACCOUNT_UPDATE"
 	UserDataEventTypeOrderTradeUpdate UserDataEventType = "ORDER_TRADE_UPDATE"
 	//UserDataEventTypeMarginCall          UserDataEventType = "MARGIN_CALL"
-	//UserDataEventTypeAccountConfigUpdate UserDataEventType = "ACCOUNT_CONFIG_UPDATE"
+	UserDataEventTypeAccountConfigUpdate         UserDataEventType = "ACCOUNT_CONFIG_UPDATE"
+	UserDataEventTypeConditionalOrderTradeUpdate UserDataEventType = "CONDITIONAL_ORDER_TRADE_UPDATE"
+	UserDataEventTypeOpenOrderLoss               UserDataEventType = "openOrderLoss"
+	UserDataEventTypeRiskLevelChange             UserDataEventType = "riskLevelChange"
+	UserDataEventTypeLiabilityChange             UserDataEventType = "liabilityChange"
+	UserDataEventTypeExecutionReport             UserDataEventType = "executionReport"
+	UserDataEventTypeOutboundAccountPosition     UserDataEventType = "outboundAccountPosition"
+	UserDataEventTypeBalanceUpdate               UserDataEventType = "balanceUpdate"
 
 	UserDataEventReasonTypeDeposit             UserDataEventReasonType = "DEPOSIT"
 	UserDataEventReasonTypeWithdraw            UserDataEventReasonType = "WITHDRAW"
@@ -183,9 +213,29 @@ const (
 	UserDataEventReasonTypeAssetTransfer       UserDataEventReasonType = "ASSET_TRANSFER"
 	UserDataEventReasonTypeOptionsPremiumFee   UserDataEventReasonType = "OPTIONS_PREMIUM_FEE"
 	UserDataEventReasonTypeOptionsSettleProfit UserDataEventReasonType = "OPTIONS_SETTLE_PROFIT"
-	//
-	//ForceOrderCloseTypeLiquidation ForceOrderCloseType = "LIQUIDATION"
-	//ForceOrderCloseTypeADL         ForceOrderCloseType = "ADL"
+
+	ForceOrderCloseTypeLiquidation ForceOrderCloseType = "LIQUIDATION"
+	ForceOrderCloseTypeADL         ForceOrderCloseType = "ADL"
+
+	IncomeTypeTransfer                 IncomeType = "TRANSFER"
+	IncomeTypeWelcomeBonus             IncomeType = "WELCOME_BONUS"
+	IncomeTypeRealizedPnl              IncomeType = "REALIZED_PNL"
+	IncomeTypeFundingFee               IncomeType = "FUNDING_FEE"
+	IncomeTypeCommission               IncomeType = "COMMISSION"
+	IncomeTypeInsuranceClear           IncomeType = "INSURANCE_CLEAR"
+	IncomeTypeReferralKickback         IncomeType = "REFERRAL_KICKBACK"
+	IncomeTypeCommissionRebate         IncomeType = "COMMISSION_REBATE"
+	IncomeTypeAPIRebate                IncomeType = "API_REBATE"
+	IncomeTypeContestReward            IncomeType = "CONTEST_REWARD"
+	IncomeTypeCrossCollateralTransfer  IncomeType = "CROSS_COLLATERAL_TRANSFER"
+	IncomeTypeOptionsPremiumFee        IncomeType = "OPTIONS_PREMIUM_FEE"
+	IncomeTypeOptionsSettleProfit      IncomeType = "OPTIONS_SETTLE_PROFIT"
+	IncomeTypeInternalTransfer         IncomeType = "INTERNAL_TRANSFER"
+	IncomeTypeAutoExchange             IncomeType = "AUTO_EXCHANGE"
+	IncomeTypeDeliveredSettlement      IncomeType = "DELIVERED_SETTELMENT"
+	IncomeTypeCoinSwapDeposit          IncomeType = "COIN_SWAP_DEPOSIT"
+	IncomeTypeCoinSwapWithdraw         IncomeType = "COIN_SWAP_WITHDRAW"
+	IncomeTypePositionLimitIncreaseFee IncomeType = "POSITION_LIMIT_INCREASE_FEE"
 
 	timestampKey  = "timestamp"
 	signatureKey  = "signature"
@@ -214,14 +264,6 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getApiEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getApiEndpoint() string {
-	if UseTestnet {
-		return baseApiTestnetUrl
-	}
-	return baseApiMainUrl
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
@@ -236,6 +278,13 @@ func NewClient(apiKey, secretKey string) *Client {
 	}
 }
 
+// NewClientWithEnvironment initialize an API client instance sending requests to the REST API of env.
+func NewClientWithEnvironment(apiKey, secretKey string, env *Environment) *Client {
+	c := NewClient(apiKey, secretKey)
+	c.BaseURL = env.BaseURL
+	return c
+}
+
 // NewClientWithIP initialize an API client instance with API key, secret key and local IP.
 func NewClientWithIP(apiKey, secretKey, ip string) *Client {
 	parsedIP := net.ParseIP(ip)
@@ -305,6 +354,14 @@ type Client struct {
 	Logger     *log.Logger
 	TimeOffset int64
 	do         doFunc
+
+	middlewares []common.APIMiddleware
+}
+
+// Use add middlewares around the REST calls of the client, the first middleware sees the request first
+func (c *Client) Use(middlewares ...common.APIMiddleware) *Client {
+	c.middlewares = append(c.middlewares, middlewares...)
+	return c
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -360,10 +417,13 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
+	// the signature and the listen key never reach the logs
+	debugURL := fullURL
 	if queryString != "" {
+		debugURL = fmt.Sprintf("%s?%s", fullURL, common.RedactQuery(queryString))
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.debug("full url: %s, body: %s", debugURL, common.RedactQuery(bodyString))
 
 	r.fullURL = fullURL
 	r.header = header
@@ -382,28 +442,17 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.debug("request: %s %s, header: %v", req.Method, common.RedactURL(req.URL), common.RedactHeader(req.Header))
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
-	if err != nil {
-		return []byte{}, &http.Header{}, err
-	}
-	data, err = ioutil.ReadAll(res.Body)
+	res, err := common.ChainAPIMiddlewares(common.NewAPIHandler(f), c.middlewares...)(req)
 	if err != nil {
 		return []byte{}, &http.Header{}, err
 	}
-	defer func() {
-		cerr := res.Body.Close()
-		// Only overwrite the retured error if the original error was nil and an
-		// error occurred while closing the body.
-		if err == nil && cerr != nil {
-			err = cerr
-		}
-	}()
-	c.debug("response: %#v", res)
+	data = res.Body
+	c.debug("response header: %v", res.Header)
 	c.debug("response body: %s", string(data))
 	c.debug("response status code: %d", res.StatusCode)
 
@@ -469,6 +518,81 @@ func (c *Client) NewCmGetAccountService() *CmGetAccountService {
 	return &CmGetAccountService{c: c}
 }
 
+// NewCmGetOrderService init getting cm order service
+func (c *Client) NewCmGetOrderService() *CmGetOrderService {
+	return &CmGetOrderService{c: c}
+}
+
+// NewCmListOrdersService init list all cm orders service
+func (c *Client) NewCmListOrdersService() *CmListOrdersService {
+	return &CmListOrdersService{c: c}
+}
+
+// NewCmModifyOrderService init modify cm order service
+func (c *Client) NewCmModifyOrderService() *CmModifyOrderService {
+	return &CmModifyOrderService{c: c}
+}
+
+// NewCmListAccountTradeService init list cm account trades service
+func (c *Client) NewCmListAccountTradeService() *CmListAccountTradeService {
+	return &CmListAccountTradeService{c: c}
+}
+
+// NewCmCreateConditionalOrderService init create cm conditional order service
+func (c *Client) NewCmCreateConditionalOrderService() *CmCreateConditionalOrderService {
+	return &CmCreateConditionalOrderService{c: c}
+}
+
+// NewCmCancelConditionalOrderService init cancel cm conditional order service
+func (c *Client) NewCmCancelConditionalOrderService() *CmCancelConditionalOrderService {
+	return &CmCancelConditionalOrderService{c: c}
+}
+
+// NewCmCancelAllConditionalOrdersService init cancel all cm conditional orders service
+func (c *Client) NewCmCancelAllConditionalOrdersService() *CmCancelAllConditionalOrdersService {
+	return &CmCancelAllConditionalOrdersService{c: c}
+}
+
+// NewCmListOpenConditionalOrdersService init list cm open conditional orders service
+func (c *Client) NewCmListOpenConditionalOrdersService() *CmListOpenConditionalOrdersService {
+	return &CmListOpenConditionalOrdersService{c: c}
+}
+
+// NewCmGetConditionalOrderHistoryService init getting cm conditional order history service
+func (c *Client) NewCmGetConditionalOrderHistoryService() *CmGetConditionalOrderHistoryService {
+	return &CmGetConditionalOrderHistoryService{c: c}
+}
+
+// NewCmListConditionalOrdersService init list all cm conditional orders service
+func (c *Client) NewCmListConditionalOrdersService() *CmListConditionalOrdersService {
+	return &CmListConditionalOrdersService{c: c}
+}
+
+// NewCmGetPositionRiskService init getting cm position risk service
+func (c *Client) NewCmGetPositionRiskService() *CmGetPositionRiskService {
+	return &CmGetPositionRiskService{c: c}
+}
+
+// NewCmGetIncomeHistoryService init getting cm income history service
+func (c *Client) NewCmGetIncomeHistoryService() *CmGetIncomeHistoryService {
+	return &CmGetIncomeHistoryService{c: c}
+}
+
+// NewCmGetADLQuantileService init getting cm adl quantile service
+func (c *Client) NewCmGetADLQuantileService() *CmGetADLQuantileService {
+	return &CmGetADLQuantileService{c: c}
+}
+
+// NewCmGetLeverageBracketService init getting cm leverage bracket service
+func (c *Client) NewCmGetLeverageBracketService() *CmGetLeverageBracketService {
+	return &CmGetLeverageBracketService{c: c}
+}
+
+// NewCmListForceOrdersService init list cm force orders service
+func (c *Client) NewCmListForceOrdersService() *CmListForceOrdersService {
+	return &CmListForceOrdersService{c: c}
+}
+
 // #### um
 // NewUmCommissionRateService init um commission rate service
 func (c *Client) NewUmCommissionRateService() *UmCommissionRateService {
@@ -515,6 +639,81 @@ func (c *Client) NewUmGetAccountService() *UmGetAccountService {
 	return &UmGetAccountService{c: c}
 }
 
+// NewUmGetOrderService init getting um order service
+func (c *Client) NewUmGetOrderService() *UmGetOrderService {
+	return &UmGetOrderService{c: c}
+}
+
+// NewUmListOrdersService init list all um orders service
+func (c *Client) NewUmListOrdersService() *UmListOrdersService {
+	return &UmListOrdersService{c: c}
+}
+
+// NewUmModifyOrderService init modify um order service
+func (c *Client) NewUmModifyOrderService() *UmModifyOrderService {
+	return &UmModifyOrderService{c: c}
+}
+
+// NewUmListAccountTradeService init list um account trades service
+func (c *Client) NewUmListAccountTradeService() *UmListAccountTradeService {
+	return &UmListAccountTradeService{c: c}
+}
+
+// NewUmCreateConditionalOrderService init create um conditional order service
+func (c *Client) NewUmCreateConditionalOrderService() *UmCreateConditionalOrderService {
+	return &UmCreateConditionalOrderService{c: c}
+}
+
+// NewUmCancelConditionalOrderService init cancel um conditional order service
+func (c *Client) NewUmCancelConditionalOrderService() *UmCancelConditionalOrderService {
+	return &UmCancelConditionalOrderService{c: c}
+}
+
+// NewUmCancelAllConditionalOrdersService init cancel all um conditional orders service
+func (c *Client) NewUmCancelAllConditionalOrdersService() *UmCancelAllConditionalOrdersService {
+	return &UmCancelAllConditionalOrdersService{c: c}
+}
+
+// NewUmListOpenConditionalOrdersService init list um open conditional orders service
+func (c *Client) NewUmListOpenConditionalOrdersService() *UmListOpenConditionalOrdersService {
+	return &UmListOpenConditionalOrdersService{c: c}
+}
+
+// NewUmGetConditionalOrderHistoryService init getting um conditional order history service
+func (c *Client) NewUmGetConditionalOrderHistoryService() *UmGetConditionalOrderHistoryService {
+	return &UmGetConditionalOrderHistoryService{c: c}
+}
+
+// NewUmListConditionalOrdersService init list all um conditional orders service
+func (c *Client) NewUmListConditionalOrdersService() *UmListConditionalOrdersService {
+	return &UmListConditionalOrdersService{c: c}
+}
+
+// NewUmGetPositionRiskService init getting um position risk service
+func (c *Client) NewUmGetPositionRiskService() *UmGetPositionRiskService {
+	return &UmGetPositionRiskService{c: c}
+}
+
+// NewUmGetIncomeHistoryService init getting um income history service
+func (c *Client) NewUmGetIncomeHistoryService() *UmGetIncomeHistoryService {
+	return &UmGetIncomeHistoryService{c: c}
+}
+
+// NewUmGetADLQuantileService init getting um adl quantile service
+func (c *Client) NewUmGetADLQuantileService() *UmGetADLQuantileService {
+	return &UmGetADLQuantileService{c: c}
+}
+
+// NewUmGetLeverageBracketService init getting um leverage bracket service
+func (c *Client) NewUmGetLeverageBracketService() *UmGetLeverageBracketService {
+	return &UmGetLeverageBracketService{c: c}
+}
+
+// NewUmListForceOrdersService init list um force orders service
+func (c *Client) NewUmListForceOrdersService() *UmListForceOrdersService {
+	return &UmListForceOrdersService{c: c}
+}
+
 // NewStartUserStreamService init starting user stream service
 func (c *Client) NewStartUserStreamService() *StartUserStreamService {
 	return &StartUserStreamService{c: c}
@@ -547,6 +746,26 @@ func (c *Client) NewMarginRepayService() *MarginRepayService {
 	return &MarginRepayService{c: c}
 }
 
+// NewMarginGetLoanHistoryService init getting margin loan history service
+func (c *Client) NewMarginGetLoanHistoryService() *MarginGetLoanHistoryService {
+	return &MarginGetLoanHistoryService{c: c}
+}
+
+// NewMarginGetRepayHistoryService init getting margin repay history service
+func (c *Client) NewMarginGetRepayHistoryService() *MarginGetRepayHistoryService {
+	return &MarginGetRepayHistoryService{c: c}
+}
+
+// NewMarginGetInterestHistoryService init getting margin interest history service
+func (c *Client) NewMarginGetInterestHistoryService() *MarginGetInterestHistoryService {
+	return &MarginGetInterestHistoryService{c: c}
+}
+
+// NewMarginListForceOrdersService init list margin force orders service
+func (c *Client) NewMarginListForceOrdersService() *MarginListForceOrdersService {
+	return &MarginListForceOrdersService{c: c}
+}
+
 func (c *Client) NewMarginCreateOrderService() *MarginCreateOrderService {
 	return &MarginCreateOrderService{c: c}
 }
@@ -555,6 +774,56 @@ func (c *Client) NewMarginCancelOrderService() *MarginCancelOrderService {
 	return &MarginCancelOrderService{c: c}
 }
 
+// NewMarginGetOrderService init getting margin order service
+func (c *Client) NewMarginGetOrderService() *MarginGetOrderService {
+	return &MarginGetOrderService{c: c}
+}
+
+// NewMarginListOpenOrdersService init list margin open orders service
+func (c *Client) NewMarginListOpenOrdersService() *MarginListOpenOrdersService {
+	return &MarginListOpenOrdersService{c: c}
+}
+
+// NewMarginCancelAllOpenOrdersService init cancel all margin open orders service
+func (c *Client) NewMarginCancelAllOpenOrdersService() *MarginCancelAllOpenOrdersService {
+	return &MarginCancelAllOpenOrdersService{c: c}
+}
+
+// NewMarginListOrdersService init list all margin orders service
+func (c *Client) NewMarginListOrdersService() *MarginListOrdersService {
+	return &MarginListOrdersService{c: c}
+}
+
+// NewMarginListTradesService init list margin trades service
+func (c *Client) NewMarginListTradesService() *MarginListTradesService {
+	return &MarginListTradesService{c: c}
+}
+
+// NewMarginCreateOCOService init create margin OCO service
+func (c *Client) NewMarginCreateOCOService() *MarginCreateOCOService {
+	return &MarginCreateOCOService{c: c}
+}
+
+// NewMarginCancelOCOService init cancel margin OCO service
+func (c *Client) NewMarginCancelOCOService() *MarginCancelOCOService {
+	return &MarginCancelOCOService{c: c}
+}
+
+// NewMarginGetOCOService init getting margin OCO service
+func (c *Client) NewMarginGetOCOService() *MarginGetOCOService {
+	return &MarginGetOCOService{c: c}
+}
+
+// NewMarginListOCOService init list all margin OCO service
+func (c *Client) NewMarginListOCOService() *MarginListOCOService {
+	return &MarginListOCOService{c: c}
+}
+
+// NewMarginListOpenOCOService init list open margin OCO service
+func (c *Client) NewMarginListOpenOCOService() *MarginListOpenOCOService {
+	return &MarginListOpenOCOService{c: c}
+}
+
 func (c *Client) NewGetAccountService() *GetAccountService {
 	return &GetAccountService{c: c}
 }
@@ -574,3 +843,8 @@ func (c *Client) NewChangeRepayFuturesSwitchService() *ChangeRepayFuturesSwitchS
 func (c *Client) NewAssetCollectionService() *AssetCollectionService {
 	return &AssetCollectionService{c: c}
 }
+
+// NewGetNegativeBalanceInterestHistoryService init getting negative balance interest history service
+func (c *Client) NewGetNegativeBalanceInterestHistoryService() *GetNegativeBalanceInterestHistoryService {
+	return &GetNegativeBalanceInterestHistoryService{c: c}
+}
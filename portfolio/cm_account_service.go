@@ -15,7 +15,7 @@ type CmGetAccountService struct {
 func (s *CmGetAccountService) Do(ctx context.Context, opts ...RequestOption) (res *UmAccount, err error) {
 	r := &request{
 		method:   http.MethodGet,
-		endpoint: "/papi/v1/um/account",
+		endpoint: "/papi/v1/cm/account",
 		secType:  secTypeSigned,
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
@@ -0,0 +1,432 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// CmCreateConditionalOrderService create conditional order
+type CmCreateConditionalOrderService struct {
+	c                   *Client
+	symbol              string
+	side                SideType
+	positionSide        *PositionSideType
+	strategyType        StrategyType
+	timeInForce         *TimeInForceType
+	quantity            *string
+	reduceOnly          *bool
+	price               *string
+	workingType         *WorkingType
+	priceProtect        *bool
+	newClientStrategyID *string
+	stopPrice           *string
+	activationPrice     *string
+	callbackRate        *string
+}
+
+// Symbol set symbol
+func (s *CmCreateConditionalOrderService) Symbol(symbol string) *CmCreateConditionalOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CmCreateConditionalOrderService) Side(side SideType) *CmCreateConditionalOrderService {
+	s.side = side
+	return s
+}
+
+// PositionSide set side
+func (s *CmCreateConditionalOrderService) PositionSide(positionSide PositionSideType) *CmCreateConditionalOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// StrategyType set strategyType
+func (s *CmCreateConditionalOrderService) StrategyType(strategyType StrategyType) *CmCreateConditionalOrderService {
+	s.strategyType = strategyType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *CmCreateConditionalOrderService) TimeInForce(timeInForce TimeInForceType) *CmCreateConditionalOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *CmCreateConditionalOrderService) Quantity(quantity string) *CmCreateConditionalOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *CmCreateConditionalOrderService) ReduceOnly(reduceOnly bool) *CmCreateConditionalOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// Price set price
+func (s *CmCreateConditionalOrderService) Price(price string) *CmCreateConditionalOrderService {
+	s.price = &price
+	return s
+}
+
+// WorkingType set workingType
+func (s *CmCreateConditionalOrderService) WorkingType(workingType WorkingType) *CmCreateConditionalOrderService {
+	s.workingType = &workingType
+	return s
+}
+
+// PriceProtect set priceProtect
+func (s *CmCreateConditionalOrderService) PriceProtect(priceProtect bool) *CmCreateConditionalOrderService {
+	s.priceProtect = &priceProtect
+	return s
+}
+
+// NewClientStrategyID set newClientStrategyID
+func (s *CmCreateConditionalOrderService) NewClientStrategyID(newClientStrategyID string) *CmCreateConditionalOrderService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *CmCreateConditionalOrderService) StopPrice(stopPrice string) *CmCreateConditionalOrderService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// ActivationPrice set activationPrice, used with TRAILING_STOP_MARKET
+func (s *CmCreateConditionalOrderService) ActivationPrice(activationPrice string) *CmCreateConditionalOrderService {
+	s.activationPrice = &activationPrice
+	return s
+}
+
+// CallbackRate set callbackRate, used with TRAILING_STOP_MARKET
+func (s *CmCreateConditionalOrderService) CallbackRate(callbackRate string) *CmCreateConditionalOrderService {
+	s.callbackRate = &callbackRate
+	return s
+}
+
+// Do send request
+func (s *CmCreateConditionalOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/cm/conditional/order",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":       s.symbol,
+		"side":         s.side,
+		"strategyType": s.strategyType,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.workingType != nil {
+		m["workingType"] = *s.workingType
+	}
+	if s.priceProtect != nil {
+		m["priceProtect"] = *s.priceProtect
+	}
+	if s.newClientStrategyID != nil {
+		m["newClientStrategyId"] = *s.newClientStrategyID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.activationPrice != nil {
+		m["activationPrice"] = *s.activationPrice
+	}
+	if s.callbackRate != nil {
+		m["callbackRate"] = *s.callbackRate
+	}
+	r.setFormParams(m)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CmConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CmConditionalOrder define conditional order info
+type CmConditionalOrder struct {
+	NewClientStrategyID string           `json:"newClientStrategyId"`
+	StrategyID          int64            `json:"strategyId"`
+	StrategyStatus      string           `json:"strategyStatus"`
+	StrategyType        StrategyType     `json:"strategyType"`
+	OrigQuantity        string           `json:"origQty"`
+	Price               string           `json:"price"`
+	ReduceOnly          bool             `json:"reduceOnly"`
+	Side                SideType         `json:"side"`
+	PositionSide        PositionSideType `json:"positionSide"`
+	StopPrice           string           `json:"stopPrice"`
+	Symbol              string           `json:"symbol"`
+	Pair                string           `json:"pair"`
+	TimeInForce         TimeInForceType  `json:"timeInForce"`
+	ActivatePrice       string           `json:"activatePrice"`
+	PriceRate           string           `json:"priceRate"`
+	BookTime            int64            `json:"bookTime"`
+	UpdateTime          int64            `json:"updateTime"`
+	WorkingType         WorkingType      `json:"workingType"`
+	PriceProtect        bool             `json:"priceProtect"`
+	OrderID             int64            `json:"orderId,omitempty"`     // only returned once triggered
+	TriggerTime         int64            `json:"triggerTime,omitempty"` // only returned once triggered
+}
+
+// CmCancelConditionalOrderService cancel a conditional order
+type CmCancelConditionalOrderService struct {
+	c                   *Client
+	symbol              string
+	strategyID          *int64
+	newClientStrategyID *string
+}
+
+// Symbol set symbol
+func (s *CmCancelConditionalOrderService) Symbol(symbol string) *CmCancelConditionalOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// StrategyID set strategyID
+func (s *CmCancelConditionalOrderService) StrategyID(strategyID int64) *CmCancelConditionalOrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// NewClientStrategyID set newClientStrategyID
+func (s *CmCancelConditionalOrderService) NewClientStrategyID(newClientStrategyID string) *CmCancelConditionalOrderService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// Do send request
+func (s *CmCancelConditionalOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/cm/conditional/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.strategyID != nil {
+		r.setFormParam("strategyId", *s.strategyID)
+	}
+	if s.newClientStrategyID != nil {
+		r.setFormParam("newClientStrategyId", *s.newClientStrategyID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CmConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CmCancelAllConditionalOrdersService cancel all open conditional orders of a symbol
+type CmCancelAllConditionalOrdersService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *CmCancelAllConditionalOrdersService) Symbol(symbol string) *CmCancelAllConditionalOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *CmCancelAllConditionalOrdersService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/cm/conditional/allOpenOrders",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	_, _, err = s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CmListOpenConditionalOrdersService list open conditional orders
+type CmListOpenConditionalOrdersService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *CmListOpenConditionalOrdersService) Symbol(symbol string) *CmListOpenConditionalOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *CmListOpenConditionalOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*CmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/conditional/openOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CmConditionalOrder{}, err
+	}
+	res = make([]*CmConditionalOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CmConditionalOrder{}, err
+	}
+	return res, nil
+}
+
+// CmGetConditionalOrderHistoryService get a conditional order from history
+type CmGetConditionalOrderHistoryService struct {
+	c                   *Client
+	symbol              string
+	strategyID          *int64
+	newClientStrategyID *string
+}
+
+// Symbol set symbol
+func (s *CmGetConditionalOrderHistoryService) Symbol(symbol string) *CmGetConditionalOrderHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// StrategyID set strategyID
+func (s *CmGetConditionalOrderHistoryService) StrategyID(strategyID int64) *CmGetConditionalOrderHistoryService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// NewClientStrategyID set newClientStrategyID
+func (s *CmGetConditionalOrderHistoryService) NewClientStrategyID(newClientStrategyID string) *CmGetConditionalOrderHistoryService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// Do send request
+func (s *CmGetConditionalOrderHistoryService) Do(ctx context.Context, opts ...RequestOption) (res *CmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/conditional/orderHistory",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.strategyID != nil {
+		r.setParam("strategyId", *s.strategyID)
+	}
+	if s.newClientStrategyID != nil {
+		r.setParam("newClientStrategyId", *s.newClientStrategyID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CmConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CmListConditionalOrdersService list all conditional orders
+type CmListConditionalOrdersService struct {
+	c          *Client
+	symbol     *string
+	strategyID *int64
+	startTime  *int64
+	endTime    *int64
+	limit      *int
+}
+
+// Symbol set symbol
+func (s *CmListConditionalOrdersService) Symbol(symbol string) *CmListConditionalOrdersService {
+	s.symbol = &symbol
+	return s
+}
+
+// StrategyID set strategyID
+func (s *CmListConditionalOrdersService) StrategyID(strategyID int64) *CmListConditionalOrdersService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// StartTime set startTime
+func (s *CmListConditionalOrdersService) StartTime(startTime int64) *CmListConditionalOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *CmListConditionalOrdersService) EndTime(endTime int64) *CmListConditionalOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *CmListConditionalOrdersService) Limit(limit int) *CmListConditionalOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *CmListConditionalOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*CmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/conditional/allOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.strategyID != nil {
+		r.setParam("strategyId", *s.strategyID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CmConditionalOrder{}, err
+	}
+	res = make([]*CmConditionalOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CmConditionalOrder{}, err
+	}
+	return res, nil
+}
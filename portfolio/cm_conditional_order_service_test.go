@@ -0,0 +1,162 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type cmConditionalOrderServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestCmConditionalOrderService(t *testing.T) {
+	suite.Run(t, new(cmConditionalOrderServiceTestSuite))
+}
+
+func (s *cmConditionalOrderServiceTestSuite) TestCreateConditionalOrder() {
+	data := []byte(`{
+		"newClientStrategyId": "testStrategy",
+		"strategyId": 123445,
+		"strategyStatus": "NEW",
+		"strategyType": "STOP_MARKET",
+		"origQty": "10",
+		"price": "0",
+		"reduceOnly": false,
+		"side": "BUY",
+		"positionSide": "BOTH",
+		"stopPrice": "31000",
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"timeInForce": "GTC",
+		"bookTime": 1566818724710,
+		"updateTime": 1566818724722,
+		"workingType": "MARK_PRICE",
+		"priceProtect": false
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/cm/conditional/order").setFormParams(params{
+			"symbol":              "BTCUSD_PERP",
+			"side":                SideTypeBuy,
+			"positionSide":        PositionSideTypeBoth,
+			"strategyType":        StrategyTypeStopMarket,
+			"quantity":            "10",
+			"stopPrice":           "31000",
+			"workingType":         WorkingTypeMarkPrice,
+			"newClientStrategyId": "testStrategy",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmCreateConditionalOrderService().Symbol("BTCUSD_PERP").Side(SideTypeBuy).
+		PositionSide(PositionSideTypeBoth).StrategyType(StrategyTypeStopMarket).Quantity("10").
+		StopPrice("31000").WorkingType(WorkingTypeMarkPrice).NewClientStrategyID("testStrategy").
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmConditionalOrder{
+		NewClientStrategyID: "testStrategy",
+		StrategyID:          123445,
+		StrategyStatus:      "NEW",
+		StrategyType:        StrategyTypeStopMarket,
+		OrigQuantity:        "10",
+		Price:               "0",
+		Side:                SideTypeBuy,
+		PositionSide:        PositionSideTypeBoth,
+		StopPrice:           "31000",
+		Symbol:              "BTCUSD_PERP",
+		Pair:                "BTCUSD",
+		TimeInForce:         TimeInForceTypeGTC,
+		BookTime:            1566818724710,
+		UpdateTime:          1566818724722,
+		WorkingType:         WorkingTypeMarkPrice,
+	}, res)
+}
+
+func (s *cmConditionalOrderServiceTestSuite) TestCancelConditionalOrder() {
+	data := []byte(`{"newClientStrategyId": "testStrategy", "strategyId": 123445, "strategyStatus": "CANCELED", "symbol": "BTCUSD_PERP", "pair": "BTCUSD"}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/cm/conditional/order").setFormParams(params{
+			"symbol":              "BTCUSD_PERP",
+			"newClientStrategyId": "testStrategy",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmCancelConditionalOrderService().Symbol("BTCUSD_PERP").
+		NewClientStrategyID("testStrategy").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmConditionalOrder{
+		NewClientStrategyID: "testStrategy",
+		StrategyID:          123445,
+		StrategyStatus:      "CANCELED",
+		Symbol:              "BTCUSD_PERP",
+		Pair:                "BTCUSD",
+	}, res)
+}
+
+func (s *cmConditionalOrderServiceTestSuite) TestCancelAllConditionalOrders() {
+	s.mockDo([]byte(`{"code": 200, "msg": "The operation of cancel all conditional open order is done."}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/cm/conditional/allOpenOrders").setFormParam("symbol", "BTCUSD_PERP")
+		s.assertRequestEqual(e, r)
+	})
+	err := s.client.NewCmCancelAllConditionalOrdersService().Symbol("BTCUSD_PERP").Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *cmConditionalOrderServiceTestSuite) TestListOpenConditionalOrders() {
+	data := []byte(`[{"strategyId": 1, "strategyStatus": "NEW", "strategyType": "TAKE_PROFIT_MARKET", "symbol": "BTCUSD_PERP"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/conditional/openOrders")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmListOpenConditionalOrdersService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*CmConditionalOrder{
+		{StrategyID: 1, StrategyStatus: "NEW", StrategyType: StrategyTypeTakeProfitMarket, Symbol: "BTCUSD_PERP"},
+	}, res)
+}
+
+func (s *cmConditionalOrderServiceTestSuite) TestGetConditionalOrderHistory() {
+	data := []byte(`{"strategyId": 123445, "strategyStatus": "TRIGGERED", "symbol": "BTCUSD_PERP", "orderId": 12123343534, "triggerTime": 1566818724730}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/conditional/orderHistory").setParams(params{
+			"symbol":     "BTCUSD_PERP",
+			"strategyId": 123445,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmGetConditionalOrderHistoryService().Symbol("BTCUSD_PERP").StrategyID(123445).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmConditionalOrder{
+		StrategyID:     123445,
+		StrategyStatus: "TRIGGERED",
+		Symbol:         "BTCUSD_PERP",
+		OrderID:        12123343534,
+		TriggerTime:    1566818724730,
+	}, res)
+}
+
+func (s *cmConditionalOrderServiceTestSuite) TestListConditionalOrders() {
+	data := []byte(`[{"strategyId": 1, "strategyStatus": "CANCELED", "symbol": "BTCUSD_PERP"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/conditional/allOrders").setParams(params{
+			"strategyId": 1,
+			"limit":      5,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmListConditionalOrdersService().StrategyID(1).Limit(5).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*CmConditionalOrder{{StrategyID: 1, StrategyStatus: "CANCELED", Symbol: "BTCUSD_PERP"}}, res)
+}
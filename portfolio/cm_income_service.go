@@ -0,0 +1,91 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// CmGetIncomeHistoryService get CM income history
+type CmGetIncomeHistoryService struct {
+	c          *Client
+	symbol     string
+	incomeType IncomeType
+	startTime  *int64
+	endTime    *int64
+	page       *int
+	limit      *int
+}
+
+// Symbol set symbol
+func (s *CmGetIncomeHistoryService) Symbol(symbol string) *CmGetIncomeHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// IncomeType set income type
+func (s *CmGetIncomeHistoryService) IncomeType(incomeType IncomeType) *CmGetIncomeHistoryService {
+	s.incomeType = incomeType
+	return s
+}
+
+// StartTime set startTime
+func (s *CmGetIncomeHistoryService) StartTime(startTime int64) *CmGetIncomeHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *CmGetIncomeHistoryService) EndTime(endTime int64) *CmGetIncomeHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Page set page
+func (s *CmGetIncomeHistoryService) Page(page int) *CmGetIncomeHistoryService {
+	s.page = &page
+	return s
+}
+
+// Limit set limit
+func (s *CmGetIncomeHistoryService) Limit(limit int) *CmGetIncomeHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *CmGetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption) (res []*IncomeHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/income",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.incomeType != "" {
+		r.setParam("incomeType", s.incomeType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*IncomeHistory{}, err
+	}
+	res = make([]*IncomeHistory, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*IncomeHistory{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type cmIncomeServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestCmIncomeService(t *testing.T) {
+	suite.Run(t, new(cmIncomeServiceTestSuite))
+}
+
+func (s *cmIncomeServiceTestSuite) TestGetIncomeHistory() {
+	data := []byte(`[{
+		"symbol": "BTCUSD_200925",
+		"incomeType": "FUNDING_FEE",
+		"income": "-0.00000071",
+		"asset": "BTC",
+		"info": "",
+		"time": 1570636800000,
+		"tranId": 21483628,
+		"tradeId": ""
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/income").setParam("incomeType", IncomeTypeFundingFee)
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmGetIncomeHistoryService().IncomeType(IncomeTypeFundingFee).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*IncomeHistory{{
+		Symbol:     "BTCUSD_200925",
+		IncomeType: IncomeTypeFundingFee,
+		Income:     "-0.00000071",
+		Asset:      "BTC",
+		Time:       1570636800000,
+		TranID:     21483628,
+	}}, res)
+}
@@ -166,6 +166,7 @@ type CmOrder struct {
 	AvgPrice         string           `json:"avgPrice"`
 	ClientOrderID    string           `json:"clientOrderId"`
 	CumQuote         string           `json:"cumQuote"`
+	CumBase          string           `json:"cumBase"`
 	ExecutedQuantity string           `json:"executedQty"`
 	OrderID          int64            `json:"orderId"`
 	OrigQuantity     string           `json:"origQty"`
@@ -315,3 +316,396 @@ func (s *CmCancelOrderService) Do(ctx context.Context, opts ...RequestOption) (r
 	}
 	return res, nil
 }
+
+// CmGetOrderService get an order
+type CmGetOrderService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// Symbol set symbol
+func (s *CmGetOrderService) Symbol(symbol string) *CmGetOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *CmGetOrderService) OrderID(orderID int64) *CmGetOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *CmGetOrderService) OrigClientOrderID(origClientOrderID string) *CmGetOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *CmGetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CmOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/order",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CmOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CmListOrdersService list all orders, active, canceled or filled
+type CmListOrdersService struct {
+	c         *Client
+	symbol    *string
+	pair      *string
+	orderID   *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *CmListOrdersService) Symbol(symbol string) *CmListOrdersService {
+	s.symbol = &symbol
+	return s
+}
+
+// Pair set pair
+func (s *CmListOrdersService) Pair(pair string) *CmListOrdersService {
+	s.pair = &pair
+	return s
+}
+
+// OrderID set orderID
+func (s *CmListOrdersService) OrderID(orderID int64) *CmListOrdersService {
+	s.orderID = &orderID
+	return s
+}
+
+// StartTime set starttime
+func (s *CmListOrdersService) StartTime(startTime int64) *CmListOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endtime
+func (s *CmListOrdersService) EndTime(endTime int64) *CmListOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *CmListOrdersService) Limit(limit int) *CmListOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *CmListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*CmOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/allOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.pair != nil {
+		r.setParam("pair", *s.pair)
+	}
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CmOrder{}, err
+	}
+	res = make([]*CmOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CmOrder{}, err
+	}
+	return res, nil
+}
+
+// CmModifyOrderService modify a LIMIT order
+type CmModifyOrderService struct {
+	c                 *Client
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          string
+	price             string
+}
+
+// Symbol set symbol
+func (s *CmModifyOrderService) Symbol(symbol string) *CmModifyOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CmModifyOrderService) Side(side SideType) *CmModifyOrderService {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *CmModifyOrderService) OrderID(orderID int64) *CmModifyOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *CmModifyOrderService) OrigClientOrderID(origClientOrderID string) *CmModifyOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Quantity set quantity
+func (s *CmModifyOrderService) Quantity(quantity string) *CmModifyOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Price set price
+func (s *CmModifyOrderService) Price(price string) *CmModifyOrderService {
+	s.price = price
+	return s
+}
+
+// Do send request
+func (s *CmModifyOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CmOrder, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/papi/v1/cm/order",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"price":    s.price,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	r.setFormParams(m)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CmOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CmListAccountTradeService list CM account trades
+type CmListAccountTradeService struct {
+	c         *Client
+	symbol    *string
+	pair      *string
+	startTime *int64
+	endTime   *int64
+	fromID    *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *CmListAccountTradeService) Symbol(symbol string) *CmListAccountTradeService {
+	s.symbol = &symbol
+	return s
+}
+
+// Pair set pair
+func (s *CmListAccountTradeService) Pair(pair string) *CmListAccountTradeService {
+	s.pair = &pair
+	return s
+}
+
+// StartTime set startTime
+func (s *CmListAccountTradeService) StartTime(startTime int64) *CmListAccountTradeService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *CmListAccountTradeService) EndTime(endTime int64) *CmListAccountTradeService {
+	s.endTime = &endTime
+	return s
+}
+
+// FromID set fromID
+func (s *CmListAccountTradeService) FromID(fromID int64) *CmListAccountTradeService {
+	s.fromID = &fromID
+	return s
+}
+
+// Limit set limit
+func (s *CmListAccountTradeService) Limit(limit int) *CmListAccountTradeService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *CmListAccountTradeService) Do(ctx context.Context, opts ...RequestOption) (res []*CmAccountTrade, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/userTrades",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.pair != nil {
+		r.setParam("pair", *s.pair)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CmAccountTrade{}, err
+	}
+	res = make([]*CmAccountTrade, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CmAccountTrade{}, err
+	}
+	return res, nil
+}
+
+// CmAccountTrade define CM account trade
+type CmAccountTrade struct {
+	Symbol          string           `json:"symbol"`
+	ID              int64            `json:"id"`
+	OrderID         int64            `json:"orderId"`
+	Pair            string           `json:"pair"`
+	Side            SideType         `json:"side"`
+	Price           string           `json:"price"`
+	Quantity        string           `json:"qty"`
+	RealizedPnl     string           `json:"realizedPnl"`
+	MarginAsset     string           `json:"marginAsset"`
+	BaseQuantity    string           `json:"baseQty"`
+	Commission      string           `json:"commission"`
+	CommissionAsset string           `json:"commissionAsset"`
+	Time            int64            `json:"time"`
+	PositionSide    PositionSideType `json:"positionSide"`
+	Buyer           bool             `json:"buyer"`
+	Maker           bool             `json:"maker"`
+}
+
+// CmListForceOrdersService list CM liquidation and ADL orders of the user
+type CmListForceOrdersService struct {
+	c             *Client
+	symbol        string
+	autoCloseType *ForceOrderCloseType
+	startTime     *int64
+	endTime       *int64
+	limit         *int
+}
+
+// Symbol set symbol
+func (s *CmListForceOrdersService) Symbol(symbol string) *CmListForceOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// AutoCloseType set autoCloseType
+func (s *CmListForceOrdersService) AutoCloseType(autoCloseType ForceOrderCloseType) *CmListForceOrdersService {
+	s.autoCloseType = &autoCloseType
+	return s
+}
+
+// StartTime set startTime
+func (s *CmListForceOrdersService) StartTime(startTime int64) *CmListForceOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *CmListForceOrdersService) EndTime(endTime int64) *CmListForceOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *CmListForceOrdersService) Limit(limit int) *CmListForceOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *CmListForceOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*CmOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/forceOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.autoCloseType != nil {
+		r.setParam("autoCloseType", *s.autoCloseType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CmOrder{}, err
+	}
+	res = make([]*CmOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CmOrder{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,315 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type cmOrderServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestCmOrderService(t *testing.T) {
+	suite.Run(t, new(cmOrderServiceTestSuite))
+}
+
+func (s *cmOrderServiceTestSuite) TestCreateOrder() {
+	data := []byte(`{
+		"clientOrderId": "testOrder",
+		"cumQty": "0",
+		"cumBase": "0",
+		"executedQty": "0",
+		"orderId": 22542179,
+		"avgPrice": "0.0",
+		"origQty": "10",
+		"price": "30000",
+		"reduceOnly": false,
+		"side": "BUY",
+		"positionSide": "LONG",
+		"status": "NEW",
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"updateTime": 1566818724722
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/cm/order").setFormParams(params{
+			"symbol":           "BTCUSD_PERP",
+			"side":             SideTypeBuy,
+			"type":             OrderTypeLimit,
+			"timeInForce":      TimeInForceTypeGTC,
+			"positionSide":     PositionSideTypeLong,
+			"quantity":         "10",
+			"price":            "30000",
+			"newClientOrderId": "testOrder",
+			"newOrderRespType": NewOrderRespTypeRESULT,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmCreateOrderService().Symbol("BTCUSD_PERP").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).PositionSide(PositionSideTypeLong).
+		Quantity("10").Price("30000").NewClientOrderID("testOrder").
+		NewOrderResponseType(NewOrderRespTypeRESULT).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmCreateOrderResponse{
+		ClientOrderID:    "testOrder",
+		CumQty:           "0",
+		CumBase:          "0",
+		ExecutedQuantity: "0",
+		OrderID:          22542179,
+		AvgPrice:         "0.0",
+		OrigQuantity:     "10",
+		Price:            "30000",
+		Side:             SideTypeBuy,
+		PositionSide:     PositionSideTypeLong,
+		Status:           OrderStatusTypeNew,
+		Symbol:           "BTCUSD_PERP",
+		Pair:             "BTCUSD",
+		TimeInForce:      TimeInForceTypeGTC,
+		Type:             OrderTypeLimit,
+		UpdateTime:       1566818724722,
+	}, res)
+}
+
+func (s *cmOrderServiceTestSuite) TestModifyOrder() {
+	data := []byte(`{
+		"orderId": 22542179,
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"status": "NEW",
+		"clientOrderId": "testOrder",
+		"price": "30100",
+		"avgPrice": "0.0",
+		"origQty": "5",
+		"executedQty": "0",
+		"cumBase": "0",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"origType": "LIMIT",
+		"side": "BUY",
+		"positionSide": "LONG",
+		"updateTime": 1566818724800
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPut, "/papi/v1/cm/order").setFormParams(params{
+			"symbol":            "BTCUSD_PERP",
+			"side":              SideTypeBuy,
+			"origClientOrderId": "testOrder",
+			"quantity":          "5",
+			"price":             "30100",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmModifyOrderService().Symbol("BTCUSD_PERP").Side(SideTypeBuy).
+		OrigClientOrderID("testOrder").Quantity("5").Price("30100").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmOrder{
+		AvgPrice:         "0.0",
+		ClientOrderID:    "testOrder",
+		CumBase:          "0",
+		ExecutedQuantity: "0",
+		OrderID:          22542179,
+		OrigQuantity:     "5",
+		OrigType:         OrderTypeLimit,
+		Price:            "30100",
+		Side:             SideTypeBuy,
+		PositionSide:     PositionSideTypeLong,
+		Status:           OrderStatusTypeNew,
+		Symbol:           "BTCUSD_PERP",
+		Pair:             "BTCUSD",
+		TimeInForce:      TimeInForceTypeGTC,
+		Type:             OrderTypeLimit,
+		UpdateTime:       1566818724800,
+	}, res)
+}
+
+func (s *cmOrderServiceTestSuite) TestGetOrder() {
+	data := []byte(`{
+		"avgPrice": "30000.0",
+		"clientOrderId": "testOrder",
+		"cumBase": "0.03333333",
+		"executedQty": "10",
+		"orderId": 22542179,
+		"origQty": "10",
+		"origType": "LIMIT",
+		"price": "30000",
+		"reduceOnly": false,
+		"side": "BUY",
+		"positionSide": "LONG",
+		"status": "FILLED",
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"time": 1566818724700,
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"updateTime": 1566818724722
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/order").setParams(params{
+			"symbol":  "BTCUSD_PERP",
+			"orderId": 22542179,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmGetOrderService().Symbol("BTCUSD_PERP").OrderID(22542179).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmOrder{
+		AvgPrice:         "30000.0",
+		ClientOrderID:    "testOrder",
+		CumBase:          "0.03333333",
+		ExecutedQuantity: "10",
+		OrderID:          22542179,
+		OrigQuantity:     "10",
+		OrigType:         OrderTypeLimit,
+		Price:            "30000",
+		Side:             SideTypeBuy,
+		PositionSide:     PositionSideTypeLong,
+		Status:           OrderStatusTypeFilled,
+		Symbol:           "BTCUSD_PERP",
+		Pair:             "BTCUSD",
+		Time:             1566818724700,
+		TimeInForce:      TimeInForceTypeGTC,
+		Type:             OrderTypeLimit,
+		UpdateTime:       1566818724722,
+	}, res)
+}
+
+func (s *cmOrderServiceTestSuite) TestListOrders() {
+	data := []byte(`[{"orderId": 1, "symbol": "BTCUSD_PERP", "pair": "BTCUSD", "status": "CANCELED"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/allOrders").setParams(params{
+			"pair":      "BTCUSD",
+			"startTime": 1566818724000,
+			"limit":     10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmListOrdersService().Pair("BTCUSD").StartTime(1566818724000).Limit(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*CmOrder{{OrderID: 1, Symbol: "BTCUSD_PERP", Pair: "BTCUSD", Status: OrderStatusTypeCanceled}}, res)
+}
+
+func (s *cmOrderServiceTestSuite) TestCancelOrder() {
+	data := []byte(`{
+		"avgPrice": "0.0",
+		"clientOrderId": "testOrder",
+		"cumQty": "0",
+		"executedQty": "0",
+		"orderId": 22542179,
+		"origQty": "10",
+		"price": "30000",
+		"reduceOnly": false,
+		"side": "BUY",
+		"positionSide": "LONG",
+		"status": "CANCELED",
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"updateTime": 1566818724722
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/cm/order").setFormParams(params{
+			"symbol":  "BTCUSD_PERP",
+			"orderId": 22542179,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmCancelOrderService().Symbol("BTCUSD_PERP").OrderID(22542179).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmCancelOrderResponse{
+		AvgPrice:         "0.0",
+		ClientOrderID:    "testOrder",
+		CumQuantity:      "0",
+		ExecutedQuantity: "0",
+		OrderID:          22542179,
+		OrigQuantity:     "10",
+		Price:            "30000",
+		Side:             SideTypeBuy,
+		PositionSide:     PositionSideTypeLong,
+		Status:           OrderStatusTypeCanceled,
+		Symbol:           "BTCUSD_PERP",
+		Pair:             "BTCUSD",
+		TimeInForce:      TimeInForceTypeGTC,
+		Type:             OrderTypeLimit,
+		UpdateTime:       1566818724722,
+	}, res)
+}
+
+func (s *cmOrderServiceTestSuite) TestCancelAllOpenOrders() {
+	s.mockDo([]byte(`{"code": 200, "msg": "The operation of cancel all open order is done."}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/cm/allOpenOrders").setFormParam("symbol", "BTCUSD_PERP")
+		s.assertRequestEqual(e, r)
+	})
+	err := s.client.NewCmCancelAllOpenOrdersService().Symbol("BTCUSD_PERP").Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *cmOrderServiceTestSuite) TestListForceOrders() {
+	data := []byte(`[{
+		"orderId": 165123080,
+		"symbol": "BTCUSD_200925",
+		"pair": "BTCUSD",
+		"status": "FILLED",
+		"clientOrderId": "adl_autoclose",
+		"price": "11326.9",
+		"avgPrice": "11326.9",
+		"origQty": "1",
+		"executedQty": "1",
+		"cumBase": "0.00882854",
+		"timeInForce": "IOC",
+		"type": "LIMIT",
+		"reduceOnly": false,
+		"side": "SELL",
+		"positionSide": "BOTH",
+		"origType": "LIMIT",
+		"time": 1596612186040,
+		"updateTime": 1596612186040
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/forceOrders").setParams(params{
+			"autoCloseType": ForceOrderCloseTypeADL,
+			"startTime":     1596612186000,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmListForceOrdersService().AutoCloseType(ForceOrderCloseTypeADL).
+		StartTime(1596612186000).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*CmOrder{{
+		AvgPrice:         "11326.9",
+		ClientOrderID:    "adl_autoclose",
+		CumBase:          "0.00882854",
+		ExecutedQuantity: "1",
+		OrderID:          165123080,
+		OrigQuantity:     "1",
+		OrigType:         OrderTypeLimit,
+		Price:            "11326.9",
+		Side:             SideTypeSell,
+		PositionSide:     PositionSideTypeBoth,
+		Status:           OrderStatusTypeFilled,
+		Symbol:           "BTCUSD_200925",
+		Pair:             "BTCUSD",
+		Time:             1596612186040,
+		TimeInForce:      TimeInForceType("IOC"),
+		Type:             OrderTypeLimit,
+		UpdateTime:       1596612186040,
+	}}, res)
+}
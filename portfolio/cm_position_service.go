@@ -116,3 +116,147 @@ type CmSetLeverageResponse struct {
 	Leverage int    `json:"leverage"`
 	MaxQty   string `json:"maxQty"`
 }
+
+// CmGetPositionRiskService get CM position risk
+type CmGetPositionRiskService struct {
+	c           *Client
+	marginAsset *string
+	pair        *string
+}
+
+// MarginAsset set marginAsset
+func (s *CmGetPositionRiskService) MarginAsset(marginAsset string) *CmGetPositionRiskService {
+	s.marginAsset = &marginAsset
+	return s
+}
+
+// Pair set pair
+func (s *CmGetPositionRiskService) Pair(pair string) *CmGetPositionRiskService {
+	s.pair = &pair
+	return s
+}
+
+// Do send request
+func (s *CmGetPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res []*CmPositionRisk, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/positionRisk",
+		secType:  secTypeSigned,
+	}
+	if s.marginAsset != nil {
+		r.setParam("marginAsset", *s.marginAsset)
+	}
+	if s.pair != nil {
+		r.setParam("pair", *s.pair)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CmPositionRisk{}, err
+	}
+	res = make([]*CmPositionRisk, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CmPositionRisk{}, err
+	}
+	return res, nil
+}
+
+// CmPositionRisk define CM position risk info
+type CmPositionRisk struct {
+	Symbol           string           `json:"symbol"`
+	PositionAmt      string           `json:"positionAmt"`
+	EntryPrice       string           `json:"entryPrice"`
+	BreakEvenPrice   string           `json:"breakEvenPrice"`
+	MarkPrice        string           `json:"markPrice"`
+	UnRealizedProfit string           `json:"unRealizedProfit"`
+	LiquidationPrice string           `json:"liquidationPrice"`
+	Leverage         string           `json:"leverage"`
+	MaxQty           string           `json:"maxQty"`
+	NotionalValue    string           `json:"notionalValue"`
+	PositionSide     PositionSideType `json:"positionSide"`
+	UpdateTime       int64            `json:"updateTime"`
+}
+
+// CmGetADLQuantileService get CM position ADL quantile estimation
+type CmGetADLQuantileService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *CmGetADLQuantileService) Symbol(symbol string) *CmGetADLQuantileService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *CmGetADLQuantileService) Do(ctx context.Context, opts ...RequestOption) (res []*ADLQuantile, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/adlQuantile",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	res = make([]*ADLQuantile, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	return res, nil
+}
+
+// CmGetLeverageBracketService get CM quantity and leverage brackets
+type CmGetLeverageBracketService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *CmGetLeverageBracketService) Symbol(symbol string) *CmGetLeverageBracketService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *CmGetLeverageBracketService) Do(ctx context.Context, opts ...RequestOption) (res []*CmLeverageBracket, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/leverageBracket",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CmLeverageBracket{}, err
+	}
+	res = make([]*CmLeverageBracket, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CmLeverageBracket{}, err
+	}
+	return res, nil
+}
+
+// CmLeverageBracket define CM leverage bracket of a symbol
+type CmLeverageBracket struct {
+	Symbol   string      `json:"symbol"`
+	Brackets []CmBracket `json:"brackets"`
+}
+
+// CmBracket define CM bracket info
+type CmBracket struct {
+	Bracket          int     `json:"bracket"`
+	InitialLeverage  int     `json:"initialLeverage"`
+	QtyCap           float64 `json:"qtyCap"`
+	QtyFloor         float64 `json:"qtyFloor"`
+	MaintMarginRatio float64 `json:"maintMarginRatio"`
+	Cum              float64 `json:"cum"`
+}
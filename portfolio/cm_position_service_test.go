@@ -0,0 +1,130 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type cmPositionServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestCmPositionService(t *testing.T) {
+	suite.Run(t, new(cmPositionServiceTestSuite))
+}
+
+func (s *cmPositionServiceTestSuite) TestChangePositionMode() {
+	s.mockDo([]byte(`{"code": 200, "msg": "success"}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/cm/positionSide/dual").setParam("dualSidePosition", false)
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmChangePositionModeService().DualSidePosition(false).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmChangePositionMode{Code: 200, Msg: "success"}, res)
+}
+
+func (s *cmPositionServiceTestSuite) TestGetPositionMode() {
+	s.mockDo([]byte(`{"dualSidePosition": false}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/positionSide/dual")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmGetPositionModeService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CmGetPositionMode{DualSidePosition: false}, res)
+}
+
+func (s *cmPositionServiceTestSuite) TestGetPositionRisk() {
+	data := []byte(`[{
+		"symbol": "BTCUSD_201225",
+		"positionAmt": "1",
+		"entryPrice": "11707.70000003",
+		"markPrice": "11788.66626667",
+		"unRealizedProfit": "0.00005866",
+		"liquidationPrice": "6170.20509059",
+		"leverage": "125",
+		"positionSide": "LONG",
+		"updateTime": 1627026881327,
+		"maxQty": "50",
+		"notionalValue": "0.00084827",
+		"breakEvenPrice": "11710.1"
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/positionRisk").setParams(params{
+			"marginAsset": "BTC",
+			"pair":        "BTCUSD",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmGetPositionRiskService().MarginAsset("BTC").Pair("BTCUSD").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*CmPositionRisk{{
+		Symbol:           "BTCUSD_201225",
+		PositionAmt:      "1",
+		EntryPrice:       "11707.70000003",
+		BreakEvenPrice:   "11710.1",
+		MarkPrice:        "11788.66626667",
+		UnRealizedProfit: "0.00005866",
+		LiquidationPrice: "6170.20509059",
+		Leverage:         "125",
+		MaxQty:           "50",
+		NotionalValue:    "0.00084827",
+		PositionSide:     PositionSideTypeLong,
+		UpdateTime:       1627026881327,
+	}}, res)
+}
+
+func (s *cmPositionServiceTestSuite) TestGetADLQuantile() {
+	data := []byte(`[{"symbol": "BTCUSD_200925", "adlQuantile": {"LONG": 3, "SHORT": 3, "HEDGE": 0}}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/adlQuantile").setParam("symbol", "BTCUSD_200925")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmGetADLQuantileService().Symbol("BTCUSD_200925").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*ADLQuantile{
+		{Symbol: "BTCUSD_200925", ADLQuantile: map[string]int64{"LONG": 3, "SHORT": 3, "HEDGE": 0}},
+	}, res)
+}
+
+func (s *cmPositionServiceTestSuite) TestGetLeverageBracket() {
+	data := []byte(`[{
+		"symbol": "BTCUSD_PERP",
+		"brackets": [{
+			"bracket": 1,
+			"initialLeverage": 125,
+			"qtyCap": 50,
+			"qtyFloor": 0,
+			"maintMarginRatio": 0.004,
+			"cum": 0.0
+		}]
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/cm/leverageBracket")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCmGetLeverageBracketService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*CmLeverageBracket{{
+		Symbol: "BTCUSD_PERP",
+		Brackets: []CmBracket{{
+			Bracket:          1,
+			InitialLeverage:  125,
+			QtyCap:           50,
+			QtyFloor:         0,
+			MaintMarginRatio: 0.004,
+			Cum:              0,
+		}},
+	}}, res)
+}
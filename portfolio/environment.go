@@ -0,0 +1,105 @@
+package portfolio
+
+import (
+	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
+)
+
+// Environment define the endpoints and connection settings used by a Client and the websocket streams,
+// the package level variables are only used to build the default one
+type Environment struct {
+	// BaseURL is the base url of the REST API
+	BaseURL string
+	// WsURL is the base endpoint of the user data streams
+	WsURL string
+	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
+	WebsocketKeepalive bool
+	// WebsocketTimeout is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
+	WebsocketTimeout time.Duration
+	// Dialer is used as is to open websocket connections when it is set
+	Dialer *websocket.Dialer
+	// Metrics receives the health measurements of the websocket connections when it is set
+	Metrics common.WsMetrics
+}
+
+// MainnetEnvironment return the production environment
+func MainnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiMainUrl,
+		WsURL:            baseWsMainUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// TestnetEnvironment return the testnet environment
+func TestnetEnvironment() *Environment {
+	return &Environment{
+		BaseURL:          baseApiTestnetUrl,
+		WsURL:            baseWsTestnetUrl,
+		WebsocketTimeout: time.Second * 60,
+	}
+}
+
+// DefaultEnvironment return the environment selected by the UseTestnet, WebsocketKeepalive,
+// WebsocketTimeout and WebsocketMetrics package variables
+func DefaultEnvironment() *Environment {
+	e := MainnetEnvironment()
+	if UseTestnet {
+		e = TestnetEnvironment()
+	}
+	e.WebsocketKeepalive = WebsocketKeepalive
+	e.WebsocketTimeout = WebsocketTimeout
+	e.Metrics = WebsocketMetrics
+	return e
+}
+
+// getApiEndpoint return the base endpoint of the Rest API according the UseTestnet flag
+func getApiEndpoint() string {
+	return DefaultEnvironment().BaseURL
+}
+
+func (e *Environment) getWsEndpoint() string {
+	return e.WsURL
+}
+
+func (e *Environment) newWsConfig(endpoint string) *WsConfig {
+	return &WsConfig{
+		Endpoint:  endpoint,
+		Keepalive: e.WebsocketKeepalive,
+		Timeout:   e.WebsocketTimeout,
+		Dialer:    e.Dialer,
+		Metrics:   e.Metrics,
+	}
+}
+
+// WsUserDataServe is a wrapper around DefaultEnvironment().WsUserDataServe
+func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServeWithIP is a wrapper around DefaultEnvironment().WsUserDataServeWithIP
+func WsUserDataServeWithIP(ip string, listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataServeWithIP(ip, listenKey, handler, errHandler)
+}
+
+// WsUserDataDispatchServe is a wrapper around DefaultEnvironment().WsUserDataDispatchServe
+func WsUserDataDispatchServe(listenKey string, dispatcher *WsUserDataDispatcher, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataDispatchServe(listenKey, dispatcher, errHandler)
+}
+
+// WsUserDataDispatchServeWithIP is a wrapper around DefaultEnvironment().WsUserDataDispatchServeWithIP
+func WsUserDataDispatchServeWithIP(ip string, listenKey string, dispatcher *WsUserDataDispatcher, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsUserDataDispatchServeWithIP(ip, listenKey, dispatcher, errHandler)
+}
+
+// UmWsPackedUserDataServe is a wrapper around DefaultEnvironment().UmWsPackedUserDataServe
+func UmWsPackedUserDataServe(listenKey string, handler WsPackedUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().UmWsPackedUserDataServe(listenKey, handler, errHandler)
+}
+
+// UmWsPackedUserDataServeWithIP is a wrapper around DefaultEnvironment().UmWsPackedUserDataServeWithIP
+func UmWsPackedUserDataServeWithIP(ip string, listenKey string, handler WsPackedUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().UmWsPackedUserDataServeWithIP(ip, listenKey, handler, errHandler)
+}
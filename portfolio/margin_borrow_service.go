@@ -89,3 +89,404 @@ func (s *MarginRepayService) Do(ctx context.Context, opts ...RequestOption) (res
 type RepayLoanResponse struct {
 	TransactionId int64 `json:"tranId"`
 }
+
+// MarginGetLoanHistoryService query margin loan records
+type MarginGetLoanHistoryService struct {
+	c         *Client
+	asset     string
+	txID      *int64
+	startTime *int64
+	endTime   *int64
+	current   *int64
+	size      *int64
+	archived  *bool
+}
+
+// Asset set asset
+func (s *MarginGetLoanHistoryService) Asset(asset string) *MarginGetLoanHistoryService {
+	s.asset = asset
+	return s
+}
+
+// TxID set transaction id
+func (s *MarginGetLoanHistoryService) TxID(txID int64) *MarginGetLoanHistoryService {
+	s.txID = &txID
+	return s
+}
+
+// StartTime set start time
+func (s *MarginGetLoanHistoryService) StartTime(startTime int64) *MarginGetLoanHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *MarginGetLoanHistoryService) EndTime(endTime int64) *MarginGetLoanHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *MarginGetLoanHistoryService) Current(current int64) *MarginGetLoanHistoryService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *MarginGetLoanHistoryService) Size(size int64) *MarginGetLoanHistoryService {
+	s.size = &size
+	return s
+}
+
+// Archived set archived, query records older than 6 months
+func (s *MarginGetLoanHistoryService) Archived(archived bool) *MarginGetLoanHistoryService {
+	s.archived = &archived
+	return s
+}
+
+// Do send request
+func (s *MarginGetLoanHistoryService) Do(ctx context.Context, opts ...RequestOption) (res *MarginLoanHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/marginLoan",
+		secType:  secTypeSigned,
+	}
+	r.setParam("asset", s.asset)
+	if s.txID != nil {
+		r.setParam("txId", *s.txID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	if s.archived != nil {
+		r.setParam("archived", *s.archived)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginLoanHistory)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginLoanHistory define margin loan history response
+type MarginLoanHistory struct {
+	Rows  []MarginLoanRecord `json:"rows"`
+	Total int64              `json:"total"`
+}
+
+// MarginLoanRecord define margin loan record
+type MarginLoanRecord struct {
+	TxID      int64  `json:"txId"`
+	Asset     string `json:"asset"`
+	Principal string `json:"principal"`
+	Timestamp int64  `json:"timestamp"`
+	Status    string `json:"status"`
+}
+
+// MarginGetRepayHistoryService query margin repay records
+type MarginGetRepayHistoryService struct {
+	c         *Client
+	asset     string
+	txID      *int64
+	startTime *int64
+	endTime   *int64
+	current   *int64
+	size      *int64
+	archived  *bool
+}
+
+// Asset set asset
+func (s *MarginGetRepayHistoryService) Asset(asset string) *MarginGetRepayHistoryService {
+	s.asset = asset
+	return s
+}
+
+// TxID set transaction id
+func (s *MarginGetRepayHistoryService) TxID(txID int64) *MarginGetRepayHistoryService {
+	s.txID = &txID
+	return s
+}
+
+// StartTime set start time
+func (s *MarginGetRepayHistoryService) StartTime(startTime int64) *MarginGetRepayHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *MarginGetRepayHistoryService) EndTime(endTime int64) *MarginGetRepayHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *MarginGetRepayHistoryService) Current(current int64) *MarginGetRepayHistoryService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *MarginGetRepayHistoryService) Size(size int64) *MarginGetRepayHistoryService {
+	s.size = &size
+	return s
+}
+
+// Archived set archived, query records older than 6 months
+func (s *MarginGetRepayHistoryService) Archived(archived bool) *MarginGetRepayHistoryService {
+	s.archived = &archived
+	return s
+}
+
+// Do send request
+func (s *MarginGetRepayHistoryService) Do(ctx context.Context, opts ...RequestOption) (res *MarginRepayHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/repayLoan",
+		secType:  secTypeSigned,
+	}
+	r.setParam("asset", s.asset)
+	if s.txID != nil {
+		r.setParam("txId", *s.txID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	if s.archived != nil {
+		r.setParam("archived", *s.archived)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginRepayHistory)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginRepayHistory define margin repay history response
+type MarginRepayHistory struct {
+	Rows  []MarginRepayRecord `json:"rows"`
+	Total int64               `json:"total"`
+}
+
+// MarginRepayRecord define margin repay record
+type MarginRepayRecord struct {
+	TxID      int64  `json:"txId"`
+	Asset     string `json:"asset"`
+	Amount    string `json:"amount"`
+	Interest  string `json:"interest"`
+	Principal string `json:"principal"`
+	Timestamp int64  `json:"timestamp"`
+	Status    string `json:"status"`
+}
+
+// MarginGetInterestHistoryService query margin interest records
+type MarginGetInterestHistoryService struct {
+	c         *Client
+	asset     *string
+	startTime *int64
+	endTime   *int64
+	current   *int64
+	size      *int64
+	archived  *bool
+}
+
+// Asset set asset
+func (s *MarginGetInterestHistoryService) Asset(asset string) *MarginGetInterestHistoryService {
+	s.asset = &asset
+	return s
+}
+
+// StartTime set start time
+func (s *MarginGetInterestHistoryService) StartTime(startTime int64) *MarginGetInterestHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *MarginGetInterestHistoryService) EndTime(endTime int64) *MarginGetInterestHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *MarginGetInterestHistoryService) Current(current int64) *MarginGetInterestHistoryService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *MarginGetInterestHistoryService) Size(size int64) *MarginGetInterestHistoryService {
+	s.size = &size
+	return s
+}
+
+// Archived set archived, query records older than 6 months
+func (s *MarginGetInterestHistoryService) Archived(archived bool) *MarginGetInterestHistoryService {
+	s.archived = &archived
+	return s
+}
+
+// Do send request
+func (s *MarginGetInterestHistoryService) Do(ctx context.Context, opts ...RequestOption) (res *MarginInterestHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/marginInterestHistory",
+		secType:  secTypeSigned,
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	if s.archived != nil {
+		r.setParam("archived", *s.archived)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginInterestHistory)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginInterestHistory define margin interest history response
+type MarginInterestHistory struct {
+	Rows  []MarginInterestRecord `json:"rows"`
+	Total int64                  `json:"total"`
+}
+
+// MarginInterestRecord define margin interest record
+type MarginInterestRecord struct {
+	TxID                int64  `json:"txId"`
+	InterestAccuredTime int64  `json:"interestAccuredTime"`
+	Asset               string `json:"asset"`
+	RawAsset            string `json:"rawAsset"` // will not be returned for isolated margin
+	Principal           string `json:"principal"`
+	Interest            string `json:"interest"`
+	InterestRate        string `json:"interestRate"`
+	Type                string `json:"type"`
+}
+
+// MarginListForceOrdersService list margin liquidation records
+type MarginListForceOrdersService struct {
+	c         *Client
+	startTime *int64
+	endTime   *int64
+	current   *int64
+	size      *int64
+}
+
+// StartTime set start time
+func (s *MarginListForceOrdersService) StartTime(startTime int64) *MarginListForceOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *MarginListForceOrdersService) EndTime(endTime int64) *MarginListForceOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *MarginListForceOrdersService) Current(current int64) *MarginListForceOrdersService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *MarginListForceOrdersService) Size(size int64) *MarginListForceOrdersService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *MarginListForceOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *MarginForceOrders, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/forceOrders",
+		secType:  secTypeSigned,
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginForceOrders)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginForceOrders define margin force orders response
+type MarginForceOrders struct {
+	Rows  []MarginForceOrder `json:"rows"`
+	Total int64              `json:"total"`
+}
+
+// MarginForceOrder define margin force order
+type MarginForceOrder struct {
+	AvgPrice    string          `json:"avgPrice"`
+	ExecutedQty string          `json:"executedQty"`
+	OrderID     int64           `json:"orderId"`
+	Price       string          `json:"price"`
+	Qty         string          `json:"qty"`
+	Side        SideType        `json:"side"`
+	Symbol      string          `json:"symbol"`
+	TimeInForce TimeInForceType `json:"timeInForce"`
+	UpdatedTime int64           `json:"updatedTime"`
+}
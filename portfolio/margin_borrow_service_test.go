@@ -0,0 +1,103 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type marginBorrowServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestMarginBorrowService(t *testing.T) {
+	suite.Run(t, new(marginBorrowServiceTestSuite))
+}
+
+func (s *marginBorrowServiceTestSuite) TestGetInterestHistory() {
+	data := []byte(`{
+		"rows": [{
+			"txId": 1352286576452864727,
+			"interestAccuredTime": 1672160400000,
+			"asset": "USDT",
+			"rawAsset": "USDT",
+			"principal": "45.3313",
+			"interest": "0.00024995",
+			"interestRate": "0.00013233",
+			"type": "ON_BORROW"
+		}],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/marginInterestHistory").setParams(params{
+			"asset":     "USDT",
+			"startTime": 1672156800000,
+			"endTime":   1672243200000,
+			"current":   1,
+			"size":      10,
+			"archived":  true,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginGetInterestHistoryService().Asset("USDT").StartTime(1672156800000).
+		EndTime(1672243200000).Current(1).Size(10).Archived(true).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginInterestHistory{
+		Rows: []MarginInterestRecord{{
+			TxID:                1352286576452864727,
+			InterestAccuredTime: 1672160400000,
+			Asset:               "USDT",
+			RawAsset:            "USDT",
+			Principal:           "45.3313",
+			Interest:            "0.00024995",
+			InterestRate:        "0.00013233",
+			Type:                "ON_BORROW",
+		}},
+		Total: 1,
+	}, res)
+}
+
+func (s *marginBorrowServiceTestSuite) TestListForceOrders() {
+	data := []byte(`{
+		"rows": [{
+			"avgPrice": "0.00388359",
+			"executedQty": "31.39000000",
+			"orderId": 180015097,
+			"price": "0.00388110",
+			"qty": "31.39000000",
+			"side": "SELL",
+			"symbol": "BNBBTC",
+			"timeInForce": "GTC",
+			"updatedTime": 1558941374745
+		}],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/forceOrders").setParams(params{
+			"startTime": 1558941374000,
+			"size":      10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginListForceOrdersService().StartTime(1558941374000).Size(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginForceOrders{
+		Rows: []MarginForceOrder{{
+			AvgPrice:    "0.00388359",
+			ExecutedQty: "31.39000000",
+			OrderID:     180015097,
+			Price:       "0.00388110",
+			Qty:         "31.39000000",
+			Side:        SideTypeSell,
+			Symbol:      "BNBBTC",
+			TimeInForce: TimeInForceTypeGTC,
+			UpdatedTime: 1558941374745,
+		}},
+		Total: 1,
+	}, res)
+}
@@ -0,0 +1,412 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// MarginCreateOCOService create margin OCO order
+type MarginCreateOCOService struct {
+	c                    *Client
+	symbol               string
+	listClientOrderID    *string
+	side                 SideType
+	quantity             string
+	limitClientOrderID   *string
+	price                string
+	limitIcebergQty      *string
+	stopClientOrderID    *string
+	stopPrice            string
+	stopLimitPrice       *string
+	stopIcebergQty       *string
+	stopLimitTimeInForce *TimeInForceType
+	newOrderRespType     *NewOrderRespType
+	sideEffectType       *SideEffectType
+}
+
+// Symbol set symbol
+func (s *MarginCreateOCOService) Symbol(symbol string) *MarginCreateOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *MarginCreateOCOService) Side(side SideType) *MarginCreateOCOService {
+	s.side = side
+	return s
+}
+
+// Quantity set quantity
+func (s *MarginCreateOCOService) Quantity(quantity string) *MarginCreateOCOService {
+	s.quantity = quantity
+	return s
+}
+
+// ListClientOrderID set listClientOrderID
+func (s *MarginCreateOCOService) ListClientOrderID(listClientOrderID string) *MarginCreateOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// LimitClientOrderID set limitClientOrderID
+func (s *MarginCreateOCOService) LimitClientOrderID(limitClientOrderID string) *MarginCreateOCOService {
+	s.limitClientOrderID = &limitClientOrderID
+	return s
+}
+
+// Price set price
+func (s *MarginCreateOCOService) Price(price string) *MarginCreateOCOService {
+	s.price = price
+	return s
+}
+
+// LimitIcebergQuantity set limitIcebergQuantity
+func (s *MarginCreateOCOService) LimitIcebergQuantity(limitIcebergQty string) *MarginCreateOCOService {
+	s.limitIcebergQty = &limitIcebergQty
+	return s
+}
+
+// StopClientOrderID set stopClientOrderID
+func (s *MarginCreateOCOService) StopClientOrderID(stopClientOrderID string) *MarginCreateOCOService {
+	s.stopClientOrderID = &stopClientOrderID
+	return s
+}
+
+// StopPrice set stop price
+func (s *MarginCreateOCOService) StopPrice(stopPrice string) *MarginCreateOCOService {
+	s.stopPrice = stopPrice
+	return s
+}
+
+// StopLimitPrice set stop limit price
+func (s *MarginCreateOCOService) StopLimitPrice(stopLimitPrice string) *MarginCreateOCOService {
+	s.stopLimitPrice = &stopLimitPrice
+	return s
+}
+
+// StopIcebergQty set stop iceberg quantity
+func (s *MarginCreateOCOService) StopIcebergQty(stopIcebergQty string) *MarginCreateOCOService {
+	s.stopIcebergQty = &stopIcebergQty
+	return s
+}
+
+// StopLimitTimeInForce set stopLimitTimeInForce
+func (s *MarginCreateOCOService) StopLimitTimeInForce(stopLimitTimeInForce TimeInForceType) *MarginCreateOCOService {
+	s.stopLimitTimeInForce = &stopLimitTimeInForce
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *MarginCreateOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *MarginCreateOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SideEffectType set sideEffectType
+func (s *MarginCreateOCOService) SideEffectType(sideEffectType SideEffectType) *MarginCreateOCOService {
+	s.sideEffectType = &sideEffectType
+	return s
+}
+
+// Do send request
+func (s *MarginCreateOCOService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOCOResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/margin/order/oco",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":    s.symbol,
+		"side":      s.side,
+		"quantity":  s.quantity,
+		"price":     s.price,
+		"stopPrice": s.stopPrice,
+	}
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	if s.limitClientOrderID != nil {
+		m["limitClientOrderId"] = *s.limitClientOrderID
+	}
+	if s.limitIcebergQty != nil {
+		m["limitIcebergQty"] = *s.limitIcebergQty
+	}
+	if s.stopClientOrderID != nil {
+		m["stopClientOrderId"] = *s.stopClientOrderID
+	}
+	if s.stopLimitPrice != nil {
+		m["stopLimitPrice"] = *s.stopLimitPrice
+	}
+	if s.stopIcebergQty != nil {
+		m["stopIcebergQty"] = *s.stopIcebergQty
+	}
+	if s.stopLimitTimeInForce != nil {
+		m["stopLimitTimeInForce"] = *s.stopLimitTimeInForce
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.sideEffectType != nil {
+		m["sideEffectType"] = *s.sideEffectType
+	}
+	r.setFormParams(m)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOCOResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginOCOResponse define create or cancel margin OCO response
+type MarginOCOResponse struct {
+	OrderListID           int64                   `json:"orderListId"`
+	ContingencyType       string                  `json:"contingencyType"`
+	ListStatusType        string                  `json:"listStatusType"`
+	ListOrderStatus       string                  `json:"listOrderStatus"`
+	ListClientOrderID     string                  `json:"listClientOrderId"`
+	TransactionTime       int64                   `json:"transactionTime"`
+	Symbol                string                  `json:"symbol"`
+	MarginBuyBorrowAsset  string                  `json:"marginBuyBorrowAsset,omitempty"`
+	MarginBuyBorrowAmount string                  `json:"marginBuyBorrowAmount,omitempty"`
+	Orders                []*MarginOCOOrder       `json:"orders"`
+	OrderReports          []*MarginOCOOrderReport `json:"orderReports"`
+}
+
+// MarginOCOOrder define order of an OCO
+type MarginOCOOrder struct {
+	Symbol        string `json:"symbol"`
+	OrderID       int64  `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+}
+
+// MarginOCOOrderReport define order report of an OCO
+type MarginOCOOrderReport struct {
+	Symbol              string          `json:"symbol"`
+	OrderID             int64           `json:"orderId"`
+	OrderListID         int64           `json:"orderListId"`
+	ClientOrderID       string          `json:"clientOrderId"`
+	OrigClientOrderID   string          `json:"origClientOrderId"`
+	TransactionTime     int64           `json:"transactTime"`
+	Price               string          `json:"price"`
+	OrigQuantity        string          `json:"origQty"`
+	ExecutedQuantity    string          `json:"executedQty"`
+	CummulativeQuoteQty string          `json:"cummulativeQuoteQty"`
+	Status              OrderStatusType `json:"status"`
+	TimeInForce         TimeInForceType `json:"timeInForce"`
+	Type                OrderType       `json:"type"`
+	Side                SideType        `json:"side"`
+	StopPrice           string          `json:"stopPrice"`
+	IcebergQuantity     string          `json:"icebergQty"`
+}
+
+// MarginCancelOCOService cancel a margin OCO
+type MarginCancelOCOService struct {
+	c                 *Client
+	symbol            string
+	orderListID       *int64
+	listClientOrderID *string
+	newClientOrderID  *string
+}
+
+// Symbol set symbol
+func (s *MarginCancelOCOService) Symbol(symbol string) *MarginCancelOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderListID set orderListID
+func (s *MarginCancelOCOService) OrderListID(orderListID int64) *MarginCancelOCOService {
+	s.orderListID = &orderListID
+	return s
+}
+
+// ListClientOrderID set listClientOrderID
+func (s *MarginCancelOCOService) ListClientOrderID(listClientOrderID string) *MarginCancelOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// NewClientOrderID set newClientOrderID
+func (s *MarginCancelOCOService) NewClientOrderID(newClientOrderID string) *MarginCancelOCOService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// Do send request
+func (s *MarginCancelOCOService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOCOResponse, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/margin/orderList",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.orderListID != nil {
+		r.setFormParam("orderListId", *s.orderListID)
+	}
+	if s.listClientOrderID != nil {
+		r.setFormParam("listClientOrderId", *s.listClientOrderID)
+	}
+	if s.newClientOrderID != nil {
+		r.setFormParam("newClientOrderId", *s.newClientOrderID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOCOResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginOCO define margin OCO info
+type MarginOCO struct {
+	OrderListID       int64             `json:"orderListId"`
+	ContingencyType   string            `json:"contingencyType"`
+	ListStatusType    string            `json:"listStatusType"`
+	ListOrderStatus   string            `json:"listOrderStatus"`
+	ListClientOrderID string            `json:"listClientOrderId"`
+	TransactionTime   int64             `json:"transactionTime"`
+	Symbol            string            `json:"symbol"`
+	Orders            []*MarginOCOOrder `json:"orders"`
+}
+
+// MarginGetOCOService get a margin OCO
+type MarginGetOCOService struct {
+	c                 *Client
+	orderListID       *int64
+	origClientOrderID *string
+}
+
+// OrderListID set orderListID
+func (s *MarginGetOCOService) OrderListID(orderListID int64) *MarginGetOCOService {
+	s.orderListID = &orderListID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *MarginGetOCOService) OrigClientOrderID(origClientOrderID string) *MarginGetOCOService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *MarginGetOCOService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOCO, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/orderList",
+		secType:  secTypeSigned,
+	}
+	if s.orderListID != nil {
+		r.setParam("orderListId", *s.orderListID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOCO)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginListOCOService list all margin OCO
+type MarginListOCOService struct {
+	c         *Client
+	fromID    *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// FromID set fromID
+func (s *MarginListOCOService) FromID(fromID int64) *MarginListOCOService {
+	s.fromID = &fromID
+	return s
+}
+
+// StartTime set startTime
+func (s *MarginListOCOService) StartTime(startTime int64) *MarginListOCOService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *MarginListOCOService) EndTime(endTime int64) *MarginListOCOService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *MarginListOCOService) Limit(limit int) *MarginListOCOService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *MarginListOCOService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginOCO, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/allOrderList",
+		secType:  secTypeSigned,
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	res = make([]*MarginOCO, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	return res, nil
+}
+
+// MarginListOpenOCOService list open margin OCO
+type MarginListOpenOCOService struct {
+	c *Client
+}
+
+// Do send request
+func (s *MarginListOpenOCOService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginOCO, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/openOrderList",
+		secType:  secTypeSigned,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	res = make([]*MarginOCO, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,240 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type marginOCOServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestMarginOCOService(t *testing.T) {
+	suite.Run(t, new(marginOCOServiceTestSuite))
+}
+
+func (s *marginOCOServiceTestSuite) TestCreateOCO() {
+	data := []byte(`{
+		"orderListId": 0,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "testList",
+		"transactionTime": 1563417480525,
+		"symbol": "LTCBTC",
+		"marginBuyBorrowAmount": "5",
+		"marginBuyBorrowAsset": "BTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 2, "clientOrderId": "testStop"},
+			{"symbol": "LTCBTC", "orderId": 3, "clientOrderId": "testLimit"}
+		],
+		"orderReports": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 2,
+				"orderListId": 0,
+				"clientOrderId": "testStop",
+				"transactTime": 1563417480525,
+				"price": "0.000000",
+				"origQty": "0.624363",
+				"executedQty": "0.000000",
+				"cummulativeQuoteQty": "0.000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "STOP_LOSS",
+				"side": "BUY",
+				"stopPrice": "0.960664"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/margin/order/oco").setFormParams(params{
+			"symbol":               "LTCBTC",
+			"side":                 SideTypeBuy,
+			"quantity":             "0.624363",
+			"price":                "1.000000",
+			"stopPrice":            "0.960664",
+			"stopLimitPrice":       "0.960000",
+			"stopLimitTimeInForce": TimeInForceTypeGTC,
+			"listClientOrderId":    "testList",
+			"limitClientOrderId":   "testLimit",
+			"stopClientOrderId":    "testStop",
+			"newOrderRespType":     NewOrderRespTypeRESULT,
+			"sideEffectType":       SideEffectTypeMarginBuy,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginCreateOCOService().Symbol("LTCBTC").Side(SideTypeBuy).
+		Quantity("0.624363").Price("1.000000").StopPrice("0.960664").StopLimitPrice("0.960000").
+		StopLimitTimeInForce(TimeInForceTypeGTC).ListClientOrderID("testList").
+		LimitClientOrderID("testLimit").StopClientOrderID("testStop").
+		NewOrderRespType(NewOrderRespTypeRESULT).SideEffectType(SideEffectTypeMarginBuy).
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginOCOResponse{
+		OrderListID:           0,
+		ContingencyType:       "OCO",
+		ListStatusType:        "EXEC_STARTED",
+		ListOrderStatus:       "EXECUTING",
+		ListClientOrderID:     "testList",
+		TransactionTime:       1563417480525,
+		Symbol:                "LTCBTC",
+		MarginBuyBorrowAsset:  "BTC",
+		MarginBuyBorrowAmount: "5",
+		Orders: []*MarginOCOOrder{
+			{Symbol: "LTCBTC", OrderID: 2, ClientOrderID: "testStop"},
+			{Symbol: "LTCBTC", OrderID: 3, ClientOrderID: "testLimit"},
+		},
+		OrderReports: []*MarginOCOOrderReport{{
+			Symbol:              "LTCBTC",
+			OrderID:             2,
+			ClientOrderID:       "testStop",
+			TransactionTime:     1563417480525,
+			Price:               "0.000000",
+			OrigQuantity:        "0.624363",
+			ExecutedQuantity:    "0.000000",
+			CummulativeQuoteQty: "0.000000",
+			Status:              OrderStatusTypeNew,
+			TimeInForce:         TimeInForceTypeGTC,
+			Type:                OrderType("STOP_LOSS"),
+			Side:                SideTypeBuy,
+			StopPrice:           "0.960664",
+		}},
+	}, res)
+}
+
+func (s *marginOCOServiceTestSuite) TestCancelOCO() {
+	data := []byte(`{
+		"orderListId": 0,
+		"contingencyType": "OCO",
+		"listStatusType": "ALL_DONE",
+		"listOrderStatus": "ALL_DONE",
+		"listClientOrderId": "testList",
+		"transactionTime": 1574040868128,
+		"symbol": "LTCBTC",
+		"orders": [{"symbol": "LTCBTC", "orderId": 2, "clientOrderId": "testStop"}],
+		"orderReports": [{
+			"symbol": "LTCBTC",
+			"origClientOrderId": "testStop",
+			"orderId": 2,
+			"orderListId": 0,
+			"clientOrderId": "cancelStop",
+			"price": "1.00000000",
+			"origQty": "10.00000000",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "CANCELED",
+			"timeInForce": "GTC",
+			"type": "STOP_LOSS_LIMIT",
+			"side": "SELL",
+			"stopPrice": "1.00000000"
+		}]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/margin/orderList").setFormParams(params{
+			"symbol":           "LTCBTC",
+			"orderListId":      0,
+			"newClientOrderId": "cancelStop",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginCancelOCOService().Symbol("LTCBTC").OrderListID(0).
+		NewClientOrderID("cancelStop").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginOCOResponse{
+		ContingencyType:   "OCO",
+		ListStatusType:    "ALL_DONE",
+		ListOrderStatus:   "ALL_DONE",
+		ListClientOrderID: "testList",
+		TransactionTime:   1574040868128,
+		Symbol:            "LTCBTC",
+		Orders:            []*MarginOCOOrder{{Symbol: "LTCBTC", OrderID: 2, ClientOrderID: "testStop"}},
+		OrderReports: []*MarginOCOOrderReport{{
+			Symbol:              "LTCBTC",
+			OrderID:             2,
+			ClientOrderID:       "cancelStop",
+			OrigClientOrderID:   "testStop",
+			Price:               "1.00000000",
+			OrigQuantity:        "10.00000000",
+			ExecutedQuantity:    "0.00000000",
+			CummulativeQuoteQty: "0.00000000",
+			Status:              OrderStatusTypeCanceled,
+			TimeInForce:         TimeInForceTypeGTC,
+			Type:                OrderType("STOP_LOSS_LIMIT"),
+			Side:                SideTypeSell,
+			StopPrice:           "1.00000000",
+		}},
+	}, res)
+}
+
+func (s *marginOCOServiceTestSuite) TestGetOCO() {
+	data := []byte(`{
+		"orderListId": 27,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "testList",
+		"transactionTime": 1565245656253,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "testStop"},
+			{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "testLimit"}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/orderList").setParam("orderListId", 27)
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginGetOCOService().OrderListID(27).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginOCO{
+		OrderListID:       27,
+		ContingencyType:   "OCO",
+		ListStatusType:    "EXEC_STARTED",
+		ListOrderStatus:   "EXECUTING",
+		ListClientOrderID: "testList",
+		TransactionTime:   1565245656253,
+		Symbol:            "LTCBTC",
+		Orders: []*MarginOCOOrder{
+			{Symbol: "LTCBTC", OrderID: 4, ClientOrderID: "testStop"},
+			{Symbol: "LTCBTC", OrderID: 5, ClientOrderID: "testLimit"},
+		},
+	}, res)
+}
+
+func (s *marginOCOServiceTestSuite) TestListOCO() {
+	data := []byte(`[{"orderListId": 29, "contingencyType": "OCO", "symbol": "LTCBTC"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/allOrderList").setParams(params{
+			"fromId": 20,
+			"limit":  10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginListOCOService().FromID(20).Limit(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*MarginOCO{{OrderListID: 29, ContingencyType: "OCO", Symbol: "LTCBTC"}}, res)
+}
+
+func (s *marginOCOServiceTestSuite) TestListOpenOCO() {
+	data := []byte(`[{"orderListId": 31, "contingencyType": "OCO", "listStatusType": "EXEC_STARTED", "symbol": "LTCBTC"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/openOrderList")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginListOpenOCOService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*MarginOCO{{OrderListID: 31, ContingencyType: "OCO", ListStatusType: "EXEC_STARTED", Symbol: "LTCBTC"}}, res)
+}
@@ -212,3 +212,314 @@ func (s *MarginCancelOrderService) Do(ctx context.Context, opts ...RequestOption
 	}
 	return res, nil
 }
+
+// MarginOrder define margin order info
+type MarginOrder struct {
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 int64                   `json:"orderId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	Price                   string                  `json:"price"`
+	OrigQuantity            string                  `json:"origQty"`
+	ExecutedQuantity        string                  `json:"executedQty"`
+	CummulativeQuoteQty     string                  `json:"cummulativeQuoteQty"`
+	Status                  OrderStatusType         `json:"status"`
+	TimeInForce             TimeInForceType         `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    SideType                `json:"side"`
+	StopPrice               string                  `json:"stopPrice"`
+	IcebergQuantity         string                  `json:"icebergQty"`
+	Time                    int64                   `json:"time"`
+	UpdateTime              int64                   `json:"updateTime"`
+	IsWorking               bool                    `json:"isWorking"`
+	AccountID               int64                   `json:"accountId"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+	PreventedMatchID        int64                   `json:"preventedMatchId,omitempty"`
+	PreventedQuantity       string                  `json:"preventedQuantity,omitempty"`
+}
+
+// MarginGetOrderService get a margin order
+type MarginGetOrderService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// Symbol set symbol
+func (s *MarginGetOrderService) Symbol(symbol string) *MarginGetOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *MarginGetOrderService) OrderID(orderID int64) *MarginGetOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *MarginGetOrderService) OrigClientOrderID(origClientOrderID string) *MarginGetOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *MarginGetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/order",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginListOpenOrdersService list margin open orders
+type MarginListOpenOrdersService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *MarginListOpenOrdersService) Symbol(symbol string) *MarginListOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *MarginListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/openOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginOrder{}, err
+	}
+	res = make([]*MarginOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginOrder{}, err
+	}
+	return res, nil
+}
+
+// MarginCancelAllOpenOrdersService cancel all margin open orders of a symbol
+type MarginCancelAllOpenOrdersService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *MarginCancelAllOpenOrdersService) Symbol(symbol string) *MarginCancelAllOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *MarginCancelAllOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginCancelOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/margin/allOpenOrders",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginCancelOrderResponse{}, err
+	}
+	res = make([]*MarginCancelOrderResponse, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginCancelOrderResponse{}, err
+	}
+	return res, nil
+}
+
+// MarginListOrdersService list all margin orders, active, canceled or filled
+type MarginListOrdersService struct {
+	c         *Client
+	symbol    string
+	orderID   *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *MarginListOrdersService) Symbol(symbol string) *MarginListOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *MarginListOrdersService) OrderID(orderID int64) *MarginListOrdersService {
+	s.orderID = &orderID
+	return s
+}
+
+// StartTime set starttime
+func (s *MarginListOrdersService) StartTime(startTime int64) *MarginListOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endtime
+func (s *MarginListOrdersService) EndTime(endTime int64) *MarginListOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *MarginListOrdersService) Limit(limit int) *MarginListOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *MarginListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/allOrders",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginOrder{}, err
+	}
+	res = make([]*MarginOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginOrder{}, err
+	}
+	return res, nil
+}
+
+// MarginListTradesService list margin account trades
+type MarginListTradesService struct {
+	c         *Client
+	symbol    string
+	orderID   *int64
+	startTime *int64
+	endTime   *int64
+	fromID    *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *MarginListTradesService) Symbol(symbol string) *MarginListTradesService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *MarginListTradesService) OrderID(orderID int64) *MarginListTradesService {
+	s.orderID = &orderID
+	return s
+}
+
+// StartTime set starttime
+func (s *MarginListTradesService) StartTime(startTime int64) *MarginListTradesService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endtime
+func (s *MarginListTradesService) EndTime(endTime int64) *MarginListTradesService {
+	s.endTime = &endTime
+	return s
+}
+
+// FromID set fromID
+func (s *MarginListTradesService) FromID(fromID int64) *MarginListTradesService {
+	s.fromID = &fromID
+	return s
+}
+
+// Limit set limit
+func (s *MarginListTradesService) Limit(limit int) *MarginListTradesService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *MarginListTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginTrade, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/myTrades",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginTrade{}, err
+	}
+	res = make([]*MarginTrade, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginTrade{}, err
+	}
+	return res, nil
+}
+
+// MarginTrade define margin trade info
+type MarginTrade struct {
+	ID              int64  `json:"id"`
+	Symbol          string `json:"symbol"`
+	OrderID         int64  `json:"orderId"`
+	Price           string `json:"price"`
+	Quantity        string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+	IsBestMatch     bool   `json:"isBestMatch"`
+}
@@ -0,0 +1,247 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type marginOrderServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestMarginOrderService(t *testing.T) {
+	suite.Run(t, new(marginOrderServiceTestSuite))
+}
+
+func (s *marginOrderServiceTestSuite) TestCreateOrder() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 28,
+		"clientOrderId": "testOrder",
+		"transactTime": 1507725176595,
+		"price": "30000.00000000",
+		"origQty": "0.50000000",
+		"executedQty": "0.00000000",
+		"cummulativeQuoteQty": "0.00000000",
+		"status": "NEW",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "BUY",
+		"marginBuyBorrowAmount": "5000",
+		"marginBuyBorrowAsset": "USDT",
+		"selfTradePreventionMode": "EXPIRE_MAKER"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/margin/order").setFormParams(params{
+			"symbol":                  "BTCUSDT",
+			"side":                    SideTypeBuy,
+			"type":                    OrderTypeLimit,
+			"timeInForce":             TimeInForceTypeGTC,
+			"quantity":                0.5,
+			"price":                   30000,
+			"newClientOrderId":        "testOrder",
+			"newOrderRespType":        NewOrderRespTypeRESULT,
+			"selfTradePreventionMode": SelfTradePreventionModeEM,
+			"sideEffectType":          SideEffectTypeMarginBuy,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		OrderType(OrderTypeLimit).TimeInForceType(TimeInForceTypeGTC).Quantity(0.5).Price(30000).
+		NewClientOrderID("testOrder").NewOrderRespType(NewOrderRespTypeRESULT).
+		SelfTradePreventionMode(SelfTradePreventionModeEM).SideEffectType(SideEffectTypeMarginBuy).
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginCreateOrderResponse{
+		Symbol:                  "BTCUSDT",
+		OrderID:                 28,
+		ClientOrderID:           "testOrder",
+		TransactTime:            1507725176595,
+		Price:                   "30000.00000000",
+		SelfTradePreventionMode: SelfTradePreventionModeEM,
+		OrigQuantity:            "0.50000000",
+		ExecutedQuantity:        "0.00000000",
+		CummulativeQuoteQty:     "0.00000000",
+		Status:                  OrderStatusTypeNew,
+		TimeInForce:             TimeInForceTypeGTC,
+		Type:                    OrderTypeLimit,
+		Side:                    SideTypeBuy,
+		MarginBuyBorrowAsset:    "USDT",
+		MarginBuyBorrowAmount:   "5000",
+	}, res)
+}
+
+func (s *marginOrderServiceTestSuite) TestGetOrder() {
+	data := []byte(`{
+		"clientOrderId": "testOrder",
+		"cummulativeQuoteQty": "0.00000000",
+		"executedQty": "0.00000000",
+		"icebergQty": "0.00000000",
+		"isWorking": true,
+		"orderId": 213205622,
+		"origQty": "0.30000000",
+		"price": "0.00493630",
+		"side": "SELL",
+		"status": "NEW",
+		"stopPrice": "0.00000000",
+		"symbol": "BNBBTC",
+		"time": 1562133008725,
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"updateTime": 1562133008725,
+		"accountId": 152950866,
+		"selfTradePreventionMode": "EXPIRE_TAKER",
+		"preventedMatchId": 0,
+		"preventedQuantity": "1.200000"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/order").setParams(params{
+			"symbol":            "BNBBTC",
+			"origClientOrderId": "testOrder",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginGetOrderService().Symbol("BNBBTC").OrigClientOrderID("testOrder").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginOrder{
+		Symbol:                  "BNBBTC",
+		OrderID:                 213205622,
+		ClientOrderID:           "testOrder",
+		Price:                   "0.00493630",
+		OrigQuantity:            "0.30000000",
+		ExecutedQuantity:        "0.00000000",
+		CummulativeQuoteQty:     "0.00000000",
+		Status:                  OrderStatusTypeNew,
+		TimeInForce:             TimeInForceTypeGTC,
+		Type:                    OrderTypeLimit,
+		Side:                    SideTypeSell,
+		StopPrice:               "0.00000000",
+		IcebergQuantity:         "0.00000000",
+		Time:                    1562133008725,
+		UpdateTime:              1562133008725,
+		IsWorking:               true,
+		AccountID:               152950866,
+		SelfTradePreventionMode: SelfTradePreventionModeET,
+		PreventedQuantity:       "1.200000",
+	}, res)
+}
+
+func (s *marginOrderServiceTestSuite) TestListOpenOrders() {
+	data := []byte(`[{"symbol": "BNBBTC", "orderId": 1, "status": "NEW"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/openOrders").setParam("symbol", "BNBBTC")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginListOpenOrdersService().Symbol("BNBBTC").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*MarginOrder{{Symbol: "BNBBTC", OrderID: 1, Status: OrderStatusTypeNew}}, res)
+}
+
+func (s *marginOrderServiceTestSuite) TestCancelOrder() {
+	data := []byte(`{
+		"symbol": "LTCBTC",
+		"orderId": 28,
+		"origClientOrderId": "testOrder",
+		"clientOrderId": "cancelMyOrder1",
+		"price": "1.00000000",
+		"origQty": "10.00000000",
+		"executedQty": "8.00000000",
+		"cummulativeQuoteQty": "8.00000000",
+		"status": "CANCELED",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "SELL",
+		"selfTradePreventionMode": "NONE"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/margin/order").setFormParams(params{
+			"symbol":            "LTCBTC",
+			"orderId":           28,
+			"origClientOrderId": "testOrder",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginCancelOrderService().Symbol("LTCBTC").OrderID(28).
+		OrigClientOrderID("testOrder").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginCancelOrderResponse{
+		Symbol:                  "LTCBTC",
+		OrderID:                 28,
+		OrigClientOrderId:       "testOrder",
+		ClientOrderID:           "cancelMyOrder1",
+		OrigQuantity:            "10.00000000",
+		Price:                   "1.00000000",
+		ExecutedQuantity:        "8.00000000",
+		CummulativeQuoteQty:     "8.00000000",
+		Status:                  OrderStatusTypeCanceled,
+		TimeInForce:             TimeInForceTypeGTC,
+		Type:                    OrderTypeLimit,
+		Side:                    SideTypeSell,
+		SelfTradePreventionMode: SelfTradePreventionModeNONE,
+	}, res)
+}
+
+func (s *marginOrderServiceTestSuite) TestCancelAllOpenOrders() {
+	data := []byte(`[{"symbol": "LTCBTC", "orderId": 28, "status": "CANCELED"}, {"symbol": "LTCBTC", "orderId": 29, "status": "CANCELED"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/margin/allOpenOrders").setFormParam("symbol", "LTCBTC")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginCancelAllOpenOrdersService().Symbol("LTCBTC").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*MarginCancelOrderResponse{
+		{Symbol: "LTCBTC", OrderID: 28, Status: OrderStatusTypeCanceled},
+		{Symbol: "LTCBTC", OrderID: 29, Status: OrderStatusTypeCanceled},
+	}, res)
+}
+
+func (s *marginOrderServiceTestSuite) TestListTrades() {
+	data := []byte(`[{
+		"commission": "0.00006000",
+		"commissionAsset": "BTC",
+		"id": 34,
+		"isBestMatch": true,
+		"isBuyer": false,
+		"isMaker": false,
+		"orderId": 39324,
+		"price": "0.02000000",
+		"qty": "3.00000000",
+		"symbol": "BNBBTC",
+		"time": 1561973357171
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/margin/myTrades").setParams(params{
+			"symbol": "BNBBTC",
+			"fromId": 30,
+			"limit":  5,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewMarginListTradesService().Symbol("BNBBTC").FromID(30).Limit(5).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*MarginTrade{{
+		ID:              34,
+		Symbol:          "BNBBTC",
+		OrderID:         39324,
+		Price:           "0.02000000",
+		Quantity:        "3.00000000",
+		Commission:      "0.00006000",
+		CommissionAsset: "BTC",
+		Time:            1561973357171,
+		IsBestMatch:     true,
+	}}, res)
+}
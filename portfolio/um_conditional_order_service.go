@@ -0,0 +1,464 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// UmCreateConditionalOrderService create conditional order
+type UmCreateConditionalOrderService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	positionSide            *PositionSideType
+	strategyType            StrategyType
+	timeInForce             *TimeInForceType
+	quantity                *string
+	reduceOnly              *bool
+	price                   *string
+	workingType             *WorkingType
+	priceProtect            *bool
+	newClientStrategyID     *string
+	stopPrice               *string
+	activationPrice         *string
+	callbackRate            *string
+	priceMatch              *PriceMatchType
+	selfTradePreventionMode SelfTradePreventionMode
+	goodTillDate            int64
+}
+
+// Symbol set symbol
+func (s *UmCreateConditionalOrderService) Symbol(symbol string) *UmCreateConditionalOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *UmCreateConditionalOrderService) Side(side SideType) *UmCreateConditionalOrderService {
+	s.side = side
+	return s
+}
+
+// PositionSide set side
+func (s *UmCreateConditionalOrderService) PositionSide(positionSide PositionSideType) *UmCreateConditionalOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// StrategyType set strategyType
+func (s *UmCreateConditionalOrderService) StrategyType(strategyType StrategyType) *UmCreateConditionalOrderService {
+	s.strategyType = strategyType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *UmCreateConditionalOrderService) TimeInForce(timeInForce TimeInForceType) *UmCreateConditionalOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *UmCreateConditionalOrderService) Quantity(quantity string) *UmCreateConditionalOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *UmCreateConditionalOrderService) ReduceOnly(reduceOnly bool) *UmCreateConditionalOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// Price set price
+func (s *UmCreateConditionalOrderService) Price(price string) *UmCreateConditionalOrderService {
+	s.price = &price
+	return s
+}
+
+// WorkingType set workingType
+func (s *UmCreateConditionalOrderService) WorkingType(workingType WorkingType) *UmCreateConditionalOrderService {
+	s.workingType = &workingType
+	return s
+}
+
+// PriceProtect set priceProtect
+func (s *UmCreateConditionalOrderService) PriceProtect(priceProtect bool) *UmCreateConditionalOrderService {
+	s.priceProtect = &priceProtect
+	return s
+}
+
+// NewClientStrategyID set newClientStrategyID
+func (s *UmCreateConditionalOrderService) NewClientStrategyID(newClientStrategyID string) *UmCreateConditionalOrderService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *UmCreateConditionalOrderService) StopPrice(stopPrice string) *UmCreateConditionalOrderService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// ActivationPrice set activationPrice, used with TRAILING_STOP_MARKET
+func (s *UmCreateConditionalOrderService) ActivationPrice(activationPrice string) *UmCreateConditionalOrderService {
+	s.activationPrice = &activationPrice
+	return s
+}
+
+// CallbackRate set callbackRate, used with TRAILING_STOP_MARKET
+func (s *UmCreateConditionalOrderService) CallbackRate(callbackRate string) *UmCreateConditionalOrderService {
+	s.callbackRate = &callbackRate
+	return s
+}
+
+// PriceMatch set priceMatch
+func (s *UmCreateConditionalOrderService) PriceMatch(priceMatch PriceMatchType) *UmCreateConditionalOrderService {
+	s.priceMatch = &priceMatch
+	return s
+}
+
+// SelfTradePrevention set selfTradePreventionMode
+func (s *UmCreateConditionalOrderService) SelfTradePrevention(selfTradePreventionMode SelfTradePreventionMode) *UmCreateConditionalOrderService {
+	s.selfTradePreventionMode = selfTradePreventionMode
+	return s
+}
+
+// GoodTillDate set goodTillDate
+func (s *UmCreateConditionalOrderService) GoodTillDate(goodTillDate int64) *UmCreateConditionalOrderService {
+	s.goodTillDate = goodTillDate
+	return s
+}
+
+// Do send request
+func (s *UmCreateConditionalOrderService) Do(ctx context.Context, opts ...RequestOption) (res *UmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/um/conditional/order",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":       s.symbol,
+		"side":         s.side,
+		"strategyType": s.strategyType,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.workingType != nil {
+		m["workingType"] = *s.workingType
+	}
+	if s.priceProtect != nil {
+		m["priceProtect"] = *s.priceProtect
+	}
+	if s.newClientStrategyID != nil {
+		m["newClientStrategyId"] = *s.newClientStrategyID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.activationPrice != nil {
+		m["activationPrice"] = *s.activationPrice
+	}
+	if s.callbackRate != nil {
+		m["callbackRate"] = *s.callbackRate
+	}
+	if s.priceMatch != nil {
+		m["priceMatch"] = *s.priceMatch
+	}
+	if s.selfTradePreventionMode != "" {
+		m["selfTradePreventionMode"] = s.selfTradePreventionMode
+	}
+	if s.goodTillDate != 0 {
+		m["goodTillDate"] = s.goodTillDate
+	}
+	r.setFormParams(m)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(UmConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UmConditionalOrder define conditional order info
+type UmConditionalOrder struct {
+	NewClientStrategyID     string                  `json:"newClientStrategyId"`
+	StrategyID              int64                   `json:"strategyId"`
+	StrategyStatus          string                  `json:"strategyStatus"`
+	StrategyType            StrategyType            `json:"strategyType"`
+	OrigQuantity            string                  `json:"origQty"`
+	Price                   string                  `json:"price"`
+	ReduceOnly              bool                    `json:"reduceOnly"`
+	Side                    SideType                `json:"side"`
+	PositionSide            PositionSideType        `json:"positionSide"`
+	StopPrice               string                  `json:"stopPrice"`
+	Symbol                  string                  `json:"symbol"`
+	TimeInForce             TimeInForceType         `json:"timeInForce"`
+	ActivatePrice           string                  `json:"activatePrice"`
+	PriceRate               string                  `json:"priceRate"`
+	BookTime                int64                   `json:"bookTime"`
+	UpdateTime              int64                   `json:"updateTime"`
+	WorkingType             WorkingType             `json:"workingType"`
+	PriceProtect            bool                    `json:"priceProtect"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+	GoodTillDate            int64                   `json:"goodTillDate"`
+	PriceMatch              PriceMatchType          `json:"priceMatch"`
+	OrderID                 int64                   `json:"orderId,omitempty"`     // only returned once triggered
+	TriggerTime             int64                   `json:"triggerTime,omitempty"` // only returned once triggered
+}
+
+// UmCancelConditionalOrderService cancel a conditional order
+type UmCancelConditionalOrderService struct {
+	c                   *Client
+	symbol              string
+	strategyID          *int64
+	newClientStrategyID *string
+}
+
+// Symbol set symbol
+func (s *UmCancelConditionalOrderService) Symbol(symbol string) *UmCancelConditionalOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// StrategyID set strategyID
+func (s *UmCancelConditionalOrderService) StrategyID(strategyID int64) *UmCancelConditionalOrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// NewClientStrategyID set newClientStrategyID
+func (s *UmCancelConditionalOrderService) NewClientStrategyID(newClientStrategyID string) *UmCancelConditionalOrderService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// Do send request
+func (s *UmCancelConditionalOrderService) Do(ctx context.Context, opts ...RequestOption) (res *UmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/um/conditional/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.strategyID != nil {
+		r.setFormParam("strategyId", *s.strategyID)
+	}
+	if s.newClientStrategyID != nil {
+		r.setFormParam("newClientStrategyId", *s.newClientStrategyID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(UmConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UmCancelAllConditionalOrdersService cancel all open conditional orders of a symbol
+type UmCancelAllConditionalOrdersService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *UmCancelAllConditionalOrdersService) Symbol(symbol string) *UmCancelAllConditionalOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *UmCancelAllConditionalOrdersService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/um/conditional/allOpenOrders",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	_, _, err = s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UmListOpenConditionalOrdersService list open conditional orders
+type UmListOpenConditionalOrdersService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *UmListOpenConditionalOrdersService) Symbol(symbol string) *UmListOpenConditionalOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *UmListOpenConditionalOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*UmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/conditional/openOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UmConditionalOrder{}, err
+	}
+	res = make([]*UmConditionalOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UmConditionalOrder{}, err
+	}
+	return res, nil
+}
+
+// UmGetConditionalOrderHistoryService get a conditional order from history
+type UmGetConditionalOrderHistoryService struct {
+	c                   *Client
+	symbol              string
+	strategyID          *int64
+	newClientStrategyID *string
+}
+
+// Symbol set symbol
+func (s *UmGetConditionalOrderHistoryService) Symbol(symbol string) *UmGetConditionalOrderHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// StrategyID set strategyID
+func (s *UmGetConditionalOrderHistoryService) StrategyID(strategyID int64) *UmGetConditionalOrderHistoryService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// NewClientStrategyID set newClientStrategyID
+func (s *UmGetConditionalOrderHistoryService) NewClientStrategyID(newClientStrategyID string) *UmGetConditionalOrderHistoryService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// Do send request
+func (s *UmGetConditionalOrderHistoryService) Do(ctx context.Context, opts ...RequestOption) (res *UmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/conditional/orderHistory",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.strategyID != nil {
+		r.setParam("strategyId", *s.strategyID)
+	}
+	if s.newClientStrategyID != nil {
+		r.setParam("newClientStrategyId", *s.newClientStrategyID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(UmConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UmListConditionalOrdersService list all conditional orders
+type UmListConditionalOrdersService struct {
+	c          *Client
+	symbol     *string
+	strategyID *int64
+	startTime  *int64
+	endTime    *int64
+	limit      *int
+}
+
+// Symbol set symbol
+func (s *UmListConditionalOrdersService) Symbol(symbol string) *UmListConditionalOrdersService {
+	s.symbol = &symbol
+	return s
+}
+
+// StrategyID set strategyID
+func (s *UmListConditionalOrdersService) StrategyID(strategyID int64) *UmListConditionalOrdersService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// StartTime set startTime
+func (s *UmListConditionalOrdersService) StartTime(startTime int64) *UmListConditionalOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *UmListConditionalOrdersService) EndTime(endTime int64) *UmListConditionalOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *UmListConditionalOrdersService) Limit(limit int) *UmListConditionalOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *UmListConditionalOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*UmConditionalOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/conditional/allOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.strategyID != nil {
+		r.setParam("strategyId", *s.strategyID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UmConditionalOrder{}, err
+	}
+	res = make([]*UmConditionalOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UmConditionalOrder{}, err
+	}
+	return res, nil
+}
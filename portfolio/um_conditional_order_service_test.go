@@ -0,0 +1,234 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type umConditionalOrderServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestUmConditionalOrderService(t *testing.T) {
+	suite.Run(t, new(umConditionalOrderServiceTestSuite))
+}
+
+func (s *umConditionalOrderServiceTestSuite) TestCreateConditionalOrder() {
+	data := []byte(`{
+		"newClientStrategyId": "testStrategy",
+		"strategyId": 123445,
+		"strategyStatus": "NEW",
+		"strategyType": "TRAILING_STOP_MARKET",
+		"origQty": "10",
+		"price": "0",
+		"reduceOnly": true,
+		"side": "SELL",
+		"positionSide": "LONG",
+		"stopPrice": "9300",
+		"symbol": "BTCUSDT",
+		"timeInForce": "GTC",
+		"activatePrice": "9020",
+		"priceRate": "0.3",
+		"bookTime": 1566818724710,
+		"updateTime": 1566818724722,
+		"workingType": "CONTRACT_PRICE",
+		"priceProtect": true,
+		"selfTradePreventionMode": "NONE",
+		"goodTillDate": 0,
+		"priceMatch": "NONE"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/um/conditional/order").setFormParams(params{
+			"symbol":                  "BTCUSDT",
+			"side":                    SideTypeSell,
+			"positionSide":            PositionSideTypeLong,
+			"strategyType":            StrategyTypeTrailingStopMarket,
+			"timeInForce":             TimeInForceTypeGTC,
+			"quantity":                "10",
+			"reduceOnly":              true,
+			"workingType":             WorkingTypeContractPrice,
+			"priceProtect":            true,
+			"newClientStrategyId":     "testStrategy",
+			"activationPrice":         "9020",
+			"callbackRate":            "0.3",
+			"selfTradePreventionMode": SelfTradePreventionModeNONE,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmCreateConditionalOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		PositionSide(PositionSideTypeLong).StrategyType(StrategyTypeTrailingStopMarket).
+		TimeInForce(TimeInForceTypeGTC).Quantity("10").ReduceOnly(true).
+		WorkingType(WorkingTypeContractPrice).PriceProtect(true).NewClientStrategyID("testStrategy").
+		ActivationPrice("9020").CallbackRate("0.3").SelfTradePrevention(SelfTradePreventionModeNONE).
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmConditionalOrder{
+		NewClientStrategyID:     "testStrategy",
+		StrategyID:              123445,
+		StrategyStatus:          "NEW",
+		StrategyType:            StrategyTypeTrailingStopMarket,
+		OrigQuantity:            "10",
+		Price:                   "0",
+		ReduceOnly:              true,
+		Side:                    SideTypeSell,
+		PositionSide:            PositionSideTypeLong,
+		StopPrice:               "9300",
+		Symbol:                  "BTCUSDT",
+		TimeInForce:             TimeInForceTypeGTC,
+		ActivatePrice:           "9020",
+		PriceRate:               "0.3",
+		BookTime:                1566818724710,
+		UpdateTime:              1566818724722,
+		WorkingType:             WorkingTypeContractPrice,
+		PriceProtect:            true,
+		SelfTradePreventionMode: SelfTradePreventionModeNONE,
+		PriceMatch:              PriceMatchTypeNone,
+	}, res)
+}
+
+func (s *umConditionalOrderServiceTestSuite) TestCreateStopOrder() {
+	s.mockDo([]byte(`{"strategyId": 123446, "strategyType": "STOP", "strategyStatus": "NEW"}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/um/conditional/order").setFormParams(params{
+			"symbol":       "BTCUSDT",
+			"side":         SideTypeBuy,
+			"strategyType": StrategyTypeStop,
+			"quantity":     "1",
+			"price":        "31000",
+			"stopPrice":    "30900",
+			"priceMatch":   PriceMatchTypeOpponent,
+			"goodTillDate": 1566818800000,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmCreateConditionalOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		StrategyType(StrategyTypeStop).Quantity("1").Price("31000").StopPrice("30900").
+		PriceMatch(PriceMatchTypeOpponent).GoodTillDate(1566818800000).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmConditionalOrder{StrategyID: 123446, StrategyType: StrategyTypeStop, StrategyStatus: "NEW"}, res)
+}
+
+func (s *umConditionalOrderServiceTestSuite) TestCancelConditionalOrder() {
+	data := []byte(`{
+		"newClientStrategyId": "testStrategy",
+		"strategyId": 123445,
+		"strategyStatus": "CANCELED",
+		"strategyType": "TRAILING_STOP_MARKET",
+		"origQty": "10",
+		"symbol": "BTCUSDT",
+		"side": "SELL",
+		"updateTime": 1566818724800
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/um/conditional/order").setFormParams(params{
+			"symbol":     "BTCUSDT",
+			"strategyId": 123445,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmCancelConditionalOrderService().Symbol("BTCUSDT").StrategyID(123445).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmConditionalOrder{
+		NewClientStrategyID: "testStrategy",
+		StrategyID:          123445,
+		StrategyStatus:      "CANCELED",
+		StrategyType:        StrategyTypeTrailingStopMarket,
+		OrigQuantity:        "10",
+		Symbol:              "BTCUSDT",
+		Side:                SideTypeSell,
+		UpdateTime:          1566818724800,
+	}, res)
+}
+
+func (s *umConditionalOrderServiceTestSuite) TestCancelAllConditionalOrders() {
+	s.mockDo([]byte(`{"code": 200, "msg": "The operation of cancel all conditional open order is done."}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/um/conditional/allOpenOrders").setFormParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	err := s.client.NewUmCancelAllConditionalOrdersService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *umConditionalOrderServiceTestSuite) TestListOpenConditionalOrders() {
+	data := []byte(`[{"strategyId": 1, "strategyStatus": "NEW", "strategyType": "STOP_MARKET", "symbol": "BTCUSDT"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/conditional/openOrders").setParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmListOpenConditionalOrdersService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*UmConditionalOrder{
+		{StrategyID: 1, StrategyStatus: "NEW", StrategyType: StrategyTypeStopMarket, Symbol: "BTCUSDT"},
+	}, res)
+}
+
+func (s *umConditionalOrderServiceTestSuite) TestGetConditionalOrderHistory() {
+	data := []byte(`{
+		"newClientStrategyId": "testStrategy",
+		"strategyId": 123445,
+		"strategyStatus": "TRIGGERED",
+		"strategyType": "STOP_MARKET",
+		"origQty": "10",
+		"symbol": "BTCUSDT",
+		"side": "SELL",
+		"stopPrice": "9300",
+		"orderId": 12132343435,
+		"triggerTime": 1566818724730
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/conditional/orderHistory").setParams(params{
+			"symbol":              "BTCUSDT",
+			"newClientStrategyId": "testStrategy",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmGetConditionalOrderHistoryService().Symbol("BTCUSDT").
+		NewClientStrategyID("testStrategy").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmConditionalOrder{
+		NewClientStrategyID: "testStrategy",
+		StrategyID:          123445,
+		StrategyStatus:      "TRIGGERED",
+		StrategyType:        StrategyTypeStopMarket,
+		OrigQuantity:        "10",
+		Symbol:              "BTCUSDT",
+		Side:                SideTypeSell,
+		StopPrice:           "9300",
+		OrderID:             12132343435,
+		TriggerTime:         1566818724730,
+	}, res)
+}
+
+func (s *umConditionalOrderServiceTestSuite) TestListConditionalOrders() {
+	data := []byte(`[{"strategyId": 1, "strategyStatus": "EXPIRED", "strategyType": "TAKE_PROFIT", "symbol": "BTCUSDT"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/conditional/allOrders").setParams(params{
+			"symbol":    "BTCUSDT",
+			"startTime": 1566818724000,
+			"endTime":   1566818725000,
+			"limit":     10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmListConditionalOrdersService().Symbol("BTCUSDT").
+		StartTime(1566818724000).EndTime(1566818725000).Limit(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*UmConditionalOrder{
+		{StrategyID: 1, StrategyStatus: "EXPIRED", StrategyType: StrategyTypeTakeProfit, Symbol: "BTCUSDT"},
+	}, res)
+}
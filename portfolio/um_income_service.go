@@ -0,0 +1,103 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// UmGetIncomeHistoryService get UM income history
+type UmGetIncomeHistoryService struct {
+	c          *Client
+	symbol     string
+	incomeType IncomeType
+	startTime  *int64
+	endTime    *int64
+	page       *int
+	limit      *int
+}
+
+// Symbol set symbol
+func (s *UmGetIncomeHistoryService) Symbol(symbol string) *UmGetIncomeHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// IncomeType set income type
+func (s *UmGetIncomeHistoryService) IncomeType(incomeType IncomeType) *UmGetIncomeHistoryService {
+	s.incomeType = incomeType
+	return s
+}
+
+// StartTime set startTime
+func (s *UmGetIncomeHistoryService) StartTime(startTime int64) *UmGetIncomeHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *UmGetIncomeHistoryService) EndTime(endTime int64) *UmGetIncomeHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Page set page
+func (s *UmGetIncomeHistoryService) Page(page int) *UmGetIncomeHistoryService {
+	s.page = &page
+	return s
+}
+
+// Limit set limit
+func (s *UmGetIncomeHistoryService) Limit(limit int) *UmGetIncomeHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *UmGetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption) (res []*IncomeHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/income",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.incomeType != "" {
+		r.setParam("incomeType", s.incomeType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*IncomeHistory{}, err
+	}
+	res = make([]*IncomeHistory, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*IncomeHistory{}, err
+	}
+	return res, nil
+}
+
+// IncomeHistory define income history info
+type IncomeHistory struct {
+	Symbol     string     `json:"symbol"`
+	IncomeType IncomeType `json:"incomeType"`
+	Income     string     `json:"income"`
+	Asset      string     `json:"asset"`
+	Info       string     `json:"info"`
+	Time       int64      `json:"time"`
+	TranID     int64      `json:"tranId"`
+	TradeID    string     `json:"tradeId"`
+}
@@ -0,0 +1,55 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type umIncomeServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestUmIncomeService(t *testing.T) {
+	suite.Run(t, new(umIncomeServiceTestSuite))
+}
+
+func (s *umIncomeServiceTestSuite) TestGetIncomeHistory() {
+	data := []byte(`[{
+		"symbol": "BTCUSDT",
+		"incomeType": "COMMISSION",
+		"income": "-0.01000000",
+		"asset": "USDT",
+		"info": "COMMISSION",
+		"time": 1570636800000,
+		"tranId": 9689322392,
+		"tradeId": "2059192"
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/income").setParams(params{
+			"symbol":     "BTCUSDT",
+			"incomeType": IncomeTypeCommission,
+			"startTime":  1570608000000,
+			"endTime":    1570694400000,
+			"page":       2,
+			"limit":      10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmGetIncomeHistoryService().Symbol("BTCUSDT").IncomeType(IncomeTypeCommission).
+		StartTime(1570608000000).EndTime(1570694400000).Page(2).Limit(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*IncomeHistory{{
+		Symbol:     "BTCUSDT",
+		IncomeType: IncomeTypeCommission,
+		Income:     "-0.01000000",
+		Asset:      "USDT",
+		Info:       "COMMISSION",
+		Time:       1570636800000,
+		TranID:     9689322392,
+		TradeID:    "2059192",
+	}}, res)
+}
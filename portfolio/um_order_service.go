@@ -332,3 +332,382 @@ func (s *UmCancelOrderService) Do(ctx context.Context, opts ...RequestOption) (r
 	}
 	return res, nil
 }
+
+// UmGetOrderService get an order
+type UmGetOrderService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// Symbol set symbol
+func (s *UmGetOrderService) Symbol(symbol string) *UmGetOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *UmGetOrderService) OrderID(orderID int64) *UmGetOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *UmGetOrderService) OrigClientOrderID(origClientOrderID string) *UmGetOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *UmGetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *UmOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/order",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(UmOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UmListOrdersService list all orders, active, canceled or filled
+type UmListOrdersService struct {
+	c         *Client
+	symbol    string
+	orderID   *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *UmListOrdersService) Symbol(symbol string) *UmListOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *UmListOrdersService) OrderID(orderID int64) *UmListOrdersService {
+	s.orderID = &orderID
+	return s
+}
+
+// StartTime set starttime
+func (s *UmListOrdersService) StartTime(startTime int64) *UmListOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endtime
+func (s *UmListOrdersService) EndTime(endTime int64) *UmListOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *UmListOrdersService) Limit(limit int) *UmListOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *UmListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*UmOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/allOrders",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UmOrder{}, err
+	}
+	res = make([]*UmOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UmOrder{}, err
+	}
+	return res, nil
+}
+
+// UmModifyOrderService modify a LIMIT order
+type UmModifyOrderService struct {
+	c                 *Client
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          string
+	price             *string
+	priceMatch        *PriceMatchType
+}
+
+// Symbol set symbol
+func (s *UmModifyOrderService) Symbol(symbol string) *UmModifyOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *UmModifyOrderService) Side(side SideType) *UmModifyOrderService {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *UmModifyOrderService) OrderID(orderID int64) *UmModifyOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *UmModifyOrderService) OrigClientOrderID(origClientOrderID string) *UmModifyOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Quantity set quantity
+func (s *UmModifyOrderService) Quantity(quantity string) *UmModifyOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Price set price
+func (s *UmModifyOrderService) Price(price string) *UmModifyOrderService {
+	s.price = &price
+	return s
+}
+
+// PriceMatch set priceMatch, can not be sent together with price
+func (s *UmModifyOrderService) PriceMatch(priceMatch PriceMatchType) *UmModifyOrderService {
+	s.priceMatch = &priceMatch
+	return s
+}
+
+// Do send request
+func (s *UmModifyOrderService) Do(ctx context.Context, opts ...RequestOption) (res *UmOrder, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/papi/v1/um/order",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.priceMatch != nil {
+		m["priceMatch"] = *s.priceMatch
+	}
+	r.setFormParams(m)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(UmOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UmListAccountTradeService list UM account trades
+type UmListAccountTradeService struct {
+	c         *Client
+	symbol    string
+	startTime *int64
+	endTime   *int64
+	fromID    *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *UmListAccountTradeService) Symbol(symbol string) *UmListAccountTradeService {
+	s.symbol = symbol
+	return s
+}
+
+// StartTime set startTime
+func (s *UmListAccountTradeService) StartTime(startTime int64) *UmListAccountTradeService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *UmListAccountTradeService) EndTime(endTime int64) *UmListAccountTradeService {
+	s.endTime = &endTime
+	return s
+}
+
+// FromID set fromID
+func (s *UmListAccountTradeService) FromID(fromID int64) *UmListAccountTradeService {
+	s.fromID = &fromID
+	return s
+}
+
+// Limit set limit
+func (s *UmListAccountTradeService) Limit(limit int) *UmListAccountTradeService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *UmListAccountTradeService) Do(ctx context.Context, opts ...RequestOption) (res []*UmAccountTrade, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/userTrades",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UmAccountTrade{}, err
+	}
+	res = make([]*UmAccountTrade, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UmAccountTrade{}, err
+	}
+	return res, nil
+}
+
+// UmAccountTrade define UM account trade
+type UmAccountTrade struct {
+	Symbol          string           `json:"symbol"`
+	ID              int64            `json:"id"`
+	OrderID         int64            `json:"orderId"`
+	Side            SideType         `json:"side"`
+	Price           string           `json:"price"`
+	Quantity        string           `json:"qty"`
+	RealizedPnl     string           `json:"realizedPnl"`
+	QuoteQuantity   string           `json:"quoteQty"`
+	Commission      string           `json:"commission"`
+	CommissionAsset string           `json:"commissionAsset"`
+	Time            int64            `json:"time"`
+	Buyer           bool             `json:"buyer"`
+	Maker           bool             `json:"maker"`
+	PositionSide    PositionSideType `json:"positionSide"`
+}
+
+// UmListForceOrdersService list UM liquidation and ADL orders of the user
+type UmListForceOrdersService struct {
+	c             *Client
+	symbol        string
+	autoCloseType *ForceOrderCloseType
+	startTime     *int64
+	endTime       *int64
+	limit         *int
+}
+
+// Symbol set symbol
+func (s *UmListForceOrdersService) Symbol(symbol string) *UmListForceOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// AutoCloseType set autoCloseType
+func (s *UmListForceOrdersService) AutoCloseType(autoCloseType ForceOrderCloseType) *UmListForceOrdersService {
+	s.autoCloseType = &autoCloseType
+	return s
+}
+
+// StartTime set startTime
+func (s *UmListForceOrdersService) StartTime(startTime int64) *UmListForceOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *UmListForceOrdersService) EndTime(endTime int64) *UmListForceOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *UmListForceOrdersService) Limit(limit int) *UmListForceOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *UmListForceOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*UmOrder, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/forceOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.autoCloseType != nil {
+		r.setParam("autoCloseType", *s.autoCloseType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UmOrder{}, err
+	}
+	res = make([]*UmOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UmOrder{}, err
+	}
+	return res, nil
+}
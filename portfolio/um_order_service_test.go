@@ -0,0 +1,371 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type umOrderServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestUmOrderService(t *testing.T) {
+	suite.Run(t, new(umOrderServiceTestSuite))
+}
+
+func (s *umOrderServiceTestSuite) TestCreateOrder() {
+	data := []byte(`{
+		"clientOrderId": "testOrder",
+		"cumQty": "0",
+		"cumQuote": "0",
+		"executedQty": "0",
+		"orderId": 22542179,
+		"avgPrice": "0.00000",
+		"origQty": "10",
+		"price": "10000",
+		"reduceOnly": false,
+		"side": "SELL",
+		"positionSide": "BOTH",
+		"status": "NEW",
+		"symbol": "BTCUSDT",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"selfTradePreventionMode": "EXPIRE_MAKER",
+		"goodTillDate": 0,
+		"updateTime": 1566818724722
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/um/order").setFormParams(params{
+			"symbol":                  "BTCUSDT",
+			"side":                    SideTypeSell,
+			"type":                    OrderTypeLimit,
+			"timeInForce":             TimeInForceTypeGTC,
+			"positionSide":            PositionSideTypeBoth,
+			"quantity":                "10",
+			"reduceOnly":              false,
+			"price":                   "10000",
+			"newClientOrderId":        "testOrder",
+			"newOrderRespType":        NewOrderRespTypeRESULT,
+			"selfTradePreventionMode": SelfTradePreventionModeEM,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).PositionSide(PositionSideTypeBoth).
+		Quantity("10").ReduceOnly(false).Price("10000").NewClientOrderID("testOrder").
+		NewOrderResponseType(NewOrderRespTypeRESULT).SelfTradePrevention(SelfTradePreventionModeEM).
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmCreateOrderResponse{
+		ClientOrderID:           "testOrder",
+		CumQty:                  "0",
+		CumQuote:                "0",
+		ExecutedQuantity:        "0",
+		OrderID:                 22542179,
+		AvgPrice:                "0.00000",
+		OrigQuantity:            "10",
+		Price:                   "10000",
+		Side:                    SideTypeSell,
+		PositionSide:            PositionSideTypeBoth,
+		Status:                  OrderStatusTypeNew,
+		Symbol:                  "BTCUSDT",
+		TimeInForce:             TimeInForceTypeGTC,
+		Type:                    OrderTypeLimit,
+		SelfTradePreventionMode: SelfTradePreventionModeEM,
+		UpdateTime:              1566818724722,
+	}, res)
+}
+
+func (s *umOrderServiceTestSuite) TestModifyOrder() {
+	data := []byte(`{
+		"avgPrice": "0.00000",
+		"clientOrderId": "testOrder",
+		"cumQuote": "0",
+		"executedQty": "0",
+		"orderId": 22542179,
+		"origQty": "5",
+		"origType": "LIMIT",
+		"price": "10100",
+		"reduceOnly": false,
+		"side": "SELL",
+		"positionSide": "BOTH",
+		"status": "NEW",
+		"symbol": "BTCUSDT",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"updateTime": 1566818724800
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPut, "/papi/v1/um/order").setFormParams(params{
+			"symbol":   "BTCUSDT",
+			"side":     SideTypeSell,
+			"orderId":  22542179,
+			"quantity": "5",
+			"price":    "10100",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmModifyOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		OrderID(22542179).Quantity("5").Price("10100").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmOrder{
+		AvgPrice:         "0.00000",
+		ClientOrderID:    "testOrder",
+		CumQuote:         "0",
+		ExecutedQuantity: "0",
+		OrderID:          22542179,
+		OrigQuantity:     "5",
+		OrigType:         OrderTypeLimit,
+		Price:            "10100",
+		Side:             SideTypeSell,
+		PositionSide:     PositionSideTypeBoth,
+		Status:           OrderStatusTypeNew,
+		Symbol:           "BTCUSDT",
+		TimeInForce:      TimeInForceTypeGTC,
+		Type:             OrderTypeLimit,
+		UpdateTime:       1566818724800,
+	}, res)
+}
+
+func (s *umOrderServiceTestSuite) TestModifyOrderPriceMatch() {
+	s.mockDo([]byte(`{"orderId": 22542179}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPut, "/papi/v1/um/order").setFormParams(params{
+			"symbol":            "BTCUSDT",
+			"side":              SideTypeBuy,
+			"origClientOrderId": "testOrder",
+			"quantity":          "5",
+			"priceMatch":        PriceMatchTypeQueue,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		OrigClientOrderID("testOrder").Quantity("5").PriceMatch(PriceMatchTypeQueue).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(int64(22542179), res.OrderID)
+}
+
+func (s *umOrderServiceTestSuite) TestGetOrder() {
+	data := []byte(`{
+		"avgPrice": "10000.1",
+		"clientOrderId": "testOrder",
+		"cumQuote": "100001",
+		"executedQty": "10",
+		"orderId": 22542179,
+		"origQty": "10",
+		"origType": "LIMIT",
+		"price": "10000",
+		"reduceOnly": false,
+		"side": "BUY",
+		"positionSide": "LONG",
+		"status": "FILLED",
+		"symbol": "BTCUSDT",
+		"time": 1566818724700,
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"updateTime": 1566818724722,
+		"selfTradePreventionMode": "NONE",
+		"goodTillDate": 0
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/order").setParams(params{
+			"symbol":            "BTCUSDT",
+			"orderId":           22542179,
+			"origClientOrderId": "testOrder",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmGetOrderService().Symbol("BTCUSDT").OrderID(22542179).
+		OrigClientOrderID("testOrder").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmOrder{
+		AvgPrice:                "10000.1",
+		ClientOrderID:           "testOrder",
+		CumQuote:                "100001",
+		ExecutedQuantity:        "10",
+		OrderID:                 22542179,
+		OrigQuantity:            "10",
+		OrigType:                OrderTypeLimit,
+		Price:                   "10000",
+		Side:                    SideTypeBuy,
+		PositionSide:            PositionSideTypeLong,
+		Status:                  OrderStatusTypeFilled,
+		Symbol:                  "BTCUSDT",
+		Time:                    1566818724700,
+		TimeInForce:             TimeInForceTypeGTC,
+		Type:                    OrderTypeLimit,
+		UpdateTime:              1566818724722,
+		SelfTradePreventionMode: SelfTradePreventionModeNONE,
+	}, res)
+}
+
+func (s *umOrderServiceTestSuite) TestListOpenOrders() {
+	data := []byte(`[{"orderId": 1, "symbol": "BTCUSDT", "status": "NEW"}, {"orderId": 2, "symbol": "BTCUSDT", "status": "PARTIALLY_FILLED"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/openOrders").setParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmListOpenOrdersService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*UmOrder{
+		{OrderID: 1, Symbol: "BTCUSDT", Status: OrderStatusTypeNew},
+		{OrderID: 2, Symbol: "BTCUSDT", Status: OrderStatusTypePartiallyFilled},
+	}, res)
+}
+
+func (s *umOrderServiceTestSuite) TestListOrders() {
+	data := []byte(`[{"orderId": 1, "symbol": "BTCUSDT", "status": "CANCELED"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/allOrders").setParams(params{
+			"symbol":    "BTCUSDT",
+			"orderId":   1,
+			"startTime": 1566818724000,
+			"endTime":   1566818725000,
+			"limit":     10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmListOrdersService().Symbol("BTCUSDT").OrderID(1).
+		StartTime(1566818724000).EndTime(1566818725000).Limit(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*UmOrder{{OrderID: 1, Symbol: "BTCUSDT", Status: OrderStatusTypeCanceled}}, res)
+}
+
+func (s *umOrderServiceTestSuite) TestCancelOrder() {
+	data := []byte(`{
+		"avgPrice": "0.00000",
+		"clientOrderId": "testOrder",
+		"cumQty": "0",
+		"cumQuote": "0",
+		"executedQty": "0",
+		"orderId": 22542179,
+		"origQty": "10",
+		"price": "10000",
+		"reduceOnly": false,
+		"side": "SELL",
+		"positionSide": "BOTH",
+		"status": "CANCELED",
+		"symbol": "BTCUSDT",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"updateTime": 1566818724722,
+		"selfTradePreventionMode": "NONE",
+		"goodTillDate": 0
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/um/order").setFormParams(params{
+			"symbol":            "BTCUSDT",
+			"orderId":           22542179,
+			"origClientOrderId": "testOrder",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmCancelOrderService().Symbol("BTCUSDT").OrderID(22542179).
+		ClientOrderID("testOrder").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmCancelOrderResponse{
+		AvgPrice:                "0.00000",
+		ClientOrderID:           "testOrder",
+		CumQuantity:             "0",
+		CumQuote:                "0",
+		ExecutedQuantity:        "0",
+		OrderID:                 22542179,
+		OrigQuantity:            "10",
+		Price:                   "10000",
+		Side:                    SideTypeSell,
+		PositionSide:            PositionSideTypeBoth,
+		Status:                  OrderStatusTypeCanceled,
+		Symbol:                  "BTCUSDT",
+		TimeInForce:             TimeInForceTypeGTC,
+		Type:                    OrderTypeLimit,
+		UpdateTime:              1566818724722,
+		SelfTradePreventionMode: SelfTradePreventionModeNONE,
+	}, res)
+}
+
+func (s *umOrderServiceTestSuite) TestCancelAllOpenOrders() {
+	s.mockDo([]byte(`{"code": 200, "msg": "The operation of cancel all open order is done."}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodDelete, "/papi/v1/um/allOpenOrders").setFormParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	err := s.client.NewUmCancelAllOpenOrdersService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *umOrderServiceTestSuite) TestCancelOrderError() {
+	s.mockDo([]byte(`{"code": -2011, "msg": "Unknown order sent."}`), nil, http.StatusBadRequest)
+	defer s.assertDo()
+	res, err := s.client.NewUmCancelOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	s.r().Error(err)
+	s.r().Nil(res)
+}
+
+func (s *umOrderServiceTestSuite) TestListForceOrders() {
+	data := []byte(`[{
+		"orderId": 6071832819,
+		"symbol": "BTCUSDT",
+		"status": "FILLED",
+		"clientOrderId": "autoclose-1596107620040000020",
+		"price": "10871.09",
+		"avgPrice": "10913.21000",
+		"origQty": "0.001",
+		"executedQty": "0.001",
+		"cumQuote": "10.91321",
+		"timeInForce": "IOC",
+		"type": "LIMIT",
+		"reduceOnly": false,
+		"side": "SELL",
+		"positionSide": "BOTH",
+		"origType": "LIMIT",
+		"time": 1596107620044,
+		"updateTime": 1596107620087
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/forceOrders").setParams(params{
+			"symbol":        "BTCUSDT",
+			"autoCloseType": ForceOrderCloseTypeLiquidation,
+			"limit":         10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmListForceOrdersService().Symbol("BTCUSDT").
+		AutoCloseType(ForceOrderCloseTypeLiquidation).Limit(10).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*UmOrder{{
+		AvgPrice:         "10913.21000",
+		ClientOrderID:    "autoclose-1596107620040000020",
+		CumQuote:         "10.91321",
+		ExecutedQuantity: "0.001",
+		OrderID:          6071832819,
+		OrigQuantity:     "0.001",
+		OrigType:         OrderTypeLimit,
+		Price:            "10871.09",
+		Side:             SideTypeSell,
+		PositionSide:     PositionSideTypeBoth,
+		Status:           OrderStatusTypeFilled,
+		Symbol:           "BTCUSDT",
+		Time:             1596107620044,
+		TimeInForce:      TimeInForceType("IOC"),
+		Type:             OrderTypeLimit,
+		UpdateTime:       1596107620087,
+	}}, res)
+}
@@ -116,3 +116,144 @@ type UmSetLeverageResponse struct {
 	Leverage         int    `json:"leverage"`
 	MaxNotionalValue string `json:"maxNotionalValue"`
 }
+
+// UmGetPositionRiskService get UM position risk
+type UmGetPositionRiskService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *UmGetPositionRiskService) Symbol(symbol string) *UmGetPositionRiskService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *UmGetPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res []*UmPositionRisk, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/positionRisk",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UmPositionRisk{}, err
+	}
+	res = make([]*UmPositionRisk, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UmPositionRisk{}, err
+	}
+	return res, nil
+}
+
+// UmPositionRisk define UM position risk info
+type UmPositionRisk struct {
+	Symbol           string           `json:"symbol"`
+	PositionAmt      string           `json:"positionAmt"`
+	EntryPrice       string           `json:"entryPrice"`
+	BreakEvenPrice   string           `json:"breakEvenPrice"`
+	MarkPrice        string           `json:"markPrice"`
+	UnRealizedProfit string           `json:"unRealizedProfit"`
+	LiquidationPrice string           `json:"liquidationPrice"`
+	Leverage         string           `json:"leverage"`
+	MaxNotionalValue string           `json:"maxNotionalValue"`
+	Notional         string           `json:"notional"`
+	PositionSide     PositionSideType `json:"positionSide"`
+	UpdateTime       int64            `json:"updateTime"`
+}
+
+// UmGetADLQuantileService get UM position ADL quantile estimation
+type UmGetADLQuantileService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *UmGetADLQuantileService) Symbol(symbol string) *UmGetADLQuantileService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *UmGetADLQuantileService) Do(ctx context.Context, opts ...RequestOption) (res []*ADLQuantile, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/adlQuantile",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	res = make([]*ADLQuantile, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	return res, nil
+}
+
+// ADLQuantile define ADL quantile of a symbol, keyed by LONG, SHORT, BOTH or HEDGE
+type ADLQuantile struct {
+	Symbol      string           `json:"symbol"`
+	ADLQuantile map[string]int64 `json:"adlQuantile"`
+}
+
+// UmGetLeverageBracketService get UM notional and leverage brackets
+type UmGetLeverageBracketService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *UmGetLeverageBracketService) Symbol(symbol string) *UmGetLeverageBracketService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *UmGetLeverageBracketService) Do(ctx context.Context, opts ...RequestOption) (res []*UmLeverageBracket, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/um/leverageBracket",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UmLeverageBracket{}, err
+	}
+	res = make([]*UmLeverageBracket, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*UmLeverageBracket{}, err
+	}
+	return res, nil
+}
+
+// UmLeverageBracket define UM leverage bracket of a symbol
+type UmLeverageBracket struct {
+	Symbol       string      `json:"symbol"`
+	NotionalCoef float64     `json:"notionalCoef"`
+	Brackets     []UmBracket `json:"brackets"`
+}
+
+// UmBracket define UM bracket info
+type UmBracket struct {
+	Bracket          int     `json:"bracket"`
+	InitialLeverage  int     `json:"initialLeverage"`
+	NotionalCap      float64 `json:"notionalCap"`
+	NotionalFloor    float64 `json:"notionalFloor"`
+	MaintMarginRatio float64 `json:"maintMarginRatio"`
+	Cum              float64 `json:"cum"`
+}
@@ -0,0 +1,133 @@
+package portfolio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type umPositionServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestUmPositionService(t *testing.T) {
+	suite.Run(t, new(umPositionServiceTestSuite))
+}
+
+func (s *umPositionServiceTestSuite) TestChangePositionMode() {
+	s.mockDo([]byte(`{"code": 200, "msg": "success"}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodPost, "/papi/v1/um/positionSide/dual").setParam("dualSidePosition", true)
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmChangePositionModeService().DualSidePosition(true).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmChangePositionMode{Code: 200, Msg: "success"}, res)
+}
+
+func (s *umPositionServiceTestSuite) TestGetPositionMode() {
+	s.mockDo([]byte(`{"dualSidePosition": true}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/positionSide/dual")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmGetPositionModeService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&UmGetPositionMode{DualSidePosition: true}, res)
+}
+
+func (s *umPositionServiceTestSuite) TestGetPositionRisk() {
+	data := []byte(`[{
+		"entryPrice": "6563.66500",
+		"leverage": "10",
+		"markPrice": "6564.73600",
+		"maxNotionalValue": "100000",
+		"positionAmt": "0.300",
+		"notional": "1969.42080000",
+		"symbol": "BTCUSDT",
+		"unRealizedProfit": "0.32130000",
+		"liquidationPrice": "5930.78",
+		"positionSide": "LONG",
+		"breakEvenPrice": "6566.29",
+		"updateTime": 1625474304765
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/positionRisk").setParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmGetPositionRiskService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*UmPositionRisk{{
+		Symbol:           "BTCUSDT",
+		PositionAmt:      "0.300",
+		EntryPrice:       "6563.66500",
+		BreakEvenPrice:   "6566.29",
+		MarkPrice:        "6564.73600",
+		UnRealizedProfit: "0.32130000",
+		LiquidationPrice: "5930.78",
+		Leverage:         "10",
+		MaxNotionalValue: "100000",
+		Notional:         "1969.42080000",
+		PositionSide:     PositionSideTypeLong,
+		UpdateTime:       1625474304765,
+	}}, res)
+}
+
+func (s *umPositionServiceTestSuite) TestGetADLQuantile() {
+	data := []byte(`[
+		{"symbol": "ETHUSDT", "adlQuantile": {"LONG": 3, "SHORT": 3, "HEDGE": 0}},
+		{"symbol": "BTCUSDT", "adlQuantile": {"LONG": 1, "SHORT": 2, "BOTH": 0}}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/adlQuantile")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmGetADLQuantileService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*ADLQuantile{
+		{Symbol: "ETHUSDT", ADLQuantile: map[string]int64{"LONG": 3, "SHORT": 3, "HEDGE": 0}},
+		{Symbol: "BTCUSDT", ADLQuantile: map[string]int64{"LONG": 1, "SHORT": 2, "BOTH": 0}},
+	}, res)
+}
+
+func (s *umPositionServiceTestSuite) TestGetLeverageBracket() {
+	data := []byte(`[{
+		"symbol": "ETHUSDT",
+		"notionalCoef": 1.50,
+		"brackets": [{
+			"bracket": 1,
+			"initialLeverage": 75,
+			"notionalCap": 10000,
+			"notionalFloor": 0,
+			"maintMarginRatio": 0.0065,
+			"cum": 0
+		}]
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest(http.MethodGet, "/papi/v1/um/leverageBracket").setParam("symbol", "ETHUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewUmGetLeverageBracketService().Symbol("ETHUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*UmLeverageBracket{{
+		Symbol:       "ETHUSDT",
+		NotionalCoef: 1.5,
+		Brackets: []UmBracket{{
+			Bracket:          1,
+			InitialLeverage:  75,
+			NotionalCap:      10000,
+			NotionalFloor:    0,
+			MaintMarginRatio: 0.0065,
+			Cum:              0,
+		}},
+	}}, res)
+}
@@ -0,0 +1,510 @@
+package portfolio
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// uniMMR levels at which the exchange acts on a portfolio margin account
+const (
+	UniMMRMarginCallLevel  = 1.5
+	UniMMRReduceOnlyLevel  = 1.2
+	UniMMRLiquidationLevel = 1.05
+)
+
+// AssetPricer return the price of asset in the quote asset of the unified account,
+// ok is false when the price is unknown
+type AssetPricer func(asset string) (price float64, ok bool)
+
+// UnifiedAsset define the balance of an asset across margin, UM and CM legs
+type UnifiedAsset struct {
+	Asset           string
+	WalletBalance   float64
+	MarginFree      float64
+	MarginLocked    float64
+	MarginBorrowed  float64
+	MarginInterest  float64
+	UmWalletBalance float64
+	UmUnrealizedPNL float64
+	CmWalletBalance float64
+	CmUnrealizedPNL float64
+	NegativeBalance float64
+	// NetBalance is wallet balance plus unrealized pnl minus liabilities
+	NetBalance float64
+	// Price and Notional are in the quote asset, both are zero when the price is unknown
+	Price      float64
+	Notional   float64
+	UpdateTime int64
+}
+
+// UnifiedPosition define a UM or CM position
+type UnifiedPosition struct {
+	BusinessUnit     BusinessUnit
+	Symbol           string
+	PositionSide     PositionSideType
+	PositionAmt      float64
+	EntryPrice       float64
+	MarkPrice        float64
+	LiquidationPrice float64
+	UnrealizedProfit float64
+	InitialMargin    float64
+	MaintMargin      float64
+	// MarginAsset is the asset the position is margined and valued in, the quote asset of UM
+	// symbols, e.g. USDT for BTCUSDT, and the base coin of CM symbols, e.g. BTC for BTCUSD_PERP
+	MarginAsset string
+	// MarginNotional is the absolute position value in MarginAsset
+	MarginNotional float64
+	// Notional is MarginNotional in the quote asset, zero when the price of MarginAsset is unknown
+	Notional   float64
+	UpdateTime int64
+}
+
+// UnifiedAccount define a portfolio level view of the UM, CM and margin legs.
+// UniMMR, the equities, the account margins and AccountStatus are those of the last Refresh
+// or riskLevelChange event, which is only sent when the risk level changes, so they go stale
+// as balances and positions change in between. Refresh regularly to keep them current
+type UnifiedAccount struct {
+	QuoteAsset           string
+	UniMMR               float64
+	AccountEquity        float64
+	ActualEquity         float64
+	AccountInitialMargin float64
+	AccountMaintMargin   float64
+	AccountStatus        string
+	Assets               map[string]*UnifiedAsset
+	Positions            []*UnifiedPosition
+	// GrossNotional is the sum of absolute position notional
+	GrossNotional float64
+	UpdateTime    int64
+	// riskTime is the time of the account level figures
+	riskTime int64
+}
+
+// UniMMRHeadroom return how far uniMMR is above level
+func (a *UnifiedAccount) UniMMRHeadroom(level float64) float64 {
+	return a.UniMMR - level
+}
+
+// EquityBuffer return the equity that can be lost before uniMMR falls to level,
+// assuming the maintenance margin stays unchanged
+func (a *UnifiedAccount) EquityBuffer(level float64) float64 {
+	return a.AccountEquity - level*a.AccountMaintMargin
+}
+
+// DistanceToLiquidation return the fraction of account equity that can be lost
+// before uniMMR reaches UniMMRLiquidationLevel
+func (a *UnifiedAccount) DistanceToLiquidation() float64 {
+	if a.AccountEquity <= 0 {
+		return 0
+	}
+	return a.EquityBuffer(UniMMRLiquidationLevel) / a.AccountEquity
+}
+
+func (a *UnifiedAccount) clone() *UnifiedAccount {
+	res := *a
+	res.Assets = make(map[string]*UnifiedAsset, len(a.Assets))
+	for k, v := range a.Assets {
+		asset := *v
+		res.Assets[k] = &asset
+	}
+	res.Positions = make([]*UnifiedPosition, 0, len(a.Positions))
+	for _, v := range a.Positions {
+		position := *v
+		res.Positions = append(res.Positions, &position)
+	}
+	return &res
+}
+
+// RiskAggregator build a UnifiedAccount from all legs of the portfolio margin account
+// and keep it up to date from the user data stream
+type RiskAggregator struct {
+	c          *Client
+	quoteAsset string
+	pricer     AssetPricer
+	mu         sync.RWMutex
+	account    *UnifiedAccount
+	// refreshing is the number of Refresh in flight, the events received meanwhile
+	// are kept in pending to be applied again on the accounts they build
+	refreshing int
+	pending    []*WsUserDataEvent
+}
+
+// NewRiskAggregator init a risk aggregator, pricer may be nil in which case
+// only the quote asset itself is valued
+func (c *Client) NewRiskAggregator(quoteAsset string, pricer AssetPricer) *RiskAggregator {
+	return &RiskAggregator{
+		c:          c,
+		quoteAsset: quoteAsset,
+		pricer:     pricer,
+	}
+}
+
+// Refresh fetch all legs concurrently and rebuild the unified account, the user data events
+// received during the fetch are applied on top. The other legs are canceled once one fails
+func (a *RiskAggregator) Refresh(ctx context.Context, opts ...RequestOption) (res *UnifiedAccount, err error) {
+	a.mu.Lock()
+	a.refreshing++
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.refreshing--
+		if a.refreshing == 0 {
+			a.pending = nil
+		}
+		a.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg          sync.WaitGroup
+		errOnce     sync.Once
+		firstErr    error
+		account     *AccountInfo
+		balances    []*Balance
+		umAccount   *UmAccount
+		cmAccount   *UmAccount
+		umPositions []*UmPositionRisk
+		cmPositions []*CmPositionRisk
+	)
+	run := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	run(func() (err error) {
+		account, err = a.c.NewGetAccountService().Do(ctx, opts...)
+		return err
+	})
+	run(func() (err error) {
+		balances, err = a.c.NewGetAllBalanceService().Do(ctx, opts...)
+		return err
+	})
+	run(func() (err error) {
+		umAccount, err = a.c.NewUmGetAccountService().Do(ctx, opts...)
+		return err
+	})
+	run(func() (err error) {
+		cmAccount, err = a.c.NewCmGetAccountService().Do(ctx, opts...)
+		return err
+	})
+	run(func() (err error) {
+		umPositions, err = a.c.NewUmGetPositionRiskService().Do(ctx, opts...)
+		return err
+	})
+	run(func() (err error) {
+		cmPositions, err = a.c.NewCmGetPositionRiskService().Do(ctx, opts...)
+		return err
+	})
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	res = a.build(account, balances, umAccount, cmAccount, umPositions, cmPositions)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, event := range a.pending {
+		a.apply(res, event)
+	}
+	a.account = res
+	return res.clone(), nil
+}
+
+// Snapshot return a copy of the latest unified account, nil before the first Refresh
+func (a *RiskAggregator) Snapshot() *UnifiedAccount {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.account == nil {
+		return nil
+	}
+	return a.account.clone()
+}
+
+func (a *RiskAggregator) build(account *AccountInfo, balances []*Balance, umAccount *UmAccount, cmAccount *UmAccount,
+	umPositions []*UmPositionRisk, cmPositions []*CmPositionRisk) *UnifiedAccount {
+	res := &UnifiedAccount{
+		QuoteAsset:           a.quoteAsset,
+		UniMMR:               parseFloat(account.UniMMR),
+		AccountEquity:        parseFloat(account.AccountEquity),
+		ActualEquity:         parseFloat(account.ActualEquity),
+		AccountInitialMargin: parseFloat(account.AccountInitialMargin),
+		AccountMaintMargin:   parseFloat(account.AccountMaintMargin),
+		AccountStatus:        account.AccountStatus,
+		Assets:               make(map[string]*UnifiedAsset, len(balances)),
+		UpdateTime:           account.UpdateTime,
+		riskTime:             account.UpdateTime,
+	}
+	for _, b := range balances {
+		res.Assets[b.Asset] = &UnifiedAsset{
+			Asset:           b.Asset,
+			WalletBalance:   parseFloat(b.TotalWalletBalance),
+			MarginFree:      parseFloat(b.CrossMarginFree),
+			MarginLocked:    parseFloat(b.CrossMarginLocked),
+			MarginBorrowed:  parseFloat(b.CrossMarginBorrowed),
+			MarginInterest:  parseFloat(b.CrossMarginInterest),
+			UmWalletBalance: parseFloat(b.UmWalletBalance),
+			UmUnrealizedPNL: parseFloat(b.UmUnrealizedPNL),
+			CmWalletBalance: parseFloat(b.CmWalletBalance),
+			CmUnrealizedPNL: parseFloat(b.CmUnrealizedPNL),
+			NegativeBalance: parseFloat(b.NegativeBalance),
+			UpdateTime:      b.UpdateTime,
+		}
+	}
+
+	type positionKey struct {
+		symbol string
+		side   PositionSideType
+	}
+	umMargins := make(map[positionKey]*UmAccountPosition)
+	for _, p := range umAccount.Positions {
+		umMargins[positionKey{p.Symbol, p.PositionSide}] = p
+	}
+	cmMargins := make(map[positionKey]*UmAccountPosition)
+	for _, p := range cmAccount.Positions {
+		cmMargins[positionKey{p.Symbol, p.PositionSide}] = p
+	}
+	for _, p := range umPositions {
+		position := &UnifiedPosition{
+			BusinessUnit:     UmBusinessUnit,
+			Symbol:           p.Symbol,
+			PositionSide:     p.PositionSide,
+			PositionAmt:      parseFloat(p.PositionAmt),
+			EntryPrice:       parseFloat(p.EntryPrice),
+			MarkPrice:        parseFloat(p.MarkPrice),
+			LiquidationPrice: parseFloat(p.LiquidationPrice),
+			UnrealizedProfit: parseFloat(p.UnRealizedProfit),
+			MarginAsset:      umMarginAsset(p.Symbol),
+			MarginNotional:   abs(parseFloat(p.Notional)),
+			UpdateTime:       p.UpdateTime,
+		}
+		if m, ok := umMargins[positionKey{p.Symbol, p.PositionSide}]; ok {
+			position.InitialMargin = parseFloat(m.InitialMargin)
+			position.MaintMargin = parseFloat(m.MaintMargin)
+		}
+		res.Positions = append(res.Positions, position)
+	}
+	for _, p := range cmPositions {
+		position := &UnifiedPosition{
+			BusinessUnit:     CmBusinessUnit,
+			Symbol:           p.Symbol,
+			PositionSide:     p.PositionSide,
+			PositionAmt:      parseFloat(p.PositionAmt),
+			EntryPrice:       parseFloat(p.EntryPrice),
+			MarkPrice:        parseFloat(p.MarkPrice),
+			LiquidationPrice: parseFloat(p.LiquidationPrice),
+			UnrealizedProfit: parseFloat(p.UnRealizedProfit),
+			MarginAsset:      cmMarginAsset(p.Symbol),
+			// notionalValue is in the base coin
+			MarginNotional: abs(parseFloat(p.NotionalValue)),
+			UpdateTime:     p.UpdateTime,
+		}
+		if m, ok := cmMargins[positionKey{p.Symbol, p.PositionSide}]; ok {
+			position.InitialMargin = parseFloat(m.InitialMargin)
+			position.MaintMargin = parseFloat(m.MaintMargin)
+		}
+		res.Positions = append(res.Positions, position)
+	}
+	a.recompute(res)
+	return res
+}
+
+// recompute refresh derived fields after balances or positions changed
+func (a *RiskAggregator) recompute(account *UnifiedAccount) {
+	for _, asset := range account.Assets {
+		// the events update the balance of each leg, the wallet balance is their sum
+		asset.WalletBalance = asset.MarginFree + asset.MarginLocked + asset.UmWalletBalance + asset.CmWalletBalance
+		asset.NetBalance = asset.WalletBalance + asset.UmUnrealizedPNL + asset.CmUnrealizedPNL -
+			asset.MarginBorrowed - asset.MarginInterest - asset.NegativeBalance
+		asset.Price, asset.Notional = 0, 0
+		if price, ok := a.price(asset.Asset); ok {
+			asset.Price = price
+			asset.Notional = asset.NetBalance * price
+		}
+	}
+	account.GrossNotional = 0
+	for _, p := range account.Positions {
+		p.Notional = 0
+		if price, ok := a.price(p.MarginAsset); ok {
+			p.Notional = p.MarginNotional * price
+		}
+		account.GrossNotional += p.Notional
+	}
+}
+
+// umMarginAsset return the quote asset of a UM symbol, e.g. USDT for BTCUSDT and BTCUSDT_250328
+func umMarginAsset(symbol string) string {
+	if i := strings.IndexByte(symbol, '_'); i >= 0 {
+		symbol = symbol[:i]
+	}
+	for _, asset := range []string{"USDT", "USDC", "FDUSD", "BUSD"} {
+		if strings.HasSuffix(symbol, asset) {
+			return asset
+		}
+	}
+	return ""
+}
+
+// cmMarginAsset return the base coin of a CM symbol, e.g. BTC for BTCUSD_PERP
+func cmMarginAsset(symbol string) string {
+	if i := strings.IndexByte(symbol, '_'); i >= 0 {
+		symbol = symbol[:i]
+	}
+	return strings.TrimSuffix(symbol, "USD")
+}
+
+func (a *RiskAggregator) price(asset string) (float64, bool) {
+	if asset == a.quoteAsset {
+		return 1, true
+	}
+	if a.pricer == nil {
+		return 0, false
+	}
+	return a.pricer(asset)
+}
+
+// HandleUserData apply a user data event to the unified account, it can be used
+// as WsUserDataHandler or as the Default handler of a WsUserDataDispatcher.
+// Events older than the state they update are ignored, as are the events received
+// before the first Refresh starts. Balances and positions are updated as they change,
+// UniMMR and the equities only by riskLevelChange events
+func (a *RiskAggregator) HandleUserData(event *WsUserDataEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.refreshing > 0 {
+		a.pending = append(a.pending, event)
+	}
+	if a.account != nil {
+		a.apply(a.account, event)
+	}
+}
+
+// apply a user data event to account, a.mu must be held
+func (a *RiskAggregator) apply(account *UnifiedAccount, event *WsUserDataEvent) {
+	switch event.Event {
+	case UserDataEventTypeRiskLevelChange:
+		if event.Time < account.riskTime {
+			return
+		}
+		account.riskTime = event.Time
+		account.UniMMR = parseFloat(event.RiskLevelChange.UniMMR)
+		account.AccountEquity = parseFloat(event.RiskLevelChange.AccountEquity)
+		account.ActualEquity = parseFloat(event.RiskLevelChange.ActualEquity)
+		account.AccountMaintMargin = parseFloat(event.RiskLevelChange.MaintenanceMargin)
+		account.AccountStatus = event.RiskLevelChange.Status
+	case UserDataEventTypeOutboundAccountPosition:
+		for _, b := range event.MarginAccountUpdate.Balances {
+			asset := a.asset(account, b.Asset)
+			if event.MarginAccountUpdate.LastUpdateTime < asset.UpdateTime {
+				continue
+			}
+			asset.MarginFree = parseFloat(b.Free)
+			asset.MarginLocked = parseFloat(b.Locked)
+			asset.UpdateTime = event.MarginAccountUpdate.LastUpdateTime
+		}
+	case UserDataEventTypeLiabilityChange:
+		// the principal and interest of the event only cover this borrow or repay,
+		// the outstanding loan including its interest is the total liability
+		asset := a.asset(account, event.LiabilityChange.Asset)
+		if event.Time < asset.UpdateTime {
+			return
+		}
+		asset.MarginBorrowed = parseFloat(event.LiabilityChange.TotalLiability)
+		asset.MarginInterest = 0
+		asset.UpdateTime = event.Time
+	case UserDataEventTypeAccountUpdate:
+		for _, b := range event.AccountUpdate.Balances {
+			asset := a.asset(account, b.Asset)
+			if event.TransactionTime < asset.UpdateTime {
+				continue
+			}
+			if event.BusinessUnit == CmBusinessUnit {
+				asset.CmWalletBalance = parseFloat(b.Balance)
+			} else {
+				asset.UmWalletBalance = parseFloat(b.Balance)
+			}
+			asset.UpdateTime = event.TransactionTime
+		}
+		for _, p := range event.AccountUpdate.Positions {
+			a.applyPosition(account, event, p)
+		}
+	default:
+		return
+	}
+	if event.Time > account.UpdateTime {
+		account.UpdateTime = event.Time
+	}
+	a.recompute(account)
+}
+
+func (a *RiskAggregator) asset(account *UnifiedAccount, name string) *UnifiedAsset {
+	asset, ok := account.Assets[name]
+	if !ok {
+		asset = &UnifiedAsset{Asset: name}
+		account.Assets[name] = asset
+	}
+	return asset
+}
+
+func (a *RiskAggregator) applyPosition(account *UnifiedAccount, event *WsUserDataEvent, p WsPosition) {
+	bu := event.BusinessUnit
+	if bu == "" {
+		bu = UmBusinessUnit
+	}
+	var position *UnifiedPosition
+	for _, v := range account.Positions {
+		if v.BusinessUnit == bu && v.Symbol == p.Symbol && v.PositionSide == p.Side {
+			position = v
+			break
+		}
+	}
+	if position == nil {
+		position = &UnifiedPosition{BusinessUnit: bu, Symbol: p.Symbol, PositionSide: p.Side}
+		if bu == UmBusinessUnit {
+			position.MarginAsset = umMarginAsset(p.Symbol)
+		} else {
+			position.MarginAsset = cmMarginAsset(p.Symbol)
+		}
+		account.Positions = append(account.Positions, position)
+	} else if event.TransactionTime < position.UpdateTime {
+		return
+	}
+	amount := parseFloat(p.Amount)
+	entryPrice := parseFloat(p.EntryPrice)
+	unrealizedProfit := parseFloat(p.UnrealizedPnL)
+	if bu == UmBusinessUnit {
+		if amount != 0 {
+			position.MarkPrice = entryPrice + unrealizedProfit/amount
+		}
+		position.MarginNotional = abs(amount) * position.MarkPrice
+	} else if position.PositionAmt != 0 {
+		// CM contracts have a fixed USD face value, scale the last known notional;
+		// positions opened since the last Refresh are valued on the next Refresh
+		position.MarginNotional = abs(position.MarginNotional / position.PositionAmt * amount)
+	}
+	position.PositionAmt = amount
+	position.EntryPrice = entryPrice
+	position.UnrealizedProfit = unrealizedProfit
+	position.UpdateTime = event.TransactionTime
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
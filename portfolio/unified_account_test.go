@@ -0,0 +1,296 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type riskAggregatorTestSuite struct {
+	suite.Suite
+}
+
+func TestRiskAggregator(t *testing.T) {
+	suite.Run(t, new(riskAggregatorTestSuite))
+}
+
+func (s *riskAggregatorTestSuite) newRiskAggregator() *RiskAggregator {
+	a := NewClient("apiKey", "secretKey").NewRiskAggregator("USDT", func(asset string) (float64, bool) {
+		if asset == "BTC" {
+			return 30000, true
+		}
+		return 0, false
+	})
+	account := &AccountInfo{
+		UniMMR:             "5.00000000",
+		AccountEquity:      "45000.00000000",
+		ActualEquity:       "45000.00000000",
+		AccountMaintMargin: "9000.00000000",
+		AccountStatus:      "NORMAL",
+		UpdateTime:         1745366400000,
+	}
+	balances := []*Balance{
+		{
+			Asset:               "BTC",
+			TotalWalletBalance:  "1.5",
+			CrossMarginAsset:    "1",
+			CrossMarginFree:     "1",
+			CrossMarginLocked:   "0",
+			CrossMarginBorrowed: "0.5",
+			CrossMarginInterest: "0.01",
+			UmWalletBalance:     "0.2",
+			CmWalletBalance:     "0.3",
+			UpdateTime:          1745366400000,
+		},
+		{
+			Asset:              "USDT",
+			TotalWalletBalance: "1000",
+			UmWalletBalance:    "1000",
+			UpdateTime:         1745366400000,
+		},
+	}
+	a.account = a.build(account, balances, &UmAccount{}, &UmAccount{}, nil, nil)
+	return a
+}
+
+func (s *riskAggregatorTestSuite) TestBuild() {
+	account := s.newRiskAggregator().Snapshot()
+	r := s.Require()
+	btc := account.Assets["BTC"]
+	r.InDelta(1.5, btc.WalletBalance, 1e-9)
+	r.InDelta(0.99, btc.NetBalance, 1e-9)
+	r.InDelta(29700, btc.Notional, 1e-6)
+	r.InDelta(1000, account.Assets["USDT"].Notional, 1e-9)
+}
+
+func (s *riskAggregatorTestSuite) TestHandleUserData() {
+	tests := []struct {
+		name  string
+		event *WsUserDataEvent
+		check func(account *UnifiedAccount)
+	}{
+		{
+			name: "liabilityChange repay",
+			event: &WsUserDataEvent{
+				Event: UserDataEventTypeLiabilityChange,
+				Time:  1745366401000,
+				LiabilityChange: WsLiabilityChange{
+					Asset:          "BTC",
+					Type:           "REPAY",
+					Principal:      "0.1",
+					Interest:       "0",
+					TotalLiability: "0.41",
+				},
+			},
+			check: func(account *UnifiedAccount) {
+				btc := account.Assets["BTC"]
+				s.InDelta(0.41, btc.MarginBorrowed, 1e-9)
+				s.Equal(0.0, btc.MarginInterest)
+				s.InDelta(1.09, btc.NetBalance, 1e-9)
+				s.InDelta(32700, btc.Notional, 1e-6)
+				s.Equal(int64(1745366401000), account.UpdateTime)
+			},
+		},
+		{
+			name: "ACCOUNT_UPDATE UM",
+			event: &WsUserDataEvent{
+				Event:           UserDataEventTypeAccountUpdate,
+				BusinessUnit:    UmBusinessUnit,
+				Time:            1745366401000,
+				TransactionTime: 1745366400999,
+				AccountUpdate: WsAccountUpdate{
+					Reason:   "ORDER",
+					Balances: []WsBalance{{Asset: "USDT", Balance: "900", CrossWalletBalance: "900", ChangeBalance: "0"}},
+				},
+			},
+			check: func(account *UnifiedAccount) {
+				usdt := account.Assets["USDT"]
+				s.Equal(900.0, usdt.UmWalletBalance)
+				s.Equal(900.0, usdt.WalletBalance)
+				s.Equal(900.0, usdt.NetBalance)
+				s.Equal(900.0, usdt.Notional)
+				s.Equal(int64(1745366400999), usdt.UpdateTime)
+			},
+		},
+		{
+			name: "ACCOUNT_UPDATE CM",
+			event: &WsUserDataEvent{
+				Event:           UserDataEventTypeAccountUpdate,
+				BusinessUnit:    CmBusinessUnit,
+				Time:            1745366401000,
+				TransactionTime: 1745366400999,
+				AccountUpdate: WsAccountUpdate{
+					Reason:   "ORDER",
+					Balances: []WsBalance{{Asset: "BTC", Balance: "0.4"}},
+				},
+			},
+			check: func(account *UnifiedAccount) {
+				btc := account.Assets["BTC"]
+				s.Equal(0.4, btc.CmWalletBalance)
+				s.Equal(0.2, btc.UmWalletBalance)
+				s.InDelta(1.6, btc.WalletBalance, 1e-9)
+				s.InDelta(1.09, btc.NetBalance, 1e-9)
+			},
+		},
+		{
+			name: "outboundAccountPosition",
+			event: &WsUserDataEvent{
+				Event: UserDataEventTypeOutboundAccountPosition,
+				Time:  1745366401000,
+				MarginAccountUpdate: WsMarginAccountUpdate{
+					LastUpdateTime: 1745366401000,
+					Balances:       []WsMarginBalance{{Asset: "BTC", Free: "0.8", Locked: "0.1"}},
+				},
+			},
+			check: func(account *UnifiedAccount) {
+				btc := account.Assets["BTC"]
+				s.InDelta(1.4, btc.WalletBalance, 1e-9)
+				s.InDelta(0.89, btc.NetBalance, 1e-9)
+			},
+		},
+		{
+			name: "riskLevelChange",
+			event: &WsUserDataEvent{
+				Event: UserDataEventTypeRiskLevelChange,
+				Time:  1745366401000,
+				RiskLevelChange: WsRiskLevelChange{
+					UniMMR:            "1.19999999",
+					Status:            "REDUCE_ONLY",
+					AccountEquity:     "10800",
+					ActualEquity:      "10700",
+					MaintenanceMargin: "9000",
+				},
+			},
+			check: func(account *UnifiedAccount) {
+				s.Equal(1.19999999, account.UniMMR)
+				s.Equal("REDUCE_ONLY", account.AccountStatus)
+				s.Equal(10800.0, account.AccountEquity)
+				s.Equal(10700.0, account.ActualEquity)
+				s.Equal(9000.0, account.AccountMaintMargin)
+				s.InDelta(0.99, account.Assets["BTC"].NetBalance, 1e-9)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			a := s.newRiskAggregator()
+			a.HandleUserData(tt.event)
+			tt.check(a.Snapshot())
+		})
+	}
+}
+
+func (s *riskAggregatorTestSuite) TestHandleUserDataBeforeRefresh() {
+	a := NewClient("apiKey", "secretKey").NewRiskAggregator("USDT", nil)
+	a.HandleUserData(&WsUserDataEvent{Event: UserDataEventTypeRiskLevelChange})
+	s.Nil(a.Snapshot())
+}
+
+func (s *riskAggregatorTestSuite) TestPositionNotional() {
+	a := s.newRiskAggregator()
+	account := a.build(&AccountInfo{}, nil, &UmAccount{}, &UmAccount{},
+		[]*UmPositionRisk{
+			{Symbol: "BTCUSDT", PositionAmt: "-0.1", MarkPrice: "30000", Notional: "-3000", PositionSide: PositionSideTypeBoth},
+			{Symbol: "BTCUSDC", PositionAmt: "0.1", MarkPrice: "30000", Notional: "3000", PositionSide: PositionSideTypeBoth},
+		},
+		[]*CmPositionRisk{
+			{Symbol: "BTCUSD_PERP", PositionAmt: "20", MarkPrice: "30000", NotionalValue: "0.06666666", PositionSide: PositionSideTypeBoth},
+		})
+	r := s.Require()
+	r.Len(account.Positions, 3)
+	r.Equal("USDT", account.Positions[0].MarginAsset)
+	r.InDelta(3000, account.Positions[0].Notional, 1e-9)
+	// USDC has no price, the position is not valued
+	r.Equal("USDC", account.Positions[1].MarginAsset)
+	r.InDelta(3000, account.Positions[1].MarginNotional, 1e-9)
+	r.Equal(0.0, account.Positions[1].Notional)
+	r.Equal("BTC", account.Positions[2].MarginAsset)
+	r.InDelta(0.06666666, account.Positions[2].MarginNotional, 1e-12)
+	r.InDelta(1999.9998, account.Positions[2].Notional, 1e-6)
+	r.InDelta(4999.9998, account.GrossNotional, 1e-6)
+}
+
+func (s *riskAggregatorTestSuite) TestHandleUserDataStale() {
+	a := s.newRiskAggregator()
+	a.HandleUserData(&WsUserDataEvent{
+		Event:           UserDataEventTypeLiabilityChange,
+		Time:            1745366399000,
+		LiabilityChange: WsLiabilityChange{Asset: "BTC", TotalLiability: "0.1"},
+	})
+	a.HandleUserData(&WsUserDataEvent{
+		Event:           UserDataEventTypeRiskLevelChange,
+		Time:            1745366399000,
+		RiskLevelChange: WsRiskLevelChange{UniMMR: "1.1"},
+	})
+	account := a.Snapshot()
+	s.InDelta(0.5, account.Assets["BTC"].MarginBorrowed, 1e-9)
+	s.Equal(5.0, account.UniMMR)
+}
+
+func (s *riskAggregatorTestSuite) TestRefreshKeepsEventsDuringFetch() {
+	a := NewClient("apiKey", "secretKey").NewRiskAggregator("USDT", nil)
+	release := make(chan struct{})
+	requested := make(chan string, 6)
+	responses := map[string][]byte{
+		"/papi/v1/account":         []byte(`{"uniMMR":"5","accountEquity":"1000","updateTime":1745366400000}`),
+		"/papi/v1/balance":         []byte(`[{"asset":"USDT","totalWalletBalance":"1000","umWalletBalance":"1000","updateTime":1745366400000}]`),
+		"/papi/v1/um/account":      []byte(`{"positions":[]}`),
+		"/papi/v1/cm/account":      []byte(`{"positions":[]}`),
+		"/papi/v1/um/positionRisk": []byte(`[]`),
+		"/papi/v1/cm/positionRisk": []byte(`[]`),
+	}
+	a.c.do = func(req *http.Request) (*http.Response, error) {
+		requested <- req.URL.Path
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBuffer(responses[req.URL.Path]))}, nil
+	}
+
+	resC := make(chan *UnifiedAccount)
+	go func() {
+		res, err := a.Refresh(context.Background())
+		s.NoError(err)
+		resC <- res
+	}()
+	for i := 0; i < 6; i++ {
+		<-requested
+	}
+	// the balance changed after the snapshot was taken but before it arrived
+	a.HandleUserData(&WsUserDataEvent{
+		Event:           UserDataEventTypeAccountUpdate,
+		BusinessUnit:    UmBusinessUnit,
+		Time:            1745366401000,
+		TransactionTime: 1745366401000,
+		AccountUpdate:   WsAccountUpdate{Balances: []WsBalance{{Asset: "USDT", Balance: "900"}}},
+	})
+	close(release)
+	res := <-resC
+	s.Equal(900.0, res.Assets["USDT"].UmWalletBalance)
+	s.Equal(900.0, a.Snapshot().Assets["USDT"].UmWalletBalance)
+	s.Empty(a.pending)
+}
+
+func (s *riskAggregatorTestSuite) TestRefreshCancelOtherLegs() {
+	a := NewClient("apiKey", "secretKey").NewRiskAggregator("USDT", nil)
+	waiting := make(chan string, 6)
+	a.c.do = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/papi/v1/balance" {
+			for i := 0; i < 5; i++ {
+				<-waiting
+			}
+			return nil, errors.New("connection reset")
+		}
+		// the other legs only return once the failure cancels them
+		waiting <- req.URL.Path
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+
+	_, err := a.Refresh(context.Background())
+	s.EqualError(err, "connection reset")
+	s.Nil(a.Snapshot())
+}
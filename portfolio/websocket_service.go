@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/dictxwang/go-binance/common"
 )
 
 // Endpoints
@@ -17,18 +19,12 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketMetrics receives the health measurements of the websocket connections when it is set
+	WebsocketMetrics common.WsMetrics
 	// UseTestnet switch all the WS streams from production to the testnet
 	UseTestnet = false
 )
 
-// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getWsEndpoint() string {
-	if UseTestnet {
-		return baseWsTestnetUrl
-	}
-	return baseWsMainUrl
-}
-
 // WsBalance define balance
 type WsBalance struct {
 	Asset              string `json:"a"`
@@ -88,51 +84,275 @@ type WsOrderTradeUpdate struct {
 	GTD                  int64              `json:"gtd"` // TIF GTD order auto cancel time
 }
 
+// WsConditionalOrderTradeUpdate define conditional order trade update
+type WsConditionalOrderTradeUpdate struct {
+	Symbol           string           `json:"s"`   // Symbol
+	ClientStrategyID string           `json:"c"`   // Strategy client ID
+	StrategyID       int64            `json:"si"`  // Strategy ID
+	Side             SideType         `json:"S"`   // Side
+	StrategyType     string           `json:"st"`  // Strategy type
+	TimeInForce      TimeInForceType  `json:"f"`   // Time in force
+	OriginalQty      string           `json:"q"`   // Quantity
+	OriginalPrice    string           `json:"p"`   // Price
+	StopPrice        string           `json:"sp"`  // Stop price
+	StrategyStatus   string           `json:"os"`  // Strategy status
+	BookTime         int64            `json:"T"`   // Order book time
+	UpdateTime       int64            `json:"ut"`  // Order update time
+	IsReduceOnly     bool             `json:"R"`   // Is this reduce only
+	WorkingType      string           `json:"wt"`  // Stop price working type
+	PositionSide     PositionSideType `json:"ps"`  // Position side
+	IsClosePosition  bool             `json:"cp"`  // If close-all, pushed with conditional order
+	ActivationPrice  string           `json:"AP"`  // Activation price, only pushed with TRAILING_STOP_MARKET order
+	CallbackRate     string           `json:"cr"`  // Callback rate, only pushed with TRAILING_STOP_MARKET order
+	OrderID          int64            `json:"i"`   // Order ID, only pushed when the strategy is triggered
+	STP              string           `json:"V"`   // STP mode
+	GTD              int64            `json:"gtd"` // TIF GTD order auto cancel time
+}
+
+// WsAccountConfigUpdate define leverage update of a symbol
+type WsAccountConfigUpdate struct {
+	Symbol   string `json:"s"`
+	Leverage int64  `json:"l"`
+}
+
+// WsAccountInfoUpdate define account info update
+type WsAccountInfoUpdate struct {
+	MultiAssetsMode bool `json:"j"`
+}
+
+// WsMarginOrderUpdate define margin order update (executionReport)
+type WsMarginOrderUpdate struct {
+	Symbol                  string             `json:"s"`
+	ClientOrderID           string             `json:"c"`
+	Side                    SideType           `json:"S"`
+	Type                    OrderType          `json:"o"`
+	TimeInForce             TimeInForceType    `json:"f"`
+	OriginalQty             string             `json:"q"`
+	OriginalPrice           string             `json:"p"`
+	StopPrice               string             `json:"P"`
+	IcebergQty              string             `json:"F"`
+	OrderListID             int64              `json:"g"`
+	OrigClientOrderID       string             `json:"C"` // original client order ID, for canceled orders
+	ExecutionType           OrderExecutionType `json:"x"`
+	Status                  OrderStatusType    `json:"X"`
+	RejectReason            string             `json:"r"`
+	ID                      int64              `json:"i"`
+	LastFilledQty           string             `json:"l"`
+	AccumulatedFilledQty    string             `json:"z"`
+	LastFilledPrice         string             `json:"L"`
+	CommissionAsset         string             `json:"N"`
+	Commission              string             `json:"n"`
+	TransactionTime         int64              `json:"T"`
+	TradeID                 int64              `json:"t"`
+	IsInOrderBook           bool               `json:"w"`
+	IsMaker                 bool               `json:"m"`
+	CreateTime              int64              `json:"O"`
+	AccumulatedQuoteQty     string             `json:"Z"`
+	LastQuoteQty            string             `json:"Y"`
+	QuoteOrderQty           string             `json:"Q"`
+	SelfTradePreventionMode string             `json:"V"`
+	WorkingTime             int64              `json:"W"`
+}
+
+// WsMarginBalance define margin account balance
+type WsMarginBalance struct {
+	Asset  string `json:"a"`
+	Free   string `json:"f"`
+	Locked string `json:"l"`
+}
+
+// WsMarginAccountUpdate define margin account update (outboundAccountPosition)
+type WsMarginAccountUpdate struct {
+	LastUpdateTime int64             `json:"u"`
+	UpdateID       int64             `json:"U"`
+	Balances       []WsMarginBalance `json:"B"`
+}
+
+// WsMarginBalanceUpdate define margin balance update
+type WsMarginBalanceUpdate struct {
+	Asset     string `json:"a"`
+	Delta     string `json:"d"`
+	UpdateID  int64  `json:"U"`
+	ClearTime int64  `json:"T"`
+}
+
+// WsLiabilityChange define margin liability change
+type WsLiabilityChange struct {
+	Asset          string `json:"a"`
+	Type           string `json:"t"`
+	TranID         int64  `json:"T"`
+	Principal      string `json:"p"`
+	Interest       string `json:"i"`
+	TotalLiability string `json:"l"`
+}
+
+// WsRiskLevelChange define risk level change
+type WsRiskLevelChange struct {
+	UniMMR            string `json:"u"`
+	Status            string `json:"s"`
+	AccountEquity     string `json:"eq"`
+	ActualEquity      string `json:"ae"`
+	MaintenanceMargin string `json:"m"`
+}
+
+// WsOpenOrderLoss define open order loss of an asset
+type WsOpenOrderLoss struct {
+	Asset  string `json:"a"`
+	Amount string `json:"o"`
+}
+
 // WsUserDataEvent define user data event
+//
+// Only the field matching Event is populated, e.g. MarginOrderUpdate for executionReport.
 type WsUserDataEvent struct {
-	Event            UserDataEventType  `json:"e"`
-	BusinessUnit     BusinessUnit       `json:"fs"`
-	Time             int64              `json:"E"`
-	TransactionTime  int64              `json:"T"`
-	AccountAlias     string             `json:"i,omitempty"`
-	AccountUpdate    WsAccountUpdate    `json:"a,omitempty"`
-	OrderTradeUpdate WsOrderTradeUpdate `json:"o,omitempty"`
+	Event                       UserDataEventType             `json:"e"`
+	BusinessUnit                BusinessUnit                  `json:"fs"`
+	Time                        int64                         `json:"E"`
+	TransactionTime             int64                         `json:"T"`
+	AccountAlias                string                        `json:"i,omitempty"`
+	AccountUpdate               WsAccountUpdate               `json:"a,omitempty"`
+	OrderTradeUpdate            WsOrderTradeUpdate            `json:"o,omitempty"`
+	ConditionalOrderTradeUpdate WsConditionalOrderTradeUpdate `json:"so,omitempty"`
+	AccountConfigUpdate         WsAccountConfigUpdate         `json:"ac,omitempty"`
+	AccountInfoUpdate           WsAccountInfoUpdate           `json:"ai,omitempty"`
+
+	// margin and account level events, their keys clash with the futures ones
+	MarginOrderUpdate   WsMarginOrderUpdate   `json:"-"`
+	MarginAccountUpdate WsMarginAccountUpdate `json:"-"`
+	MarginBalanceUpdate WsMarginBalanceUpdate `json:"-"`
+	LiabilityChange     WsLiabilityChange     `json:"-"`
+	RiskLevelChange     WsRiskLevelChange     `json:"-"`
+	OpenOrderLoss       []WsOpenOrderLoss     `json:"-"`
+}
+
+// parseWsUserDataEvent decode a user data message according to its event type
+func parseWsUserDataEvent(message []byte) (*WsUserDataEvent, error) {
+	j, err := newJSON(message)
+	if err != nil {
+		return nil, err
+	}
+	event := new(WsUserDataEvent)
+	event.Event = UserDataEventType(j.Get("e").MustString())
+	event.Time = j.Get("E").MustInt64()
+
+	switch event.Event {
+	case UserDataEventTypeAccountUpdate, UserDataEventTypeOrderTradeUpdate,
+		UserDataEventTypeConditionalOrderTradeUpdate, UserDataEventTypeAccountConfigUpdate:
+		err = json.Unmarshal(message, event)
+	case UserDataEventTypeExecutionReport:
+		event.TransactionTime = j.Get("T").MustInt64()
+		err = json.Unmarshal(message, &event.MarginOrderUpdate)
+	case UserDataEventTypeOutboundAccountPosition:
+		err = json.Unmarshal(message, &event.MarginAccountUpdate)
+	case UserDataEventTypeBalanceUpdate:
+		err = json.Unmarshal(message, &event.MarginBalanceUpdate)
+	case UserDataEventTypeLiabilityChange:
+		err = json.Unmarshal(message, &event.LiabilityChange)
+	case UserDataEventTypeRiskLevelChange:
+		err = json.Unmarshal(message, &event.RiskLevelChange)
+	case UserDataEventTypeOpenOrderLoss:
+		event.OpenOrderLoss = make([]WsOpenOrderLoss, 0)
+		var data []byte
+		data, err = j.Get("O").Encode()
+		if err == nil {
+			err = json.Unmarshal(data, &event.OpenOrderLoss)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
 }
 
 // WsUserDataHandler handle WsUserDataEvent
 type WsUserDataHandler func(event *WsUserDataEvent)
 
-func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		fmt.Println(string(message))
-		event := new(WsUserDataEvent)
-		err := json.Unmarshal(message, event)
+func newWsUserDataHandler(handler WsUserDataHandler, errHandler ErrHandler) WsHandler {
+	return func(message []byte) {
+		event, err := parseWsUserDataEvent(message)
 		if err != nil {
 			errHandler(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
 }
 
-func WsUserDataServeWithIP(ip string, listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.getWsEndpoint(), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
+	return wsServe(cfg, newWsUserDataHandler(handler, errHandler), errHandler)
+}
+
+func (e *Environment) WsUserDataServeWithIP(ip string, listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.getWsEndpoint(), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
 	cfg.WithIP(ip)
+	return wsServe(cfg, newWsUserDataHandler(handler, errHandler), errHandler)
+}
 
-	wsHandler := func(message []byte) {
-		event := new(WsUserDataEvent)
-		err := json.Unmarshal(message, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
+// WsUserDataDispatcher route user data events to the handler of their type,
+// events without a handler go to Default when it is set
+type WsUserDataDispatcher struct {
+	OnListenKeyExpired            WsUserDataHandler
+	OnAccountUpdate               WsUserDataHandler
+	OnOrderTradeUpdate            WsUserDataHandler
+	OnConditionalOrderTradeUpdate WsUserDataHandler
+	OnAccountConfigUpdate         WsUserDataHandler
+	OnOpenOrderLoss               WsUserDataHandler
+	OnMarginOrderUpdate           WsUserDataHandler
+	OnMarginAccountUpdate         WsUserDataHandler
+	OnMarginBalanceUpdate         WsUserDataHandler
+	OnLiabilityChange             WsUserDataHandler
+	OnRiskLevelChange             WsUserDataHandler
+	Default                       WsUserDataHandler
+}
+
+// Dispatch call the handler registered for the event type
+func (d *WsUserDataDispatcher) Dispatch(event *WsUserDataEvent) {
+	var handler WsUserDataHandler
+	switch event.Event {
+	case UserDataEventTypeListenKeyExpired:
+		handler = d.OnListenKeyExpired
+	case UserDataEventTypeAccountUpdate:
+		handler = d.OnAccountUpdate
+	case UserDataEventTypeOrderTradeUpdate:
+		handler = d.OnOrderTradeUpdate
+	case UserDataEventTypeConditionalOrderTradeUpdate:
+		handler = d.OnConditionalOrderTradeUpdate
+	case UserDataEventTypeAccountConfigUpdate:
+		handler = d.OnAccountConfigUpdate
+	case UserDataEventTypeOpenOrderLoss:
+		handler = d.OnOpenOrderLoss
+	case UserDataEventTypeExecutionReport:
+		handler = d.OnMarginOrderUpdate
+	case UserDataEventTypeOutboundAccountPosition:
+		handler = d.OnMarginAccountUpdate
+	case UserDataEventTypeBalanceUpdate:
+		handler = d.OnMarginBalanceUpdate
+	case UserDataEventTypeLiabilityChange:
+		handler = d.OnLiabilityChange
+	case UserDataEventTypeRiskLevelChange:
+		handler = d.OnRiskLevelChange
+	}
+	if handler == nil {
+		handler = d.Default
+	}
+	if handler != nil {
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsUserDataDispatchServe serve user data stream and route events through dispatcher
+func (e *Environment) WsUserDataDispatchServe(listenKey string, dispatcher *WsUserDataDispatcher, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.WsUserDataServe(listenKey, dispatcher.Dispatch, errHandler)
+}
+
+// WsUserDataDispatchServeWithIP serve user data stream from the local ip and route events through dispatcher
+func (e *Environment) WsUserDataDispatchServeWithIP(ip string, listenKey string, dispatcher *WsUserDataDispatcher, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.WsUserDataServeWithIP(ip, listenKey, dispatcher.Dispatch, errHandler)
 }
 
 // WsPackedUserDataEvent define user data event
@@ -140,16 +360,15 @@ type WsPackedUserDataEvent struct {
 	EventType    string `json:"e"`
 	EventTs      int64  `json:"E"`
 	EventContent string `json:"EventContent,omitempty"`
+	// UserData is nil when the message could not be decoded into a WsUserDataEvent
+	UserData *WsUserDataEvent `json:"-"`
 }
 
 // WsPackedUserDataHandler handle WsPackedUserDataEvent
 type WsPackedUserDataHandler func(event *WsPackedUserDataEvent)
 
-func UmWsPackedUserDataServe(listenKey string, handler WsPackedUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		fmt.Println(string(message))
+func newWsPackedUserDataHandler(handler WsPackedUserDataHandler, errHandler ErrHandler) WsHandler {
+	return func(message []byte) {
 		event := new(WsPackedUserDataEvent)
 		err := json.Unmarshal(message, event)
 		if err != nil {
@@ -157,25 +376,26 @@ func UmWsPackedUserDataServe(listenKey string, handler WsPackedUserDataHandler,
 			return
 		}
 		event.EventContent = string(message)
-		handler(event)
-	}
-	return wsServe(cfg, wsHandler, errHandler)
-}
-
-func UmWsPackedUserDataServeWithIP(ip string, listenKey string, handler WsPackedUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
-	cfg.WithIP(ip)
-
-	wsHandler := func(message []byte) {
-		event := new(WsPackedUserDataEvent)
-		err := json.Unmarshal(message, event)
+		// the raw message is delivered even when the typed decode fails, the error is reported to errHandler
+		event.UserData, err = parseWsUserDataEvent(message)
 		if err != nil {
 			errHandler(err)
-			return
 		}
-		event.EventContent = string(message)
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+}
+
+func (e *Environment) UmWsPackedUserDataServe(listenKey string, handler WsPackedUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.getWsEndpoint(), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
+	return wsServe(cfg, newWsPackedUserDataHandler(handler, errHandler), errHandler)
+}
+
+func (e *Environment) UmWsPackedUserDataServeWithIP(ip string, listenKey string, handler WsPackedUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.getWsEndpoint(), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
+	cfg.WithIP(ip)
+	return wsServe(cfg, newWsPackedUserDataHandler(handler, errHandler), errHandler)
 }
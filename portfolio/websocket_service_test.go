@@ -0,0 +1,342 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	wsExecutionReportFixture = `{
+		"e": "executionReport",
+		"E": 1499405658658,
+		"s": "ETHBTC",
+		"c": "mUvoqJxFIILMdfAW5iGSOW",
+		"S": "BUY",
+		"o": "LIMIT",
+		"f": "GTC",
+		"q": "1.00000000",
+		"p": "0.10264410",
+		"P": "0.00000000",
+		"F": "0.00000000",
+		"g": -1,
+		"C": "",
+		"x": "TRADE",
+		"X": "PARTIALLY_FILLED",
+		"r": "NONE",
+		"i": 4293153,
+		"l": "0.40000000",
+		"z": "0.40000000",
+		"L": "0.10264410",
+		"n": "0.00004000",
+		"N": "BNB",
+		"T": 1499405658657,
+		"t": 7,
+		"w": true,
+		"m": false,
+		"O": 1499405658657,
+		"Z": "0.04105764",
+		"Y": "0.04105764",
+		"Q": "0.00000000",
+		"V": "EXPIRE_MAKER",
+		"W": 1499405658657
+	}`
+	wsLiabilityChangeFixture = `{
+		"e": "liabilityChange",
+		"E": 1573200697110,
+		"a": "BTC",
+		"t": "BORROW",
+		"T": 1352286576452864727,
+		"p": "1.03453430",
+		"i": "0",
+		"l": "1.03476851"
+	}`
+	wsRiskLevelChangeFixture = `{
+		"e": "riskLevelChange",
+		"E": 1587727187525,
+		"u": "1.99999999",
+		"s": "MARGIN_CALL",
+		"eq": "30.23416728",
+		"ae": "30.23416728",
+		"m": "15.11708371"
+	}`
+	wsOpenOrderLossFixture = `{
+		"e": "openOrderLoss",
+		"E": 1678710578788,
+		"O": [
+			{"a": "BUSD", "o": "-0.1232313"},
+			{"a": "BNB", "o": "-12.1232313"}
+		]
+	}`
+	wsConditionalOrderTradeUpdateFixture = `{
+		"e": "CONDITIONAL_ORDER_TRADE_UPDATE",
+		"T": 1669262908216,
+		"E": 1669262908218,
+		"fs": "UM",
+		"so": {
+			"s": "BTCUSDT",
+			"c": "TEST",
+			"si": 176057039,
+			"S": "SELL",
+			"st": "TRAILING_STOP_MARKET",
+			"f": "GTC",
+			"q": "0.001",
+			"p": "0",
+			"sp": "7103.04",
+			"os": "NEW",
+			"T": 1568879465650,
+			"ut": 1669262908216,
+			"R": false,
+			"wt": "MARK_PRICE",
+			"ps": "LONG",
+			"cp": false,
+			"AP": "7476.89",
+			"cr": "5.0",
+			"V": "EXPIRE_TAKER",
+			"gtd": 0
+		}
+	}`
+	wsAccountConfigUpdateFixture = `{
+		"e": "ACCOUNT_CONFIG_UPDATE",
+		"E": 1611646737479,
+		"T": 1611646737476,
+		"fs": "UM",
+		"ac": {
+			"s": "BTCUSDT",
+			"l": 25
+		}
+	}`
+)
+
+type websocketServiceTestSuite struct {
+	suite.Suite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+}
+
+func TestWebsocketService(t *testing.T) {
+	suite.Run(t, new(websocketServiceTestSuite))
+}
+
+func (s *websocketServiceTestSuite) SetupTest() {
+	s.origWsServe = wsServe
+}
+
+func (s *websocketServiceTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+}
+
+func (s *websocketServiceTestSuite) mockWsServe(messages ...string) {
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			<-stopC
+			close(doneC)
+		}()
+		for _, message := range messages {
+			handler([]byte(message))
+		}
+		return doneC, stopC, nil
+	}
+}
+
+func (s *websocketServiceTestSuite) TestParseWsUserDataEvent() {
+	tests := []struct {
+		name    string
+		message string
+		want    *WsUserDataEvent
+	}{
+		{
+			name:    "executionReport",
+			message: wsExecutionReportFixture,
+			want: &WsUserDataEvent{
+				Event:           UserDataEventTypeExecutionReport,
+				Time:            1499405658658,
+				TransactionTime: 1499405658657,
+				MarginOrderUpdate: WsMarginOrderUpdate{
+					Symbol:                  "ETHBTC",
+					ClientOrderID:           "mUvoqJxFIILMdfAW5iGSOW",
+					Side:                    SideTypeBuy,
+					Type:                    OrderTypeLimit,
+					TimeInForce:             TimeInForceTypeGTC,
+					OriginalQty:             "1.00000000",
+					OriginalPrice:           "0.10264410",
+					StopPrice:               "0.00000000",
+					IcebergQty:              "0.00000000",
+					OrderListID:             -1,
+					ExecutionType:           "TRADE",
+					Status:                  "PARTIALLY_FILLED",
+					RejectReason:            "NONE",
+					ID:                      4293153,
+					LastFilledQty:           "0.40000000",
+					AccumulatedFilledQty:    "0.40000000",
+					LastFilledPrice:         "0.10264410",
+					CommissionAsset:         "BNB",
+					Commission:              "0.00004000",
+					TransactionTime:         1499405658657,
+					TradeID:                 7,
+					IsInOrderBook:           true,
+					CreateTime:              1499405658657,
+					AccumulatedQuoteQty:     "0.04105764",
+					LastQuoteQty:            "0.04105764",
+					QuoteOrderQty:           "0.00000000",
+					SelfTradePreventionMode: "EXPIRE_MAKER",
+					WorkingTime:             1499405658657,
+				},
+			},
+		},
+		{
+			name:    "liabilityChange",
+			message: wsLiabilityChangeFixture,
+			want: &WsUserDataEvent{
+				Event: UserDataEventTypeLiabilityChange,
+				Time:  1573200697110,
+				LiabilityChange: WsLiabilityChange{
+					Asset:          "BTC",
+					Type:           "BORROW",
+					TranID:         1352286576452864727,
+					Principal:      "1.03453430",
+					Interest:       "0",
+					TotalLiability: "1.03476851",
+				},
+			},
+		},
+		{
+			name:    "riskLevelChange",
+			message: wsRiskLevelChangeFixture,
+			want: &WsUserDataEvent{
+				Event: UserDataEventTypeRiskLevelChange,
+				Time:  1587727187525,
+				RiskLevelChange: WsRiskLevelChange{
+					UniMMR:            "1.99999999",
+					Status:            "MARGIN_CALL",
+					AccountEquity:     "30.23416728",
+					ActualEquity:      "30.23416728",
+					MaintenanceMargin: "15.11708371",
+				},
+			},
+		},
+		{
+			name:    "openOrderLoss",
+			message: wsOpenOrderLossFixture,
+			want: &WsUserDataEvent{
+				Event: UserDataEventTypeOpenOrderLoss,
+				Time:  1678710578788,
+				OpenOrderLoss: []WsOpenOrderLoss{
+					{Asset: "BUSD", Amount: "-0.1232313"},
+					{Asset: "BNB", Amount: "-12.1232313"},
+				},
+			},
+		},
+		{
+			name:    "CONDITIONAL_ORDER_TRADE_UPDATE",
+			message: wsConditionalOrderTradeUpdateFixture,
+			want: &WsUserDataEvent{
+				Event:           UserDataEventTypeConditionalOrderTradeUpdate,
+				BusinessUnit:    "UM",
+				Time:            1669262908218,
+				TransactionTime: 1669262908216,
+				ConditionalOrderTradeUpdate: WsConditionalOrderTradeUpdate{
+					Symbol:           "BTCUSDT",
+					ClientStrategyID: "TEST",
+					StrategyID:       176057039,
+					Side:             SideTypeSell,
+					StrategyType:     "TRAILING_STOP_MARKET",
+					TimeInForce:      TimeInForceTypeGTC,
+					OriginalQty:      "0.001",
+					OriginalPrice:    "0",
+					StopPrice:        "7103.04",
+					StrategyStatus:   "NEW",
+					BookTime:         1568879465650,
+					UpdateTime:       1669262908216,
+					WorkingType:      "MARK_PRICE",
+					PositionSide:     PositionSideTypeLong,
+					ActivationPrice:  "7476.89",
+					CallbackRate:     "5.0",
+					STP:              "EXPIRE_TAKER",
+				},
+			},
+		},
+		{
+			name:    "ACCOUNT_CONFIG_UPDATE",
+			message: wsAccountConfigUpdateFixture,
+			want: &WsUserDataEvent{
+				Event:               UserDataEventTypeAccountConfigUpdate,
+				BusinessUnit:        "UM",
+				Time:                1611646737479,
+				TransactionTime:     1611646737476,
+				AccountConfigUpdate: WsAccountConfigUpdate{Symbol: "BTCUSDT", Leverage: 25},
+			},
+		},
+	}
+	for _, tt := range tests {
+		event, err := parseWsUserDataEvent([]byte(tt.message))
+		s.Require().NoError(err, tt.name)
+		s.Equal(tt.want, event, tt.name)
+	}
+}
+
+func (s *websocketServiceTestSuite) TestWsUserDataDispatcher() {
+	s.mockWsServe(wsExecutionReportFixture, wsLiabilityChangeFixture, wsRiskLevelChangeFixture,
+		wsOpenOrderLossFixture, wsConditionalOrderTradeUpdateFixture, wsAccountConfigUpdateFixture)
+	var got []string
+	record := func(name string) WsUserDataHandler {
+		return func(event *WsUserDataEvent) {
+			got = append(got, name+":"+string(event.Event))
+		}
+	}
+	dispatcher := &WsUserDataDispatcher{
+		OnMarginOrderUpdate:           record("margin"),
+		OnLiabilityChange:             record("liability"),
+		OnRiskLevelChange:             record("risk"),
+		OnConditionalOrderTradeUpdate: record("conditional"),
+		Default:                       record("default"),
+	}
+	doneC, stopC, err := WsUserDataDispatchServe("listenKey", dispatcher, func(err error) {
+		s.Fail("unexpected error", err)
+	})
+	s.Require().NoError(err)
+	close(stopC)
+	<-doneC
+	s.Equal([]string{
+		"margin:executionReport",
+		"liability:liabilityChange",
+		"risk:riskLevelChange",
+		"default:openOrderLoss",
+		"conditional:CONDITIONAL_ORDER_TRADE_UPDATE",
+		"default:ACCOUNT_CONFIG_UPDATE",
+	}, got)
+}
+
+func (s *websocketServiceTestSuite) TestWsPackedUserDataServe() {
+	// the order id is not a number, the typed decode fails
+	invalid := `{"e":"executionReport","E":1499405658658,"s":"ETHBTC","i":"x"}`
+	s.mockWsServe(wsLiabilityChangeFixture, invalid, `not json`)
+	var events []*WsPackedUserDataEvent
+	var errs []error
+	doneC, stopC, err := UmWsPackedUserDataServe("listenKey", func(event *WsPackedUserDataEvent) {
+		events = append(events, event)
+	}, func(err error) {
+		errs = append(errs, err)
+	})
+	s.Require().NoError(err)
+	close(stopC)
+	<-doneC
+
+	s.Require().Len(events, 2)
+	s.Equal("liabilityChange", events[0].EventType)
+	s.Equal(int64(1573200697110), events[0].EventTs)
+	s.Equal(wsLiabilityChangeFixture, events[0].EventContent)
+	s.Require().NotNil(events[0].UserData)
+	s.Equal("1.03476851", events[0].UserData.LiabilityChange.TotalLiability)
+
+	s.Equal("executionReport", events[1].EventType)
+	s.Equal(invalid, events[1].EventContent)
+	s.Nil(events[1].UserData)
+
+	// the typed decode failure of the second message and the invalid third one
+	s.Require().Len(errs, 2)
+	var typeErr *json.UnmarshalTypeError
+	s.ErrorAs(errs[0], &typeErr)
+}
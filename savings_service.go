@@ -6,6 +6,8 @@ import (
 )
 
 // ListSavingsFlexibleProductsService https://binance-docs.github.io/apidocs/spot/en/#get-flexible-product-list-user_data
+//
+// Deprecated: use the Simple Earn services, ListSimpleEarnFlexibleProductsService replaces it.
 type ListSavingsFlexibleProductsService struct {
 	c        *Client
 	status   string
@@ -88,6 +90,8 @@ type SavingsFlexibleProduct struct {
 }
 
 // PurchaseSavingsFlexibleProductService https://binance-docs.github.io/apidocs/spot/en/#purchase-flexible-product-user_data
+//
+// Deprecated: use the Simple Earn services, SubscribeSimpleEarnFlexibleProductService replaces it.
 type PurchaseSavingsFlexibleProductService struct {
 	c         *Client
 	productId string
@@ -136,6 +140,8 @@ type PurchaseSavingsFlexibleProductResponse struct {
 }
 
 // RedeemSavingsFlexibleProductService https://binance-docs.github.io/apidocs/spot/en/#redeem-flexible-product-user_data
+//
+// Deprecated: use the Simple Earn services, RedeemSimpleEarnFlexibleProductService replaces it.
 type RedeemSavingsFlexibleProductService struct {
 	c          *Client
 	productId  string
@@ -182,6 +188,8 @@ func (s *RedeemSavingsFlexibleProductService) Do(ctx context.Context, opts ...Re
 }
 
 // ListSavingsFixedAndActivityProductsService https://binance-docs.github.io/apidocs/spot/en/#get-fixed-and-activity-project-list-user_data
+//
+// Deprecated: use the Simple Earn services, ListSimpleEarnLockedProductsService replaces it.
 type ListSavingsFixedAndActivityProductsService struct {
 	c           *Client
 	asset       string
@@ -296,6 +304,8 @@ type SavingsFixedProduct struct {
 }
 
 // SavingFlexibleProductPositionsService fetches the saving flexible product positions
+//
+// Deprecated: use the Simple Earn services, ListSimpleEarnFlexiblePositionsService replaces it.
 type SavingFlexibleProductPositionsService struct {
 	c     *Client
 	asset string
@@ -349,6 +359,8 @@ type SavingFlexibleProductPosition struct {
 }
 
 // SavingFixedProjectPositionsService fetches the saving flexible product positions
+//
+// Deprecated: use the Simple Earn services, ListSimpleEarnLockedPositionsService replaces it.
 type SavingFixedProjectPositionsService struct {
 	c         *Client
 	asset     string
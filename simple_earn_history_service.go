@@ -0,0 +1,667 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+)
+
+// SimpleEarnFlexibleRewardsType define the type of the rewards of Simple Earn flexible products
+type SimpleEarnFlexibleRewardsType string
+
+// Simple Earn flexible rewards types
+const (
+	SimpleEarnFlexibleRewardsTypeBonus    SimpleEarnFlexibleRewardsType = "BONUS"
+	SimpleEarnFlexibleRewardsTypeRealTime SimpleEarnFlexibleRewardsType = "REALTIME"
+	SimpleEarnFlexibleRewardsTypeRewards  SimpleEarnFlexibleRewardsType = "REWARDS"
+)
+
+// ListSimpleEarnFlexibleSubscriptionRecordsService list the subscriptions of Simple Earn flexible products
+type ListSimpleEarnFlexibleSubscriptionRecordsService struct {
+	c          *Client
+	productID  *string
+	purchaseID *int64
+	asset      *string
+	startTime  *int64
+	endTime    *int64
+	current    *int64
+	size       *int64
+}
+
+// ProductID set productId
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) ProductID(productID string) *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	s.productID = &productID
+	return s
+}
+
+// PurchaseID set purchaseId
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) PurchaseID(purchaseID int64) *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	s.purchaseID = &purchaseID
+	return s
+}
+
+// Asset set asset
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) Asset(asset string) *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	s.asset = &asset
+	return s
+}
+
+// StartTime set startTime
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) StartTime(startTime int64) *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) EndTime(endTime int64) *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) Current(current int64) *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) Size(size int64) *ListSimpleEarnFlexibleSubscriptionRecordsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleSubscriptionRecordsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnFlexibleSubscriptionRecordList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/history/subscriptionRecord",
+		secType:  secTypeSigned,
+	}
+	if s.productID != nil {
+		r.setParam("productId", *s.productID)
+	}
+	if s.purchaseID != nil {
+		r.setParam("purchaseId", *s.purchaseID)
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnFlexibleSubscriptionRecordList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleSubscriptionRecordList define a page of Simple Earn flexible subscription records
+type SimpleEarnFlexibleSubscriptionRecordList struct {
+	Rows  []*SimpleEarnFlexibleSubscriptionRecord `json:"rows"`
+	Total int64                                   `json:"total"`
+}
+
+// SimpleEarnFlexibleSubscriptionRecord define a Simple Earn flexible subscription record
+type SimpleEarnFlexibleSubscriptionRecord struct {
+	Amount            string `json:"amount"`
+	Asset             string `json:"asset"`
+	Time              int64  `json:"time"`
+	PurchaseID        int64  `json:"purchaseId"`
+	ProductID         string `json:"productId"`
+	Type              string `json:"type"`
+	SourceAccount     string `json:"sourceAccount"`
+	AmountFromSpot    string `json:"amtFromSpot"`
+	AmountFromFunding string `json:"amtFromFunding"`
+	Status            string `json:"status"`
+}
+
+// ListSimpleEarnLockedSubscriptionRecordsService list the subscriptions of Simple Earn locked products
+type ListSimpleEarnLockedSubscriptionRecordsService struct {
+	c          *Client
+	purchaseID *int64
+	asset      *string
+	startTime  *int64
+	endTime    *int64
+	current    *int64
+	size       *int64
+}
+
+// PurchaseID set purchaseId
+func (s *ListSimpleEarnLockedSubscriptionRecordsService) PurchaseID(purchaseID int64) *ListSimpleEarnLockedSubscriptionRecordsService {
+	s.purchaseID = &purchaseID
+	return s
+}
+
+// Asset set asset
+func (s *ListSimpleEarnLockedSubscriptionRecordsService) Asset(asset string) *ListSimpleEarnLockedSubscriptionRecordsService {
+	s.asset = &asset
+	return s
+}
+
+// StartTime set startTime
+func (s *ListSimpleEarnLockedSubscriptionRecordsService) StartTime(startTime int64) *ListSimpleEarnLockedSubscriptionRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListSimpleEarnLockedSubscriptionRecordsService) EndTime(endTime int64) *ListSimpleEarnLockedSubscriptionRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnLockedSubscriptionRecordsService) Current(current int64) *ListSimpleEarnLockedSubscriptionRecordsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnLockedSubscriptionRecordsService) Size(size int64) *ListSimpleEarnLockedSubscriptionRecordsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedSubscriptionRecordsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnLockedSubscriptionRecordList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/history/subscriptionRecord",
+		secType:  secTypeSigned,
+	}
+	if s.purchaseID != nil {
+		r.setParam("purchaseId", *s.purchaseID)
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnLockedSubscriptionRecordList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedSubscriptionRecordList define a page of Simple Earn locked subscription records
+type SimpleEarnLockedSubscriptionRecordList struct {
+	Rows  []*SimpleEarnLockedSubscriptionRecord `json:"rows"`
+	Total int64                                 `json:"total"`
+}
+
+// SimpleEarnLockedSubscriptionRecord define a Simple Earn locked subscription record
+type SimpleEarnLockedSubscriptionRecord struct {
+	PositionID        string `json:"positionId"`
+	PurchaseID        int64  `json:"purchaseId"`
+	ProjectID         string `json:"projectId"`
+	Time              int64  `json:"time"`
+	Asset             string `json:"asset"`
+	Amount            string `json:"amount"`
+	LockPeriod        string `json:"lockPeriod"`
+	Type              string `json:"type"`
+	SourceAccount     string `json:"sourceAccount"`
+	AmountFromSpot    string `json:"amtFromSpot"`
+	AmountFromFunding string `json:"amtFromFunding"`
+	Status            string `json:"status"`
+}
+
+// ListSimpleEarnFlexibleRedemptionRecordsService list the redemptions of Simple Earn flexible products
+type ListSimpleEarnFlexibleRedemptionRecordsService struct {
+	c         *Client
+	productID *string
+	redeemID  *string
+	asset     *string
+	startTime *int64
+	endTime   *int64
+	current   *int64
+	size      *int64
+}
+
+// ProductID set productId
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) ProductID(productID string) *ListSimpleEarnFlexibleRedemptionRecordsService {
+	s.productID = &productID
+	return s
+}
+
+// RedeemID set redeemId
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) RedeemID(redeemID string) *ListSimpleEarnFlexibleRedemptionRecordsService {
+	s.redeemID = &redeemID
+	return s
+}
+
+// Asset set asset
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) Asset(asset string) *ListSimpleEarnFlexibleRedemptionRecordsService {
+	s.asset = &asset
+	return s
+}
+
+// StartTime set startTime
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) StartTime(startTime int64) *ListSimpleEarnFlexibleRedemptionRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) EndTime(endTime int64) *ListSimpleEarnFlexibleRedemptionRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) Current(current int64) *ListSimpleEarnFlexibleRedemptionRecordsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) Size(size int64) *ListSimpleEarnFlexibleRedemptionRecordsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleRedemptionRecordsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnFlexibleRedemptionRecordList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/history/redemptionRecord",
+		secType:  secTypeSigned,
+	}
+	if s.productID != nil {
+		r.setParam("productId", *s.productID)
+	}
+	if s.redeemID != nil {
+		r.setParam("redeemId", *s.redeemID)
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnFlexibleRedemptionRecordList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleRedemptionRecordList define a page of Simple Earn flexible redemption records
+type SimpleEarnFlexibleRedemptionRecordList struct {
+	Rows  []*SimpleEarnFlexibleRedemptionRecord `json:"rows"`
+	Total int64                                 `json:"total"`
+}
+
+// SimpleEarnFlexibleRedemptionRecord define a Simple Earn flexible redemption record
+type SimpleEarnFlexibleRedemptionRecord struct {
+	Amount      string `json:"amount"`
+	Asset       string `json:"asset"`
+	Time        int64  `json:"time"`
+	ProductID   string `json:"productId"`
+	RedeemID    string `json:"redeemId"`
+	DestAccount string `json:"destAccount"`
+	Status      string `json:"status"`
+}
+
+// ListSimpleEarnLockedRedemptionRecordsService list the redemptions of Simple Earn locked products
+type ListSimpleEarnLockedRedemptionRecordsService struct {
+	c          *Client
+	positionID *string
+	redeemID   *string
+	asset      *string
+	startTime  *int64
+	endTime    *int64
+	current    *int64
+	size       *int64
+}
+
+// PositionID set positionId
+func (s *ListSimpleEarnLockedRedemptionRecordsService) PositionID(positionID string) *ListSimpleEarnLockedRedemptionRecordsService {
+	s.positionID = &positionID
+	return s
+}
+
+// RedeemID set redeemId
+func (s *ListSimpleEarnLockedRedemptionRecordsService) RedeemID(redeemID string) *ListSimpleEarnLockedRedemptionRecordsService {
+	s.redeemID = &redeemID
+	return s
+}
+
+// Asset set asset
+func (s *ListSimpleEarnLockedRedemptionRecordsService) Asset(asset string) *ListSimpleEarnLockedRedemptionRecordsService {
+	s.asset = &asset
+	return s
+}
+
+// StartTime set startTime
+func (s *ListSimpleEarnLockedRedemptionRecordsService) StartTime(startTime int64) *ListSimpleEarnLockedRedemptionRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListSimpleEarnLockedRedemptionRecordsService) EndTime(endTime int64) *ListSimpleEarnLockedRedemptionRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnLockedRedemptionRecordsService) Current(current int64) *ListSimpleEarnLockedRedemptionRecordsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnLockedRedemptionRecordsService) Size(size int64) *ListSimpleEarnLockedRedemptionRecordsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedRedemptionRecordsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnLockedRedemptionRecordList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/history/redemptionRecord",
+		secType:  secTypeSigned,
+	}
+	if s.positionID != nil {
+		r.setParam("positionId", *s.positionID)
+	}
+	if s.redeemID != nil {
+		r.setParam("redeemId", *s.redeemID)
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnLockedRedemptionRecordList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedRedemptionRecordList define a page of Simple Earn locked redemption records
+type SimpleEarnLockedRedemptionRecordList struct {
+	Rows  []*SimpleEarnLockedRedemptionRecord `json:"rows"`
+	Total int64                               `json:"total"`
+}
+
+// SimpleEarnLockedRedemptionRecord define a Simple Earn locked redemption record
+type SimpleEarnLockedRedemptionRecord struct {
+	PositionID  string `json:"positionId"`
+	RedeemID    string `json:"redeemId"`
+	Time        int64  `json:"time"`
+	Asset       string `json:"asset"`
+	LockPeriod  string `json:"lockPeriod"`
+	Amount      string `json:"amount"`
+	Type        string `json:"type"`
+	DeliverDate string `json:"deliverDate"`
+	Status      string `json:"status"`
+}
+
+// ListSimpleEarnFlexibleRewardsRecordsService list the rewards of Simple Earn flexible products
+type ListSimpleEarnFlexibleRewardsRecordsService struct {
+	c           *Client
+	rewardsType SimpleEarnFlexibleRewardsType
+	productID   *string
+	asset       *string
+	startTime   *int64
+	endTime     *int64
+	current     *int64
+	size        *int64
+}
+
+// Type set type, required
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) Type(rewardsType SimpleEarnFlexibleRewardsType) *ListSimpleEarnFlexibleRewardsRecordsService {
+	s.rewardsType = rewardsType
+	return s
+}
+
+// ProductID set productId
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) ProductID(productID string) *ListSimpleEarnFlexibleRewardsRecordsService {
+	s.productID = &productID
+	return s
+}
+
+// Asset set asset
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) Asset(asset string) *ListSimpleEarnFlexibleRewardsRecordsService {
+	s.asset = &asset
+	return s
+}
+
+// StartTime set startTime
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) StartTime(startTime int64) *ListSimpleEarnFlexibleRewardsRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) EndTime(endTime int64) *ListSimpleEarnFlexibleRewardsRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) Current(current int64) *ListSimpleEarnFlexibleRewardsRecordsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) Size(size int64) *ListSimpleEarnFlexibleRewardsRecordsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleRewardsRecordsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnFlexibleRewardsRecordList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/history/rewardsRecord",
+		secType:  secTypeSigned,
+	}
+	r.setParam("type", s.rewardsType)
+	if s.productID != nil {
+		r.setParam("productId", *s.productID)
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnFlexibleRewardsRecordList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleRewardsRecordList define a page of Simple Earn flexible reward records
+type SimpleEarnFlexibleRewardsRecordList struct {
+	Rows  []*SimpleEarnFlexibleRewardsRecord `json:"rows"`
+	Total int64                              `json:"total"`
+}
+
+// SimpleEarnFlexibleRewardsRecord define a Simple Earn flexible reward record
+type SimpleEarnFlexibleRewardsRecord struct {
+	Asset     string `json:"asset"`
+	Rewards   string `json:"rewards"`
+	ProjectID string `json:"projectId"`
+	Type      string `json:"type"`
+	Time      int64  `json:"time"`
+}
+
+// ListSimpleEarnLockedRewardsRecordsService list the rewards of Simple Earn locked products
+type ListSimpleEarnLockedRewardsRecordsService struct {
+	c          *Client
+	positionID *string
+	asset      *string
+	startTime  *int64
+	endTime    *int64
+	current    *int64
+	size       *int64
+}
+
+// PositionID set positionId
+func (s *ListSimpleEarnLockedRewardsRecordsService) PositionID(positionID string) *ListSimpleEarnLockedRewardsRecordsService {
+	s.positionID = &positionID
+	return s
+}
+
+// Asset set asset
+func (s *ListSimpleEarnLockedRewardsRecordsService) Asset(asset string) *ListSimpleEarnLockedRewardsRecordsService {
+	s.asset = &asset
+	return s
+}
+
+// StartTime set startTime
+func (s *ListSimpleEarnLockedRewardsRecordsService) StartTime(startTime int64) *ListSimpleEarnLockedRewardsRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListSimpleEarnLockedRewardsRecordsService) EndTime(endTime int64) *ListSimpleEarnLockedRewardsRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnLockedRewardsRecordsService) Current(current int64) *ListSimpleEarnLockedRewardsRecordsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnLockedRewardsRecordsService) Size(size int64) *ListSimpleEarnLockedRewardsRecordsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedRewardsRecordsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnLockedRewardsRecordList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/history/rewardsRecord",
+		secType:  secTypeSigned,
+	}
+	if s.positionID != nil {
+		r.setParam("positionId", *s.positionID)
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnLockedRewardsRecordList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedRewardsRecordList define a page of Simple Earn locked reward records
+type SimpleEarnLockedRewardsRecordList struct {
+	Rows  []*SimpleEarnLockedRewardsRecord `json:"rows"`
+	Total int64                            `json:"total"`
+}
+
+// SimpleEarnLockedRewardsRecord define a Simple Earn locked reward record
+type SimpleEarnLockedRewardsRecord struct {
+	PositionID string `json:"positionId"`
+	Time       int64  `json:"time"`
+	Asset      string `json:"asset"`
+	LockPeriod string `json:"lockPeriod"`
+	Amount     string `json:"amount"`
+	Type       string `json:"type"`
+}
@@ -0,0 +1,830 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+)
+
+// SimpleEarnAccountType define the spot or funding wallet funding a subscription or receiving a redemption
+type SimpleEarnAccountType string
+
+// Simple Earn wallets, SimpleEarnAccountTypeAll subscribes from the spot wallet first and then the funding wallet
+const (
+	SimpleEarnAccountTypeSpot SimpleEarnAccountType = "SPOT"
+	SimpleEarnAccountTypeFund SimpleEarnAccountType = "FUND"
+	SimpleEarnAccountTypeAll  SimpleEarnAccountType = "ALL"
+)
+
+// ListSimpleEarnFlexibleProductsService list the Simple Earn flexible products
+type ListSimpleEarnFlexibleProductsService struct {
+	c       *Client
+	asset   *string
+	current *int64
+	size    *int64
+}
+
+// Asset set asset
+func (s *ListSimpleEarnFlexibleProductsService) Asset(asset string) *ListSimpleEarnFlexibleProductsService {
+	s.asset = &asset
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnFlexibleProductsService) Current(current int64) *ListSimpleEarnFlexibleProductsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnFlexibleProductsService) Size(size int64) *ListSimpleEarnFlexibleProductsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleProductsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnFlexibleProductList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/list",
+		secType:  secTypeSigned,
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnFlexibleProductList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleProductList define a page of Simple Earn flexible products
+type SimpleEarnFlexibleProductList struct {
+	Rows  []*SimpleEarnFlexibleProduct `json:"rows"`
+	Total int64                        `json:"total"`
+}
+
+// SimpleEarnFlexibleProduct define a Simple Earn flexible product
+type SimpleEarnFlexibleProduct struct {
+	Asset                      string             `json:"asset"`
+	LatestAnnualPercentageRate string             `json:"latestAnnualPercentageRate"`
+	TierAnnualPercentageRate   map[string]float64 `json:"tierAnnualPercentageRate"`
+	AirDropPercentageRate      string             `json:"airDropPercentageRate"`
+	CanPurchase                bool               `json:"canPurchase"`
+	CanRedeem                  bool               `json:"canRedeem"`
+	IsSoldOut                  bool               `json:"isSoldOut"`
+	Hot                        bool               `json:"hot"`
+	MinPurchaseAmount          string             `json:"minPurchaseAmount"`
+	ProductID                  string             `json:"productId"`
+	SubscriptionStartTime      int64              `json:"subscriptionStartTime"`
+	Status                     string             `json:"status"`
+}
+
+// ListSimpleEarnLockedProductsService list the Simple Earn locked products
+type ListSimpleEarnLockedProductsService struct {
+	c       *Client
+	asset   *string
+	current *int64
+	size    *int64
+}
+
+// Asset set asset
+func (s *ListSimpleEarnLockedProductsService) Asset(asset string) *ListSimpleEarnLockedProductsService {
+	s.asset = &asset
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnLockedProductsService) Current(current int64) *ListSimpleEarnLockedProductsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnLockedProductsService) Size(size int64) *ListSimpleEarnLockedProductsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedProductsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnLockedProductList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/list",
+		secType:  secTypeSigned,
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnLockedProductList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedProductList define a page of Simple Earn locked products
+type SimpleEarnLockedProductList struct {
+	Rows  []*SimpleEarnLockedProduct `json:"rows"`
+	Total int64                      `json:"total"`
+}
+
+// SimpleEarnLockedProduct define a Simple Earn locked product
+type SimpleEarnLockedProduct struct {
+	ProjectID string `json:"projectId"`
+	Detail    struct {
+		Asset                 string `json:"asset"`
+		RewardAsset           string `json:"rewardAsset"`
+		Duration              int64  `json:"duration"`
+		Renewable             bool   `json:"renewable"`
+		IsSoldOut             bool   `json:"isSoldOut"`
+		APR                   string `json:"apr"`
+		Status                string `json:"status"`
+		SubscriptionStartTime int64  `json:"subscriptionStartTime"`
+		ExtraRewardAsset      string `json:"extraRewardAsset"`
+		ExtraRewardAPR        string `json:"extraRewardAPR"`
+	} `json:"detail"`
+	Quota struct {
+		TotalPersonalQuota string `json:"totalPersonalQuota"`
+		Minimum            string `json:"minimum"`
+	} `json:"quota"`
+}
+
+// SubscribeSimpleEarnFlexibleProductService subscribe a Simple Earn flexible product
+type SubscribeSimpleEarnFlexibleProductService struct {
+	c             *Client
+	productID     string
+	amount        string
+	autoSubscribe *bool
+	sourceAccount *SimpleEarnAccountType
+}
+
+// ProductID set productId
+func (s *SubscribeSimpleEarnFlexibleProductService) ProductID(productID string) *SubscribeSimpleEarnFlexibleProductService {
+	s.productID = productID
+	return s
+}
+
+// Amount set amount
+func (s *SubscribeSimpleEarnFlexibleProductService) Amount(amount string) *SubscribeSimpleEarnFlexibleProductService {
+	s.amount = amount
+	return s
+}
+
+// AutoSubscribe set autoSubscribe, default true
+func (s *SubscribeSimpleEarnFlexibleProductService) AutoSubscribe(autoSubscribe bool) *SubscribeSimpleEarnFlexibleProductService {
+	s.autoSubscribe = &autoSubscribe
+	return s
+}
+
+// SourceAccount set sourceAccount, default SimpleEarnAccountTypeSpot
+func (s *SubscribeSimpleEarnFlexibleProductService) SourceAccount(sourceAccount SimpleEarnAccountType) *SubscribeSimpleEarnFlexibleProductService {
+	s.sourceAccount = &sourceAccount
+	return s
+}
+
+// Do send request
+func (s *SubscribeSimpleEarnFlexibleProductService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnSubscribeResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/flexible/subscribe",
+		secType:  secTypeSigned,
+	}
+	r.setParam("productId", s.productID)
+	r.setParam("amount", s.amount)
+	if s.autoSubscribe != nil {
+		r.setParam("autoSubscribe", *s.autoSubscribe)
+	}
+	if s.sourceAccount != nil {
+		r.setParam("sourceAccount", *s.sourceAccount)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnSubscribeResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubscribeSimpleEarnLockedProductService subscribe a Simple Earn locked product
+type SubscribeSimpleEarnLockedProductService struct {
+	c             *Client
+	projectID     string
+	amount        string
+	autoSubscribe *bool
+	sourceAccount *SimpleEarnAccountType
+	redeemTo      *string
+}
+
+// ProjectID set projectId
+func (s *SubscribeSimpleEarnLockedProductService) ProjectID(projectID string) *SubscribeSimpleEarnLockedProductService {
+	s.projectID = projectID
+	return s
+}
+
+// Amount set amount
+func (s *SubscribeSimpleEarnLockedProductService) Amount(amount string) *SubscribeSimpleEarnLockedProductService {
+	s.amount = amount
+	return s
+}
+
+// AutoSubscribe set autoSubscribe, default true
+func (s *SubscribeSimpleEarnLockedProductService) AutoSubscribe(autoSubscribe bool) *SubscribeSimpleEarnLockedProductService {
+	s.autoSubscribe = &autoSubscribe
+	return s
+}
+
+// SourceAccount set sourceAccount, default SimpleEarnAccountTypeSpot
+func (s *SubscribeSimpleEarnLockedProductService) SourceAccount(sourceAccount SimpleEarnAccountType) *SubscribeSimpleEarnLockedProductService {
+	s.sourceAccount = &sourceAccount
+	return s
+}
+
+// RedeemTo set redeemTo ("SPOT", "FLEXIBLE"), the destination of the principal when the product ends, default "SPOT"
+func (s *SubscribeSimpleEarnLockedProductService) RedeemTo(redeemTo string) *SubscribeSimpleEarnLockedProductService {
+	s.redeemTo = &redeemTo
+	return s
+}
+
+// Do send request
+func (s *SubscribeSimpleEarnLockedProductService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnSubscribeResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/locked/subscribe",
+		secType:  secTypeSigned,
+	}
+	r.setParam("projectId", s.projectID)
+	r.setParam("amount", s.amount)
+	if s.autoSubscribe != nil {
+		r.setParam("autoSubscribe", *s.autoSubscribe)
+	}
+	if s.sourceAccount != nil {
+		r.setParam("sourceAccount", *s.sourceAccount)
+	}
+	if s.redeemTo != nil {
+		r.setParam("redeemTo", *s.redeemTo)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnSubscribeResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnSubscribeResponse define the response of a Simple Earn subscription, PositionID is only set for locked products
+type SimpleEarnSubscribeResponse struct {
+	PurchaseID int64  `json:"purchaseId"`
+	PositionID string `json:"positionId"`
+	Success    bool   `json:"success"`
+}
+
+// RedeemSimpleEarnFlexibleProductService redeem a Simple Earn flexible product
+type RedeemSimpleEarnFlexibleProductService struct {
+	c           *Client
+	productID   string
+	redeemAll   *bool
+	amount      *string
+	destAccount *SimpleEarnAccountType
+}
+
+// ProductID set productId
+func (s *RedeemSimpleEarnFlexibleProductService) ProductID(productID string) *RedeemSimpleEarnFlexibleProductService {
+	s.productID = productID
+	return s
+}
+
+// RedeemAll set redeemAll, Amount is required when it is false
+func (s *RedeemSimpleEarnFlexibleProductService) RedeemAll(redeemAll bool) *RedeemSimpleEarnFlexibleProductService {
+	s.redeemAll = &redeemAll
+	return s
+}
+
+// Amount set amount
+func (s *RedeemSimpleEarnFlexibleProductService) Amount(amount string) *RedeemSimpleEarnFlexibleProductService {
+	s.amount = &amount
+	return s
+}
+
+// DestAccount set destAccount, default SimpleEarnAccountTypeSpot
+func (s *RedeemSimpleEarnFlexibleProductService) DestAccount(destAccount SimpleEarnAccountType) *RedeemSimpleEarnFlexibleProductService {
+	s.destAccount = &destAccount
+	return s
+}
+
+// Do send request
+func (s *RedeemSimpleEarnFlexibleProductService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnRedeemResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/flexible/redeem",
+		secType:  secTypeSigned,
+	}
+	r.setParam("productId", s.productID)
+	if s.redeemAll != nil {
+		r.setParam("redeemAll", *s.redeemAll)
+	}
+	if s.amount != nil {
+		r.setParam("amount", *s.amount)
+	}
+	if s.destAccount != nil {
+		r.setParam("destAccount", *s.destAccount)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnRedeemResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RedeemSimpleEarnLockedProductService redeem a Simple Earn locked position before it ends
+type RedeemSimpleEarnLockedProductService struct {
+	c          *Client
+	positionID string
+}
+
+// PositionID set positionId
+func (s *RedeemSimpleEarnLockedProductService) PositionID(positionID string) *RedeemSimpleEarnLockedProductService {
+	s.positionID = positionID
+	return s
+}
+
+// Do send request
+func (s *RedeemSimpleEarnLockedProductService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnRedeemResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/locked/redeem",
+		secType:  secTypeSigned,
+	}
+	r.setParam("positionId", s.positionID)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnRedeemResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnRedeemResponse define the response of a Simple Earn redemption
+type SimpleEarnRedeemResponse struct {
+	RedeemID int64 `json:"redeemId"`
+	Success  bool  `json:"success"`
+}
+
+// ListSimpleEarnFlexiblePositionsService list the Simple Earn flexible positions
+type ListSimpleEarnFlexiblePositionsService struct {
+	c         *Client
+	asset     *string
+	productID *string
+	current   *int64
+	size      *int64
+}
+
+// Asset set asset
+func (s *ListSimpleEarnFlexiblePositionsService) Asset(asset string) *ListSimpleEarnFlexiblePositionsService {
+	s.asset = &asset
+	return s
+}
+
+// ProductID set productId
+func (s *ListSimpleEarnFlexiblePositionsService) ProductID(productID string) *ListSimpleEarnFlexiblePositionsService {
+	s.productID = &productID
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnFlexiblePositionsService) Current(current int64) *ListSimpleEarnFlexiblePositionsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnFlexiblePositionsService) Size(size int64) *ListSimpleEarnFlexiblePositionsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexiblePositionsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnFlexiblePositionList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/position",
+		secType:  secTypeSigned,
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.productID != nil {
+		r.setParam("productId", *s.productID)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnFlexiblePositionList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexiblePositionList define a page of Simple Earn flexible positions
+type SimpleEarnFlexiblePositionList struct {
+	Rows  []*SimpleEarnFlexiblePosition `json:"rows"`
+	Total int64                         `json:"total"`
+}
+
+// SimpleEarnFlexiblePosition define a Simple Earn flexible position
+type SimpleEarnFlexiblePosition struct {
+	TotalAmount                    string             `json:"totalAmount"`
+	TierAnnualPercentageRate       map[string]float64 `json:"tierAnnualPercentageRate"`
+	LatestAnnualPercentageRate     string             `json:"latestAnnualPercentageRate"`
+	YesterdayAirdropPercentageRate string             `json:"yesterdayAirdropPercentageRate"`
+	Asset                          string             `json:"asset"`
+	AirDropAsset                   string             `json:"airDropAsset"`
+	CanRedeem                      bool               `json:"canRedeem"`
+	CollateralAmount               string             `json:"collateralAmount"`
+	ProductID                      string             `json:"productId"`
+	YesterdayRealTimeRewards       string             `json:"yesterdayRealTimeRewards"`
+	CumulativeBonusRewards         string             `json:"cumulativeBonusRewards"`
+	CumulativeRealTimeRewards      string             `json:"cumulativeRealTimeRewards"`
+	CumulativeTotalRewards         string             `json:"cumulativeTotalRewards"`
+	AutoSubscribe                  bool               `json:"autoSubscribe"`
+}
+
+// ListSimpleEarnLockedPositionsService list the Simple Earn locked positions
+type ListSimpleEarnLockedPositionsService struct {
+	c          *Client
+	asset      *string
+	positionID *string
+	projectID  *string
+	current    *int64
+	size       *int64
+}
+
+// Asset set asset
+func (s *ListSimpleEarnLockedPositionsService) Asset(asset string) *ListSimpleEarnLockedPositionsService {
+	s.asset = &asset
+	return s
+}
+
+// PositionID set positionId
+func (s *ListSimpleEarnLockedPositionsService) PositionID(positionID string) *ListSimpleEarnLockedPositionsService {
+	s.positionID = &positionID
+	return s
+}
+
+// ProjectID set projectId
+func (s *ListSimpleEarnLockedPositionsService) ProjectID(projectID string) *ListSimpleEarnLockedPositionsService {
+	s.projectID = &projectID
+	return s
+}
+
+// Current set current page, starting from 1
+func (s *ListSimpleEarnLockedPositionsService) Current(current int64) *ListSimpleEarnLockedPositionsService {
+	s.current = &current
+	return s
+}
+
+// Size set size, default 10, max 100
+func (s *ListSimpleEarnLockedPositionsService) Size(size int64) *ListSimpleEarnLockedPositionsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedPositionsService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnLockedPositionList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/position",
+		secType:  secTypeSigned,
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.positionID != nil {
+		r.setParam("positionId", *s.positionID)
+	}
+	if s.projectID != nil {
+		r.setParam("projectId", *s.projectID)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnLockedPositionList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedPositionList define a page of Simple Earn locked positions
+type SimpleEarnLockedPositionList struct {
+	Rows  []*SimpleEarnLockedPosition `json:"rows"`
+	Total int64                       `json:"total"`
+}
+
+// SimpleEarnLockedPosition define a Simple Earn locked position
+type SimpleEarnLockedPosition struct {
+	PositionID            int64  `json:"positionId"`
+	ProjectID             string `json:"projectId"`
+	Asset                 string `json:"asset"`
+	Amount                string `json:"amount"`
+	PurchaseTime          string `json:"purchaseTime"`
+	Duration              string `json:"duration"`
+	AccrualDays           string `json:"accrualDays"`
+	RewardAsset           string `json:"rewardAsset"`
+	APY                   string `json:"APY"`
+	RewardAmount          string `json:"rewardAmt"`
+	ExtraRewardAsset      string `json:"extraRewardAsset"`
+	ExtraRewardAPR        string `json:"extraRewardAPR"`
+	EstExtraRewardAmount  string `json:"estExtraRewardAmt"`
+	NextPay               string `json:"nextPay"`
+	NextPayDate           string `json:"nextPayDate"`
+	PayPeriod             string `json:"payPeriod"`
+	RedeemAmountEarly     string `json:"redeemAmountEarly"`
+	RewardsEndDate        string `json:"rewardsEndDate"`
+	DeliverDate           string `json:"deliverDate"`
+	RedeemPeriod          string `json:"redeemPeriod"`
+	RedeemingAmount       string `json:"redeemingAmt"`
+	RedeemTo              string `json:"redeemTo"`
+	PartialAmtDeliverDate string `json:"partialAmtDeliverDate"`
+	CanRedeemEarly        bool   `json:"canRedeemEarly"`
+	CanFastRedemption     bool   `json:"canFastRedemption"`
+	AutoSubscribe         bool   `json:"autoSubscribe"`
+	Type                  string `json:"type"`
+	Status                string `json:"status"`
+	CanReStake            bool   `json:"canReStake"`
+}
+
+// GetSimpleEarnAccountService get the Simple Earn holdings of the account
+type GetSimpleEarnAccountService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetSimpleEarnAccountService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnAccount, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/account",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnAccount)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnAccount define the Simple Earn holdings of the account
+type SimpleEarnAccount struct {
+	TotalAmountInBTC          string `json:"totalAmountInBTC"`
+	TotalAmountInUSDT         string `json:"totalAmountInUSDT"`
+	TotalFlexibleAmountInBTC  string `json:"totalFlexibleAmountInBTC"`
+	TotalFlexibleAmountInUSDT string `json:"totalFlexibleAmountInUSDT"`
+	TotalLockedInBTC          string `json:"totalLockedInBTC"`
+	TotalLockedInUSDT         string `json:"totalLockedInUSDT"`
+}
+
+// GetSimpleEarnFlexiblePersonalLeftQuotaService get the amount of a Simple Earn flexible product the account can still subscribe
+type GetSimpleEarnFlexiblePersonalLeftQuotaService struct {
+	c         *Client
+	productID string
+}
+
+// ProductID set productId
+func (s *GetSimpleEarnFlexiblePersonalLeftQuotaService) ProductID(productID string) *GetSimpleEarnFlexiblePersonalLeftQuotaService {
+	s.productID = productID
+	return s
+}
+
+// Do send request
+func (s *GetSimpleEarnFlexiblePersonalLeftQuotaService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnPersonalLeftQuota, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/personalLeftQuota",
+		secType:  secTypeSigned,
+	}
+	r.setParam("productId", s.productID)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnPersonalLeftQuota)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetSimpleEarnLockedPersonalLeftQuotaService get the amount of a Simple Earn locked product the account can still subscribe
+type GetSimpleEarnLockedPersonalLeftQuotaService struct {
+	c         *Client
+	projectID string
+}
+
+// ProjectID set projectId
+func (s *GetSimpleEarnLockedPersonalLeftQuotaService) ProjectID(projectID string) *GetSimpleEarnLockedPersonalLeftQuotaService {
+	s.projectID = projectID
+	return s
+}
+
+// Do send request
+func (s *GetSimpleEarnLockedPersonalLeftQuotaService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnPersonalLeftQuota, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/personalLeftQuota",
+		secType:  secTypeSigned,
+	}
+	r.setParam("projectId", s.projectID)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnPersonalLeftQuota)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnPersonalLeftQuota define the amount of a Simple Earn product the account can still subscribe
+type SimpleEarnPersonalLeftQuota struct {
+	LeftPersonalQuota string `json:"leftPersonalQuota"`
+}
+
+// GetSimpleEarnFlexibleSubscriptionPreviewService estimate the rewards of a Simple Earn flexible subscription
+type GetSimpleEarnFlexibleSubscriptionPreviewService struct {
+	c         *Client
+	productID string
+	amount    string
+}
+
+// ProductID set productId
+func (s *GetSimpleEarnFlexibleSubscriptionPreviewService) ProductID(productID string) *GetSimpleEarnFlexibleSubscriptionPreviewService {
+	s.productID = productID
+	return s
+}
+
+// Amount set amount
+func (s *GetSimpleEarnFlexibleSubscriptionPreviewService) Amount(amount string) *GetSimpleEarnFlexibleSubscriptionPreviewService {
+	s.amount = amount
+	return s
+}
+
+// Do send request
+func (s *GetSimpleEarnFlexibleSubscriptionPreviewService) Do(ctx context.Context, opts ...RequestOption) (res *SimpleEarnFlexibleSubscriptionPreview, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/subscriptionPreview",
+		secType:  secTypeSigned,
+	}
+	r.setParam("productId", s.productID)
+	r.setParam("amount", s.amount)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SimpleEarnFlexibleSubscriptionPreview)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleSubscriptionPreview define the estimated daily rewards of a Simple Earn flexible subscription
+type SimpleEarnFlexibleSubscriptionPreview struct {
+	TotalAmount             string `json:"totalAmount"`
+	RewardAsset             string `json:"rewardAsset"`
+	AirDropAsset            string `json:"airDropAsset"`
+	EstDailyBonusRewards    string `json:"estDailyBonusRewards"`
+	EstDailyRealTimeRewards string `json:"estDailyRealTimeRewards"`
+	EstDailyAirdropRewards  string `json:"estDailyAirdropRewards"`
+}
+
+// GetSimpleEarnLockedSubscriptionPreviewService estimate the rewards of a Simple Earn locked subscription
+type GetSimpleEarnLockedSubscriptionPreviewService struct {
+	c             *Client
+	projectID     string
+	amount        string
+	autoSubscribe *bool
+}
+
+// ProjectID set projectId
+func (s *GetSimpleEarnLockedSubscriptionPreviewService) ProjectID(projectID string) *GetSimpleEarnLockedSubscriptionPreviewService {
+	s.projectID = projectID
+	return s
+}
+
+// Amount set amount
+func (s *GetSimpleEarnLockedSubscriptionPreviewService) Amount(amount string) *GetSimpleEarnLockedSubscriptionPreviewService {
+	s.amount = amount
+	return s
+}
+
+// AutoSubscribe set autoSubscribe, default true
+func (s *GetSimpleEarnLockedSubscriptionPreviewService) AutoSubscribe(autoSubscribe bool) *GetSimpleEarnLockedSubscriptionPreviewService {
+	s.autoSubscribe = &autoSubscribe
+	return s
+}
+
+// Do send request
+func (s *GetSimpleEarnLockedSubscriptionPreviewService) Do(ctx context.Context, opts ...RequestOption) (res []*SimpleEarnLockedSubscriptionPreview, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/subscriptionPreview",
+		secType:  secTypeSigned,
+	}
+	r.setParam("projectId", s.projectID)
+	r.setParam("amount", s.amount)
+	if s.autoSubscribe != nil {
+		r.setParam("autoSubscribe", *s.autoSubscribe)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]*SimpleEarnLockedSubscriptionPreview, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedSubscriptionPreview define the estimated rewards and dates of a Simple Earn locked subscription
+type SimpleEarnLockedSubscriptionPreview struct {
+	RewardAsset               string `json:"rewardAsset"`
+	TotalRewardAmount         string `json:"totalRewardAmt"`
+	ExtraRewardAsset          string `json:"extraRewardAsset"`
+	EstTotalExtraRewardAmount string `json:"estTotalExtraRewardAmt"`
+	BoostRewardAsset          string `json:"boostRewardAsset"`
+	EstDailyRewardAmount      string `json:"estDailyRewardAmt"`
+	NextPay                   string `json:"nextPay"`
+	NextPayDate               string `json:"nextPayDate"`
+	ValueDate                 string `json:"valueDate"`
+	RewardsEndDate            string `json:"rewardsEndDate"`
+	DeliverDate               string `json:"deliverDate"`
+	NextSubscriptionDate      string `json:"nextSubscriptionDate"`
+}
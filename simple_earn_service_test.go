@@ -0,0 +1,540 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type simpleEarnServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestSimpleEarnService(t *testing.T) {
+	suite.Run(t, new(simpleEarnServiceTestSuite))
+}
+
+func (s *simpleEarnServiceTestSuite) TestListFlexibleProducts() {
+	data := []byte(`{
+    "rows": [
+        {
+            "asset": "BTC",
+            "latestAnnualPercentageRate": "0.05000000",
+            "tierAnnualPercentageRate": {
+                "0-5BTC": 0.05,
+                "5-10BTC": 0.03
+            },
+            "airDropPercentageRate": "0.05000000",
+            "canPurchase": true,
+            "canRedeem": true,
+            "isSoldOut": true,
+            "hot": true,
+            "minPurchaseAmount": "0.01000000",
+            "productId": "BTC001",
+            "subscriptionStartTime": 1646182276000,
+            "status": "PURCHASING"
+        }
+    ],
+    "total": 1
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"asset":   "BTC",
+			"current": 1,
+			"size":    10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSimpleEarnFlexibleProductsService().Asset("BTC").Current(1).Size(10).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1), res.Total)
+	r.Len(res.Rows, 1)
+	r.Equal(&SimpleEarnFlexibleProduct{
+		Asset:                      "BTC",
+		LatestAnnualPercentageRate: "0.05000000",
+		TierAnnualPercentageRate:   map[string]float64{"0-5BTC": 0.05, "5-10BTC": 0.03},
+		AirDropPercentageRate:      "0.05000000",
+		CanPurchase:                true,
+		CanRedeem:                  true,
+		IsSoldOut:                  true,
+		Hot:                        true,
+		MinPurchaseAmount:          "0.01000000",
+		ProductID:                  "BTC001",
+		SubscriptionStartTime:      1646182276000,
+		Status:                     "PURCHASING",
+	}, res.Rows[0])
+}
+
+func (s *simpleEarnServiceTestSuite) TestListLockedProducts() {
+	data := []byte(`{
+    "rows": [
+        {
+            "projectId": "Axs*90",
+            "detail": {
+                "asset": "AXS",
+                "rewardAsset": "AXS",
+                "duration": 90,
+                "renewable": true,
+                "isSoldOut": true,
+                "apr": "1.2069",
+                "status": "CREATED",
+                "subscriptionStartTime": 1646182276000,
+                "extraRewardAsset": "BNB",
+                "extraRewardAPR": "0.23"
+            },
+            "quota": {
+                "totalPersonalQuota": "2",
+                "minimum": "0.001"
+            }
+        }
+    ],
+    "total": 1
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"asset": "AXS",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSimpleEarnLockedProductsService().Asset("AXS").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Rows, 1)
+	product := res.Rows[0]
+	r.Equal("Axs*90", product.ProjectID)
+	r.Equal("AXS", product.Detail.Asset)
+	r.Equal(int64(90), product.Detail.Duration)
+	r.Equal("1.2069", product.Detail.APR)
+	r.Equal("0.23", product.Detail.ExtraRewardAPR)
+	r.Equal("2", product.Quota.TotalPersonalQuota)
+	r.Equal("0.001", product.Quota.Minimum)
+}
+
+func (s *simpleEarnServiceTestSuite) TestSubscribeFlexibleProduct() {
+	data := []byte(`{
+    "purchaseId": 40607,
+    "success": true
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"productId":     "BTC001",
+			"amount":        "0.1",
+			"autoSubscribe": false,
+			"sourceAccount": "FUND",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewSubscribeSimpleEarnFlexibleProductService().
+		ProductID("BTC001").
+		Amount("0.1").
+		AutoSubscribe(false).
+		SourceAccount(SimpleEarnAccountTypeFund).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnSubscribeResponse{PurchaseID: 40607, Success: true}, res)
+}
+
+func (s *simpleEarnServiceTestSuite) TestSubscribeLockedProduct() {
+	data := []byte(`{
+    "purchaseId": 40607,
+    "positionId": "12345",
+    "success": true
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"projectId": "Axs*90",
+			"amount":    "1",
+			"redeemTo":  "FLEXIBLE",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewSubscribeSimpleEarnLockedProductService().
+		ProjectID("Axs*90").
+		Amount("1").
+		RedeemTo("FLEXIBLE").
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnSubscribeResponse{PurchaseID: 40607, PositionID: "12345", Success: true}, res)
+}
+
+func (s *simpleEarnServiceTestSuite) TestRedeemFlexibleProduct() {
+	data := []byte(`{
+    "redeemId": 40607,
+    "success": true
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"productId":   "BTC001",
+			"redeemAll":   true,
+			"destAccount": "SPOT",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewRedeemSimpleEarnFlexibleProductService().
+		ProductID("BTC001").
+		RedeemAll(true).
+		DestAccount(SimpleEarnAccountTypeSpot).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnRedeemResponse{RedeemID: 40607, Success: true}, res)
+}
+
+func (s *simpleEarnServiceTestSuite) TestRedeemLockedProduct() {
+	data := []byte(`{
+    "redeemId": 40607,
+    "success": true
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"positionId": "12345",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewRedeemSimpleEarnLockedProductService().PositionID("12345").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnRedeemResponse{RedeemID: 40607, Success: true}, res)
+}
+
+func (s *simpleEarnServiceTestSuite) TestListFlexiblePositions() {
+	data := []byte(`{
+    "rows": [
+        {
+            "totalAmount": "75.46000000",
+            "tierAnnualPercentageRate": {
+                "0-5BTC": 0.05
+            },
+            "latestAnnualPercentageRate": "0.02599895",
+            "yesterdayAirdropPercentageRate": "0.02599895",
+            "asset": "USDT",
+            "airDropAsset": "BETH",
+            "canRedeem": true,
+            "collateralAmount": "232.23123213",
+            "productId": "USDT001",
+            "yesterdayRealTimeRewards": "0.10293829",
+            "cumulativeBonusRewards": "0.22759183",
+            "cumulativeRealTimeRewards": "0.22759183",
+            "cumulativeTotalRewards": "0.45459183",
+            "autoSubscribe": true
+        }
+    ],
+    "total": 1
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"productId": "USDT001",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSimpleEarnFlexiblePositionsService().ProductID("USDT001").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Rows, 1)
+	position := res.Rows[0]
+	r.Equal("75.46000000", position.TotalAmount)
+	r.Equal(map[string]float64{"0-5BTC": 0.05}, position.TierAnnualPercentageRate)
+	r.Equal("232.23123213", position.CollateralAmount)
+	r.Equal("0.45459183", position.CumulativeTotalRewards)
+	r.True(position.AutoSubscribe)
+}
+
+func (s *simpleEarnServiceTestSuite) TestListLockedPositions() {
+	data := []byte(`{
+    "rows": [
+        {
+            "positionId": 123123,
+            "projectId": "Axs*90",
+            "asset": "AXS",
+            "amount": "122.09202928",
+            "purchaseTime": "1646182276000",
+            "duration": "60",
+            "accrualDays": "4",
+            "rewardAsset": "AXS",
+            "APY": "0.2032",
+            "rewardAmt": "5.17181528",
+            "nextPay": "1.29295383",
+            "nextPayDate": "1646697600000",
+            "payPeriod": "1",
+            "redeemAmountEarly": "2802.24068892",
+            "rewardsEndDate": "1651449600000",
+            "deliverDate": "1651536000000",
+            "redeemPeriod": "1",
+            "redeemingAmt": "232.2323",
+            "redeemTo": "FLEXIBLE",
+            "partialAmtDeliverDate": "1651536000000",
+            "canRedeemEarly": true,
+            "canFastRedemption": true,
+            "autoSubscribe": true,
+            "type": "AUTO",
+            "status": "HOLDING",
+            "canReStake": true
+        }
+    ],
+    "total": 1
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"projectId": "Axs*90",
+			"current":   2,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSimpleEarnLockedPositionsService().ProjectID("Axs*90").Current(2).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Rows, 1)
+	position := res.Rows[0]
+	r.Equal(int64(123123), position.PositionID)
+	r.Equal("122.09202928", position.Amount)
+	r.Equal("0.2032", position.APY)
+	r.Equal("5.17181528", position.RewardAmount)
+	r.Equal("232.2323", position.RedeemingAmount)
+	r.Equal("HOLDING", position.Status)
+	r.True(position.CanReStake)
+}
+
+func (s *simpleEarnServiceTestSuite) TestGetAccount() {
+	data := []byte(`{
+    "totalAmountInBTC": "0.01067982",
+    "totalAmountInUSDT": "77.13289230",
+    "totalFlexibleAmountInBTC": "0.00000000",
+    "totalFlexibleAmountInUSDT": "0.00000000",
+    "totalLockedInBTC": "0.01067982",
+    "totalLockedInUSDT": "77.13289230"
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetSimpleEarnAccountService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnAccount{
+		TotalAmountInBTC:          "0.01067982",
+		TotalAmountInUSDT:         "77.13289230",
+		TotalFlexibleAmountInBTC:  "0.00000000",
+		TotalFlexibleAmountInUSDT: "0.00000000",
+		TotalLockedInBTC:          "0.01067982",
+		TotalLockedInUSDT:         "77.13289230",
+	}, res)
+}
+
+func (s *simpleEarnServiceTestSuite) TestListFlexibleRewardsRecords() {
+	data := []byte(`{
+    "rows": [
+        {
+            "asset": "BUSD",
+            "rewards": "0.00006408",
+            "projectId": "USDT001",
+            "type": "BONUS",
+            "time": 1577233578000
+        }
+    ],
+    "total": 1
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"type":      "BONUS",
+			"asset":     "BUSD",
+			"startTime": 1577233500000,
+			"endTime":   1577233600000,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSimpleEarnFlexibleRewardsRecordsService().
+		Type(SimpleEarnFlexibleRewardsTypeBonus).
+		Asset("BUSD").
+		StartTime(1577233500000).
+		EndTime(1577233600000).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1), res.Total)
+	r.Equal(&SimpleEarnFlexibleRewardsRecord{
+		Asset:     "BUSD",
+		Rewards:   "0.00006408",
+		ProjectID: "USDT001",
+		Type:      "BONUS",
+		Time:      1577233578000,
+	}, res.Rows[0])
+}
+
+func (s *simpleEarnServiceTestSuite) TestListLockedSubscriptionRecords() {
+	data := []byte(`{
+    "rows": [
+        {
+            "positionId": "123123",
+            "purchaseId": 26055,
+            "projectId": "Axs*90",
+            "time": 1575018453000,
+            "asset": "BNB",
+            "amount": "21312.23223",
+            "lockPeriod": "30",
+            "type": "AUTO",
+            "sourceAccount": "SPOT",
+            "amtFromSpot": "30",
+            "amtFromFunding": "70",
+            "status": "SUCCESS"
+        }
+    ],
+    "total": 1
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"purchaseId": 26055,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSimpleEarnLockedSubscriptionRecordsService().PurchaseID(26055).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnLockedSubscriptionRecord{
+		PositionID:        "123123",
+		PurchaseID:        26055,
+		ProjectID:         "Axs*90",
+		Time:              1575018453000,
+		Asset:             "BNB",
+		Amount:            "21312.23223",
+		LockPeriod:        "30",
+		Type:              "AUTO",
+		SourceAccount:     "SPOT",
+		AmountFromSpot:    "30",
+		AmountFromFunding: "70",
+		Status:            "SUCCESS",
+	}, res.Rows[0])
+}
+
+func (s *simpleEarnServiceTestSuite) TestListFlexibleRedemptionRecords() {
+	data := []byte(`{
+    "rows": [
+        {
+            "amount": "10.54000000",
+            "asset": "USDT",
+            "time": 1577257222000,
+            "productId": "USDT001",
+            "redeemId": "40607",
+            "destAccount": "SPOT",
+            "status": "PAID"
+        }
+    ],
+    "total": 1
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"redeemId": "40607",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListSimpleEarnFlexibleRedemptionRecordsService().RedeemID("40607").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnFlexibleRedemptionRecord{
+		Amount:      "10.54000000",
+		Asset:       "USDT",
+		Time:        1577257222000,
+		ProductID:   "USDT001",
+		RedeemID:    "40607",
+		DestAccount: "SPOT",
+		Status:      "PAID",
+	}, res.Rows[0])
+}
+
+func (s *simpleEarnServiceTestSuite) TestGetFlexiblePersonalLeftQuota() {
+	data := []byte(`{
+    "leftPersonalQuota": "1000"
+}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"productId": "USDT001",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetSimpleEarnFlexiblePersonalLeftQuotaService().ProductID("USDT001").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&SimpleEarnPersonalLeftQuota{LeftPersonalQuota: "1000"}, res)
+}
+
+func (s *simpleEarnServiceTestSuite) TestGetLockedSubscriptionPreview() {
+	data := []byte(`[
+    {
+        "rewardAsset": "AXS",
+        "totalRewardAmt": "5.17181528",
+        "extraRewardAsset": "BNB",
+        "estTotalExtraRewardAmt": "5.17181528",
+        "boostRewardAsset": "AXS",
+        "estDailyRewardAmt": "1.20928901",
+        "nextPay": "1.29295383",
+        "nextPayDate": "1646697600000",
+        "valueDate": "1646697600000",
+        "rewardsEndDate": "1651449600000",
+        "deliverDate": "1651536000000",
+        "nextSubscriptionDate": "1651536000000"
+    }
+]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"projectId":     "Axs*90",
+			"amount":        "1",
+			"autoSubscribe": true,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetSimpleEarnLockedSubscriptionPreviewService().
+		ProjectID("Axs*90").
+		Amount("1").
+		AutoSubscribe(true).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal("5.17181528", res[0].TotalRewardAmount)
+	r.Equal("1.20928901", res[0].EstDailyRewardAmount)
+	r.Equal("1651536000000", res[0].NextSubscriptionDate)
+}
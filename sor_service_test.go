@@ -0,0 +1,178 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type sorServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestSORService(t *testing.T) {
+	suite.Run(t, new(sorServiceTestSuite))
+}
+
+func (s *sorServiceTestSuite) TestCreateSOROrder() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 2,
+		"orderListId": -1,
+		"clientOrderId": "sBI1KM6nNtOfj5tccZSKly",
+		"transactTime": 1689149087774,
+		"price": "31000.00000000",
+		"origQty": "0.50000000",
+		"executedQty": "0.50000000",
+		"cummulativeQuoteQty": "14000.00000000",
+		"status": "FILLED",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "BUY",
+		"workingTime": 1689149087774,
+		"fills": [
+			{
+				"matchType": "ONE_PARTY_TRADE_REPORT",
+				"price": "28000.00000000",
+				"qty": "0.50000000",
+				"commission": "0.00000000",
+				"commissionAsset": "BTC",
+				"tradeId": -1,
+				"allocId": 0
+			}
+		],
+		"workingFloor": "SOR",
+		"selfTradePreventionMode": "NONE",
+		"usedSor": true
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":      "BTCUSDT",
+			"side":        SideTypeBuy,
+			"type":        OrderTypeLimit,
+			"timeInForce": TimeInForceTypeGTC,
+			"quantity":    "0.5",
+			"price":       "31000",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateSOROrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("0.5").
+		Price("31000").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.True(res.UsedSor)
+	r.Equal("SOR", res.WorkingFloor)
+	r.Equal(int64(1689149087774), res.WorkingTime)
+	r.Len(res.Fills, 1)
+	e := &Fill{
+		TradeID:         -1,
+		Price:           "28000.00000000",
+		Quantity:        "0.50000000",
+		Commission:      "0.00000000",
+		CommissionAsset: "BTC",
+		MatchType:       "ONE_PARTY_TRADE_REPORT",
+		AllocID:         0,
+	}
+	r.Equal(e, res.Fills[0])
+}
+
+func (s *sorServiceTestSuite) TestTestSOROrder() {
+	data := []byte(`{}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":   "BTCUSDT",
+			"side":     SideTypeSell,
+			"type":     OrderTypeMarket,
+			"quantity": "0.1",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	err := s.client.NewCreateSOROrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeMarket).Quantity("0.1").Test(newContext())
+	s.r().NoError(err)
+}
+
+func (s *sorServiceTestSuite) TestListAllocations() {
+	data := []byte(`[
+		{
+			"symbol": "BTCUSDT",
+			"allocationId": 0,
+			"allocationType": "SOR",
+			"orderId": 1,
+			"orderListId": -1,
+			"price": "1.00000000",
+			"qty": "5.00000000",
+			"quoteQty": "5.00000000",
+			"commission": "0.00000000",
+			"commissionAsset": "BTC",
+			"time": 1687506878118,
+			"isBuyer": true,
+			"isMaker": false,
+			"isAllocator": false
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":  "BTCUSDT",
+			"orderId": int64(1),
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListAllocationsService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &Allocation{
+		Symbol:          "BTCUSDT",
+		AllocationID:    0,
+		AllocationType:  "SOR",
+		OrderID:         1,
+		OrderListID:     -1,
+		Price:           "1.00000000",
+		Quantity:        "5.00000000",
+		QuoteQuantity:   "5.00000000",
+		Commission:      "0.00000000",
+		CommissionAsset: "BTC",
+		Time:            1687506878118,
+		IsBuyer:         true,
+		IsMaker:         false,
+		IsAllocator:     false,
+	}
+	r.Equal(e, res[0])
+}
+
+func (s *sorServiceTestSuite) TestExchangeInfoSors() {
+	data := []byte(`{
+		"timezone": "UTC",
+		"serverTime": 1565246363776,
+		"rateLimits": [],
+		"exchangeFilters": [],
+		"symbols": [],
+		"sors": [
+			{
+				"baseAsset": "BTC",
+				"symbols": ["BTCUSDT", "BTCUSDC"]
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	res, err := s.client.NewExchangeInfoService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]SOR{{BaseAsset: "BTC", Symbols: []string{"BTCUSDT", "BTCUSDC"}}}, res.Sors)
+}
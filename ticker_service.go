@@ -288,3 +288,67 @@ func (s *ListSymbolTickerService) Do(ctx context.Context, opts ...RequestOption)
 	}
 	return res, nil
 }
+
+// TradingDayTickerService show the price change statistics of the current trading day
+type TradingDayTickerService struct {
+	c          *Client
+	symbol     *string
+	symbols    []string
+	timeZone   *string
+	tickerType *TickerType
+}
+
+// Symbol set symbol
+func (s *TradingDayTickerService) Symbol(symbol string) *TradingDayTickerService {
+	s.symbol = &symbol
+	return s
+}
+
+// Symbols set symbols, at most 100 symbols
+func (s *TradingDayTickerService) Symbols(symbols []string) *TradingDayTickerService {
+	s.symbols = symbols
+	return s
+}
+
+// TimeZone set timeZone, the trading day starts at midnight in this offset from UTC, e.g. "-1:00" or "05:45", defaults to 0
+func (s *TradingDayTickerService) TimeZone(timeZone string) *TradingDayTickerService {
+	s.timeZone = &timeZone
+	return s
+}
+
+// Type set type, TickerTypeMini only returns the prices and volumes, defaults to TickerTypeFull
+func (s *TradingDayTickerService) Type(tickerType TickerType) *TradingDayTickerService {
+	s.tickerType = &tickerType
+	return s
+}
+
+// Do send request
+func (s *TradingDayTickerService) Do(ctx context.Context, opts ...RequestOption) (res []*SymbolTicker, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/ticker/tradingDay",
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	} else if s.symbols != nil {
+		s, _ := json.Marshal(s.symbols)
+		r.setParam("symbols", string(s))
+	}
+	if s.timeZone != nil {
+		r.setParam("timeZone", *s.timeZone)
+	}
+	if s.tickerType != nil {
+		r.setParam("type", *s.tickerType)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*SymbolTicker{}, err
+	}
+	data = common.ToJSONList(data)
+	res = make([]*SymbolTicker, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*SymbolTicker{}, err
+	}
+	return res, nil
+}
@@ -556,6 +556,94 @@ func (s *tickerServiceTestSuite) TestListSymbolTicker() {
 	s.assertSymbolTicker(e, res)
 }
 
+func (s *tickerServiceTestSuite) TestTradingDayTicker() {
+	data := []byte(`[
+		{
+			"symbol": "BTCUSDT",
+			"priceChange": "-83.13000000",
+			"priceChangePercent": "-0.317",
+			"weightedAvgPrice": "26234.58803036",
+			"openPrice": "26304.80000000",
+			"highPrice": "26397.46000000",
+			"lowPrice": "26088.34000000",
+			"lastPrice": "26221.67000000",
+			"volume": "18495.35066000",
+			"quoteVolume": "485217905.04210480",
+			"openTime": 1695686400000,
+			"closeTime": 1695772799999,
+			"firstId": 3220151555,
+			"lastId": 3220849281,
+			"count": 697727
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbols := []string{"BTCUSDT", "BNBUSDT"}
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbols":  `["BTCUSDT","BNBUSDT"]`,
+			"timeZone": "8",
+			"type":     TickerTypeFull,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewTradingDayTickerService().Symbols(symbols).TimeZone("8").Type(TickerTypeFull).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	s.assertSymbolTicker([]*SymbolTicker{{
+		Symbol:             "BTCUSDT",
+		PriceChange:        "-83.13000000",
+		PriceChangePercent: "-0.317",
+		WeightedAvgPrice:   "26234.58803036",
+		OpenPrice:          "26304.80000000",
+		HighPrice:          "26397.46000000",
+		LowPrice:           "26088.34000000",
+		LastPrice:          "26221.67000000",
+		Volume:             "18495.35066000",
+		QuoteVolume:        "485217905.04210480",
+		OpenTime:           1695686400000,
+		CloseTime:          1695772799999,
+		FirstId:            3220151555,
+		LastId:             3220849281,
+		Count:              697727,
+	}}, res)
+}
+
+func (s *tickerServiceTestSuite) TestTradingDayTickerSingle() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"openPrice": "26304.80000000",
+		"highPrice": "26397.46000000",
+		"lowPrice": "26088.34000000",
+		"lastPrice": "26221.67000000",
+		"volume": "18495.35066000",
+		"quoteVolume": "485217905.04210480",
+		"openTime": 1695686400000,
+		"closeTime": 1695772799999,
+		"firstId": 3220151555,
+		"lastId": 3220849281,
+		"count": 697727
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest().setParam("symbol", "BTCUSDT").setParam("type", TickerTypeMini)
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewTradingDayTickerService().Symbol("BTCUSDT").Type(TickerTypeMini).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal("BTCUSDT", res[0].Symbol)
+	r.Equal("26221.67000000", res[0].LastPrice)
+	r.Equal("", res[0].PriceChange)
+}
+
 func (s *tickerServiceTestSuite) assertSymbolTicker(e, st []*SymbolTicker) {
 	for i := range e {
 		s.r().Equal(e[i].Symbol, st[i].Symbol, "Symbol")
@@ -2,19 +2,15 @@ package binance
 
 import (
 	"crypto"
-	"crypto/x509"
-	"encoding/base64"
 	//"encoding/json"
-	"encoding/pem"
 	"fmt"
-	"github.com/gorilla/websocket"
 	"net"
 	"net/http"
-	"sort"
-	"strconv"
 	"strings"
-	"sync/atomic"
 	"time"
+
+	"github.com/dictxwang/go-binance/common"
+	"github.com/gorilla/websocket"
 )
 
 const (
@@ -105,6 +101,30 @@ type (
 		Status int64         `json:"status,omitempty"`
 		Result []OrderResult `json:"result,omitempty"`
 	}
+
+	OrderListOrder struct {
+		Symbol        string `json:"symbol"`
+		OrderId       int64  `json:"orderId"`
+		ClientOrderId string `json:"clientOrderId"`
+	}
+
+	OrderListResult struct {
+		OrderListId       int64            `json:"orderListId"`
+		ContingencyType   string           `json:"contingencyType"`
+		ListStatusType    string           `json:"listStatusType"`
+		ListOrderStatus   string           `json:"listOrderStatus"`
+		ListClientOrderId string           `json:"listClientOrderId"`
+		TransactionTime   int64            `json:"transactionTime"`
+		Symbol            string           `json:"symbol"`
+		Orders            []OrderListOrder `json:"orders"`
+		OrderReports      []OrderResult    `json:"orderReports,omitempty"`
+	}
+
+	OrderListResp struct {
+		ID     string          `json:"id,omitempty"`
+		Status int64           `json:"status,omitempty"`
+		Result OrderListResult `json:"result,omitempty"`
+	}
 )
 
 func (a *Basic) GetResult(k string) (interface{}, bool) {
@@ -125,6 +145,7 @@ type ClientWs struct {
 	LoginChan          chan *LoginResp
 	OrderRespChan      chan *OrderResp
 	OrderArrayRespChan chan *OrderArrayResp
+	OrderListRespChan  chan *OrderListResp
 	sendChan           chan []byte
 	AuthRequested      *time.Time
 	Authorized         bool
@@ -132,6 +153,9 @@ type ClientWs struct {
 	ServiceIP          string
 	lastTransmit       *time.Time
 	resolver           *net.Resolver
+	dialer             *websocket.Dialer
+	metrics            common.WsMetrics
+	name               string
 }
 
 func NewTradingWsClient(apiKey, secretKey, localIP string, serviceIP string) (*ClientWs, error) {
@@ -145,10 +169,12 @@ func NewTradingWsClient(apiKey, secretKey, localIP string, serviceIP string) (*C
 		StopChan:  make(chan string),
 		DoneChan:  make(chan string),
 		LocalIP:   localIP,
+		name:      common.NewWsAPIConnName(localIP),
 		ServiceIP: serviceIP,
+		metrics:   WebsocketMetrics,
 	}
 
-	privateKey, err := parsePrivateKey(c.secretKey)
+	privateKey, err := common.ParsePrivateKey(c.secretKey)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +182,29 @@ func NewTradingWsClient(apiKey, secretKey, localIP string, serviceIP string) (*C
 	return c, nil
 }
 
+// NewTradingWsClientWithEnvironment is similar to NewTradingWsClient, but it connects to the WS API of env
+func NewTradingWsClientWithEnvironment(apiKey, secretKey, localIP string, serviceIP string, env *Environment) (*ClientWs, error) {
+	c, err := NewTradingWsClient(apiKey, secretKey, localIP, serviceIP)
+	if err != nil {
+		return nil, err
+	}
+	c.url = env.WsAPIURL
+	c.dialer = env.Dialer
+	c.metrics = env.Metrics
+	return c, nil
+}
+
+// SetMetrics set the metrics receiving the health of the connection, defaults to WebsocketMetrics
+func (c *ClientWs) SetMetrics(metrics common.WsMetrics) {
+	c.metrics = metrics
+}
+
+// SetName set the name of the connection reported to the metrics, defaults to wsAPI numbered in the process
+// and followed by the local IP, e.g. wsAPI#1@10.0.0.1
+func (c *ClientWs) SetName(name string) {
+	c.name = name
+}
+
 func (c *ClientWs) SetResolver(resolver *net.Resolver) {
 	c.resolver = resolver
 }
@@ -167,6 +216,11 @@ func (c *ClientWs) SetChannels(errCh chan *Error, lCh chan *LoginResp, osCh chan
 	c.OrderArrayRespChan = osArrayCh
 }
 
+// SetOrderListChannel set the channel receiving order list responses
+func (c *ClientWs) SetOrderListChannel(olCh chan *OrderListResp) {
+	c.OrderListRespChan = olCh
+}
+
 func (c *ClientWs) Send(method string, args map[string]interface{}, extras ...map[string]string) error {
 	if method != "session.logon" {
 		err := c.Connect()
@@ -295,8 +349,8 @@ func (c *ClientWs) Login() error {
 		"timestamp":  time.Now().UnixMilli(), // use the current time in milliseconds
 	}
 
-	payload := makeQueryString(args)
-	signature, err := signPayload(payload, c.privateKey)
+	payload := common.MakeQueryString(args)
+	signature, err := common.SignPayload(payload, c.privateKey)
 
 	if err != nil {
 		fmt.Printf("Failed to sign payload: %v\n", err)
@@ -322,7 +376,9 @@ func (c *ClientWs) Login() error {
 
 func (c *ClientWs) dial() error {
 	var dialer websocket.Dialer
-	if c.LocalIP != "" {
+	if c.dialer != nil {
+		dialer = *c.dialer
+	} else if c.LocalIP != "" {
 		dialer = websocket.Dialer{
 			NetDial: func(network, addr string) (net.Conn, error) {
 				localAddr, err := net.ResolveTCPAddr("tcp", c.LocalIP+":0") // 替换为您的出口IP地址
@@ -411,6 +467,9 @@ func (c *ClientWs) dial() error {
 	}()
 
 	c.conn = conn
+	if c.metrics != nil {
+		c.metrics.WsConnected(c.name)
+	}
 	c.closed = false
 
 	return nil
@@ -475,11 +534,17 @@ func (c *ClientWs) receiver() error {
 			mt, data, err := c.conn.ReadMessage()
 
 			if err != nil {
+				if c.metrics != nil {
+					c.metrics.WsDisconnected(c.name, err)
+				}
 				return fmt.Errorf("failed to read message from ws connection, error: %v\n", err)
 			}
 
 			now := time.Now()
 			c.lastTransmit = &now
+			if c.metrics != nil {
+				common.ObserveWsMessage(c.metrics, c.name, data, now)
+			}
 
 			if mt == websocket.TextMessage && string(data) != "pong" {
 				//fmt.Printf("Raw JSON data: %s\n", data)
@@ -559,6 +624,18 @@ func (c *ClientWs) process(data []byte, e *Basic) bool {
 			return true
 		}
 
+		_, listOk := e.GetResult("contingencyType")
+		if listOk {
+			e := OrderListResp{}
+			_ = json.Unmarshal(data, &e)
+			go func() {
+				if c.OrderListRespChan != nil {
+					c.OrderListRespChan <- &e
+				}
+			}()
+			return true
+		}
+
 		_, statusOk := e.GetResult("status")
 		if !statusOk {
 			return false
@@ -634,14 +711,14 @@ func (c *ClientWs) PlaceOrder(order *WsPlaceOrder) error {
 	if order.Timestamp == 0 {
 		order.Timestamp = time.Now().UnixMilli()
 	}
-	args := s2m(order)
+	args := common.StructToMap(order)
 	//args["apiKey"] = c.apiKey
 	//args["recvWindow"] = 5000
 	//
-	//payload := makeQueryString(args)
+	//payload := common.MakeQueryString(args)
 	//
 	//fmt.Printf("Place Query: %s\n", payload)
-	//signature, err := signPayload(payload, c.privateKey)
+	//signature, err := common.SignPayload(payload, c.privateKey)
 	//
 	//if err != nil {
 	//	fmt.Printf("Failed to sign place payload: %v\n", err)
@@ -658,12 +735,12 @@ func (c *ClientWs) CancelOrder(order *WsCancelOrder) error {
 	if order.Timestamp == 0 {
 		order.Timestamp = time.Now().UnixMilli()
 	}
-	args := s2m(order)
+	args := common.StructToMap(order)
 	//args["apiKey"] = c.apiKey
 	//args["recvWindow"] = 5000
 	//
-	//payload := makeQueryString(args)
-	//signature, err := signPayload(payload, c.privateKey)
+	//payload := common.MakeQueryString(args)
+	//signature, err := common.SignPayload(payload, c.privateKey)
 	//
 	//if err != nil {
 	//	fmt.Printf("Failed to sign cancel payload: %v\n", err)
@@ -680,69 +757,69 @@ func (c *ClientWs) CancelAllOpenOrders(order *WsCancelAll) error {
 	if order.Timestamp == 0 {
 		order.Timestamp = time.Now().UnixMilli()
 	}
-	args := s2m(order)
+	args := common.StructToMap(order)
 
 	return c.Send("openOrders.cancelAll", args)
 }
 
-func s2m(i interface{}) map[string]interface{} {
-	m := make(map[string]interface{})
-
-	j, _ := json.Marshal(i)
-	_ = json.Unmarshal(j, &m)
-
-	return m
+type WsCancelOrderList struct {
+	Symbol            string `json:"symbol"`
+	OrderListId       int64  `json:"orderListId,omitempty"`
+	ListClientOrderId string `json:"listClientOrderId,omitempty"`
+	Timestamp         int64  `json:"timestamp"`
 }
 
-func getTimestampInMS() int64 {
-	return time.Now().UnixNano() / 1e6
+type WsGetOrderList struct {
+	OrderListId       int64  `json:"orderListId,omitempty"`
+	OrigClientOrderId string `json:"origClientOrderId,omitempty"`
+	Timestamp         int64  `json:"timestamp"`
 }
 
-var gClientOrderID = getTimestampInMS()
+// PlaceOrderListOCO place an OCO order list built the same way as for the REST API
+func (c *ClientWs) PlaceOrderListOCO(s *CreateOrderListOCOService) error {
+	return c.sendOrderList("orderList.place.oco", s.params())
+}
 
-func GetReqID() string {
-	atomic.AddInt64(&gClientOrderID, 1)
-	return strconv.FormatInt(atomic.LoadInt64(&gClientOrderID), 10)
+// PlaceOrderListOTO place an OTO order list built the same way as for the REST API
+func (c *ClientWs) PlaceOrderListOTO(s *CreateOrderListOTOService) error {
+	return c.sendOrderList("orderList.place.oto", s.params())
 }
 
-func makeQueryString(params map[string]interface{}) string {
-	keys := make([]string, 0, len(params))
-	for key := range params {
-		keys = append(keys, key)
-	}
-	sort.Strings(keys)
+// PlaceOrderListOTOCO place an OTOCO order list built the same way as for the REST API
+func (c *ClientWs) PlaceOrderListOTOCO(s *CreateOrderListOTOCOService) error {
+	return c.sendOrderList("orderList.place.otoco", s.params())
+}
 
-	var payloadBuilder strings.Builder
-	for _, key := range keys {
-		payloadBuilder.WriteString(fmt.Sprintf("%s=%v&", key, params[key]))
+func (c *ClientWs) sendOrderList(method string, m params) error {
+	args := make(map[string]interface{}, len(m)+1)
+	for k, v := range m {
+		args[k] = v
 	}
-	return strings.TrimRight(payloadBuilder.String(), "&")
+	args[timestampKey] = time.Now().UnixMilli()
+	return c.Send(method, args)
 }
 
-func parsePrivateKey(apiSecret string) (crypto.PrivateKey, error) {
-	block, _ := pem.Decode([]byte(apiSecret))
-	if block == nil || block.Type != "PRIVATE KEY" {
-		return nil, fmt.Errorf("failed to decode PEM block containing private key")
-	}
+func (c *ClientWs) CancelOrderList(orderList *WsCancelOrderList) error {
 
-	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
-	if err != nil {
-		return nil, err
+	if orderList.Timestamp == 0 {
+		orderList.Timestamp = time.Now().UnixMilli()
 	}
+	args := common.StructToMap(orderList)
 
-	return privateKey, nil
+	return c.Send("orderList.cancel", args)
 }
 
-func signPayload(payload string, privateKey crypto.PrivateKey) (string, error) {
-	key, ok := privateKey.(crypto.Signer)
-	if !ok {
-		return "", fmt.Errorf("key does not implement crypto.Signer")
-	}
+func (c *ClientWs) GetOrderList(orderList *WsGetOrderList) error {
 
-	signature, err := key.Sign(nil, []byte(payload), crypto.Hash(0))
-	if err != nil {
-		return "", err
+	if orderList.Timestamp == 0 {
+		orderList.Timestamp = time.Now().UnixMilli()
 	}
+	args := common.StructToMap(orderList)
 
-	return base64.StdEncoding.EncodeToString(signature), nil
+	return c.Send("orderList.status", args)
+}
+
+// GetReqID return a unique id for a websocket API request
+func GetReqID() string {
+	return common.GetReqID()
 }
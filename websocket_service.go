@@ -6,8 +6,10 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/dictxwang/go-binance/common"
 	"github.com/gorilla/websocket"
 )
 
@@ -27,36 +29,14 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketMetrics receives the health measurements of the websocket connections when it is set
+	WebsocketMetrics common.WsMetrics
 )
 
-// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getWsEndpoint() string {
-	if UseTestnet {
-		return BaseWsTestnetURL
-	}
-	return BaseWsMainURL
-}
-
-// getCombinedEndpoint return the base endpoint of the combined stream according the UseTestnet flag
-func getCombinedEndpoint() string {
-	if UseTestnet {
-		return BaseCombinedTestnetURL
-	}
-	return BaseCombinedMainURL
-}
-
 func getCombinedIntranetEndpoint() string {
 	return BaseCombinedMainURL
 }
 
-// getTradingWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getTradingWsEndpoint() string {
-	if UseTestnet {
-		return baseTradingWsTestUrl
-	}
-	return baseTradingWsUrl
-}
-
 // WsPartialDepthEvent define websocket partial depth book event
 type WsPartialDepthEvent struct {
 	Symbol       string
@@ -69,20 +49,20 @@ type WsPartialDepthEvent struct {
 type WsPartialDepthHandler func(event *WsPartialDepthEvent)
 
 // WsPartialDepthServe serve websocket partial depth handler with a symbol, using 1sec updates
-func WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth%s", getWsEndpoint(), strings.ToLower(symbol), levels)
-	return wsPartialDepthServe(endpoint, symbol, handler, errHandler)
+func (e *Environment) WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth%s", e.getWsEndpoint(), strings.ToLower(symbol), levels)
+	return e.wsPartialDepthServe(endpoint, symbol, handler, errHandler)
 }
 
 // WsPartialDepthServe100Ms serve websocket partial depth handler with a symbol, using 100msec updates
-func WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth%s@100ms", getWsEndpoint(), strings.ToLower(symbol), levels)
-	return wsPartialDepthServe(endpoint, symbol, handler, errHandler)
+func (e *Environment) WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth%s@100ms", e.getWsEndpoint(), strings.ToLower(symbol), levels)
+	return e.wsPartialDepthServe(endpoint, symbol, handler, errHandler)
 }
 
 // WsPartialDepthServe serve websocket partial depth handler with a symbol
-func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -116,13 +96,13 @@ func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthH
 }
 
 // WsCombinedPartialDepthServe is similar to WsPartialDepthServe, but it for multiple symbols
-func WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for s, l := range symbolLevels {
 		endpoint += fmt.Sprintf("%s@depth%s", strings.ToLower(s), l) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -163,20 +143,20 @@ func WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsParti
 type WsDepthHandler func(event *WsDepthEvent)
 
 // WsDepthServe serve websocket depth handler with a symbol, using 1sec updates
-func WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth", getWsEndpoint(), strings.ToLower(symbol))
-	return wsDepthServe(endpoint, handler, errHandler)
+func (e *Environment) WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth", e.getWsEndpoint(), strings.ToLower(symbol))
+	return e.wsDepthServe(endpoint, handler, errHandler)
 }
 
 // WsDepthServe100Ms serve websocket depth handler with a symbol, using 100msec updates
-func WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth@100ms", getWsEndpoint(), strings.ToLower(symbol))
-	return wsDepthServe(endpoint, handler, errHandler)
+func (e *Environment) WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth@100ms", e.getWsEndpoint(), strings.ToLower(symbol))
+	return e.wsDepthServe(endpoint, handler, errHandler)
 }
 
 // WsDepthServe serve websocket depth handler with an arbitrary endpoint address
-func wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -224,26 +204,26 @@ type WsDepthEvent struct {
 }
 
 // WsCombinedDepthServe is similar to WsDepthServe, but it for multiple symbols
-func WsCombinedDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@depth", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return wsCombinedDepthServe(endpoint, handler, errHandler)
+	return e.wsCombinedDepthServe(endpoint, handler, errHandler)
 }
 
-func WsCombinedDepthServe100Ms(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedDepthServe100Ms(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@depth@100ms", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return wsCombinedDepthServe(endpoint, handler, errHandler)
+	return e.wsCombinedDepthServe(endpoint, handler, errHandler)
 }
 
-func wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -286,13 +266,13 @@ func wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler Er
 type WsKlineHandler func(event *WsKlineEvent)
 
 // WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
-func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for symbol, interval := range symbolIntervalPair {
 		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -321,9 +301,9 @@ func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineH
 }
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
-func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@kline_%s", e.getWsEndpoint(), strings.ToLower(symbol), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -368,9 +348,9 @@ type WsKline struct {
 type WsAggTradeHandler func(event *WsAggTradeEvent)
 
 // WsAggTradeServe serve websocket aggregate handler with a symbol
-func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@aggTrade", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@aggTrade", e.getWsEndpoint(), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
 		err := json.Unmarshal(message, event)
@@ -384,13 +364,13 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 }
 
 // WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbolx
-func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for s := range symbols {
 		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(symbols[s])) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -439,9 +419,9 @@ type WsTradeHandler func(event *WsTradeEvent)
 type WsCombinedTradeHandler func(event *WsCombinedTradeEvent)
 
 // WsTradeServe serve websocket handler with a symbol
-func WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@trade", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@trade", e.getWsEndpoint(), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsTradeEvent)
 		err := json.Unmarshal(message, event)
@@ -454,13 +434,13 @@ func WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler)
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
-func WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@trade/", strings.ToLower(s))
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedTradeEvent)
 		err := json.Unmarshal(message, event)
@@ -601,9 +581,10 @@ type WsOCOOrder struct {
 type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
-func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.getWsEndpoint(), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -652,9 +633,10 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 }
 
 // WsUserDataServeWithIp serve user data handler with listen key through local address
-func WsUserDataServeWithIp(listenKey string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsUserDataServeWithIp(listenKey string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.getWsEndpoint(), listenKey)
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenKey)
 	if localIP != "" {
 		cfg.WithIP(localIP)
 	}
@@ -708,13 +690,13 @@ func WsUserDataServeWithIp(listenKey string, localIP string, handler WsUserDataH
 // WsUserDataServeWithListenToken subscribes to user data stream via WS API using listenToken.
 // This replaces WsUserDataServe for margin accounts after Binance retired the listenKey approach.
 // Events are pushed as {"subscriptionId":0,"event":{...}} and unwrapped before calling handler.
-func WsUserDataServeWithListenToken(listenToken string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
-	return wsServeListenToken(listenToken, "", handler, errHandler)
+func (e *Environment) WsUserDataServeWithListenToken(listenToken string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
+	return e.wsServeListenToken(listenToken, "", handler, errHandler)
 }
 
 // WsUserDataServeWithListenTokenAndIp is like WsUserDataServeWithListenToken but binds to a local IP.
-func WsUserDataServeWithListenTokenAndIp(listenToken string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
-	return wsServeListenToken(listenToken, localIP, handler, errHandler)
+func (e *Environment) WsUserDataServeWithListenTokenAndIp(listenToken string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
+	return e.wsServeListenToken(listenToken, localIP, handler, errHandler)
 }
 
 // wsAPIEventWrapper is the outer envelope for WS API push events
@@ -730,9 +712,10 @@ type wsAPISubscribeError struct {
 	Msg  string `json:"msg"`
 }
 
-func wsServeListenToken(listenToken string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
-	endpoint := getTradingWsEndpoint()
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsServeListenToken(listenToken string, localIP string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, renewC chan<- string, err error) {
+	endpoint := e.getTradingWsEndpoint()
+	cfg := e.newWsConfig(endpoint)
+	cfg.Name = common.UserDataConnName(listenToken)
 	if localIP != "" {
 		cfg.WithIP(localIP)
 	}
@@ -743,7 +726,9 @@ func wsServeListenToken(listenToken string, localIP string, handler WsUserDataHa
 	renewCh := make(chan string, 1)
 
 	var dialer websocket.Dialer
-	if cfg.IP == "" {
+	if cfg.Dialer != nil {
+		dialer = *cfg.Dialer
+	} else if cfg.IP == "" {
 		dialer = websocket.Dialer{
 			Proxy:             http.ProxyFromEnvironment,
 			HandshakeTimeout:  45 * time.Second,
@@ -769,6 +754,17 @@ func wsServeListenToken(listenToken string, localIP string, handler WsUserDataHa
 		return nil, nil, nil, err
 	}
 	c.SetReadLimit(655350)
+	name := cfg.name()
+	if cfg.Metrics != nil {
+		cfg.Metrics.WsConnected(name)
+	}
+	var lastPing int64
+	c.SetPongHandler(func(string) error {
+		if ping := atomic.LoadInt64(&lastPing); ping > 0 && cfg.Metrics != nil {
+			cfg.Metrics.WsPingRTT(name, time.Since(time.Unix(0, ping)))
+		}
+		return nil
+	})
 
 	// Send subscribe message
 	subMsg, _ := stdjson.Marshal(map[string]interface{}{
@@ -817,6 +813,7 @@ func wsServeListenToken(listenToken string, localIP string, handler WsUserDataHa
 				select {
 				case <-ticker.C:
 					deadline := time.Now().Add(10 * time.Second)
+					atomic.StoreInt64(&lastPing, time.Now().UnixNano())
 					if pingErr := c.WriteControl(websocket.PingMessage, []byte{}, deadline); pingErr != nil {
 						return
 					}
@@ -842,11 +839,21 @@ func wsServeListenToken(listenToken string, localIP string, handler WsUserDataHa
 		for {
 			_, message, readErr := c.ReadMessage()
 			if readErr != nil {
+				if cfg.Metrics != nil {
+					if silent {
+						cfg.Metrics.WsDisconnected(name, nil)
+					} else {
+						cfg.Metrics.WsDisconnected(name, readErr)
+					}
+				}
 				if !silent {
 					errHandler(readErr)
 				}
 				return
 			}
+			if cfg.Metrics != nil {
+				common.ObserveWsMessage(cfg.Metrics, name, message, time.Now())
+			}
 
 			// Parse outer wrapper
 			var wrapper wsAPIEventWrapper
@@ -910,13 +917,13 @@ func wsServeListenToken(listenToken string, localIP string, handler WsUserDataHa
 type WsMarketStatHandler func(event *WsMarketStatEvent)
 
 // WsCombinedMarketStatServe is similar to WsMarketStatServe, but it handles multiple symbolx
-func WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for s := range symbols {
 		endpoint += fmt.Sprintf("%s@ticker", strings.ToLower(symbols[s])) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
@@ -947,9 +954,9 @@ func WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, er
 }
 
 // WsMarketStatServe serve websocket that push 24hr statistics for single market every second
-func WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@ticker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@ticker", e.getWsEndpoint(), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsMarketStatEvent
 		err := json.Unmarshal(message, &event)
@@ -966,9 +973,9 @@ func WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler Er
 type WsAllMarketsStatHandler func(event WsAllMarketsStatEvent)
 
 // WsAllMarketsStatServe serve websocket that push 24hr statistics for all market every second
-func WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!ticker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!ticker@arr", e.getWsEndpoint())
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketsStatEvent
 		err := json.Unmarshal(message, &event)
@@ -1015,9 +1022,9 @@ type WsMarketStatEvent struct {
 type WsAllMiniMarketsStatServeHandler func(event WsAllMiniMarketsStatEvent)
 
 // WsAllMiniMarketsStatServe serve websocket that push mini version of 24hr statistics for all market every second
-func WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!miniTicker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!miniTicker@arr", e.getWsEndpoint())
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketsStatEvent
 		err := json.Unmarshal(message, &event)
@@ -1046,6 +1053,100 @@ type WsMiniMarketsStatEvent struct {
 	QuoteVolume string `json:"q"`
 }
 
+// WsMarketRollingWindowStatHandler handle websocket that push single market rolling window statistics
+type WsMarketRollingWindowStatHandler func(event *WsMarketRollingWindowStatEvent)
+
+// WsMarketRollingWindowStatServe serve websocket that push the statistics of a symbol over a rolling window
+// of windowSize (1h, 4h or 1d) every second
+func (e *Environment) WsMarketRollingWindowStatServe(symbol string, windowSize string, handler WsMarketRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@ticker_%s", e.getWsEndpoint(), strings.ToLower(symbol), windowSize)
+	cfg := e.newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsMarketRollingWindowStatEvent)
+		err := json.Unmarshal(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAllMarketsRollingWindowStatHandler handle websocket that push all markets rolling window statistics
+type WsAllMarketsRollingWindowStatHandler func(event WsAllMarketsRollingWindowStatEvent)
+
+// WsAllMarketsRollingWindowStatServe serve websocket that push the statistics over a rolling window of windowSize
+// (1h, 4h or 1d) of the symbols which changed, every second
+func (e *Environment) WsAllMarketsRollingWindowStatServe(windowSize string, handler WsAllMarketsRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!ticker_%s@arr", e.getWsEndpoint(), windowSize)
+	cfg := e.newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		var event WsAllMarketsRollingWindowStatEvent
+		err := json.Unmarshal(message, &event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAllMarketsRollingWindowStatEvent define array of websocket market rolling window statistics events
+type WsAllMarketsRollingWindowStatEvent []*WsMarketRollingWindowStatEvent
+
+// WsMarketRollingWindowStatEvent define websocket market rolling window statistics event, Event is named
+// after the window, e.g. 1hTicker
+type WsMarketRollingWindowStatEvent struct {
+	Event              string `json:"e"`
+	Time               int64  `json:"E"`
+	Symbol             string `json:"s"`
+	PriceChange        string `json:"p"`
+	PriceChangePercent string `json:"P"`
+	OpenPrice          string `json:"o"`
+	HighPrice          string `json:"h"`
+	LowPrice           string `json:"l"`
+	LastPrice          string `json:"c"`
+	WeightedAvgPrice   string `json:"w"`
+	BaseVolume         string `json:"v"`
+	QuoteVolume        string `json:"q"`
+	OpenTime           int64  `json:"O"`
+	CloseTime          int64  `json:"C"`
+	FirstID            int64  `json:"F"`
+	LastID             int64  `json:"L"`
+	Count              int64  `json:"n"`
+}
+
+// WsAvgPriceHandler handle websocket that push the average price of a symbol
+type WsAvgPriceHandler func(event *WsAvgPriceEvent)
+
+// WsAvgPriceServe serve websocket that push the average price of a symbol over a fixed interval every second
+func (e *Environment) WsAvgPriceServe(symbol string, handler WsAvgPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@avgPrice", e.getWsEndpoint(), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsAvgPriceEvent)
+		err := json.Unmarshal(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAvgPriceEvent define websocket average price event
+type WsAvgPriceEvent struct {
+	Event         string `json:"e"`
+	Time          int64  `json:"E"`
+	Symbol        string `json:"s"`
+	Interval      string `json:"i"`
+	AvgPrice      string `json:"w"`
+	LastTradeTime int64  `json:"T"`
+}
+
 // WsBookTickerEvent define websocket best book ticker event.
 type WsBookTickerEvent struct {
 	UpdateID     int64  `json:"u"`
@@ -1065,9 +1166,9 @@ type WsCombinedBookTickerEvent struct {
 type WsBookTickerHandler func(event *WsBookTickerEvent)
 
 // WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
-func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@bookTicker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@bookTicker", e.getWsEndpoint(), strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -1081,13 +1182,13 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 }
 
 // WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
-func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
 		err := json.Unmarshal(message, event)
@@ -1101,13 +1202,13 @@ func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, er
 }
 
 // WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
-func WsCombinedBookTickerServeWithIntranet(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedBookTickerServeWithIntranet(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
 		err := json.Unmarshal(message, event)
@@ -1121,13 +1222,13 @@ func WsCombinedBookTickerServeWithIntranet(symbols []string, handler WsBookTicke
 }
 
 // WsCombinedBookTickerServeWithIP is similar to WsCombinedBookTickerServe, but it is using assigned IP to connect ws service
-func WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	cfg.WithIP(ip)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
@@ -1142,13 +1243,13 @@ func WsCombinedBookTickerServeWithIP(ip string, symbols []string, handler WsBook
 }
 
 // WsCombinedBookTickerServeWithIPAndResolver is similar to WsCombinedBookTickerServe, but it is using assigned IP to connect ws service
-func WsCombinedBookTickerServeWithIPAndResolver(sourceIP string, resolver *net.Resolver, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+func (e *Environment) WsCombinedBookTickerServeWithIPAndResolver(sourceIP string, resolver *net.Resolver, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.getCombinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	cfg.WithIP(sourceIP)
 	cfg.WithResolver(resolver)
 	wsHandler := func(message []byte) {
@@ -1164,9 +1265,9 @@ func WsCombinedBookTickerServeWithIPAndResolver(sourceIP string, resolver *net.R
 }
 
 // WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
-func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!bookTicker", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!bookTicker", e.getWsEndpoint())
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -3,6 +3,7 @@ package binance
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -101,6 +102,32 @@ func (s *websocketServiceTestSuite) TestPartialDepthServe() {
 	<-doneC
 }
 
+func (s *websocketServiceTestSuite) TestEnvironmentPartialDepthServe() {
+	var cfgs []*WsConfig
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, innerErr error) {
+		cfgs = append(cfgs, cfg)
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+	handler := func(event *WsPartialDepthEvent) {}
+	errHandler := func(err error) {}
+
+	env := TestnetEnvironment()
+	env.WebsocketKeepalive = true
+	env.WebsocketTimeout = 10 * time.Second
+	_, _, err := env.WsPartialDepthServe("ETHBTC", "5", handler, errHandler)
+	s.r().NoError(err)
+
+	_, _, err = WsPartialDepthServe("ETHBTC", "5", handler, errHandler)
+	s.r().NoError(err)
+
+	s.r().Len(cfgs, 2)
+	s.r().Equal("wss://testnet.binance.vision/ws/ethbtc@depth5", cfgs[0].Endpoint)
+	s.r().True(cfgs[0].Keepalive)
+	s.r().Equal(10*time.Second, cfgs[0].Timeout)
+	s.r().Equal("wss://stream.binance.com:9443/ws/ethbtc@depth5", cfgs[1].Endpoint)
+	s.r().Equal(WebsocketKeepalive, cfgs[1].Keepalive)
+}
+
 func (s *websocketServiceTestSuite) TestPartialDepthServe100Ms() {
 	data := []byte(`{
 	  "lastUpdateId": 160,
@@ -1522,6 +1549,136 @@ func (s *websocketServiceTestSuite) TestWsCombinedTradeServe() {
 	<-doneC
 }
 
+func (s *websocketServiceTestSuite) TestWsMarketRollingWindowStatServe() {
+	data := []byte(`{
+		"e": "1hTicker",
+		"E": 1672515782136,
+		"s": "BNBBTC",
+		"p": "0.0015",
+		"P": "250.00",
+		"o": "0.0010",
+		"h": "0.0025",
+		"l": "0.0010",
+		"c": "0.0025",
+		"w": "0.0018",
+		"v": "10000",
+		"q": "18",
+		"O": 0,
+		"C": 3600000,
+		"F": 0,
+		"L": 18150,
+		"n": 18151
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+	mockServe := wsServe
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.r().Equal(BaseWsMainURL+"/bnbbtc@ticker_1h", cfg.Endpoint)
+		return mockServe(cfg, handler, errHandler)
+	}
+
+	doneC, stopC, err := WsMarketRollingWindowStatServe("BNBBTC", "1h", func(event *WsMarketRollingWindowStatEvent) {
+		s.r().Equal(&WsMarketRollingWindowStatEvent{
+			Event:              "1hTicker",
+			Time:               1672515782136,
+			Symbol:             "BNBBTC",
+			PriceChange:        "0.0015",
+			PriceChangePercent: "250.00",
+			OpenPrice:          "0.0010",
+			HighPrice:          "0.0025",
+			LowPrice:           "0.0010",
+			LastPrice:          "0.0025",
+			WeightedAvgPrice:   "0.0018",
+			BaseVolume:         "10000",
+			QuoteVolume:        "18",
+			OpenTime:           0,
+			CloseTime:          3600000,
+			FirstID:            0,
+			LastID:             18150,
+			Count:              18151,
+		}, event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsAllMarketsRollingWindowStatServe() {
+	data := []byte(`[{
+		"e": "4hTicker",
+		"E": 1672515782136,
+		"s": "BNBBTC",
+		"c": "0.0025",
+		"n": 18151
+	},{
+		"e": "4hTicker",
+		"E": 1672515782136,
+		"s": "ETHBTC",
+		"c": "0.0712",
+		"n": 2001
+	}]`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+	mockServe := wsServe
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.r().Equal(BaseWsMainURL+"/!ticker_4h@arr", cfg.Endpoint)
+		return mockServe(cfg, handler, errHandler)
+	}
+
+	doneC, stopC, err := WsAllMarketsRollingWindowStatServe("4h", func(event WsAllMarketsRollingWindowStatEvent) {
+		s.r().Len(event, 2)
+		s.r().Equal("4hTicker", event[0].Event)
+		s.r().Equal("BNBBTC", event[0].Symbol)
+		s.r().Equal("0.0025", event[0].LastPrice)
+		s.r().Equal("ETHBTC", event[1].Symbol)
+		s.r().Equal(int64(2001), event[1].Count)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsAvgPriceServe() {
+	data := []byte(`{
+		"e": "avgPrice",
+		"E": 1693907033000,
+		"s": "BTCUSDT",
+		"i": "5m",
+		"w": "25776.86000000",
+		"T": 1693907032213
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+	mockServe := wsServe
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.r().Equal(BaseWsMainURL+"/btcusdt@avgPrice", cfg.Endpoint)
+		return mockServe(cfg, handler, errHandler)
+	}
+
+	doneC, stopC, err := WsAvgPriceServe("BTCUSDT", func(event *WsAvgPriceEvent) {
+		s.r().Equal(&WsAvgPriceEvent{
+			Event:         "avgPrice",
+			Time:          1693907033000,
+			Symbol:        "BTCUSDT",
+			Interval:      "5m",
+			AvgPrice:      "25776.86000000",
+			LastTradeTime: 1693907032213,
+		}, event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 func (s *websocketServiceTestSuite) TestWsAllMiniMarketsStatServe() {
 	data := []byte(`[{
   		"e": "24hrMiniTicker",
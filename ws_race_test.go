@@ -0,0 +1,103 @@
+package binance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type wsRaceTestSuite struct {
+	suite.Suite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+	handlers    []WsHandler
+	kills       []chan struct{}
+}
+
+func TestWsRace(t *testing.T) {
+	suite.Run(t, new(wsRaceTestSuite))
+}
+
+func (s *wsRaceTestSuite) SetupTest() {
+	s.origWsServe = wsServe
+	s.handlers = nil
+	s.kills = nil
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		kill := make(chan struct{})
+		s.handlers = append(s.handlers, handler)
+		s.kills = append(s.kills, kill)
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			select {
+			case <-stopC:
+			case <-kill:
+			}
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}
+}
+
+func (s *wsRaceTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+}
+
+func (s *wsRaceTestSuite) TestWsCombinedTradeServe() {
+	trade := func(id int64) []byte {
+		return []byte(fmt.Sprintf(`{"stream":"btcusdt@trade","data":{"e":"trade","s":"BTCUSDT","t":%d}}`, id))
+	}
+	race := NewWsRace("", "")
+	var delivered []int64
+	doneC, stopC, err := race.WsCombinedTradeServe([]string{"BTCUSDT"}, func(event *WsCombinedTradeEvent) {
+		delivered = append(delivered, event.Data.TradeID)
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.Require().Len(s.handlers, 2)
+
+	s.handlers[0](trade(1))
+	s.handlers[1](trade(1))
+	s.handlers[1](trade(2))
+	s.handlers[0](trade(2))
+	s.Require().Equal([]int64{1, 2}, delivered)
+
+	// the race keeps delivering once a connection dies
+	close(s.kills[0])
+	s.Require().Eventually(func() bool {
+		return !race.Conns()[0].Alive
+	}, time.Second, time.Millisecond)
+	s.handlers[1](trade(3))
+	s.Require().Equal([]int64{1, 2, 3}, delivered)
+
+	conns := race.Conns()
+	s.Require().Equal(int64(2), conns[0].Events)
+	s.Require().Equal(int64(1), conns[0].Wins)
+	s.Require().InDelta(0.5, conns[0].WinRate(), 1e-9)
+	s.Require().Equal(int64(3), conns[1].Events)
+	s.Require().Equal(int64(2), conns[1].Wins)
+	s.Require().True(conns[1].Alive)
+
+	close(stopC)
+	<-doneC
+}
+
+func (s *wsRaceTestSuite) TestWsCombinedBookTickerServe() {
+	ticker := func(id int64) []byte {
+		return []byte(fmt.Sprintf(`{"stream":"btcusdt@bookTicker","data":{"s":"BTCUSDT","u":%d}}`, id))
+	}
+	race := NewWsRace("", "")
+	var delivered []int64
+	doneC, stopC, err := race.WsCombinedBookTickerServe([]string{"BTCUSDT"}, func(event *WsBookTickerEvent) {
+		delivered = append(delivered, event.UpdateID)
+	}, func(err error) {})
+	s.Require().NoError(err)
+
+	s.handlers[1](ticker(400))
+	s.handlers[0](ticker(400))
+	s.handlers[0](ticker(401))
+	s.Require().Equal([]int64{400, 401}, delivered)
+
+	close(stopC)
+	<-doneC
+}